@@ -0,0 +1,163 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const userNameTransferApprovalKey = core.ProtectedKeyPrefix + "userNameTransferApproval"
+
+type approveUserNameTransfer struct {
+	baseActiveHandler
+	gasCost         uint64
+	mapDnsAddresses map[string]struct{}
+	epochHook       BlockChainEpochHook
+	mutExecution    sync.RWMutex
+}
+
+// NewApproveUserNameTransferFunc returns the built-in function which records the consent of an account to receive a
+// username from its current holder through TransferUserName
+func NewApproveUserNameTransferFunc(
+	gasCost uint64,
+	mapDnsAddresses map[string]struct{},
+	enableEpochsHandler vmcommon.EnableEpochsHandler,
+) (*approveUserNameTransfer, error) {
+	if mapDnsAddresses == nil {
+		return nil, ErrNilDnsAddresses
+	}
+	if check.IfNil(enableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+
+	a := &approveUserNameTransfer{
+		gasCost:         gasCost,
+		mapDnsAddresses: make(map[string]struct{}, len(mapDnsAddresses)),
+		epochHook:       &disabledBlockchainHook{},
+	}
+	for key := range mapDnsAddresses {
+		a.mapDnsAddresses[key] = struct{}{}
+	}
+	a.activeHandler = func() bool {
+		return enableEpochsHandler.IsFlagEnabled(UserNameLifecycleFlag)
+	}
+
+	return a, nil
+}
+
+// SetBlockChainEpochHook sets the component providing the current epoch
+func (a *approveUserNameTransfer) SetBlockChainEpochHook(epochHook BlockChainEpochHook) error {
+	if check.IfNil(epochHook) {
+		return ErrNilBlockchainHook
+	}
+
+	a.mutExecution.Lock()
+	a.epochHook = epochHook
+	a.mutExecution.Unlock()
+
+	return nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (a *approveUserNameTransfer) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	a.mutExecution.Lock()
+	a.gasCost = gasCost.BuiltInCost.TransferUserName
+	a.mutExecution.Unlock()
+}
+
+// ProcessBuiltinFunction saves on the destination account the approval to receive the username from its current
+// holder. The destination account must not hold a username which has not expired. The arguments are the username and
+// the address of its current holder
+func (a *approveUserNameTransfer) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	a.mutExecution.RLock()
+	defer a.mutExecution.RUnlock()
+
+	err := inputCheckForUserNameCall(acntSnd, vmInput, a.mapDnsAddresses, a.gasCost, 2)
+	if err != nil {
+		return nil, err
+	}
+	userName, currentHolder := vmInput.Arguments[0], vmInput.Arguments[1]
+	if len(currentHolder) != len(vmInput.RecipientAddr) {
+		return nil, ErrInvalidAddressLength
+	}
+	if len(userName) == 0 || bytes.Equal(currentHolder, vmInput.RecipientAddr) {
+		return nil, ErrInvalidArguments
+	}
+
+	if check.IfNil(acntDst) {
+		return createCrossShardUserNameCall(vmInput, vmInput.Function, vmInput.GasProvided-a.gasCost)
+	}
+
+	err = checkUserNameCanBeReceived(acntDst, a.epochHook.CurrentEpoch())
+	if err != nil {
+		return nil, err
+	}
+
+	err = acntDst.AccountDataHandler().SaveKeyValue([]byte(userNameTransferApprovalKey), createUserNameTransferApproval(currentHolder, userName))
+	if err != nil {
+		return nil, err
+	}
+
+	gasRemaining := vmInput.GasProvided
+	if !check.IfNil(acntSnd) {
+		gasRemaining = vmInput.GasProvided - a.gasCost
+	}
+	vmOutput := &vmcommon.VMOutput{
+		GasRemaining: gasRemaining,
+		ReturnCode:   vmcommon.Ok,
+	}
+	vmOutput.Logs = []*vmcommon.LogEntry{{
+		Identifier: []byte(vmInput.Function),
+		Address:    vmInput.RecipientAddr,
+		Topics:     [][]byte{userName, currentHolder},
+	}}
+
+	return vmOutput, nil
+}
+
+// checkUserNameCanBeReceived returns an error if the account holds a username which has not expired
+func checkUserNameCanBeReceived(account vmcommon.UserAccountHandler, currentEpoch uint32) error {
+	if len(account.GetUserName()) == 0 {
+		return nil
+	}
+	expiryEpoch, err := getUserNameExpiryEpoch(account)
+	if err != nil {
+		return err
+	}
+	if !isUserNameExpired(expiryEpoch, currentEpoch) {
+		return ErrUserNameAlreadySet
+	}
+
+	return nil
+}
+
+func createUserNameTransferApproval(currentHolder []byte, userName []byte) []byte {
+	approval := make([]byte, 0, len(currentHolder)+len(userName))
+	approval = append(approval, currentHolder...)
+	return append(approval, userName...)
+}
+
+// isUserNameTransferApproved returns true if the account approved to receive the username from the given holder
+func isUserNameTransferApproved(account vmcommon.UserAccountHandler, currentHolder []byte, userName []byte) (bool, error) {
+	approval, _, err := account.AccountDataHandler().RetrieveValue([]byte(userNameTransferApprovalKey))
+	if core.IsGetNodeFromDBError(err) {
+		return false, err
+	}
+
+	return bytes.Equal(approval, createUserNameTransferApproval(currentHolder, userName)), nil
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (a *approveUserNameTransfer) IsInterfaceNil() bool {
+	return a == nil
+}
@@ -0,0 +1,113 @@
+package builtInFunctions
+
+import (
+	"encoding/hex"
+	"testing"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewApproveUserNameTransferFunc(t *testing.T) {
+	t.Parallel()
+
+	dnsAddresses := map[string]struct{}{"dns": {}}
+	instance, err := NewApproveUserNameTransferFunc(1, nil, createUserNameLifecycleEnableEpochsHandler())
+	require.Nil(t, instance)
+	require.Equal(t, ErrNilDnsAddresses, err)
+
+	instance, err = NewApproveUserNameTransferFunc(1, dnsAddresses, nil)
+	require.Nil(t, instance)
+	require.Equal(t, ErrNilEnableEpochsHandler, err)
+
+	instance, err = NewApproveUserNameTransferFunc(1, dnsAddresses, createUserNameLifecycleEnableEpochsHandler())
+	require.Nil(t, err)
+	require.False(t, instance.IsInterfaceNil())
+	require.True(t, instance.IsActive())
+	require.Equal(t, ErrNilBlockchainHook, instance.SetBlockChainEpochHook(nil))
+
+	instance, _ = NewApproveUserNameTransferFunc(1, dnsAddresses, &mock.EnableEpochsHandlerStub{})
+	require.False(t, instance.IsActive())
+
+	instance.SetNewGasConfig(&vmcommon.GasCost{BuiltInCost: vmcommon.BuiltInCost{SaveUserName: 5, TransferUserName: 37}})
+	require.Equal(t, uint64(37), instance.gasCost)
+}
+
+func TestApproveUserNameTransfer_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	userName := []byte("name.elrond")
+	holderAddress := []byte("holder01")
+	newHolderAddress := []byte("holder02")
+	createApproveFunc := func() *approveUserNameTransfer {
+		approveFunc, _ := NewApproveUserNameTransferFunc(1, map[string]struct{}{"dns": {}}, createUserNameLifecycleEnableEpochsHandler())
+		_ = approveFunc.SetBlockChainEpochHook(&mock.BlockChainEpochHookStub{
+			CurrentEpochCalled: func() uint32 {
+				return 100
+			},
+		})
+		return approveFunc
+	}
+
+	t.Run("invalid arguments should error", func(t *testing.T) {
+		t.Parallel()
+
+		approveFunc := createApproveFunc()
+		newHolder := mock.NewUserAccount(newHolderAddress)
+
+		_, err := approveFunc.ProcessBuiltinFunction(nil, newHolder, createUserNameLifecycleInput(vmcommon.BuiltInFunctionApproveUserNameTransfer, newHolderAddress, userName))
+		require.Equal(t, ErrInvalidArguments, err)
+
+		_, err = approveFunc.ProcessBuiltinFunction(nil, newHolder, createUserNameLifecycleInput(vmcommon.BuiltInFunctionApproveUserNameTransfer, newHolderAddress, userName, []byte("short")))
+		require.Equal(t, ErrInvalidAddressLength, err)
+
+		_, err = approveFunc.ProcessBuiltinFunction(nil, newHolder, createUserNameLifecycleInput(vmcommon.BuiltInFunctionApproveUserNameTransfer, newHolderAddress, userName, newHolderAddress))
+		require.Equal(t, ErrInvalidArguments, err)
+	})
+	t.Run("account holding a valid username should error", func(t *testing.T) {
+		t.Parallel()
+
+		approveFunc := createApproveFunc()
+		newHolder := mock.NewUserAccount(newHolderAddress)
+		newHolder.SetUserName([]byte("other.elrond"))
+
+		vmInput := createUserNameLifecycleInput(vmcommon.BuiltInFunctionApproveUserNameTransfer, newHolderAddress, userName, holderAddress)
+		_, err := approveFunc.ProcessBuiltinFunction(nil, newHolder, vmInput)
+		require.Equal(t, ErrUserNameAlreadySet, err)
+
+		_ = newHolder.AccountDataHandler().SaveKeyValue([]byte(userNameExpiryEpochKey), userNameExpiryEpochToBytes(100))
+		_, err = approveFunc.ProcessBuiltinFunction(nil, newHolder, vmInput)
+		require.Nil(t, err)
+	})
+	t.Run("should save the approval", func(t *testing.T) {
+		t.Parallel()
+
+		approveFunc := createApproveFunc()
+		newHolder := mock.NewUserAccount(newHolderAddress)
+
+		vmInput := createUserNameLifecycleInput(vmcommon.BuiltInFunctionApproveUserNameTransfer, newHolderAddress, userName, holderAddress)
+		vmOutput, err := approveFunc.ProcessBuiltinFunction(mock.NewUserAccount([]byte("dns")), newHolder, vmInput)
+		require.Nil(t, err)
+		require.Equal(t, uint64(9), vmOutput.GasRemaining)
+		isApproved, _ := isUserNameTransferApproved(newHolder, holderAddress, userName)
+		require.True(t, isApproved)
+		require.Equal(t, []*vmcommon.LogEntry{{
+			Identifier: []byte(vmcommon.BuiltInFunctionApproveUserNameTransfer),
+			Address:    newHolderAddress,
+			Topics:     [][]byte{userName, holderAddress},
+		}}, vmOutput.Logs)
+	})
+	t.Run("cross shard call from the DNS address", func(t *testing.T) {
+		t.Parallel()
+
+		approveFunc := createApproveFunc()
+		vmInput := createUserNameLifecycleInput(vmcommon.BuiltInFunctionApproveUserNameTransfer, newHolderAddress, userName, holderAddress)
+		vmOutput, err := approveFunc.ProcessBuiltinFunction(mock.NewUserAccount([]byte("dns")), nil, vmInput)
+		require.Nil(t, err)
+
+		outputTransfer := vmOutput.OutputAccounts[string(newHolderAddress)].OutputTransfers[0]
+		expectedData := vmcommon.BuiltInFunctionApproveUserNameTransfer + "@" + hex.EncodeToString(userName) + "@" + hex.EncodeToString(holderAddress)
+		require.Equal(t, []byte(expectedData), outputTransfer.Data)
+	})
+}
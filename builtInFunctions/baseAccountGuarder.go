@@ -15,15 +15,19 @@ import (
 // all necessary args to create a newBaseAccountGuarder
 type BaseAccountGuarderArgs struct {
 	GuardedAccountHandler vmcommon.GuardedAccountHandler
-	Marshaller            marshal.Marshalizer
-	EnableEpochsHandler   vmcommon.EnableEpochsHandler
-	FuncGasCost           uint64
+	// MultiGuardedAccountHandler is optional, guardian sets are not supported if it is not provided
+	MultiGuardedAccountHandler vmcommon.MultiGuardedAccountHandler
+	Marshaller                 marshal.Marshalizer
+	EnableEpochsHandler        vmcommon.EnableEpochsHandler
+	FuncGasCost                uint64
 }
 
 type baseAccountGuarder struct {
 	baseActiveHandler
-	marshaller            marshal.Marshalizer
-	guardedAccountHandler vmcommon.GuardedAccountHandler
+	marshaller                 marshal.Marshalizer
+	guardedAccountHandler      vmcommon.GuardedAccountHandler
+	multiGuardedAccountHandler vmcommon.MultiGuardedAccountHandler
+	enableEpochsHandler        vmcommon.EnableEpochsHandler
 
 	mutExecution sync.RWMutex
 	funcGasCost  uint64
@@ -41,10 +45,12 @@ func newBaseAccountGuarder(args BaseAccountGuarderArgs) (*baseAccountGuarder, er
 	}
 
 	accGuarder := &baseAccountGuarder{
-		funcGasCost:           args.FuncGasCost,
-		marshaller:            args.Marshaller,
-		mutExecution:          sync.RWMutex{},
-		guardedAccountHandler: args.GuardedAccountHandler,
+		funcGasCost:                args.FuncGasCost,
+		marshaller:                 args.Marshaller,
+		mutExecution:               sync.RWMutex{},
+		guardedAccountHandler:      args.GuardedAccountHandler,
+		multiGuardedAccountHandler: args.MultiGuardedAccountHandler,
+		enableEpochsHandler:        args.EnableEpochsHandler,
 	}
 
 	accGuarder.activeHandler = func() bool {
@@ -82,6 +88,21 @@ func (baf *baseAccountGuarder) checkBaseAccountGuarderArgs(
 	return nil
 }
 
+// getActiveGuardianSet returns nil if the account is not protected by a guardian set
+func (baf *baseAccountGuarder) getActiveGuardianSet(account vmcommon.UserAccountHandler) (*vmcommon.GuardianSet, error) {
+	if !baf.enableEpochsHandler.IsFlagEnabled(MultiGuardianFlag) || check.IfNil(baf.multiGuardedAccountHandler) {
+		return nil, nil
+	}
+
+	return baf.multiGuardedAccountHandler.GetActiveGuardianSet(account)
+}
+
+// guardianSetToTopics encodes the guardian set as threshold, service UID and the guardian addresses
+func guardianSetToTopics(guardianSet *vmcommon.GuardianSet) [][]byte {
+	topics := [][]byte{big.NewInt(int64(guardianSet.Threshold)).Bytes(), guardianSet.ServiceUID}
+	return append(topics, guardianSet.Guardians...)
+}
+
 func isZero(n *big.Int) bool {
 	return len(n.Bits()) == 0
 }
@@ -174,7 +174,8 @@ func createBaseAccountGuarderArgs() BaseAccountGuarderArgs {
 				return false
 			},
 		},
-		FuncGasCost:           100000,
-		GuardedAccountHandler: &mockvm.GuardedAccountHandlerStub{},
+		FuncGasCost:                100000,
+		GuardedAccountHandler:      &mockvm.GuardedAccountHandlerStub{},
+		MultiGuardedAccountHandler: &mockvm.GuardedAccountHandlerStub{},
 	}
 }
@@ -10,6 +10,7 @@ import (
 
 type baseComponentsHolder struct {
 	esdtStorageHandler    vmcommon.ESDTNFTStorageHandler
+	esdtSupplyHandler     vmcommon.ESDTSupplyHandler
 	globalSettingsHandler vmcommon.GlobalMetadataHandler
 	shardCoordinator      vmcommon.Coordinator
 	enableEpochsHandler   vmcommon.EnableEpochsHandler
@@ -30,7 +31,7 @@ func (b *baseComponentsHolder) addNFTToDestination(
 	if err != nil && !errors.Is(err, ErrNFTTokenDoesNotExist) {
 		return err
 	}
-	err = checkFrozeAndPause(dstAddress, esdtTokenKey, currentESDTData, b.globalSettingsHandler, isReturnWithError)
+	err = checkFrozeAndPause(userAccount, esdtTokenKey, currentESDTData, b.globalSettingsHandler, b.enableEpochsHandler, isReturnWithError)
 	if err != nil {
 		return err
 	}
@@ -73,6 +74,14 @@ func (b *baseComponentsHolder) addNFTToDestination(
 			return err
 		}
 	}
+	if isSenderESDTSCAddr {
+		tokenID, err := getEsdtIdentifierWithoutBaseKeyPrefix(esdtTokenKey)
+		if err != nil {
+			return err
+		}
+
+		return b.esdtSupplyHandler.AddToSupplySystemAcc(tokenID, transferValue)
+	}
 
 	return nil
 }
@@ -33,21 +33,23 @@ func newBaseGuardAccount(args GuardAccountArgs) (*baseGuardAccount, error) {
 	return baseGuardAcc, nil
 }
 
+// checkGuardAccountArgs returns the active guardian set of the account, or nil if the account is protected by a
+// single guardian
 func (bfa *baseGuardAccount) checkGuardAccountArgs(
 	acntSnd vmcommon.UserAccountHandler,
 	vmInput *vmcommon.ContractCallInput,
-) error {
+) (*vmcommon.GuardianSet, error) {
 	if check.IfNil(acntSnd) {
-		return fmt.Errorf("%w for sender", ErrNilUserAccount)
+		return nil, fmt.Errorf("%w for sender", ErrNilUserAccount)
 	}
 	if vmInput == nil {
-		return ErrNilVmInput
+		return nil, ErrNilVmInput
 	}
 
 	senderAddr := acntSnd.AddressBytes()
 	senderIsNotCaller := !bytes.Equal(senderAddr, vmInput.CallerAddr)
 	if senderIsNotCaller {
-		return ErrOperationNotPermitted
+		return nil, ErrOperationNotPermitted
 	}
 	err := bfa.checkBaseAccountGuarderArgs(
 		senderAddr,
@@ -57,12 +59,17 @@ func (bfa *baseGuardAccount) checkGuardAccountArgs(
 		vmInput.Arguments,
 		noOfArgsGuardAccount)
 	if err != nil {
-		return err
+		return nil, err
+	}
+
+	guardianSet, err := bfa.getActiveGuardianSet(acntSnd)
+	if err != nil || guardianSet != nil {
+		return guardianSet, err
 	}
 
 	// cannot guard if account has no active guardian
 	_, err = bfa.guardedAccountHandler.GetActiveGuardian(acntSnd)
-	return err
+	return nil, err
 }
 
 func getCodeMetaData(account vmcommon.UserAccountHandler) vmcommon.CodeMetadata {
@@ -69,7 +69,7 @@ func (c *changeOwnerAddress) ProcessBuiltinFunction(
 	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: gasRemaining}
 
 	if check.IfNil(acntDst) {
-		c.addOutputTransferToVmOutputForCallThroughSC(acntDst, vmInput, vmOutput)
+		addOutputTransferForOwnerCallThroughSC(c.enableEpochsHandler, core.BuiltInFunctionChangeOwnerAddress, acntDst, vmInput, vmOutput)
 		return vmOutput, nil
 	}
 
@@ -81,6 +81,10 @@ func (c *changeOwnerAddress) ProcessBuiltinFunction(
 	if err != nil {
 		return nil, err
 	}
+	err = c.removePendingOwnerAddress(acntDst)
+	if err != nil {
+		return nil, err
+	}
 
 	logEntry := &vmcommon.LogEntry{
 		Identifier: []byte(vmInput.Function),
@@ -93,8 +97,16 @@ func (c *changeOwnerAddress) ProcessBuiltinFunction(
 	return vmOutput, nil
 }
 
-func (c *changeOwnerAddress) addOutputTransferToVmOutputForCallThroughSC(acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput, vmOutput *vmcommon.VMOutput) {
-	if !c.enableEpochsHandler.IsFlagEnabled(IsChangeOwnerAddressCrossShardThroughSCFlag) {
+// addOutputTransferForOwnerCallThroughSC forwards the ownership built-in functions called by a smart contract to the
+// shard of the destination contract
+func addOutputTransferForOwnerCallThroughSC(
+	enableEpochsHandler vmcommon.EnableEpochsHandler,
+	function string,
+	acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+	vmOutput *vmcommon.VMOutput,
+) {
+	if !enableEpochsHandler.IsFlagEnabled(IsChangeOwnerAddressCrossShardThroughSCFlag) {
 		return
 	}
 
@@ -106,7 +118,7 @@ func (c *changeOwnerAddress) addOutputTransferToVmOutputForCallThroughSC(acntDst
 	addOutputTransferToVMOutput(
 		1,
 		vmInput.CallerAddr,
-		core.BuiltInFunctionChangeOwnerAddress,
+		function,
 		vmInput.Arguments,
 		vmInput.RecipientAddr,
 		vmInput.GasLocked,
@@ -114,6 +126,24 @@ func (c *changeOwnerAddress) addOutputTransferToVmOutputForCallThroughSC(acntDst
 		vmOutput)
 }
 
+// removePendingOwnerAddress drops the owner proposed by the previous owner, which could otherwise still accept the
+// ownership
+func (c *changeOwnerAddress) removePendingOwnerAddress(acntDst vmcommon.UserAccountHandler) error {
+	if !c.enableEpochsHandler.IsFlagEnabled(TwoStepOwnershipTransferFlag) {
+		return nil
+	}
+
+	_, err := getPendingOwnerAddress(acntDst)
+	if err == ErrNoPendingOwnerAddress {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	return acntDst.AccountDataHandler().SaveKeyValue([]byte(pendingOwnerAddressKey), nil)
+}
+
 func computeGasRemainingIfNeeded(snd vmcommon.UserAccountHandler, gasProvided uint64, gasToUse uint64, noGasUse bool) uint64 {
 	if noGasUse {
 		return gasProvided
@@ -130,3 +130,35 @@ func TestProcessBuiltInFunctionCallThroughSC(t *testing.T) {
 	require.Equal(t, []byte("ChangeOwnerAddress@3030303030303030303030"), outputTransfer.Data)
 	require.Equal(t, vm.DirectCall, outputTransfer.CallType)
 }
+
+func TestChangeOwnerAddress_ProcessBuiltinFunctionRemovesPendingOwner(t *testing.T) {
+	t.Parallel()
+
+	coa, _ := NewChangeOwnerAddressFunc(1, &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return flag == TwoStepOwnershipTransferFlag
+		},
+	})
+
+	owner := []byte("owner123")
+	newOwner := []byte("other123")
+	acc := mock.NewUserAccount([]byte("contract"))
+	acc.OwnerAddress = owner
+	_ = acc.AccountDataHandler().SaveKeyValue([]byte(pendingOwnerAddressKey), []byte("pending1"))
+
+	vmInput := &vmcommon.ContractCallInput{
+		Function: core.BuiltInFunctionChangeOwnerAddress,
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  owner,
+			CallValue:   big.NewInt(0),
+			GasProvided: 10,
+			Arguments:   [][]byte{newOwner},
+		},
+	}
+	_, err := coa.ProcessBuiltinFunction(nil, acc, vmInput)
+	require.Nil(t, err)
+	require.Equal(t, newOwner, acc.OwnerAddress)
+
+	_, err = getPendingOwnerAddress(acc)
+	require.Equal(t, ErrNoPendingOwnerAddress, err)
+}
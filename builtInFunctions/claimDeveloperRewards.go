@@ -12,13 +12,51 @@ import (
 
 type claimDeveloperRewards struct {
 	baseAlwaysActiveHandler
-	gasCost      uint64
-	mutExecution sync.RWMutex
+	gasCost               uint64
+	gasCostPerBeneficiary uint64
+	mutExecution          sync.RWMutex
+
+	enableEpochsHandler vmcommon.EnableEpochsHandler
+	accounts            vmcommon.AccountsAdapter
+	shardCoordinator    vmcommon.Coordinator
+}
+
+// ClaimDeveloperRewardsFuncArgs holds the arguments needed to create the claim developer rewards built-in function
+type ClaimDeveloperRewardsFuncArgs struct {
+	FuncGasCost         uint64
+	EnableEpochsHandler vmcommon.EnableEpochsHandler
+	// GasCostPerBeneficiary is charged for every beneficiary of the developer rewards split, on top of FuncGasCost
+	GasCostPerBeneficiary uint64
+	// Accounts and ShardCoordinator are used to credit the beneficiaries of the developer rewards split which are in
+	// the shard of the contract
+	Accounts         vmcommon.AccountsAdapter
+	ShardCoordinator vmcommon.Coordinator
 }
 
 // NewClaimDeveloperRewardsFunc returns a new developer rewards implementation
-func NewClaimDeveloperRewardsFunc(gasCost uint64) *claimDeveloperRewards {
-	return &claimDeveloperRewards{gasCost: gasCost}
+func NewClaimDeveloperRewardsFunc(args ClaimDeveloperRewardsFuncArgs) (*claimDeveloperRewards, error) {
+	if check.IfNil(args.EnableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+	if check.IfNil(args.Accounts) {
+		return nil, ErrNilAccountsAdapter
+	}
+	if check.IfNil(args.ShardCoordinator) {
+		return nil, ErrNilShardCoordinator
+	}
+
+	return &claimDeveloperRewards{
+		gasCost:               args.FuncGasCost,
+		gasCostPerBeneficiary: args.GasCostPerBeneficiary,
+		enableEpochsHandler:   args.EnableEpochsHandler,
+		accounts:              args.Accounts,
+		shardCoordinator:      args.ShardCoordinator,
+	}, nil
+}
+
+// computeGasCostPerDeveloperRewardsBeneficiary returns the cost of loading and saving the account of a beneficiary
+func computeGasCostPerDeveloperRewardsBeneficiary(builtInCost vmcommon.BuiltInCost) uint64 {
+	return builtInCost.TrieLoadPerNode + builtInCost.TrieStorePerNode
 }
 
 // SetNewGasConfig is called whenever gas cost is changed
@@ -29,10 +67,12 @@ func (c *claimDeveloperRewards) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 
 	c.mutExecution.Lock()
 	c.gasCost = gasCost.BuiltInCost.ClaimDeveloperRewards
+	c.gasCostPerBeneficiary = computeGasCostPerDeveloperRewardsBeneficiary(gasCost.BuiltInCost)
 	c.mutExecution.Unlock()
 }
 
-// ProcessBuiltinFunction processes the protocol built-in smart contract function
+// ProcessBuiltinFunction processes the protocol built-in smart contract function. Besides the function cost, the
+// cost of loading and saving an account is charged for every beneficiary of the developer rewards split
 func (c *claimDeveloperRewards) ProcessBuiltinFunction(
 	acntSnd, acntDst vmcommon.UserAccountHandler,
 	vmInput *vmcommon.ContractCallInput,
@@ -57,7 +97,12 @@ func (c *claimDeveloperRewards) ProcessBuiltinFunction(
 	if !bytes.Equal(vmInput.CallerAddr, acntDst.GetOwnerAddress()) {
 		return nil, ErrOperationNotPermitted
 	}
-	if vmInput.GasProvided < c.gasCost {
+	shares, err := c.getShares(acntDst)
+	if err != nil {
+		return nil, err
+	}
+	gasCost := c.gasCost + uint64(len(shares))*c.gasCostPerBeneficiary
+	if vmInput.GasProvided < gasCost {
 		return nil, ErrNotEnoughGas
 	}
 
@@ -65,11 +110,13 @@ func (c *claimDeveloperRewards) ProcessBuiltinFunction(
 	if err != nil {
 		return nil, err
 	}
+	payouts := computeDeveloperRewardsPayouts(shares, vmInput.CallerAddr, value)
+	ownerValue := payouts[len(payouts)-1].value
 
-	vmOutput := &vmcommon.VMOutput{GasRemaining: gasRemaining, ReturnCode: vmcommon.Ok}
+	vmOutput := &vmcommon.VMOutput{GasRemaining: computeGasRemaining(acntSnd, vmInput.GasProvided, gasCost), ReturnCode: vmcommon.Ok}
 	outTransfer := vmcommon.OutputTransfer{
 		Index:         1,
-		Value:         big.NewInt(0).Set(value),
+		Value:         big.NewInt(0).Set(ownerValue),
 		GasLimit:      0,
 		Data:          nil,
 		CallType:      vm.DirectCall,
@@ -89,26 +136,113 @@ func (c *claimDeveloperRewards) ProcessBuiltinFunction(
 
 	vmOutput.OutputAccounts = make(map[string]*vmcommon.OutputAccount)
 	vmOutput.OutputAccounts[string(outputAcc.Address)] = outputAcc
+	err = c.payBeneficiaries(acntDst, vmInput, vmOutput, payouts[:len(payouts)-1])
+	if err != nil {
+		return nil, err
+	}
 
 	if check.IfNil(acntSnd) {
 		// The call is cross-shard, and we are at the destination shard.
 		addLogEntryForClaimDeveloperRewards(vmInput, vmOutput, value, vmInput.CallerAddr)
+		addPayoutsToClaimDeveloperRewardsLog(vmOutput, payouts)
 		return vmOutput, nil
 	}
 
-	err = acntSnd.AddToBalance(value)
+	err = acntSnd.AddToBalance(ownerValue)
 	if err != nil {
 		return nil, err
 	}
 
 	if vmcommon.IsSmartContractAddress(vmInput.CallerAddr) {
-		vmOutput.OutputAccounts = make(map[string]*vmcommon.OutputAccount)
+		delete(vmOutput.OutputAccounts, string(vmInput.CallerAddr))
 	}
 
 	addLogEntryForClaimDeveloperRewards(vmInput, vmOutput, value, vmInput.CallerAddr)
+	addPayoutsToClaimDeveloperRewardsLog(vmOutput, payouts)
 	return vmOutput, nil
 }
 
+// getShares returns the developer rewards split of the contract. Without a split, the owner receives the whole value
+func (c *claimDeveloperRewards) getShares(acntDst vmcommon.UserAccountHandler) ([]*developerRewardsShare, error) {
+	if !c.enableEpochsHandler.IsFlagEnabled(DeveloperRewardsSplitFlag) {
+		return nil, nil
+	}
+
+	return getDeveloperRewardsSplit(acntDst)
+}
+
+// payBeneficiaries pays the beneficiaries the same way as the owner: the ones in the shard of the contract are
+// credited directly, the output transfers being kept only for the user accounts, while the others are paid by the
+// output transfers sent by the contract
+func (c *claimDeveloperRewards) payBeneficiaries(
+	acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+	vmOutput *vmcommon.VMOutput,
+	payouts []*developerRewardsPayout,
+) error {
+	for i, payout := range payouts {
+		isCredited, err := c.creditBeneficiaryInShard(acntDst, payout)
+		if err != nil {
+			return err
+		}
+		if isCredited && vmcommon.IsSmartContractAddress(payout.address) {
+			continue
+		}
+
+		vmOutput.OutputAccounts[string(payout.address)] = &vmcommon.OutputAccount{
+			Address:      payout.address,
+			BalanceDelta: big.NewInt(0),
+			OutputTransfers: []vmcommon.OutputTransfer{{
+				Index:         uint32(i + 2),
+				Value:         big.NewInt(0).Set(payout.value),
+				CallType:      vm.DirectCall,
+				SenderAddress: vmInput.RecipientAddr,
+			}},
+		}
+	}
+
+	return nil
+}
+
+// creditBeneficiaryInShard adds the payout to the balance of the beneficiary if it is in the shard of the contract
+func (c *claimDeveloperRewards) creditBeneficiaryInShard(acntDst vmcommon.UserAccountHandler, payout *developerRewardsPayout) (bool, error) {
+	if c.shardCoordinator.SelfId() != c.shardCoordinator.ComputeId(payout.address) {
+		return false, nil
+	}
+	if bytes.Equal(payout.address, acntDst.AddressBytes()) {
+		return true, acntDst.AddToBalance(payout.value)
+	}
+
+	accountHandler, err := c.accounts.LoadAccount(payout.address)
+	if err != nil {
+		return false, err
+	}
+	beneficiary, ok := accountHandler.(vmcommon.UserAccountHandler)
+	if !ok {
+		return false, ErrWrongTypeAssertion
+	}
+
+	err = beneficiary.AddToBalance(payout.value)
+	if err != nil {
+		return false, err
+	}
+
+	return true, c.accounts.SaveAccount(beneficiary)
+}
+
+// addPayoutsToClaimDeveloperRewardsLog appends the address and the value of every payout to the log entry, when the
+// rewards were split among several beneficiaries
+func addPayoutsToClaimDeveloperRewardsLog(vmOutput *vmcommon.VMOutput, payouts []*developerRewardsPayout) {
+	if len(payouts) < 2 {
+		return
+	}
+
+	logEntry := vmOutput.Logs[0]
+	for _, payout := range payouts {
+		logEntry.Topics = append(logEntry.Topics, payout.address, payout.value.Bytes())
+	}
+}
+
 func addLogEntryForClaimDeveloperRewards(
 	vmInput *vmcommon.ContractCallInput,
 	vmOutput *vmcommon.VMOutput,
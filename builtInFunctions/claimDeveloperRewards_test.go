@@ -9,10 +9,47 @@ import (
 	"github.com/stretchr/testify/require"
 )
 
+func TestNewClaimDeveloperRewardsFunc(t *testing.T) {
+	t.Parallel()
+
+	cdr, err := NewClaimDeveloperRewardsFunc(ClaimDeveloperRewardsFuncArgs{
+		FuncGasCost: 1,
+	})
+	require.Nil(t, cdr)
+	require.Equal(t, ErrNilEnableEpochsHandler, err)
+
+	cdr, err = NewClaimDeveloperRewardsFunc(ClaimDeveloperRewardsFuncArgs{
+		FuncGasCost:         1,
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		ShardCoordinator:    &mock.ShardCoordinatorStub{},
+	})
+	require.Nil(t, cdr)
+	require.Equal(t, ErrNilAccountsAdapter, err)
+
+	cdr, err = NewClaimDeveloperRewardsFunc(ClaimDeveloperRewardsFuncArgs{
+		FuncGasCost:         1,
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		Accounts:            &mock.AccountsStub{},
+	})
+	require.Nil(t, cdr)
+	require.Equal(t, ErrNilShardCoordinator, err)
+
+	cdr, err = NewClaimDeveloperRewardsFunc(ClaimDeveloperRewardsFuncArgs{
+		FuncGasCost:         1,
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+		Accounts:            &mock.AccountsStub{},
+		ShardCoordinator:    &mock.ShardCoordinatorStub{},
+	})
+	require.Nil(t, err)
+	require.False(t, cdr.IsInterfaceNil())
+}
+
 func TestClaimDeveloperRewards_ProcessBuiltinFunction(t *testing.T) {
 	t.Parallel()
 
-	cdr := claimDeveloperRewards{}
+	cdr := claimDeveloperRewards{
+		enableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+	}
 
 	sender := []byte("sender")
 	acc := mock.NewUserAccount([]byte("addr12"))
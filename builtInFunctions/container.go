@@ -2,29 +2,66 @@ package builtInFunctions
 
 import (
 	"fmt"
+	"sync"
 
+	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 	"github.com/multiversx/mx-chain-vm-common-go/container"
 )
 
 var _ vmcommon.BuiltInFunctionContainer = (*functionContainer)(nil)
+var _ vmcommon.EpochAwareBuiltInFunctionContainer = (*functionContainer)(nil)
+
+type functionVersion struct {
+	flag     core.EnableEpochFlag
+	function vmcommon.BuiltinFunction
+}
+
+type functionSchedule struct {
+	activationFlag   core.EnableEpochFlag
+	deactivationFlag core.EnableEpochFlag
+	versions         []*functionVersion
+}
 
 // functionContainer is an interceptors holder organized by type
 type functionContainer struct {
-	objects *container.MutexMap
+	objects             *container.MutexMap
+	enableEpochsHandler vmcommon.EnableEpochsHandler
+	mutSchedules        sync.RWMutex
+	schedules           map[string]*functionSchedule
 }
 
 // NewBuiltInFunctionContainer will create a new instance of a container
 func NewBuiltInFunctionContainer() *functionContainer {
 	return &functionContainer{
-		objects: container.NewMutexMap(),
+		objects:   container.NewMutexMap(),
+		schedules: make(map[string]*functionSchedule),
+	}
+}
+
+// NewEpochAwareBuiltInFunctionContainer will create a new instance of a container which can schedule the built-in
+// functions by epoch, using the flags of the provided enable epochs handler
+func NewEpochAwareBuiltInFunctionContainer(enableEpochsHandler vmcommon.EnableEpochsHandler) (*functionContainer, error) {
+	if check.IfNil(enableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
 	}
+
+	f := NewBuiltInFunctionContainer()
+	f.enableEpochsHandler = enableEpochsHandler
+
+	return f, nil
 }
 
-// Get returns the object stored at a certain key.
-// Returns an error if the element does not exist
+// Get returns the implementation of the function stored at a certain key which is active in the current epoch.
+// Returns an error if the element does not exist or if it is not active according to its schedule
 func (f *functionContainer) Get(key string) (vmcommon.BuiltinFunction, error) {
+	return f.getScheduled(key, func(flag core.EnableEpochFlag) bool {
+		return f.enableEpochsHandler.IsFlagEnabled(flag)
+	})
+}
+
+func (f *functionContainer) getStored(key string) (vmcommon.BuiltinFunction, error) {
 	value, ok := f.objects.Get(key)
 	if !ok {
 		return nil, fmt.Errorf("%w in function container for key %v", ErrInvalidContainerKey, key)
@@ -69,9 +106,13 @@ func (f *functionContainer) Replace(key string, function vmcommon.BuiltinFunctio
 	return nil
 }
 
-// Remove will remove an object at a given key
+// Remove will remove an object at a given key, together with its schedule
 func (f *functionContainer) Remove(key string) {
 	f.objects.Remove(key)
+
+	f.mutSchedules.Lock()
+	delete(f.schedules, key)
+	f.mutSchedules.Unlock()
 }
 
 // Len returns the length of the added objects
@@ -79,7 +120,7 @@ func (f *functionContainer) Len() int {
 	return f.objects.Len()
 }
 
-// Keys returns all the keys in the containers
+// Keys returns all the keys in the containers, including the functions which are not active
 func (f *functionContainer) Keys() map[string]struct{} {
 	keys := make(map[string]struct{}, f.Len())
 
@@ -95,6 +136,159 @@ func (f *functionContainer) Keys() map[string]struct{} {
 	return keys
 }
 
+// SetActivation sets the flags which introduce and retire the function stored at the given key. An empty
+// activation flag means the function is active from genesis and an empty deactivation flag means it is never retired
+func (f *functionContainer) SetActivation(key string, activationFlag core.EnableEpochFlag, deactivationFlag core.EnableEpochFlag) error {
+	err := f.checkScheduleArguments(key, activationFlag, deactivationFlag)
+	if err != nil {
+		return err
+	}
+
+	f.mutSchedules.Lock()
+	defer f.mutSchedules.Unlock()
+
+	schedule := f.getOrCreateSchedule(key)
+	schedule.activationFlag = activationFlag
+	schedule.deactivationFlag = deactivationFlag
+
+	return nil
+}
+
+// AddVersion adds an implementation of the function stored at the given key which replaces it starting with the
+// epoch the flag is enabled in
+func (f *functionContainer) AddVersion(key string, flag core.EnableEpochFlag, function vmcommon.BuiltinFunction) error {
+	if check.IfNil(function) {
+		return ErrNilContainerElement
+	}
+	if len(flag) == 0 {
+		return ErrEmptyEnableEpochFlag
+	}
+	err := f.checkScheduleArguments(key, flag)
+	if err != nil {
+		return err
+	}
+
+	f.mutSchedules.Lock()
+	defer f.mutSchedules.Unlock()
+
+	schedule := f.getOrCreateSchedule(key)
+	schedule.versions = append(schedule.versions, &functionVersion{
+		flag:     flag,
+		function: function,
+	})
+
+	return nil
+}
+
+func (f *functionContainer) checkScheduleArguments(key string, flags ...core.EnableEpochFlag) error {
+	if check.IfNil(f.enableEpochsHandler) {
+		return ErrNilEnableEpochsHandler
+	}
+	_, found := f.objects.Get(key)
+	if !found {
+		return fmt.Errorf("%w in function container for key %v", ErrInvalidContainerKey, key)
+	}
+
+	for _, flag := range flags {
+		if len(flag) > 0 && !f.enableEpochsHandler.IsFlagDefined(flag) {
+			return fmt.Errorf("%w: %s", ErrUndefinedEnableEpochFlag, flag)
+		}
+	}
+
+	return nil
+}
+
+func (f *functionContainer) getOrCreateSchedule(key string) *functionSchedule {
+	schedule, found := f.schedules[key]
+	if !found {
+		schedule = &functionSchedule{
+			versions: make([]*functionVersion, 0),
+		}
+		f.schedules[key] = schedule
+	}
+
+	return schedule
+}
+
+// GetInEpoch returns the implementation of the function stored at the given key which is active in the provided
+// epoch. When more versions are enabled, the one with the latest activation epoch is returned
+func (f *functionContainer) GetInEpoch(key string, epoch uint32) (vmcommon.BuiltinFunction, error) {
+	function, err := f.getScheduled(key, func(flag core.EnableEpochFlag) bool {
+		return f.enableEpochsHandler.IsFlagEnabledInEpoch(flag, epoch)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w in epoch %d", err, epoch)
+	}
+
+	return function, nil
+}
+
+func (f *functionContainer) getScheduled(key string, isFlagEnabled func(flag core.EnableEpochFlag) bool) (vmcommon.BuiltinFunction, error) {
+	function, err := f.getStored(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mutSchedules.RLock()
+	defer f.mutSchedules.RUnlock()
+
+	schedule, found := f.schedules[key]
+	if !found {
+		return function, nil
+	}
+	if !isScheduleFlagEnabled(schedule.activationFlag, isFlagEnabled, true) ||
+		isScheduleFlagEnabled(schedule.deactivationFlag, isFlagEnabled, false) {
+		return nil, fmt.Errorf("%w: %s", ErrBuiltInFunctionIsNotActive, key)
+	}
+
+	latestActivationEpoch := uint32(0)
+	for _, version := range schedule.versions {
+		if !isFlagEnabled(version.flag) {
+			continue
+		}
+
+		activationEpoch := f.enableEpochsHandler.GetActivationEpoch(version.flag)
+		if activationEpoch >= latestActivationEpoch {
+			latestActivationEpoch = activationEpoch
+			function = version.function
+		}
+	}
+
+	return function, nil
+}
+
+func isScheduleFlagEnabled(flag core.EnableEpochFlag, isFlagEnabled func(flag core.EnableEpochFlag) bool, valueIfEmpty bool) bool {
+	if len(flag) == 0 {
+		return valueIfEmpty
+	}
+
+	return isFlagEnabled(flag)
+}
+
+// GetAllVersions returns the function stored at the given key followed by all its scheduled implementations,
+// regardless of the schedule
+func (f *functionContainer) GetAllVersions(key string) ([]vmcommon.BuiltinFunction, error) {
+	function, err := f.getStored(key)
+	if err != nil {
+		return nil, err
+	}
+
+	f.mutSchedules.RLock()
+	defer f.mutSchedules.RUnlock()
+
+	functions := []vmcommon.BuiltinFunction{function}
+	schedule, found := f.schedules[key]
+	if !found {
+		return functions, nil
+	}
+
+	for _, version := range schedule.versions {
+		functions = append(functions, version.function)
+	}
+
+	return functions, nil
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (f *functionContainer) IsInterfaceNil() bool {
 	return f == nil
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-vm-common-go/mock"
 	"github.com/stretchr/testify/assert"
@@ -156,3 +157,154 @@ func TestBuiltInFunctionContainer_LenShouldWork(t *testing.T) {
 	c.Remove("key1")
 	assert.Equal(t, 1, c.Len())
 }
+
+//------- Epoch schedule
+
+func createEpochAwareContainer(activationEpochs map[core.EnableEpochFlag]uint32) *functionContainer {
+	c, _ := NewEpochAwareBuiltInFunctionContainer(&mock.EnableEpochsHandlerStub{
+		IsFlagDefinedCalled: func(flag core.EnableEpochFlag) bool {
+			_, found := activationEpochs[flag]
+			return found
+		},
+		IsFlagEnabledInEpochCalled: func(flag core.EnableEpochFlag, epoch uint32) bool {
+			return epoch >= activationEpochs[flag]
+		},
+		GetActivationEpochCalled: func(flag core.EnableEpochFlag) uint32 {
+			return activationEpochs[flag]
+		},
+	})
+
+	return c
+}
+
+func TestNewEpochAwareBuiltInFunctionContainer(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewEpochAwareBuiltInFunctionContainer(nil)
+	assert.Nil(t, c)
+	assert.Equal(t, ErrNilEnableEpochsHandler, err)
+
+	c, err = NewEpochAwareBuiltInFunctionContainer(&mock.EnableEpochsHandlerStub{})
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(c))
+}
+
+func TestBuiltInFunctionContainer_SetActivation(t *testing.T) {
+	t.Parallel()
+
+	c := NewBuiltInFunctionContainer()
+	_ = c.Add("key", &mock.BuiltInFunctionStub{})
+	err := c.SetActivation("key", "activation", "")
+	assert.Equal(t, ErrNilEnableEpochsHandler, err)
+
+	c = createEpochAwareContainer(map[core.EnableEpochFlag]uint32{"activation": 5, "deactivation": 10})
+	err = c.SetActivation("key", "activation", "")
+	assert.True(t, errors.Is(err, ErrInvalidContainerKey))
+
+	function := &mock.BuiltInFunctionStub{}
+	_ = c.Add("key", function)
+	err = c.SetActivation("key", "activation", "undefined")
+	assert.True(t, errors.Is(err, ErrUndefinedEnableEpochFlag))
+
+	err = c.SetActivation("key", "activation", "deactivation")
+	assert.Nil(t, err)
+
+	recovered, err := c.GetInEpoch("key", 4)
+	assert.Nil(t, recovered)
+	assert.True(t, errors.Is(err, ErrBuiltInFunctionIsNotActive))
+
+	recovered, err = c.GetInEpoch("key", 5)
+	assert.Nil(t, err)
+	assert.True(t, function == recovered)
+
+	recovered, err = c.GetInEpoch("key", 10)
+	assert.Nil(t, recovered)
+	assert.True(t, errors.Is(err, ErrBuiltInFunctionIsNotActive))
+
+	recovered, err = c.Get("key")
+	assert.Nil(t, recovered)
+	assert.True(t, errors.Is(err, ErrBuiltInFunctionIsNotActive))
+}
+
+func TestBuiltInFunctionContainer_GetShouldFollowTheScheduleInTheCurrentEpoch(t *testing.T) {
+	t.Parallel()
+
+	currentEpoch := uint32(0)
+	activationEpochs := map[core.EnableEpochFlag]uint32{"activation": 5, "deactivation": 10, "v2": 7}
+	c, _ := NewEpochAwareBuiltInFunctionContainer(&mock.EnableEpochsHandlerStub{
+		IsFlagDefinedCalled: func(flag core.EnableEpochFlag) bool {
+			_, found := activationEpochs[flag]
+			return found
+		},
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return currentEpoch >= activationEpochs[flag]
+		},
+		GetActivationEpochCalled: func(flag core.EnableEpochFlag) uint32 {
+			return activationEpochs[flag]
+		},
+	})
+	v1 := &mock.BuiltInFunctionStub{}
+	v2 := &mock.BuiltInFunctionStub{}
+	_ = c.Add("key", v1)
+	_ = c.SetActivation("key", "activation", "deactivation")
+	_ = c.AddVersion("key", "v2", v2)
+
+	_, err := c.Get("key")
+	assert.True(t, errors.Is(err, ErrBuiltInFunctionIsNotActive))
+
+	currentEpoch = 5
+	recovered, err := c.Get("key")
+	assert.Nil(t, err)
+	assert.True(t, v1 == recovered)
+
+	currentEpoch = 7
+	recovered, _ = c.Get("key")
+	assert.True(t, v2 == recovered)
+
+	currentEpoch = 10
+	_, err = c.Get("key")
+	assert.True(t, errors.Is(err, ErrBuiltInFunctionIsNotActive))
+
+	versions, err := c.GetAllVersions("key")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(versions))
+}
+
+func TestBuiltInFunctionContainer_AddVersion(t *testing.T) {
+	t.Parallel()
+
+	c := createEpochAwareContainer(map[core.EnableEpochFlag]uint32{"v2": 5, "v3": 10})
+	v1 := &mock.BuiltInFunctionStub{}
+	v2 := &mock.BuiltInFunctionStub{}
+	v3 := &mock.BuiltInFunctionStub{}
+
+	err := c.AddVersion("key", "v2", v2)
+	assert.True(t, errors.Is(err, ErrInvalidContainerKey))
+
+	_ = c.Add("key", v1)
+	assert.Equal(t, ErrNilContainerElement, c.AddVersion("key", "v2", nil))
+	assert.Equal(t, ErrEmptyEnableEpochFlag, c.AddVersion("key", "", v2))
+	assert.True(t, errors.Is(c.AddVersion("key", "undefined", v2), ErrUndefinedEnableEpochFlag))
+
+	assert.Nil(t, c.AddVersion("key", "v3", v3))
+	assert.Nil(t, c.AddVersion("key", "v2", v2))
+
+	recovered, _ := c.GetInEpoch("key", 0)
+	assert.True(t, v1 == recovered)
+	recovered, _ = c.GetInEpoch("key", 7)
+	assert.True(t, v2 == recovered)
+	recovered, _ = c.GetInEpoch("key", 10)
+	assert.True(t, v3 == recovered)
+
+	versions, err := c.GetAllVersions("key")
+	assert.Nil(t, err)
+	assert.Equal(t, 3, len(versions))
+	assert.True(t, v1 == versions[0])
+
+	c.Remove("key")
+	_ = c.Add("key", v1)
+	recovered, _ = c.GetInEpoch("key", 10)
+	assert.True(t, v1 == recovered)
+	versions, _ = c.GetAllVersions("key")
+	assert.Equal(t, 1, len(versions))
+}
@@ -1,11 +1,13 @@
 package builtInFunctions
 
 import (
-	"github.com/mitchellh/mapstructure"
+	"fmt"
+
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/gasSchedule"
 )
 
 var _ vmcommon.BuiltInFunctionFactory = (*builtInFuncCreator)(nil)
@@ -27,9 +29,20 @@ type ArgsCreateBuiltInFunctionContainer struct {
 	ShardCoordinator                  vmcommon.Coordinator
 	EnableEpochsHandler               vmcommon.EnableEpochsHandler
 	GuardedAccountHandler             vmcommon.GuardedAccountHandler
+	MultiGuardedAccountHandler        vmcommon.MultiGuardedAccountHandler
 	MaxNumOfAddressesForTransferRole  uint32
 	ConfigAddress                     []byte
 	SelfESDTPrefix                    []byte
+	BuiltInFunctionsActivation        map[string]BuiltInFunctionActivation
+	CrossChainPrefixPolicies          []CrossChainPrefixPolicy
+	AccountFreezeAuthorityAddress     []byte
+}
+
+// BuiltInFunctionActivation defines the enable epoch flags which introduce and retire a built-in function. An
+// empty flag means the function is active from genesis, respectively never retired
+type BuiltInFunctionActivation struct {
+	ActivationFlag   core.EnableEpochFlag
+	DeactivationFlag core.EnableEpochFlag
 }
 
 type builtInFuncCreator struct {
@@ -39,16 +52,24 @@ type builtInFuncCreator struct {
 	enableUserNameChange              bool
 	marshaller                        vmcommon.Marshalizer
 	accounts                          vmcommon.AccountsAdapter
-	builtInFunctions                  vmcommon.BuiltInFunctionContainer
+	builtInFunctions                  vmcommon.EpochAwareBuiltInFunctionContainer
 	gasConfig                         *vmcommon.GasCost
 	shardCoordinator                  vmcommon.Coordinator
 	esdtStorageHandler                vmcommon.ESDTNFTStorageHandler
+	esdtSupplyHandler                 vmcommon.ESDTSupplyHandler
 	esdtGlobalSettingsHandler         vmcommon.ESDTGlobalSettingsHandler
 	enableEpochsHandler               vmcommon.EnableEpochsHandler
 	guardedAccountHandler             vmcommon.GuardedAccountHandler
+	multiGuardedAccountHandler        vmcommon.MultiGuardedAccountHandler
 	maxNumOfAddressesForTransferRole  uint32
 	configAddress                     []byte
 	selfESDTPrefix                    []byte
+	builtInFunctionsActivation        map[string]BuiltInFunctionActivation
+	crossChainPrefixPolicies          []CrossChainPrefixPolicy
+	accountFreezeAuthorityAddress     []byte
+	crossChainOperationsRegistry      *crossChainOperationsRegistry
+	esdtVestingHandler                *esdtVestingHandler
+	guardedSpendingPolicyHandler      *guardedSpendingPolicyHandler
 }
 
 // NewBuiltInFunctionsCreator creates a component which will instantiate the built in functions contracts
@@ -88,37 +109,70 @@ func NewBuiltInFunctionsCreator(args ArgsCreateBuiltInFunctionContainer) (*built
 		shardCoordinator:                  args.ShardCoordinator,
 		enableEpochsHandler:               args.EnableEpochsHandler,
 		guardedAccountHandler:             args.GuardedAccountHandler,
+		multiGuardedAccountHandler:        args.MultiGuardedAccountHandler,
 		maxNumOfAddressesForTransferRole:  args.MaxNumOfAddressesForTransferRole,
 		configAddress:                     args.ConfigAddress,
 		selfESDTPrefix:                    args.SelfESDTPrefix,
 		mapWhiteListedCrossChainAddresses: args.MapWhiteListedCrossChainAddresses,
+		builtInFunctionsActivation:        args.BuiltInFunctionsActivation,
+		crossChainPrefixPolicies:          args.CrossChainPrefixPolicies,
+		accountFreezeAuthorityAddress:     args.AccountFreezeAuthorityAddress,
 	}
 
-	b.gasConfig, err = createGasConfig(args.GasMap)
+	b.gasConfig, err = gasSchedule.CreateGasCost(args.GasMap)
+	if err != nil {
+		return nil, err
+	}
+	b.builtInFunctions, err = NewEpochAwareBuiltInFunctionContainer(b.enableEpochsHandler)
+	if err != nil {
+		return nil, err
+	}
+	b.crossChainOperationsRegistry, err = NewCrossChainOperationsRegistry(b.accounts)
+	if err != nil {
+		return nil, err
+	}
+	b.esdtVestingHandler, err = NewESDTVestingHandler(b.enableEpochsHandler)
+	if err != nil {
+		return nil, err
+	}
+	b.guardedSpendingPolicyHandler, err = NewGuardedSpendingPolicyHandler(b.enableEpochsHandler, b.guardedAccountHandler, b.multiGuardedAccountHandler)
 	if err != nil {
 		return nil, err
 	}
-	b.builtInFunctions = NewBuiltInFunctionContainer()
 
 	return b, nil
 }
 
-// GasScheduleChange is called when gas schedule is changed, thus all contracts must be updated
-func (b *builtInFuncCreator) GasScheduleChange(gasSchedule map[string]map[string]uint64) {
-	newGasConfig, err := createGasConfig(gasSchedule)
+// GasScheduleChange is called when gas schedule is changed, thus all contracts must be updated. An invalid gas
+// schedule is logged and the current gas config is kept, use UpdateGasSchedule to get the error
+func (b *builtInFuncCreator) GasScheduleChange(newGasSchedule map[string]map[string]uint64) {
+	err := b.UpdateGasSchedule(newGasSchedule)
 	if err != nil {
-		return
+		log.Error("builtInFuncCreator.GasScheduleChange", "error", err)
+	}
+}
+
+// UpdateGasSchedule validates the new gas schedule and sets it on all the built-in functions. If the gas schedule
+// is not valid, the current gas config is kept and the error is returned
+func (b *builtInFuncCreator) UpdateGasSchedule(newGasSchedule map[string]map[string]uint64) error {
+	newGasConfig, err := gasSchedule.CreateGasCost(newGasSchedule)
+	if err != nil {
+		return err
 	}
 
 	b.gasConfig = newGasConfig
 	for key := range b.builtInFunctions.Keys() {
-		builtInFunc, errGet := b.builtInFunctions.Get(key)
+		builtInFuncs, errGet := b.builtInFunctions.GetAllVersions(key)
 		if errGet != nil {
-			return
+			return errGet
 		}
 
-		builtInFunc.SetNewGasConfig(b.gasConfig)
+		for _, builtInFunc := range builtInFuncs {
+			builtInFunc.SetNewGasConfig(b.gasConfig)
+		}
 	}
+
+	return nil
 }
 
 // NFTStorageHandler will return the esdt storage handler from the built in functions factory
@@ -126,11 +180,27 @@ func (b *builtInFuncCreator) NFTStorageHandler() vmcommon.SimpleESDTNFTStorageHa
 	return b.esdtStorageHandler
 }
 
+// ESDTSupplyHandler will return the handler of the esdt supply counters from the built in functions factory
+func (b *builtInFuncCreator) ESDTSupplyHandler() vmcommon.ESDTSupplyHandler {
+	return b.esdtSupplyHandler
+}
+
 // ESDTGlobalSettingsHandler will return the esdt global settings handler from the built in functions factory
 func (b *builtInFuncCreator) ESDTGlobalSettingsHandler() vmcommon.ESDTGlobalSettingsHandler {
 	return b.esdtGlobalSettingsHandler
 }
 
+// CrossChainOperationsRegistry will return the registry of the processed cross chain operations
+func (b *builtInFuncCreator) CrossChainOperationsRegistry() CrossChainOperationsRegistryHandler {
+	return b.crossChainOperationsRegistry
+}
+
+// UnguardedTransferChecker will return the component which tells if the transfers of a guarded account are covered
+// by its spending policy
+func (b *builtInFuncCreator) UnguardedTransferChecker() UnguardedTransferChecker {
+	return b.guardedSpendingPolicyHandler
+}
+
 // BuiltInFunctionContainer will return the built in function container
 func (b *builtInFuncCreator) BuiltInFunctionContainer() vmcommon.BuiltInFunctionContainer {
 	return b.builtInFunctions
@@ -138,11 +208,33 @@ func (b *builtInFuncCreator) BuiltInFunctionContainer() vmcommon.BuiltInFunction
 
 // CreateBuiltInFunctionContainer will create the list of built-in functions
 func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
+	var err error
+	b.builtInFunctions, err = NewEpochAwareBuiltInFunctionContainer(b.enableEpochsHandler)
+	if err != nil {
+		return err
+	}
 
-	b.builtInFunctions = NewBuiltInFunctionContainer()
 	var newFunc vmcommon.BuiltinFunction
-	newFunc = NewClaimDeveloperRewardsFunc(b.gasConfig.BuiltInCost.ClaimDeveloperRewards)
-	err := b.builtInFunctions.Add(core.BuiltInFunctionClaimDeveloperRewards, newFunc)
+	newFunc, err = NewClaimDeveloperRewardsFunc(ClaimDeveloperRewardsFuncArgs{
+		FuncGasCost:           b.gasConfig.BuiltInCost.ClaimDeveloperRewards,
+		GasCostPerBeneficiary: computeGasCostPerDeveloperRewardsBeneficiary(b.gasConfig.BuiltInCost),
+		EnableEpochsHandler:   b.enableEpochsHandler,
+		Accounts:              b.accounts,
+		ShardCoordinator:      b.shardCoordinator,
+	})
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(core.BuiltInFunctionClaimDeveloperRewards, newFunc)
+	if err != nil {
+		return err
+	}
+
+	newFunc, err = NewSetDeveloperRewardsSplitFunc(b.gasConfig.BuiltInCost.SetDeveloperRewardsSplit, b.gasConfig.BaseOperationCost, b.enableEpochsHandler)
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionSetDeveloperRewardsSplit, newFunc)
 	if err != nil {
 		return err
 	}
@@ -157,6 +249,33 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
+	newFunc, err = NewOwnerAddressTransferFunc(vmcommon.BuiltInFunctionProposeOwnerAddress, b.gasConfig.BuiltInCost.OwnerAddressTransfer, b.enableEpochsHandler)
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionProposeOwnerAddress, newFunc)
+	if err != nil {
+		return err
+	}
+
+	newFunc, err = NewOwnerAddressTransferFunc(vmcommon.BuiltInFunctionAcceptOwnerAddress, b.gasConfig.BuiltInCost.OwnerAddressTransfer, b.enableEpochsHandler)
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionAcceptOwnerAddress, newFunc)
+	if err != nil {
+		return err
+	}
+
+	newFunc, err = NewOwnerAddressTransferFunc(vmcommon.BuiltInFunctionCancelOwnerAddressProposal, b.gasConfig.BuiltInCost.OwnerAddressTransfer, b.enableEpochsHandler)
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionCancelOwnerAddressProposal, newFunc)
+	if err != nil {
+		return err
+	}
+
 	newFunc, err = NewSaveUserNameFunc(b.gasConfig.BuiltInCost.SaveUserName, b.mapDNSAddresses, b.mapDNSV2Addresses, b.enableEpochsHandler)
 	if err != nil {
 		return err
@@ -175,6 +294,33 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
+	newFunc, err = NewRenewUserNameFunc(b.gasConfig.BuiltInCost.RenewUserName, b.mapDNSV2Addresses, b.enableEpochsHandler)
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionRenewUserName, newFunc)
+	if err != nil {
+		return err
+	}
+
+	newFunc, err = NewTransferUserNameFunc(b.gasConfig.BuiltInCost.TransferUserName, b.mapDNSV2Addresses, b.enableEpochsHandler)
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionTransferUserName, newFunc)
+	if err != nil {
+		return err
+	}
+
+	newFunc, err = NewApproveUserNameTransferFunc(b.gasConfig.BuiltInCost.TransferUserName, b.mapDNSV2Addresses, b.enableEpochsHandler)
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionApproveUserNameTransfer, newFunc)
+	if err != nil {
+		return err
+	}
+
 	newFunc, err = NewSaveKeyValueStorageFunc(b.gasConfig.BaseOperationCost, b.gasConfig.BuiltInCost.SaveKeyValue, b.enableEpochsHandler)
 	if err != nil {
 		return err
@@ -200,7 +346,35 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 	}
 	b.esdtGlobalSettingsHandler = globalSettingsFunc
 
-	crossChainTokenCheckerHandler, err := NewCrossChainTokenChecker(b.selfESDTPrefix, b.mapWhiteListedCrossChainAddresses)
+	crossChainTokenCheckerHandler, err := NewCrossChainTokenCheckerWithPolicies(ArgsCrossChainTokenChecker{
+		SelfESDTPrefix:       b.selfESDTPrefix,
+		WhiteListedAddresses: b.mapWhiteListedCrossChainAddresses,
+		Accounts:             b.accounts,
+		PrefixPolicies:       b.crossChainPrefixPolicies,
+	})
+	if err != nil {
+		return err
+	}
+
+	args := ArgsNewESDTDataStorage{
+		Accounts:                      b.accounts,
+		GlobalSettingsHandler:         globalSettingsFunc,
+		Marshalizer:                   b.marshaller,
+		EnableEpochsHandler:           b.enableEpochsHandler,
+		ShardCoordinator:              b.shardCoordinator,
+		CrossChainTokenCheckerHandler: crossChainTokenCheckerHandler,
+	}
+	esdtStorage, err := NewESDTDataStorage(args)
+	if err != nil {
+		return err
+	}
+	b.esdtStorageHandler = esdtStorage
+	b.esdtSupplyHandler = esdtStorage
+
+	transferFeeHandler, err := NewESDTTransferFeeHandler(ArgsNewESDTTransferFeeHandler{
+		Accounts:            b.accounts,
+		EnableEpochsHandler: b.enableEpochsHandler,
+	})
 	if err != nil {
 		return err
 	}
@@ -214,13 +388,19 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewESDTTransferFunc(
-		b.gasConfig.BuiltInCost.ESDTTransfer,
-		b.marshaller,
-		globalSettingsFunc,
-		b.shardCoordinator,
-		setRoleFunc,
-		b.enableEpochsHandler)
+	newFunc, err = NewESDTTransferFunc(ESDTTransferFuncArgs{
+		FuncGasCost:           b.gasConfig.BuiltInCost.ESDTTransfer,
+		Marshaller:            b.marshaller,
+		ESDTSupplyHandler:     b.esdtSupplyHandler,
+		GlobalSettingsHandler: globalSettingsFunc,
+		ShardCoordinator:      b.shardCoordinator,
+		RolesHandler:          setRoleFunc,
+		EnableEpochsHandler:   b.enableEpochsHandler,
+		LockedBalanceHandler:  b.esdtVestingHandler,
+		TransferFeeHandler:    transferFeeHandler,
+		SpendingPolicyHandler: b.guardedSpendingPolicyHandler,
+		AccessListChecker:     globalSettingsFunc,
+	})
 	if err != nil {
 		return err
 	}
@@ -229,7 +409,14 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	newFunc, err = NewESDTBurnFunc(b.gasConfig.BuiltInCost.ESDTBurn, b.marshaller, globalSettingsFunc, b.enableEpochsHandler)
+	newFunc, err = NewESDTBurnFunc(ESDTBurnFuncArgs{
+		FuncGasCost:           b.gasConfig.BuiltInCost.ESDTBurn,
+		Marshaller:            b.marshaller,
+		ESDTSupplyHandler:     b.esdtSupplyHandler,
+		GlobalSettingsHandler: globalSettingsFunc,
+		EnableEpochsHandler:   b.enableEpochsHandler,
+		LockedBalanceHandler:  b.esdtVestingHandler,
+	})
 	if err != nil {
 		return err
 	}
@@ -268,6 +455,8 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		GlobalSettingsHandler: globalSettingsFunc,
 		RolesHandler:          setRoleFunc,
 		EnableEpochsHandler:   b.enableEpochsHandler,
+		ESDTSupplyHandler:     b.esdtSupplyHandler,
+		LockedBalanceHandler:  b.esdtVestingHandler,
 	}
 	newFunc, err = NewESDTLocalBurnFunc(argsEsdtLocalBurn)
 	if err != nil {
@@ -279,11 +468,14 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 	}
 
 	argsLocalMint := ESDTLocalMintBurnFuncArgs{
-		FuncGasCost:           b.gasConfig.BuiltInCost.ESDTLocalMint,
-		Marshaller:            b.marshaller,
-		GlobalSettingsHandler: globalSettingsFunc,
-		RolesHandler:          setRoleFunc,
-		EnableEpochsHandler:   b.enableEpochsHandler,
+		FuncGasCost:                   b.gasConfig.BuiltInCost.ESDTLocalMint,
+		Marshaller:                    b.marshaller,
+		GlobalSettingsHandler:         globalSettingsFunc,
+		RolesHandler:                  setRoleFunc,
+		EnableEpochsHandler:           b.enableEpochsHandler,
+		ESDTSupplyHandler:             b.esdtSupplyHandler,
+		CrossChainTokenCheckerHandler: crossChainTokenCheckerHandler,
+		CrossChainOperationsRegistry:  b.crossChainOperationsRegistry,
 	}
 	newFunc, err = NewESDTLocalMintFunc(argsLocalMint)
 	if err != nil {
@@ -294,19 +486,6 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	args := ArgsNewESDTDataStorage{
-		Accounts:                      b.accounts,
-		GlobalSettingsHandler:         globalSettingsFunc,
-		Marshalizer:                   b.marshaller,
-		EnableEpochsHandler:           b.enableEpochsHandler,
-		ShardCoordinator:              b.shardCoordinator,
-		CrossChainTokenCheckerHandler: crossChainTokenCheckerHandler,
-	}
-	b.esdtStorageHandler, err = NewESDTDataStorage(args)
-	if err != nil {
-		return err
-	}
-
 	newFunc, err = NewESDTNFTAddQuantityFunc(
 		b.gasConfig.BuiltInCost.ESDTNFTAddQuantity,
 		b.esdtStorageHandler,
@@ -341,6 +520,7 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		GasConfig:                     b.gasConfig.BaseOperationCost,
 		GlobalSettingsHandler:         globalSettingsFunc,
 		CrossChainTokenCheckerHandler: crossChainTokenCheckerHandler,
+		CrossChainOperationsRegistry:  b.crossChainOperationsRegistry,
 	}
 	newFunc, err = NewESDTNFTCreateFunc(argsESDTNFTCreate)
 	if err != nil {
@@ -386,7 +566,9 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		b.gasConfig.BaseOperationCost,
 		setRoleFunc,
 		b.esdtStorageHandler,
-		b.enableEpochsHandler)
+		b.enableEpochsHandler,
+		b.guardedSpendingPolicyHandler,
+		globalSettingsFunc)
 	if err != nil {
 		return err
 	}
@@ -430,7 +612,11 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		b.gasConfig.BaseOperationCost,
 		b.enableEpochsHandler,
 		setRoleFunc,
-		b.esdtStorageHandler)
+		b.esdtStorageHandler,
+		b.esdtVestingHandler,
+		transferFeeHandler,
+		b.guardedSpendingPolicyHandler,
+		globalSettingsFunc)
 	if err != nil {
 		return err
 	}
@@ -552,6 +738,96 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
+	newFunc, err = NewESDTGlobalSettingsFunc(
+		b.accounts,
+		b.marshaller,
+		true,
+		vmcommon.BuiltInFunctionESDTSetAllowListRequired,
+		func() bool {
+			return b.enableEpochsHandler.IsFlagEnabled(ESDTAccessListsFlag)
+		},
+	)
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionESDTSetAllowListRequired, newFunc)
+	if err != nil {
+		return err
+	}
+
+	newFunc, err = NewESDTGlobalSettingsFunc(
+		b.accounts,
+		b.marshaller,
+		false,
+		vmcommon.BuiltInFunctionESDTUnSetAllowListRequired,
+		func() bool {
+			return b.enableEpochsHandler.IsFlagEnabled(ESDTAccessListsFlag)
+		},
+	)
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionESDTUnSetAllowListRequired, newFunc)
+	if err != nil {
+		return err
+	}
+
+	newFunc, err = NewESDTAccessListAddressFunc(ArgsNewESDTAccessListAddressFunc{
+		Accounts:            b.accounts,
+		EnableEpochsHandler: b.enableEpochsHandler,
+		IsDenyList:          false,
+		Add:                 true,
+	})
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionESDTAllowListAddAddress, newFunc)
+	if err != nil {
+		return err
+	}
+
+	newFunc, err = NewESDTAccessListAddressFunc(ArgsNewESDTAccessListAddressFunc{
+		Accounts:            b.accounts,
+		EnableEpochsHandler: b.enableEpochsHandler,
+		IsDenyList:          false,
+		Add:                 false,
+	})
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionESDTAllowListDeleteAddress, newFunc)
+	if err != nil {
+		return err
+	}
+
+	newFunc, err = NewESDTAccessListAddressFunc(ArgsNewESDTAccessListAddressFunc{
+		Accounts:            b.accounts,
+		EnableEpochsHandler: b.enableEpochsHandler,
+		IsDenyList:          true,
+		Add:                 true,
+	})
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionESDTDenyListAddAddress, newFunc)
+	if err != nil {
+		return err
+	}
+
+	newFunc, err = NewESDTAccessListAddressFunc(ArgsNewESDTAccessListAddressFunc{
+		Accounts:            b.accounts,
+		EnableEpochsHandler: b.enableEpochsHandler,
+		IsDenyList:          true,
+		Add:                 false,
+	})
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionESDTDenyListDeleteAddress, newFunc)
+	if err != nil {
+		return err
+	}
+
 	argsSetGuardian := SetGuardianArgs{
 		BaseAccountGuarderArgs: b.createBaseAccountGuarderArgs(b.gasConfig.BuiltInCost.SetGuardian),
 	}
@@ -564,6 +840,32 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
+	if !check.IfNil(b.multiGuardedAccountHandler) {
+		argsSetGuardianSet := SetGuardianArgs{
+			BaseAccountGuarderArgs: b.createBaseAccountGuarderArgs(b.gasConfig.BuiltInCost.SetGuardianSet),
+		}
+		newFunc, err = NewSetGuardianSetFunc(argsSetGuardianSet)
+		if err != nil {
+			return err
+		}
+		err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionSetGuardianSet, newFunc)
+		if err != nil {
+			return err
+		}
+	}
+
+	argsSetGuardedSpendingPolicy := SetGuardianArgs{
+		BaseAccountGuarderArgs: b.createBaseAccountGuarderArgs(b.gasConfig.BuiltInCost.SetGuardedSpendingPolicy),
+	}
+	newFunc, err = NewSetGuardedSpendingPolicyFunc(argsSetGuardedSpendingPolicy)
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionSetGuardedSpendingPolicy, newFunc)
+	if err != nil {
+		return err
+	}
+
 	argsGuardAccount := b.createGuardAccountArgs()
 	newFunc, err = NewGuardAccountFunc(argsGuardAccount)
 	if err != nil {
@@ -649,53 +951,214 @@ func (b *builtInFuncCreator) CreateBuiltInFunctionContainer() error {
 		return err
 	}
 
-	return nil
-}
+	argsCrossChainWhiteList := ArgsNewCrossChainWhiteListFunc{
+		Accounts:            b.accounts,
+		AllowedAddress:      b.configAddress,
+		Add:                 true,
+		FuncGasCost:         b.gasConfig.BuiltInCost.CrossChainWhiteList,
+		GasConfig:           b.gasConfig.BaseOperationCost,
+		EnableEpochsHandler: b.enableEpochsHandler,
+	}
+	newFunc, err = NewCrossChainWhiteListFunc(argsCrossChainWhiteList)
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionAddCrossChainWhiteListedAddress, newFunc)
+	if err != nil {
+		return err
+	}
 
-func (b *builtInFuncCreator) createBaseAccountGuarderArgs(funcGasCost uint64) BaseAccountGuarderArgs {
-	return BaseAccountGuarderArgs{
+	argsCrossChainWhiteList.Add = false
+	newFunc, err = NewCrossChainWhiteListFunc(argsCrossChainWhiteList)
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionRemoveCrossChainWhiteListedAddress, newFunc)
+	if err != nil {
+		return err
+	}
+
+	argsBridge := ESDTBridgeFuncArgs{
+		FuncGasCost:                   b.gasConfig.BuiltInCost.ESDTBridgeDeposit,
+		Marshaller:                    b.marshaller,
+		Accounts:                      b.accounts,
+		GlobalSettingsHandler:         globalSettingsFunc,
+		EsdtStorageHandler:            b.esdtStorageHandler,
+		CrossChainTokenCheckerHandler: crossChainTokenCheckerHandler,
+		CrossChainOperationsRegistry:  b.crossChainOperationsRegistry,
+		EnableEpochsHandler:           b.enableEpochsHandler,
+		LockedBalanceHandler:          b.esdtVestingHandler,
+	}
+	newFunc, err = NewESDTBridgeDepositFunc(argsBridge)
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionESDTBridgeDeposit, newFunc)
+	if err != nil {
+		return err
+	}
+
+	argsBridge.FuncGasCost = b.gasConfig.BuiltInCost.ESDTBridgeWithdraw
+	newFunc, err = NewESDTBridgeWithdrawFunc(argsBridge)
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionESDTBridgeWithdraw, newFunc)
+	if err != nil {
+		return err
+	}
+
+	argsNativeToken := ESDTNativeTokenFuncArgs{
+		FuncGasCost:           b.gasConfig.BuiltInCost.ESDTNativeIssue,
 		Marshaller:            b.marshaller,
-		FuncGasCost:           funcGasCost,
-		GuardedAccountHandler: b.guardedAccountHandler,
+		Accounts:              b.accounts,
+		GlobalSettingsHandler: globalSettingsFunc,
+		EsdtStorageHandler:    b.esdtStorageHandler,
+		SelfESDTPrefix:        b.selfESDTPrefix,
 		EnableEpochsHandler:   b.enableEpochsHandler,
 	}
-}
+	newFunc, err = NewESDTNativeIssueFunc(argsNativeToken, vmcommon.BuiltInFunctionESDTNativeIssue)
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionESDTNativeIssue, newFunc)
+	if err != nil {
+		return err
+	}
 
-func (b *builtInFuncCreator) createGuardAccountArgs() GuardAccountArgs {
-	return GuardAccountArgs{
-		BaseAccountGuarderArgs: b.createBaseAccountGuarderArgs(b.gasConfig.BuiltInCost.GuardAccount),
+	newFunc, err = NewESDTNativeIssueFunc(argsNativeToken, vmcommon.BuiltInFunctionESDTNativeIssueCollection)
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionESDTNativeIssueCollection, newFunc)
+	if err != nil {
+		return err
 	}
-}
 
-func createGasConfig(gasMap map[string]map[string]uint64) (*vmcommon.GasCost, error) {
-	baseOps := &vmcommon.BaseOperationCost{}
-	err := mapstructure.Decode(gasMap[core.BaseOperationCostString], baseOps)
+	argsNativeToken.FuncGasCost = b.gasConfig.BuiltInCost.ESDTNativeSetTokenProperties
+	newFunc, err = NewESDTNativeSetTokenPropertiesFunc(argsNativeToken)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionESDTNativeSetTokenProperties, newFunc)
+	if err != nil {
+		return err
 	}
 
-	err = check.ForZeroUintFields(*baseOps)
+	argsNativeToken.FuncGasCost = b.gasConfig.BuiltInCost.ESDTNativeTransferOwnership
+	newFunc, err = NewESDTNativeTransferOwnershipFunc(argsNativeToken)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionESDTNativeTransferOwnership, newFunc)
+	if err != nil {
+		return err
 	}
 
-	builtInOps := &vmcommon.BuiltInCost{}
-	err = mapstructure.Decode(gasMap[core.BuiltInCostString], builtInOps)
+	maxSupplyActiveHandler := func() bool {
+		return b.enableEpochsHandler.IsFlagEnabled(ESDTSupplyTrackingFlag) && b.enableEpochsHandler.IsFlagEnabled(ESDTMaxSupplyFlag)
+	}
+	newFunc, err = NewESDTSetMaxSupplyFunc(b.gasConfig.BuiltInCost.ESDTSetMaxSupply, b.accounts, maxSupplyActiveHandler)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionESDTSetMaxSupply, newFunc)
+	if err != nil {
+		return err
+	}
+
+	argsVesting := ESDTVestingFuncArgs{
+		FuncGasCost:           b.gasConfig.BuiltInCost.ESDTVestingTransfer,
+		Marshaller:            b.marshaller,
+		GlobalSettingsHandler: globalSettingsFunc,
+		RolesHandler:          setRoleFunc,
+		VestingHandler:        b.esdtVestingHandler,
+		AccessListChecker:     globalSettingsFunc,
+		TransferFeeHandler:    transferFeeHandler,
+		SpendingPolicyHandler: b.guardedSpendingPolicyHandler,
+		EnableEpochsHandler:   b.enableEpochsHandler,
+	}
+	newFunc, err = NewESDTVestingTransferFunc(argsVesting)
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionESDTVestingTransfer, newFunc)
+	if err != nil {
+		return err
 	}
 
-	err = check.ForZeroUintFields(*builtInOps)
+	argsVesting.FuncGasCost = b.gasConfig.BuiltInCost.ESDTReleaseVesting
+	newFunc, err = NewESDTReleaseVestingFunc(argsVesting)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionESDTReleaseVesting, newFunc)
+	if err != nil {
+		return err
 	}
 
-	gasCost := vmcommon.GasCost{
-		BaseOperationCost: *baseOps,
-		BuiltInCost:       *builtInOps,
+	newFunc, err = NewESDTSetTransferFeeFunc(b.gasConfig.BuiltInCost.ESDTSetTransferFee, b.accounts, b.enableEpochsHandler)
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionESDTSetTransferFee, newFunc)
+	if err != nil {
+		return err
 	}
 
-	return &gasCost, nil
+	argsFreezeAccount := ArgsNewFreezeAccountFunc{
+		FuncGasCost:         b.gasConfig.BuiltInCost.FreezeAccount,
+		AuthorityAddress:    b.accountFreezeAuthorityAddress,
+		Freeze:              true,
+		EnableEpochsHandler: b.enableEpochsHandler,
+	}
+	newFunc, err = NewFreezeAccountFunc(argsFreezeAccount)
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionFreezeAccount, newFunc)
+	if err != nil {
+		return err
+	}
+
+	argsFreezeAccount.Freeze = false
+	newFunc, err = NewFreezeAccountFunc(argsFreezeAccount)
+	if err != nil {
+		return err
+	}
+	err = b.builtInFunctions.Add(vmcommon.BuiltInFunctionUnFreezeAccount, newFunc)
+	if err != nil {
+		return err
+	}
+
+	return b.setBuiltInFunctionsActivation()
+}
+
+func (b *builtInFuncCreator) setBuiltInFunctionsActivation() error {
+	for function, activation := range b.builtInFunctionsActivation {
+		err := b.builtInFunctions.SetActivation(function, activation.ActivationFlag, activation.DeactivationFlag)
+		if err != nil {
+			return fmt.Errorf("%w for built-in function %s", err, function)
+		}
+	}
+
+	return nil
+}
+
+func (b *builtInFuncCreator) createBaseAccountGuarderArgs(funcGasCost uint64) BaseAccountGuarderArgs {
+	return BaseAccountGuarderArgs{
+		Marshaller:                 b.marshaller,
+		FuncGasCost:                funcGasCost,
+		GuardedAccountHandler:      b.guardedAccountHandler,
+		MultiGuardedAccountHandler: b.multiGuardedAccountHandler,
+		EnableEpochsHandler:        b.enableEpochsHandler,
+	}
+}
+
+func (b *builtInFuncCreator) createGuardAccountArgs() GuardAccountArgs {
+	return GuardAccountArgs{
+		BaseAccountGuarderArgs: b.createBaseAccountGuarderArgs(b.gasConfig.BuiltInCost.GuardAccount),
+	}
 }
 
 // SetBlockchainHook sets the blockchain hook to the needed functions
@@ -704,25 +1167,49 @@ func (b *builtInFuncCreator) SetBlockchainHook(blockchainHook vmcommon.Blockchai
 		return ErrNilBlockchainHook
 	}
 
-	builtInFuncs := b.builtInFunctions.Keys()
-	for funcName := range builtInFuncs {
-		builtInFunc, err := b.builtInFunctions.Get(funcName)
+	// the username expiry and the pruning of the cross chain operations registry depend on the current epoch
+	epochHook, isEpochHook := blockchainHook.(BlockChainEpochHook)
+	if !isEpochHook {
+		return ErrBlockchainHookWithoutCurrentEpoch
+	}
+
+	for funcName := range b.builtInFunctions.Keys() {
+		builtInFuncs, err := b.builtInFunctions.GetAllVersions(funcName)
 		if err != nil {
 			return err
 		}
 
-		esdtBlockchainDataProvider, ok := builtInFunc.(vmcommon.BlockchainDataProvider)
-		if !ok {
-			continue
+		for _, builtInFunc := range builtInFuncs {
+			epochHookSetter, ok := builtInFunc.(blockChainEpochHookSetter)
+			if ok {
+				err = epochHookSetter.SetBlockChainEpochHook(epochHook)
+				if err != nil {
+					return err
+				}
+			}
+
+			esdtBlockchainDataProvider, ok := builtInFunc.(vmcommon.BlockchainDataProvider)
+			if !ok {
+				continue
+			}
+
+			err = esdtBlockchainDataProvider.SetBlockchainHook(blockchainHook)
+			if err != nil {
+				return err
+			}
 		}
+	}
 
-		err = esdtBlockchainDataProvider.SetBlockchainHook(blockchainHook)
-		if err != nil {
-			return err
-		}
+	err := b.esdtVestingHandler.SetBlockchainHook(blockchainHook)
+	if err != nil {
+		return err
+	}
+	err = b.guardedSpendingPolicyHandler.SetBlockchainHook(blockchainHook)
+	if err != nil {
+		return err
 	}
 
-	return nil
+	return b.crossChainOperationsRegistry.SetBlockChainEpochHook(epochHook)
 }
 
 // SetPayableHandler sets the payableCheck interface to the needed functions
@@ -742,19 +1229,21 @@ func (b *builtInFuncCreator) SetPayableHandler(payableHandler vmcommon.PayableHa
 	}
 
 	for _, transferFunc := range listOfTransferFunc {
-		builtInFunc, err := b.builtInFunctions.Get(transferFunc)
+		builtInFuncs, err := b.builtInFunctions.GetAllVersions(transferFunc)
 		if err != nil {
 			return err
 		}
 
-		esdtTransferFunc, ok := builtInFunc.(vmcommon.AcceptPayableChecker)
-		if !ok {
-			return ErrWrongTypeAssertion
-		}
+		for _, builtInFunc := range builtInFuncs {
+			esdtTransferFunc, ok := builtInFunc.(vmcommon.AcceptPayableChecker)
+			if !ok {
+				return ErrWrongTypeAssertion
+			}
 
-		err = esdtTransferFunc.SetPayableChecker(payableChecker)
-		if err != nil {
-			return err
+			err = esdtTransferFunc.SetPayableChecker(payableChecker)
+			if err != nil {
+				return err
+			}
 		}
 	}
 
@@ -7,6 +7,7 @@ import (
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/gasSchedule"
 	"github.com/multiversx/mx-chain-vm-common-go/mock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -26,6 +27,7 @@ func createMockArguments() ArgsCreateBuiltInFunctionContainer {
 		ShardCoordinator:                  mock.NewMultiShardsCoordinatorMock(1),
 		EnableEpochsHandler:               &mock.EnableEpochsHandlerStub{},
 		GuardedAccountHandler:             &mock.GuardedAccountHandlerStub{},
+		MultiGuardedAccountHandler:        &mock.GuardedAccountHandlerStub{},
 		MaxNumOfAddressesForTransferRole:  100,
 		MapWhiteListedCrossChainAddresses: getWhiteListedAddress(),
 	}
@@ -87,6 +89,23 @@ func fillGasMapBuiltInCosts(value uint64) map[string]uint64 {
 	gasMap["ESDTNFTSetNewURIs"] = value
 	gasMap["ESDTNFTUpdate"] = value
 
+	gasMap["CrossChainWhiteList"] = value
+	gasMap["ESDTBridgeDeposit"] = value
+	gasMap["ESDTBridgeWithdraw"] = value
+	gasMap["ESDTNativeIssue"] = value
+	gasMap["ESDTNativeSetTokenProperties"] = value
+	gasMap["ESDTNativeTransferOwnership"] = value
+	gasMap["ESDTSetMaxSupply"] = value
+	gasMap["ESDTVestingTransfer"] = value
+	gasMap["ESDTReleaseVesting"] = value
+	gasMap["ESDTSetTransferFee"] = value
+	gasMap["FreezeAccount"] = value
+	gasMap["SetGuardianSet"] = value
+	gasMap["SetGuardedSpendingPolicy"] = value
+	gasMap["OwnerAddressTransfer"] = value
+	gasMap["SetDeveloperRewardsSplit"] = value
+	gasMap["RenewUserName"] = value
+	gasMap["TransferUserName"] = value
 	return gasMap
 }
 
@@ -162,13 +181,87 @@ func TestCreateBuiltInContainer_GasScheduleChange(t *testing.T) {
 	assert.Equal(t, f.gasConfig.BuiltInCost.ClaimDeveloperRewards, uint64(5))
 }
 
+func TestCreateBuiltInContainer_UpdateGasSchedule(t *testing.T) {
+	args := createMockArguments()
+	f, _ := NewBuiltInFunctionsCreator(args)
+	_ = f.CreateBuiltInFunctionContainer()
+
+	fillGasMapInternal(args.GasMap, 5)
+	delete(args.GasMap[core.BuiltInCostString], "ClaimDeveloperRewards")
+	err := f.UpdateGasSchedule(args.GasMap)
+	assert.True(t, errors.Is(err, gasSchedule.ErrMissingGasCosts))
+	assert.Equal(t, uint64(1), f.gasConfig.BuiltInCost.ESDTTransfer)
+
+	args.GasMap[core.BuiltInCostString]["ClaimDeveloperRewards"] = 0
+	err = f.UpdateGasSchedule(args.GasMap)
+	assert.True(t, errors.Is(err, gasSchedule.ErrZeroGasCosts))
+	assert.Equal(t, uint64(1), f.gasConfig.BuiltInCost.ESDTTransfer)
+
+	args.GasMap[core.BuiltInCostString]["ClaimDeveloperRewards"] = 5
+	err = f.UpdateGasSchedule(args.GasMap)
+	assert.Nil(t, err)
+	assert.Equal(t, uint64(5), f.gasConfig.BuiltInCost.ESDTTransfer)
+}
+
+func TestCreateBuiltInContainer_BuiltInFunctionsActivation(t *testing.T) {
+	args := createMockArguments()
+	args.BuiltInFunctionsActivation = map[string]BuiltInFunctionActivation{"unknown": {}}
+	f, _ := NewBuiltInFunctionsCreator(args)
+	err := f.CreateBuiltInFunctionContainer()
+	assert.True(t, errors.Is(err, ErrInvalidContainerKey))
+
+	args.EnableEpochsHandler = &mock.EnableEpochsHandlerStub{
+		IsFlagDefinedCalled: func(flag core.EnableEpochFlag) bool {
+			return flag != "undefined"
+		},
+		IsFlagEnabledInEpochCalled: func(flag core.EnableEpochFlag, epoch uint32) bool {
+			return epoch >= 3
+		},
+	}
+	args.BuiltInFunctionsActivation = map[string]BuiltInFunctionActivation{
+		core.BuiltInFunctionESDTTransfer: {ActivationFlag: "activation", DeactivationFlag: "undefined"},
+	}
+	f, err = NewBuiltInFunctionsCreator(args)
+	require.Nil(t, err)
+	err = f.CreateBuiltInFunctionContainer()
+	assert.True(t, errors.Is(err, ErrUndefinedEnableEpochFlag))
+
+	args.BuiltInFunctionsActivation = map[string]BuiltInFunctionActivation{
+		core.BuiltInFunctionESDTTransfer: {ActivationFlag: "activation"},
+	}
+	f, _ = NewBuiltInFunctionsCreator(args)
+	err = f.CreateBuiltInFunctionContainer()
+	require.Nil(t, err)
+
+	_, err = f.builtInFunctions.GetInEpoch(core.BuiltInFunctionESDTTransfer, 2)
+	assert.True(t, errors.Is(err, ErrBuiltInFunctionIsNotActive))
+	function, err := f.builtInFunctions.GetInEpoch(core.BuiltInFunctionESDTTransfer, 3)
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(function))
+}
+
+func TestCreateBuiltInContainer_CreateWithoutMultiGuardedAccountHandler(t *testing.T) {
+	args := createMockArguments()
+	args.MultiGuardedAccountHandler = nil
+	f, _ := NewBuiltInFunctionsCreator(args)
+
+	err := f.CreateBuiltInFunctionContainer()
+	assert.Nil(t, err)
+	assert.Equal(t, 70, f.BuiltInFunctionContainer().Len())
+
+	_, err = f.BuiltInFunctionContainer().Get(vmcommon.BuiltInFunctionSetGuardianSet)
+	assert.NotNil(t, err)
+	_, err = f.BuiltInFunctionContainer().Get(core.BuiltInFunctionSetGuardian)
+	assert.Nil(t, err)
+}
+
 func TestCreateBuiltInContainer_Create(t *testing.T) {
 	args := createMockArguments()
 	f, _ := NewBuiltInFunctionsCreator(args)
 
 	err := f.CreateBuiltInFunctionContainer()
 	assert.Nil(t, err)
-	assert.Equal(t, 42, f.BuiltInFunctionContainer().Len())
+	assert.Equal(t, 71, f.BuiltInFunctionContainer().Len())
 
 	err = f.SetPayableHandler(nil)
 	assert.Equal(t, ErrNilPayableHandler, err)
@@ -179,6 +272,9 @@ func TestCreateBuiltInContainer_Create(t *testing.T) {
 	err = f.SetBlockchainHook(nil)
 	assert.Equal(t, ErrNilBlockchainHook, err)
 
+	err = f.SetBlockchainHook(&roundOnlyBlockchainHook{})
+	assert.Equal(t, ErrBlockchainHookWithoutCurrentEpoch, err)
+
 	numSetBlockDataHandlerCalls := 0
 	for funcName := range f.builtInFunctions.Keys() {
 		builtInFunc, _ := f.builtInFunctions.Get(funcName)
@@ -197,9 +293,16 @@ func TestCreateBuiltInContainer_Create(t *testing.T) {
 		assert.Nil(t, err)
 	}
 
-	err = f.SetBlockchainHook(&disabledBlockchainHook{})
+	err = f.SetBlockchainHook(&mock.BlockDataHandlerStub{
+		CurrentEpochCalled: func() uint32 {
+			return 37
+		},
+	})
 	assert.Nil(t, err)
 	assert.Equal(t, 7, numSetBlockDataHandlerCalls)
+	assert.False(t, check.IfNil(f.CrossChainOperationsRegistry()))
+	assert.False(t, check.IfNil(f.UnguardedTransferChecker()))
+	assert.Equal(t, uint32(37), f.crossChainOperationsRegistry.currentEpoch())
 
 	fillGasMapInternal(args.GasMap, 5)
 	f.GasScheduleChange(args.GasMap)
@@ -207,4 +310,15 @@ func TestCreateBuiltInContainer_Create(t *testing.T) {
 
 	nftStorageHandler := f.NFTStorageHandler()
 	assert.False(t, check.IfNil(nftStorageHandler))
+	assert.False(t, check.IfNil(f.ESDTSupplyHandler()))
+}
+
+type roundOnlyBlockchainHook struct{}
+
+func (r *roundOnlyBlockchainHook) CurrentRound() uint64 {
+	return 0
+}
+
+func (r *roundOnlyBlockchainHook) IsInterfaceNil() bool {
+	return r == nil
 }
@@ -0,0 +1,236 @@
+package builtInFunctions
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const (
+	crossChainOperationKeyPrefix           = core.ProtectedKeyPrefix + "crossChainOperationID"
+	crossChainOperationsCountKeyPrefix     = core.ProtectedKeyPrefix + "crossChainOperationsCount"
+	crossChainOperationsIndexKeyPrefix     = core.ProtectedKeyPrefix + "crossChainOperationsIndex"
+	crossChainOperationsOldestEpochKey     = core.ProtectedKeyPrefix + "crossChainOperationsOldestEpoch"
+	maxCrossChainOperationIDLength         = 64
+	crossChainOperationsEpochEncodedLength = 4
+)
+
+type crossChainOperationsRegistry struct {
+	accounts     vmcommon.AccountsAdapter
+	epochHook    BlockChainEpochHook
+	mutEpochHook sync.RWMutex
+}
+
+// NewCrossChainOperationsRegistry creates the registry of processed cross chain operations, saved on the system
+// account. Each operation is recorded together with the epoch in which it was processed, so that old operations
+// can be pruned
+func NewCrossChainOperationsRegistry(accounts vmcommon.AccountsAdapter) (*crossChainOperationsRegistry, error) {
+	if check.IfNil(accounts) {
+		return nil, ErrNilAccountsAdapter
+	}
+
+	return &crossChainOperationsRegistry{
+		accounts:  accounts,
+		epochHook: &disabledBlockchainHook{},
+	}, nil
+}
+
+// SetBlockChainEpochHook sets the component providing the epoch in which the operations are registered
+func (r *crossChainOperationsRegistry) SetBlockChainEpochHook(epochHook BlockChainEpochHook) error {
+	if check.IfNil(epochHook) {
+		return ErrNilBlockchainHook
+	}
+
+	r.mutEpochHook.Lock()
+	r.epochHook = epochHook
+	r.mutEpochHook.Unlock()
+
+	return nil
+}
+
+// RegisterOperation records the operation as processed in the current epoch. Returns an error if the operation
+// was already processed
+func (r *crossChainOperationsRegistry) RegisterOperation(operationID []byte) error {
+	err := checkCrossChainOperationID(operationID)
+	if err != nil {
+		return err
+	}
+
+	systemAcc, err := getSystemAccount(r.accounts)
+	if err != nil {
+		return err
+	}
+
+	isProcessed, err := isCrossChainOperationProcessed(systemAcc, operationID)
+	if err != nil {
+		return err
+	}
+	if isProcessed {
+		return fmt.Errorf("%w: %x", ErrCrossChainOperationAlreadyProcessed, operationID)
+	}
+
+	epoch := r.currentEpoch()
+	err = systemAcc.AccountDataHandler().SaveKeyValue(getCrossChainOperationKey(operationID), encodeCrossChainOperationsEpoch(epoch))
+	if err != nil {
+		return err
+	}
+
+	numOperations, err := getUint64FromKey(systemAcc, getCrossChainOperationsCountKey(epoch))
+	if err != nil {
+		return err
+	}
+	err = systemAcc.AccountDataHandler().SaveKeyValue(getCrossChainOperationsIndexKey(epoch, numOperations), operationID)
+	if err != nil {
+		return err
+	}
+	err = systemAcc.AccountDataHandler().SaveKeyValue(getCrossChainOperationsCountKey(epoch), big.NewInt(0).SetUint64(numOperations+1).Bytes())
+	if err != nil {
+		return err
+	}
+
+	return r.accounts.SaveAccount(systemAcc)
+}
+
+// IsOperationProcessed returns true if the operation was processed and was not pruned yet, or if it is a bridge
+// withdraw operation, as those are never pruned
+func (r *crossChainOperationsRegistry) IsOperationProcessed(operationID []byte) (bool, error) {
+	err := checkCrossChainOperationID(operationID)
+	if err != nil {
+		return false, err
+	}
+
+	systemAcc, err := getSystemAccount(r.accounts)
+	if err != nil {
+		return false, err
+	}
+
+	return isCrossChainOperationProcessed(systemAcc, operationID)
+}
+
+// PruneOperations removes all the operations registered in an epoch lower than the provided one. It is meant to be
+// called by the node, through the registry exposed by the built-in functions creator. The bridge withdraw operations
+// are not kept in this registry, so they are never pruned
+func (r *crossChainOperationsRegistry) PruneOperations(epoch uint32) error {
+	systemAcc, err := getSystemAccount(r.accounts)
+	if err != nil {
+		return err
+	}
+
+	oldestEpoch, err := getUint64FromKey(systemAcc, []byte(crossChainOperationsOldestEpochKey))
+	if err != nil {
+		return err
+	}
+	if uint64(epoch) <= oldestEpoch {
+		return nil
+	}
+
+	for prunedEpoch := uint32(oldestEpoch); prunedEpoch < epoch; prunedEpoch++ {
+		err = pruneCrossChainOperationsInEpoch(systemAcc, prunedEpoch)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = systemAcc.AccountDataHandler().SaveKeyValue([]byte(crossChainOperationsOldestEpochKey), big.NewInt(int64(epoch)).Bytes())
+	if err != nil {
+		return err
+	}
+
+	return r.accounts.SaveAccount(systemAcc)
+}
+
+func pruneCrossChainOperationsInEpoch(systemAcc vmcommon.UserAccountHandler, epoch uint32) error {
+	countKey := getCrossChainOperationsCountKey(epoch)
+	numOperations, err := getUint64FromKey(systemAcc, countKey)
+	if err != nil {
+		return err
+	}
+
+	for index := uint64(0); index < numOperations; index++ {
+		indexKey := getCrossChainOperationsIndexKey(epoch, index)
+		operationID, _, errRetrieve := systemAcc.AccountDataHandler().RetrieveValue(indexKey)
+		if core.IsGetNodeFromDBError(errRetrieve) {
+			return errRetrieve
+		}
+
+		if len(operationID) > 0 {
+			err = systemAcc.AccountDataHandler().SaveKeyValue(getCrossChainOperationKey(operationID), nil)
+			if err != nil {
+				return err
+			}
+		}
+		err = systemAcc.AccountDataHandler().SaveKeyValue(indexKey, nil)
+		if err != nil {
+			return err
+		}
+	}
+
+	return systemAcc.AccountDataHandler().SaveKeyValue(countKey, nil)
+}
+
+func (r *crossChainOperationsRegistry) currentEpoch() uint32 {
+	r.mutEpochHook.RLock()
+	defer r.mutEpochHook.RUnlock()
+
+	return r.epochHook.CurrentEpoch()
+}
+
+func checkCrossChainOperationID(operationID []byte) error {
+	if len(operationID) == 0 || len(operationID) > maxCrossChainOperationIDLength {
+		return fmt.Errorf("%w, length should be between 1 and %d", ErrInvalidCrossChainOperationID, maxCrossChainOperationIDLength)
+	}
+
+	return nil
+}
+
+func isCrossChainOperationProcessed(systemAcc vmcommon.UserAccountHandler, operationID []byte) (bool, error) {
+	epoch, _, err := systemAcc.AccountDataHandler().RetrieveValue(getCrossChainOperationKey(operationID))
+	if core.IsGetNodeFromDBError(err) {
+		return false, err
+	}
+
+	if err == nil && len(epoch) > 0 {
+		return true, nil
+	}
+
+	return wasBridgeOperationExecuted(systemAcc, operationID)
+}
+
+func getUint64FromKey(systemAcc vmcommon.UserAccountHandler, key []byte) (uint64, error) {
+	value, _, err := systemAcc.AccountDataHandler().RetrieveValue(key)
+	if core.IsGetNodeFromDBError(err) {
+		return 0, err
+	}
+
+	return getUIn46FromBytes(value), nil
+}
+
+func encodeCrossChainOperationsEpoch(epoch uint32) []byte {
+	encodedEpoch := make([]byte, crossChainOperationsEpochEncodedLength)
+	binary.BigEndian.PutUint32(encodedEpoch, epoch)
+
+	return encodedEpoch
+}
+
+func getCrossChainOperationKey(operationID []byte) []byte {
+	return append([]byte(crossChainOperationKeyPrefix), operationID...)
+}
+
+func getCrossChainOperationsCountKey(epoch uint32) []byte {
+	return append([]byte(crossChainOperationsCountKeyPrefix), encodeCrossChainOperationsEpoch(epoch)...)
+}
+
+func getCrossChainOperationsIndexKey(epoch uint32, index uint64) []byte {
+	key := append([]byte(crossChainOperationsIndexKeyPrefix), encodeCrossChainOperationsEpoch(epoch)...)
+	return append(key, big.NewInt(0).SetUint64(index).Bytes()...)
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (r *crossChainOperationsRegistry) IsInterfaceNil() bool {
+	return r == nil
+}
@@ -0,0 +1,127 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewCrossChainOperationsRegistry(t *testing.T) {
+	t.Parallel()
+
+	registry, err := NewCrossChainOperationsRegistry(nil)
+	assert.Nil(t, registry)
+	assert.Equal(t, ErrNilAccountsAdapter, err)
+
+	registry, err = NewCrossChainOperationsRegistry(&mock.AccountsStub{})
+	assert.Nil(t, err)
+	assert.False(t, registry.IsInterfaceNil())
+	assert.Equal(t, ErrNilBlockchainHook, registry.SetBlockChainEpochHook(nil))
+}
+
+func TestCrossChainOperationsRegistry_RegisterOperation(t *testing.T) {
+	t.Parallel()
+
+	registry, _ := NewCrossChainOperationsRegistry(createAccountsWithSystemAccount(mock.NewAccountWrapMock(vmcommon.SystemAccountAddress)))
+	operationID := []byte("operationID")
+
+	err := registry.RegisterOperation(nil)
+	assert.ErrorIs(t, err, ErrInvalidCrossChainOperationID)
+	_, err = registry.IsOperationProcessed(make([]byte, maxCrossChainOperationIDLength+1))
+	assert.ErrorIs(t, err, ErrInvalidCrossChainOperationID)
+
+	isProcessed, err := registry.IsOperationProcessed(operationID)
+	require.Nil(t, err)
+	assert.False(t, isProcessed)
+
+	err = registry.RegisterOperation(operationID)
+	require.Nil(t, err)
+	isProcessed, err = registry.IsOperationProcessed(operationID)
+	require.Nil(t, err)
+	assert.True(t, isProcessed)
+
+	err = registry.RegisterOperation(operationID)
+	assert.ErrorIs(t, err, ErrCrossChainOperationAlreadyProcessed)
+}
+
+func TestCrossChainOperationsRegistry_StorageErrors(t *testing.T) {
+	t.Parallel()
+
+	expectedErr := errors.New("expected error")
+	registry, _ := NewCrossChainOperationsRegistry(&mock.AccountsStub{
+		LoadAccountCalled: func(_ []byte) (vmcommon.AccountHandler, error) {
+			return nil, expectedErr
+		},
+	})
+	assert.Equal(t, expectedErr, registry.RegisterOperation([]byte("operationID")))
+	_, err := registry.IsOperationProcessed([]byte("operationID"))
+	assert.Equal(t, expectedErr, err)
+	assert.Equal(t, expectedErr, registry.PruneOperations(1))
+
+	getNodeErr := core.NewGetNodeFromDBErrWithKey([]byte("key"), expectedErr, "")
+	registry, _ = NewCrossChainOperationsRegistry(&mock.AccountsStub{
+		LoadAccountCalled: func(_ []byte) (vmcommon.AccountHandler, error) {
+			return &mock.AccountWrapMock{
+				RetrieveValueCalled: func(_ []byte) ([]byte, uint32, error) {
+					return nil, 0, getNodeErr
+				},
+			}, nil
+		},
+	})
+	assert.Equal(t, getNodeErr, registry.RegisterOperation([]byte("operationID")))
+	_, err = registry.IsOperationProcessed([]byte("operationID"))
+	assert.Equal(t, getNodeErr, err)
+}
+
+func TestCrossChainOperationsRegistry_PruneOperations(t *testing.T) {
+	t.Parallel()
+
+	registry, _ := NewCrossChainOperationsRegistry(createAccountsWithSystemAccount(mock.NewAccountWrapMock(vmcommon.SystemAccountAddress)))
+	currentEpoch := uint32(0)
+	_ = registry.SetBlockChainEpochHook(&mock.BlockChainEpochHookStub{
+		CurrentEpochCalled: func() uint32 {
+			return currentEpoch
+		},
+	})
+
+	operations := map[uint32][][]byte{
+		0: {[]byte("op0a"), []byte("op0b")},
+		2: {[]byte("op2")},
+		3: {[]byte("op3a"), []byte("op3b")},
+	}
+	for _, epoch := range []uint32{0, 2, 3} {
+		currentEpoch = epoch
+		for _, operationID := range operations[epoch] {
+			require.Nil(t, registry.RegisterOperation(operationID))
+		}
+	}
+	checkProcessed := func(epoch uint32, expected bool) {
+		for _, operationID := range operations[epoch] {
+			isProcessed, err := registry.IsOperationProcessed(operationID)
+			require.Nil(t, err)
+			assert.Equal(t, expected, isProcessed, string(operationID))
+		}
+	}
+
+	require.Nil(t, registry.PruneOperations(3))
+	checkProcessed(0, false)
+	checkProcessed(2, false)
+	checkProcessed(3, true)
+
+	// pruning an already pruned epoch does nothing
+	require.Nil(t, registry.PruneOperations(1))
+	checkProcessed(3, true)
+
+	// a pruned operation can be registered again
+	currentEpoch = 4
+	require.Nil(t, registry.RegisterOperation([]byte("op0a")))
+	require.Nil(t, registry.PruneOperations(4))
+	checkProcessed(3, false)
+	isProcessed, _ := registry.IsOperationProcessed([]byte("op0a"))
+	assert.True(t, isProcessed)
+}
@@ -5,13 +5,41 @@ import (
 	"fmt"
 	"sync"
 
+	"github.com/multiversx/mx-chain-core-go/core/check"
 	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 )
 
+var _ CrossChainActionCheckerHandler = (*crossChainTokenChecker)(nil)
+var _ CrossChainWhiteListCheckerHandler = (*crossChainTokenChecker)(nil)
+
+// CrossChainPrefixPolicy defines the rules applied to the cross chain tokens having the given prefix. An empty
+// prefix refers to the tokens without prefix, issued on the main chain
+type CrossChainPrefixPolicy struct {
+	Prefix               string
+	WhiteListedAddresses map[string]struct{}
+	AllowedActions       []string
+}
+
+// ArgsCrossChainTokenChecker defines the arguments needed to create a cross chain token checker with per prefix policies
+type ArgsCrossChainTokenChecker struct {
+	SelfESDTPrefix       []byte
+	WhiteListedAddresses map[string]struct{}
+	Accounts             vmcommon.AccountsAdapter
+	PrefixPolicies       []CrossChainPrefixPolicy
+}
+
+type crossChainPolicy struct {
+	whiteListedAddresses map[string]struct{}
+	allowedActions       map[string]struct{}
+}
+
 type crossChainTokenChecker struct {
 	selfESDTPrefix        []byte
 	whiteListedAddresses  map[string]struct{}
 	mutWhiteListedAddress sync.RWMutex
+	accounts              vmcommon.AccountsAdapter
+	policies              map[string]*crossChainPolicy
 }
 
 // NewCrossChainTokenChecker creates a new cross chain token checker
@@ -36,6 +64,63 @@ func NewCrossChainTokenChecker(selfESDTPrefix []byte, whiteListedAddresses map[s
 	return ctc, nil
 }
 
+// NewCrossChainTokenCheckerWithPolicies creates a new cross chain token checker which also takes into account the
+// addresses added to or removed from the whitelist through the built-in functions, as saved on the system account.
+// If prefix policies are provided, only the tokens having one of the configured prefixes are accepted from other chains
+func NewCrossChainTokenCheckerWithPolicies(args ArgsCrossChainTokenChecker) (*crossChainTokenChecker, error) {
+	if check.IfNil(args.Accounts) {
+		return nil, ErrNilAccountsAdapter
+	}
+
+	ctc, err := NewCrossChainTokenChecker(args.SelfESDTPrefix, args.WhiteListedAddresses)
+	if err != nil {
+		return nil, err
+	}
+
+	ctc.accounts = args.Accounts
+	ctc.policies, err = ctc.createPolicies(args.PrefixPolicies)
+	if err != nil {
+		return nil, err
+	}
+
+	return ctc, nil
+}
+
+func (ctc *crossChainTokenChecker) createPolicies(prefixPolicies []CrossChainPrefixPolicy) (map[string]*crossChainPolicy, error) {
+	crossChainActions := getCrossChainActions()
+	policies := make(map[string]*crossChainPolicy, len(prefixPolicies))
+	for _, prefixPolicy := range prefixPolicies {
+		if len(prefixPolicy.Prefix) > 0 && !esdt.IsValidTokenPrefix(prefixPolicy.Prefix) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidTokenPrefix, prefixPolicy.Prefix)
+		}
+		if prefixPolicy.Prefix == string(ctc.selfESDTPrefix) {
+			return nil, fmt.Errorf("%w: %s", ErrSelfESDTPrefixInCrossChainPolicy, prefixPolicy.Prefix)
+		}
+		if _, exists := policies[prefixPolicy.Prefix]; exists {
+			return nil, fmt.Errorf("%w: %s", ErrDuplicatedCrossChainPrefixPolicy, prefixPolicy.Prefix)
+		}
+
+		allowedActions := crossChainActions
+		if len(prefixPolicy.AllowedActions) > 0 {
+			allowedActions = make(map[string]struct{}, len(prefixPolicy.AllowedActions))
+		}
+		for _, action := range prefixPolicy.AllowedActions {
+			if _, isCrossChainAction := crossChainActions[action]; !isCrossChainAction {
+				return nil, fmt.Errorf("%w: %s for prefix %s", ErrInvalidCrossChainAction, action, prefixPolicy.Prefix)
+			}
+
+			allowedActions[action] = struct{}{}
+		}
+
+		policies[prefixPolicy.Prefix] = &crossChainPolicy{
+			whiteListedAddresses: prefixPolicy.WhiteListedAddresses,
+			allowedActions:       allowedActions,
+		}
+	}
+
+	return policies, nil
+}
+
 // IsCrossChainOperation checks if the provided token comes from another chain/sovereign shard
 func (ctc *crossChainTokenChecker) IsCrossChainOperation(tokenID []byte) bool {
 	tokenPrefix, hasPrefix := esdt.IsValidPrefixedToken(string(tokenID))
@@ -49,10 +134,67 @@ func (ctc *crossChainTokenChecker) IsCrossChainOperation(tokenID []byte) bool {
 
 // IsCrossChainOperationAllowed checks whether an address is allowed to mint/create/add quantity a token
 func (ctc *crossChainTokenChecker) IsCrossChainOperationAllowed(address []byte, tokenID []byte) bool {
-	return ctc.isWhiteListed(address) && ctc.IsCrossChainOperation(tokenID)
+	if !ctc.IsWhiteListed(address) || !ctc.IsCrossChainOperation(tokenID) {
+		return false
+	}
+
+	_, isAllowed := ctc.getPolicy(address, tokenID)
+	return isAllowed
 }
 
-func (ctc *crossChainTokenChecker) isWhiteListed(address []byte) bool {
+// IsCrossChainActionAllowed checks whether an address is allowed to execute the provided action on a cross chain
+// token, according to the policy configured for the token prefix
+func (ctc *crossChainTokenChecker) IsCrossChainActionAllowed(address []byte, tokenID []byte, action []byte) bool {
+	if !ctc.IsWhiteListed(address) || !ctc.IsCrossChainOperation(tokenID) {
+		return false
+	}
+
+	policy, isAllowed := ctc.getPolicy(address, tokenID)
+	if !isAllowed {
+		return false
+	}
+	if policy == nil {
+		_, isCrossChainAction := getCrossChainActions()[string(action)]
+		return isCrossChainAction
+	}
+
+	_, isActionAllowed := policy.allowedActions[string(action)]
+	return isActionAllowed
+}
+
+// getPolicy returns the policy configured for the token prefix and whether the address may act on the token. A nil
+// policy is returned if no policies are configured, case in which all the cross chain tokens are accepted
+func (ctc *crossChainTokenChecker) getPolicy(address []byte, tokenID []byte) (*crossChainPolicy, bool) {
+	if len(ctc.policies) == 0 {
+		return nil, true
+	}
+
+	tokenPrefix, _ := esdt.IsValidPrefixedToken(string(tokenID))
+	policy, found := ctc.policies[tokenPrefix]
+	if !found {
+		return nil, false
+	}
+	if len(policy.whiteListedAddresses) == 0 {
+		return policy, true
+	}
+
+	_, isWhiteListed := policy.whiteListedAddresses[string(address)]
+	return policy, isWhiteListed
+}
+
+// IsWhiteListed checks whether an address is whitelisted for cross chain operations
+func (ctc *crossChainTokenChecker) IsWhiteListed(address []byte) bool {
+	if !check.IfNil(ctc.accounts) {
+		isWhiteListed, isSet, err := isWhiteListedOnSystemAccount(ctc.accounts, address)
+		if err != nil {
+			log.Warn("crossChainTokenChecker.IsWhiteListed", "address", address, "error", err)
+			return false
+		}
+		if isSet {
+			return isWhiteListed
+		}
+	}
+
 	ctc.mutWhiteListedAddress.RLock()
 	defer ctc.mutWhiteListedAddress.RUnlock()
 
@@ -60,6 +202,27 @@ func (ctc *crossChainTokenChecker) isWhiteListed(address []byte) bool {
 	return found
 }
 
+// isCrossChainActionAllowed checks the action against the per token prefix policies if the checker enforces them,
+// otherwise it only checks whether the address is allowed to operate on the cross chain token
+func isCrossChainActionAllowed(checker CrossChainTokenCheckerHandler, address []byte, tokenID []byte, action []byte) bool {
+	actionChecker, ok := checker.(CrossChainActionCheckerHandler)
+	if !ok {
+		return checker.IsCrossChainOperationAllowed(address, tokenID)
+	}
+
+	return actionChecker.IsCrossChainActionAllowed(address, tokenID, action)
+}
+
+// isCrossChainWhiteListed returns false if the checker does not expose its whitelist
+func isCrossChainWhiteListed(checker CrossChainTokenCheckerHandler, address []byte) bool {
+	whiteListChecker, ok := checker.(CrossChainWhiteListCheckerHandler)
+	if !ok {
+		return false
+	}
+
+	return whiteListChecker.IsWhiteListed(address)
+}
+
 // IsInterfaceNil checks if the underlying pointer is nil
 func (ctc *crossChainTokenChecker) IsInterfaceNil() bool {
 	return ctc == nil
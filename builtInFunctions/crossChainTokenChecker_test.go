@@ -3,6 +3,9 @@ package builtInFunctions
 import (
 	"testing"
 
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -64,7 +67,7 @@ func TestCrossChainTokenChecker_IsCrossChainOperation(t *testing.T) {
 	})
 }
 
-func TestCrossChainTokenChecker_isWhiteListed(t *testing.T) {
+func TestCrossChainTokenChecker_IsWhiteListed(t *testing.T) {
 	t.Parallel()
 
 	whiteListedAddr1 := "whiteListedAddress1"
@@ -75,10 +78,10 @@ func TestCrossChainTokenChecker_isWhiteListed(t *testing.T) {
 	}
 	ctc, _ := NewCrossChainTokenChecker(nil, whiteListedAddresses)
 
-	require.True(t, ctc.isWhiteListed([]byte(whiteListedAddr1)))
-	require.True(t, ctc.isWhiteListed([]byte(whiteListedAddr2)))
-	require.False(t, ctc.isWhiteListed([]byte("addr3")))
-	require.False(t, ctc.isWhiteListed(nil))
+	require.True(t, ctc.IsWhiteListed([]byte(whiteListedAddr1)))
+	require.True(t, ctc.IsWhiteListed([]byte(whiteListedAddr2)))
+	require.False(t, ctc.IsWhiteListed([]byte("addr3")))
+	require.False(t, ctc.IsWhiteListed(nil))
 }
 
 func TestCrossChainTokenChecker_IsCrossChainOperationAllowed(t *testing.T) {
@@ -103,3 +106,116 @@ func TestCrossChainTokenChecker_IsCrossChainOperationAllowed(t *testing.T) {
 		require.False(t, ctc.IsCrossChainOperationAllowed(whiteListAddr, []byte("pref-ALICE-abcdef")))
 	})
 }
+
+func TestNewCrossChainTokenCheckerWithPolicies(t *testing.T) {
+	t.Parallel()
+
+	createArgs := func(policies ...CrossChainPrefixPolicy) ArgsCrossChainTokenChecker {
+		return ArgsCrossChainTokenChecker{
+			SelfESDTPrefix:       []byte("sov1"),
+			WhiteListedAddresses: getWhiteListedAddress(),
+			Accounts:             &mock.AccountsStub{},
+			PrefixPolicies:       policies,
+		}
+	}
+
+	t.Run("nil accounts adapter, should not work", func(t *testing.T) {
+		args := createArgs()
+		args.Accounts = nil
+		ctc, err := NewCrossChainTokenCheckerWithPolicies(args)
+		require.Equal(t, ErrNilAccountsAdapter, err)
+		require.Nil(t, ctc)
+	})
+
+	t.Run("invalid prefix, should not work", func(t *testing.T) {
+		ctc, err := NewCrossChainTokenCheckerWithPolicies(createArgs(CrossChainPrefixPolicy{Prefix: "PREFIX"}))
+		require.ErrorIs(t, err, ErrInvalidTokenPrefix)
+		require.Nil(t, ctc)
+	})
+
+	t.Run("self prefix, should not work", func(t *testing.T) {
+		ctc, err := NewCrossChainTokenCheckerWithPolicies(createArgs(CrossChainPrefixPolicy{Prefix: "sov1"}))
+		require.ErrorIs(t, err, ErrSelfESDTPrefixInCrossChainPolicy)
+		require.Nil(t, ctc)
+	})
+
+	t.Run("duplicated prefix, should not work", func(t *testing.T) {
+		ctc, err := NewCrossChainTokenCheckerWithPolicies(createArgs(CrossChainPrefixPolicy{Prefix: "sov2"}, CrossChainPrefixPolicy{Prefix: "sov2"}))
+		require.ErrorIs(t, err, ErrDuplicatedCrossChainPrefixPolicy)
+		require.Nil(t, ctc)
+	})
+
+	t.Run("non cross chain action, should not work", func(t *testing.T) {
+		ctc, err := NewCrossChainTokenCheckerWithPolicies(createArgs(CrossChainPrefixPolicy{
+			Prefix:         "sov2",
+			AllowedActions: []string{core.ESDTRoleLocalMint, core.ESDTRoleModifyCreator},
+		}))
+		require.ErrorIs(t, err, ErrInvalidCrossChainAction)
+		require.Nil(t, ctc)
+	})
+
+	t.Run("should work", func(t *testing.T) {
+		ctc, err := NewCrossChainTokenCheckerWithPolicies(createArgs(CrossChainPrefixPolicy{Prefix: ""}, CrossChainPrefixPolicy{Prefix: "sov2"}))
+		require.Nil(t, err)
+		require.Equal(t, 2, len(ctc.policies))
+	})
+}
+
+func TestCrossChainTokenChecker_IsCrossChainActionAllowed(t *testing.T) {
+	t.Parallel()
+
+	whiteListAddr := []byte("whiteListedAddress")
+	bridgeAddr := []byte("bridgeAddress")
+	whiteListedAddresses := map[string]struct{}{
+		string(whiteListAddr): {},
+		string(bridgeAddr):    {},
+	}
+	localMint := []byte(core.ESDTRoleLocalMint)
+	nftCreate := []byte(core.ESDTRoleNFTCreate)
+	accounts := createAccountsWithSystemAccount(mock.NewAccountWrapMock(vmcommon.SystemAccountAddress))
+
+	t.Run("no policies, all cross chain tokens and actions are accepted", func(t *testing.T) {
+		ctc, _ := NewCrossChainTokenCheckerWithPolicies(ArgsCrossChainTokenChecker{
+			SelfESDTPrefix:       []byte("sov1"),
+			WhiteListedAddresses: whiteListedAddresses,
+			Accounts:             accounts,
+		})
+
+		require.True(t, ctc.IsCrossChainActionAllowed(whiteListAddr, []byte("sov2-ALICE-abcdef"), localMint))
+		require.True(t, ctc.IsCrossChainActionAllowed(bridgeAddr, []byte("ALICE-abcdef"), nftCreate))
+		require.False(t, ctc.IsCrossChainActionAllowed(whiteListAddr, []byte("sov2-ALICE-abcdef"), []byte(core.ESDTRoleModifyCreator)))
+		require.False(t, ctc.IsCrossChainActionAllowed(whiteListAddr, []byte("sov1-ALICE-abcdef"), localMint))
+		require.False(t, ctc.IsCrossChainActionAllowed([]byte("anotherAddress"), []byte("sov2-ALICE-abcdef"), localMint))
+	})
+
+	t.Run("per prefix policies", func(t *testing.T) {
+		ctc, _ := NewCrossChainTokenCheckerWithPolicies(ArgsCrossChainTokenChecker{
+			SelfESDTPrefix:       []byte("sov1"),
+			WhiteListedAddresses: whiteListedAddresses,
+			Accounts:             accounts,
+			PrefixPolicies: []CrossChainPrefixPolicy{
+				{
+					Prefix: "",
+				},
+				{
+					Prefix:               "sov2",
+					WhiteListedAddresses: map[string]struct{}{string(bridgeAddr): {}},
+					AllowedActions:       []string{core.ESDTRoleLocalMint, core.ESDTRoleLocalBurn},
+				},
+			},
+		})
+
+		require.True(t, ctc.IsCrossChainActionAllowed(whiteListAddr, []byte("ALICE-abcdef"), nftCreate))
+		require.True(t, ctc.IsCrossChainActionAllowed(bridgeAddr, []byte("ALICE-abcdef"), localMint))
+
+		require.True(t, ctc.IsCrossChainActionAllowed(bridgeAddr, []byte("sov2-ALICE-abcdef"), localMint))
+		require.False(t, ctc.IsCrossChainActionAllowed(bridgeAddr, []byte("sov2-ALICE-abcdef"), nftCreate))
+		require.False(t, ctc.IsCrossChainActionAllowed(whiteListAddr, []byte("sov2-ALICE-abcdef"), localMint))
+		require.True(t, ctc.IsCrossChainOperationAllowed(bridgeAddr, []byte("sov2-ALICE-abcdef")))
+		require.False(t, ctc.IsCrossChainOperationAllowed(whiteListAddr, []byte("sov2-ALICE-abcdef")))
+
+		require.False(t, ctc.IsCrossChainActionAllowed(bridgeAddr, []byte("sov3-ALICE-abcdef"), localMint))
+		require.False(t, ctc.IsCrossChainOperationAllowed(bridgeAddr, []byte("sov3-ALICE-abcdef")))
+		require.True(t, ctc.IsCrossChainOperation([]byte("sov3-ALICE-abcdef")))
+	})
+}
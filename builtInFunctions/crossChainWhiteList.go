@@ -0,0 +1,182 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const crossChainWhiteListKeyPrefix = core.ProtectedKeyPrefix + "crossChainWhiteList"
+
+var (
+	whiteListedMarker    = []byte{1}
+	notWhiteListedMarker = []byte{0}
+)
+
+type crossChainWhiteList struct {
+	baseActiveHandler
+	accounts       vmcommon.AccountsAdapter
+	allowedAddress []byte
+	add            bool
+	function       string
+	funcGasCost    uint64
+	gasConfig      vmcommon.BaseOperationCost
+	mutExecution   sync.RWMutex
+}
+
+// ArgsNewCrossChainWhiteListFunc defines the argument list for the built-in functions which add or remove
+// whitelisted cross chain addresses
+type ArgsNewCrossChainWhiteListFunc struct {
+	Accounts            vmcommon.AccountsAdapter
+	AllowedAddress      []byte
+	Add                 bool
+	FuncGasCost         uint64
+	GasConfig           vmcommon.BaseOperationCost
+	EnableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+// NewCrossChainWhiteListFunc returns the built-in function component which adds or removes whitelisted cross
+// chain addresses. The changes are saved on the system account
+func NewCrossChainWhiteListFunc(args ArgsNewCrossChainWhiteListFunc) (*crossChainWhiteList, error) {
+	if check.IfNil(args.Accounts) {
+		return nil, ErrNilAccountsAdapter
+	}
+	if check.IfNil(args.EnableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+
+	function := vmcommon.BuiltInFunctionRemoveCrossChainWhiteListedAddress
+	if args.Add {
+		function = vmcommon.BuiltInFunctionAddCrossChainWhiteListedAddress
+	}
+
+	c := &crossChainWhiteList{
+		accounts:       args.Accounts,
+		allowedAddress: args.AllowedAddress,
+		add:            args.Add,
+		function:       function,
+		funcGasCost:    args.FuncGasCost,
+		gasConfig:      args.GasConfig,
+	}
+	c.activeHandler = func() bool {
+		return args.EnableEpochsHandler.IsFlagEnabled(CrossChainWhiteListFlag)
+	}
+
+	return c, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (c *crossChainWhiteList) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	c.mutExecution.Lock()
+	c.funcGasCost = gasCost.BuiltInCost.CrossChainWhiteList
+	c.gasConfig = gasCost.BaseOperationCost
+	c.mutExecution.Unlock()
+}
+
+// ProcessBuiltinFunction adds or removes the addresses provided as arguments from the cross chain whitelist. Besides
+// the function cost, the persist cost is charged for every address
+func (c *crossChainWhiteList) ProcessBuiltinFunction(
+	_, _ vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	c.mutExecution.RLock()
+	defer c.mutExecution.RUnlock()
+
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if vmInput.CallValue == nil {
+		return nil, ErrNilValue
+	}
+	if vmInput.CallValue.Cmp(zero) != 0 {
+		return nil, ErrBuiltInFunctionCalledWithValue
+	}
+	if !bytes.Equal(vmInput.CallerAddr, c.allowedAddress) {
+		return nil, ErrAddressIsNotAllowed
+	}
+	if !bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
+		return nil, ErrInvalidRcvAddr
+	}
+	if len(vmInput.Arguments) == 0 {
+		return nil, ErrInvalidNumOfArgs
+	}
+	gasCost := c.funcGasCost
+	for _, address := range vmInput.Arguments {
+		if len(address) != len(vmInput.CallerAddr) {
+			return nil, ErrInvalidAddressLength
+		}
+		gasCost += uint64(len(address)) * c.gasConfig.PersistPerByte
+	}
+	if vmInput.GasProvided < gasCost {
+		return nil, ErrNotEnoughGas
+	}
+
+	systemAcc, err := getSystemAccount(c.accounts)
+	if err != nil {
+		return nil, err
+	}
+
+	marker := notWhiteListedMarker
+	if c.add {
+		marker = whiteListedMarker
+	}
+
+	vmOutput := &vmcommon.VMOutput{
+		ReturnCode:   vmcommon.Ok,
+		GasRemaining: vmInput.GasProvided - gasCost,
+	}
+	for _, address := range vmInput.Arguments {
+		err = systemAcc.AccountDataHandler().SaveKeyValue(crossChainWhiteListKey(address), marker)
+		if err != nil {
+			return nil, err
+		}
+
+		vmOutput.Logs = append(vmOutput.Logs, &vmcommon.LogEntry{
+			Identifier: []byte(c.function),
+			Address:    vmInput.CallerAddr,
+			Topics:     [][]byte{address},
+		})
+	}
+
+	err = c.accounts.SaveAccount(systemAcc)
+	if err != nil {
+		return nil, err
+	}
+
+	return vmOutput, nil
+}
+
+// isWhiteListedOnSystemAccount returns whether the address was added to or removed from the whitelist through the
+// built-in functions. The second returned value is false if no change was saved for the address
+func isWhiteListedOnSystemAccount(accounts vmcommon.AccountsAdapter, address []byte) (bool, bool, error) {
+	systemAcc, err := getSystemAccount(accounts)
+	if err != nil {
+		return false, false, err
+	}
+
+	marker, _, err := systemAcc.AccountDataHandler().RetrieveValue(crossChainWhiteListKey(address))
+	if core.IsGetNodeFromDBError(err) {
+		return false, false, err
+	}
+	if err != nil || len(marker) == 0 {
+		return false, false, nil
+	}
+
+	return bytes.Equal(marker, whiteListedMarker), true, nil
+}
+
+func crossChainWhiteListKey(address []byte) []byte {
+	return append([]byte(crossChainWhiteListKeyPrefix), address...)
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (c *crossChainWhiteList) IsInterfaceNil() bool {
+	return c == nil
+}
@@ -0,0 +1,209 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createAccountsWithSystemAccount(systemAcc *mock.AccountWrapMock) *mock.AccountsStub {
+	return &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			return systemAcc, nil
+		},
+	}
+}
+
+func createCrossChainWhiteListInput(caller []byte, addresses ...[]byte) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  caller,
+			CallValue:   big.NewInt(0),
+			Arguments:   addresses,
+			GasProvided: 1000,
+		},
+		RecipientAddr: caller,
+	}
+}
+
+func createCrossChainWhiteListEnableEpochsHandler() vmcommon.EnableEpochsHandler {
+	return &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return flag == CrossChainWhiteListFlag
+		},
+	}
+}
+
+func TestNewCrossChainWhiteListFunc(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewCrossChainWhiteListFunc(ArgsNewCrossChainWhiteListFunc{})
+	assert.Nil(t, c)
+	assert.Equal(t, ErrNilAccountsAdapter, err)
+
+	c, err = NewCrossChainWhiteListFunc(ArgsNewCrossChainWhiteListFunc{Accounts: &mock.AccountsStub{}})
+	assert.Nil(t, c)
+	assert.Equal(t, ErrNilEnableEpochsHandler, err)
+
+	c, err = NewCrossChainWhiteListFunc(ArgsNewCrossChainWhiteListFunc{
+		Accounts:            &mock.AccountsStub{},
+		Add:                 true,
+		EnableEpochsHandler: createCrossChainWhiteListEnableEpochsHandler(),
+	})
+	assert.Nil(t, err)
+	assert.False(t, c.IsInterfaceNil())
+	assert.True(t, c.IsActive())
+	assert.Equal(t, vmcommon.BuiltInFunctionAddCrossChainWhiteListedAddress, c.function)
+
+	c, _ = NewCrossChainWhiteListFunc(ArgsNewCrossChainWhiteListFunc{
+		Accounts:            &mock.AccountsStub{},
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+	})
+	assert.False(t, c.IsActive())
+
+	c.SetNewGasConfig(&vmcommon.GasCost{
+		BaseOperationCost: vmcommon.BaseOperationCost{PersistPerByte: 3},
+		BuiltInCost:       vmcommon.BuiltInCost{CrossChainWhiteList: 37},
+	})
+	assert.Equal(t, uint64(37), c.funcGasCost)
+	assert.Equal(t, uint64(3), c.gasConfig.PersistPerByte)
+}
+
+func TestCrossChainWhiteList_ProcessBuiltinFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	configAddress := bytes.Repeat([]byte{1}, 32)
+	address := bytes.Repeat([]byte{2}, 32)
+	c, _ := NewCrossChainWhiteListFunc(ArgsNewCrossChainWhiteListFunc{
+		Accounts:            &mock.AccountsStub{},
+		AllowedAddress:      configAddress,
+		Add:                 true,
+		FuncGasCost:         10,
+		GasConfig:           vmcommon.BaseOperationCost{PersistPerByte: 1},
+		EnableEpochsHandler: createCrossChainWhiteListEnableEpochsHandler(),
+	})
+
+	vmOutput, err := c.ProcessBuiltinFunction(nil, nil, nil)
+	assert.Nil(t, vmOutput)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	input := createCrossChainWhiteListInput(configAddress, address)
+	input.CallValue = big.NewInt(1)
+	_, err = c.ProcessBuiltinFunction(nil, nil, input)
+	assert.Equal(t, ErrBuiltInFunctionCalledWithValue, err)
+
+	_, err = c.ProcessBuiltinFunction(nil, nil, createCrossChainWhiteListInput(address, address))
+	assert.Equal(t, ErrAddressIsNotAllowed, err)
+
+	input = createCrossChainWhiteListInput(configAddress, address)
+	input.RecipientAddr = address
+	_, err = c.ProcessBuiltinFunction(nil, nil, input)
+	assert.Equal(t, ErrInvalidRcvAddr, err)
+
+	_, err = c.ProcessBuiltinFunction(nil, nil, createCrossChainWhiteListInput(configAddress))
+	assert.Equal(t, ErrInvalidNumOfArgs, err)
+
+	_, err = c.ProcessBuiltinFunction(nil, nil, createCrossChainWhiteListInput(configAddress, address, []byte("short")))
+	assert.Equal(t, ErrInvalidAddressLength, err)
+
+	input = createCrossChainWhiteListInput(configAddress, address, address)
+	input.GasProvided = 10 + 2*32 - 1
+	_, err = c.ProcessBuiltinFunction(nil, nil, input)
+	assert.Equal(t, ErrNotEnoughGas, err)
+
+	expectedErr := errors.New("expected error")
+	c.accounts = &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			return nil, expectedErr
+		},
+	}
+	_, err = c.ProcessBuiltinFunction(nil, nil, createCrossChainWhiteListInput(configAddress, address))
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestCrossChainWhiteList_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	configAddress := bytes.Repeat([]byte{1}, 32)
+	configWhiteListed := bytes.Repeat([]byte{2}, 32)
+	newWhiteListed := bytes.Repeat([]byte{3}, 32)
+	systemAcc := mock.NewAccountWrapMock(vmcommon.SystemAccountAddress)
+	accounts := createAccountsWithSystemAccount(systemAcc)
+
+	ctc, err := NewCrossChainTokenCheckerWithPolicies(ArgsCrossChainTokenChecker{
+		WhiteListedAddresses: map[string]struct{}{string(configWhiteListed): {}},
+		Accounts:             accounts,
+	})
+	require.Nil(t, err)
+	crossChainToken := []byte("sov1-ALICE-abcdef")
+	assert.True(t, ctc.IsCrossChainOperationAllowed(configWhiteListed, crossChainToken))
+	assert.False(t, ctc.IsCrossChainOperationAllowed(newWhiteListed, crossChainToken))
+
+	args := ArgsNewCrossChainWhiteListFunc{
+		Accounts:            accounts,
+		AllowedAddress:      configAddress,
+		Add:                 true,
+		FuncGasCost:         10,
+		GasConfig:           vmcommon.BaseOperationCost{PersistPerByte: 1},
+		EnableEpochsHandler: createCrossChainWhiteListEnableEpochsHandler(),
+	}
+	addFunc, _ := NewCrossChainWhiteListFunc(args)
+	args.Add = false
+	removeFunc, _ := NewCrossChainWhiteListFunc(args)
+
+	vmOutput, err := addFunc.ProcessBuiltinFunction(nil, nil, createCrossChainWhiteListInput(configAddress, newWhiteListed))
+	require.Nil(t, err)
+	assert.Equal(t, uint64(1000-10-32), vmOutput.GasRemaining)
+	assert.Equal(t, []*vmcommon.LogEntry{
+		{
+			Identifier: []byte(vmcommon.BuiltInFunctionAddCrossChainWhiteListedAddress),
+			Address:    configAddress,
+			Topics:     [][]byte{newWhiteListed},
+		},
+	}, vmOutput.Logs)
+	assert.True(t, ctc.IsCrossChainOperationAllowed(newWhiteListed, crossChainToken))
+	assert.True(t, ctc.IsCrossChainOperationAllowed(configWhiteListed, crossChainToken))
+
+	vmOutput, err = removeFunc.ProcessBuiltinFunction(nil, nil, createCrossChainWhiteListInput(configAddress, configWhiteListed, newWhiteListed))
+	require.Nil(t, err)
+	assert.Equal(t, 2, len(vmOutput.Logs))
+	assert.Equal(t, uint64(1000-10-2*32), vmOutput.GasRemaining)
+	assert.Equal(t, []byte(vmcommon.BuiltInFunctionRemoveCrossChainWhiteListedAddress), vmOutput.Logs[1].Identifier)
+	assert.Equal(t, [][]byte{newWhiteListed}, vmOutput.Logs[1].Topics)
+	assert.False(t, ctc.IsCrossChainOperationAllowed(newWhiteListed, crossChainToken))
+	assert.False(t, ctc.IsCrossChainOperationAllowed(configWhiteListed, crossChainToken))
+
+	_, err = addFunc.ProcessBuiltinFunction(nil, nil, createCrossChainWhiteListInput(configAddress, configWhiteListed))
+	require.Nil(t, err)
+	assert.True(t, ctc.IsCrossChainOperationAllowed(configWhiteListed, crossChainToken))
+}
+
+func TestCrossChainTokenChecker_IsWhiteListedStorageError(t *testing.T) {
+	t.Parallel()
+
+	whiteListed := []byte("whiteListedAddress")
+	ctc, err := NewCrossChainTokenCheckerWithPolicies(ArgsCrossChainTokenChecker{WhiteListedAddresses: getWhiteListedAddress()})
+	assert.Nil(t, ctc)
+	assert.Equal(t, ErrNilAccountsAdapter, err)
+
+	ctc, _ = NewCrossChainTokenCheckerWithPolicies(ArgsCrossChainTokenChecker{
+		WhiteListedAddresses: getWhiteListedAddress(),
+		Accounts: &mock.AccountsStub{
+			LoadAccountCalled: func(_ []byte) (vmcommon.AccountHandler, error) {
+				return &mock.AccountWrapMock{
+					RetrieveValueCalled: func(_ []byte) ([]byte, uint32, error) {
+						return nil, 0, core.NewGetNodeFromDBErrWithKey([]byte("key"), errors.New("error"), "")
+					},
+				}, nil
+			},
+		},
+	})
+	assert.False(t, ctc.IsWhiteListed(whiteListed))
+}
@@ -9,9 +9,10 @@ import (
 
 type deleteUserName struct {
 	baseActiveHandler
-	gasCost         uint64
-	mapDnsAddresses map[string]struct{}
-	mutExecution    sync.RWMutex
+	gasCost             uint64
+	mapDnsAddresses     map[string]struct{}
+	enableEpochsHandler vmcommon.EnableEpochsHandler
+	mutExecution        sync.RWMutex
 }
 
 // NewDeleteUserNameFunc returns a delete username built in function implementation
@@ -28,8 +29,9 @@ func NewDeleteUserNameFunc(
 	}
 
 	d := &deleteUserName{
-		gasCost:         gasCost,
-		mapDnsAddresses: make(map[string]struct{}, len(mapDnsAddresses)),
+		gasCost:             gasCost,
+		mapDnsAddresses:     make(map[string]struct{}, len(mapDnsAddresses)),
+		enableEpochsHandler: enableEpochsHandler,
 	}
 	for key := range mapDnsAddresses {
 		d.mapDnsAddresses[key] = struct{}{}
@@ -71,6 +73,12 @@ func (d *deleteUserName) ProcessBuiltinFunction(
 
 	oldUserName := acntDst.GetUserName()
 	acntDst.SetUserName(nil)
+	if d.enableEpochsHandler.IsFlagEnabled(UserNameLifecycleFlag) {
+		err = acntDst.AccountDataHandler().SaveKeyValue([]byte(userNameExpiryEpochKey), nil)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	gasRemaining := vmInput.GasProvided
 	if !check.IfNil(acntSnd) {
@@ -51,8 +51,9 @@ func TestDeleteUserName_ProcessBuiltinFunction(t *testing.T) {
 	mapDnsAddresses := make(map[string]struct{})
 	mapDnsAddresses[string(dnsAddr)] = struct{}{}
 	d := deleteUserName{
-		gasCost:         100,
-		mapDnsAddresses: mapDnsAddresses,
+		gasCost:             100,
+		mapDnsAddresses:     mapDnsAddresses,
+		enableEpochsHandler: &mock.EnableEpochsHandlerStub{},
 	}
 
 	addr := []byte("addr")
@@ -0,0 +1,245 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const (
+	developerRewardsSplitKey              = core.ProtectedKeyPrefix + "developerRewardsSplit"
+	maxDeveloperRewardsBasisPoints        = 10000
+	maxNumDeveloperRewardsBeneficiaries   = 20
+	developerRewardsBasisPointsLength     = 2
+	numArgsPerDeveloperRewardsBeneficiary = 2
+)
+
+type developerRewardsShare struct {
+	address     []byte
+	basisPoints uint32
+}
+
+type developerRewardsPayout struct {
+	address []byte
+	value   *big.Int
+}
+
+func developerRewardsSplitFromBytes(encoded []byte) ([]*developerRewardsShare, error) {
+	shares := make([]*developerRewardsShare, 0)
+	for len(encoded) > 0 {
+		address, rest, err := readLengthPrefixedField(encoded)
+		if err != nil || len(rest) < developerRewardsBasisPointsLength {
+			return nil, ErrInvalidDeveloperRewardsSplit
+		}
+
+		shares = append(shares, &developerRewardsShare{
+			address:     address,
+			basisPoints: uint32(binary.BigEndian.Uint16(rest[:developerRewardsBasisPointsLength])),
+		})
+		encoded = rest[developerRewardsBasisPointsLength:]
+	}
+
+	return shares, nil
+}
+
+// developerRewardsSplitToBytes encodes every share as the length prefixed address followed by the basis points
+func developerRewardsSplitToBytes(shares []*developerRewardsShare) []byte {
+	encoded := make([]byte, 0)
+	for _, share := range shares {
+		encoded = appendLengthPrefixedField(encoded, share.address)
+		encoded = binary.BigEndian.AppendUint16(encoded, uint16(share.basisPoints))
+	}
+
+	return encoded
+}
+
+func getDeveloperRewardsSplit(contract vmcommon.UserAccountHandler) ([]*developerRewardsShare, error) {
+	val, _, err := contract.AccountDataHandler().RetrieveValue([]byte(developerRewardsSplitKey))
+	if core.IsGetNodeFromDBError(err) {
+		return nil, err
+	}
+	if len(val) == 0 {
+		return nil, nil
+	}
+
+	return developerRewardsSplitFromBytes(val)
+}
+
+// computeDeveloperRewardsPayouts splits the claimed value among the beneficiaries. Every beneficiary receives its
+// share of the value rounded down, the owner receives the rest: the share not assigned to any beneficiary and the
+// rounding remainder. The owner payout is always the last one and includes the share of the owner, if the owner is a
+// beneficiary. The beneficiaries with nothing to receive are left out
+func computeDeveloperRewardsPayouts(shares []*developerRewardsShare, owner []byte, value *big.Int) []*developerRewardsPayout {
+	payouts := make([]*developerRewardsPayout, 0, len(shares)+1)
+	ownerPayout := &developerRewardsPayout{address: owner, value: big.NewInt(0).Set(value)}
+	for _, share := range shares {
+		shareValue := big.NewInt(0).Mul(value, big.NewInt(int64(share.basisPoints)))
+		shareValue.Div(shareValue, big.NewInt(maxDeveloperRewardsBasisPoints))
+		if bytes.Equal(share.address, owner) || shareValue.Sign() == 0 {
+			continue
+		}
+
+		ownerPayout.value.Sub(ownerPayout.value, shareValue)
+		payouts = append(payouts, &developerRewardsPayout{address: share.address, value: shareValue})
+	}
+
+	return append(payouts, ownerPayout)
+}
+
+type setDeveloperRewardsSplit struct {
+	baseActiveHandler
+	gasCost      uint64
+	gasConfig    vmcommon.BaseOperationCost
+	mutExecution sync.RWMutex
+
+	enableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+// NewSetDeveloperRewardsSplitFunc creates the built-in function which sets the table used to split the developer
+// rewards of a smart contract among several beneficiaries
+func NewSetDeveloperRewardsSplitFunc(
+	gasCost uint64,
+	gasConfig vmcommon.BaseOperationCost,
+	enableEpochsHandler vmcommon.EnableEpochsHandler,
+) (*setDeveloperRewardsSplit, error) {
+	if check.IfNil(enableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+
+	s := &setDeveloperRewardsSplit{
+		gasCost:             gasCost,
+		gasConfig:           gasConfig,
+		enableEpochsHandler: enableEpochsHandler,
+	}
+	s.activeHandler = func() bool {
+		return enableEpochsHandler.IsFlagEnabled(DeveloperRewardsSplitFlag)
+	}
+
+	return s, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (s *setDeveloperRewardsSplit) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	s.mutExecution.Lock()
+	s.gasCost = gasCost.BuiltInCost.SetDeveloperRewardsSplit
+	s.gasConfig = gasCost.BaseOperationCost
+	s.mutExecution.Unlock()
+}
+
+// ProcessBuiltinFunction sets the developer rewards split of the destination contract. Only the owner of the contract
+// can call it. No arguments remove the split, otherwise the arguments are pairs of beneficiary address and share in
+// basis points, the shares adding up to at most 10000. Besides the function cost, the persist cost is charged for every
+// byte of the stored split
+func (s *setDeveloperRewardsSplit) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	s.mutExecution.RLock()
+	defer s.mutExecution.RUnlock()
+
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if vmInput.CallValue == nil {
+		return nil, ErrNilValue
+	}
+	if vmInput.CallValue.Cmp(zero) != 0 {
+		return nil, ErrBuiltInFunctionCalledWithValue
+	}
+	shares, err := createDeveloperRewardsSplit(vmInput.CallerAddr, vmInput.Arguments)
+	if err != nil {
+		return nil, err
+	}
+	var encoded []byte
+	if len(shares) > 0 {
+		encoded = developerRewardsSplitToBytes(shares)
+	}
+	gasCost := s.gasCost + uint64(len(encoded))*s.gasConfig.PersistPerByte
+	if vmInput.GasProvided < gasCost {
+		return nil, ErrNotEnoughGas
+	}
+
+	vmOutput := &vmcommon.VMOutput{
+		ReturnCode:   vmcommon.Ok,
+		GasRemaining: computeGasRemaining(acntSnd, vmInput.GasProvided, gasCost),
+	}
+	if check.IfNil(acntDst) {
+		addOutputTransferForOwnerCallThroughSC(s.enableEpochsHandler, vmcommon.BuiltInFunctionSetDeveloperRewardsSplit, acntDst, vmInput, vmOutput)
+		return vmOutput, nil
+	}
+
+	if !bytes.Equal(vmInput.CallerAddr, acntDst.GetOwnerAddress()) {
+		return nil, fmt.Errorf("%w not the owner of the account", ErrOperationNotPermitted)
+	}
+
+	err = acntDst.AccountDataHandler().SaveKeyValue([]byte(developerRewardsSplitKey), encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	topics := make([][]byte, 0, len(shares)*numArgsPerDeveloperRewardsBeneficiary)
+	for _, share := range shares {
+		topics = append(topics, share.address, big.NewInt(int64(share.basisPoints)).Bytes())
+	}
+	vmOutput.Logs = []*vmcommon.LogEntry{{
+		Identifier: []byte(vmcommon.BuiltInFunctionSetDeveloperRewardsSplit),
+		Address:    vmInput.RecipientAddr,
+		Topics:     topics,
+	}}
+
+	return vmOutput, nil
+}
+
+func createDeveloperRewardsSplit(owner []byte, arguments [][]byte) ([]*developerRewardsShare, error) {
+	if len(arguments)%numArgsPerDeveloperRewardsBeneficiary != 0 {
+		return nil, fmt.Errorf("%w, expected pairs of beneficiary and share", ErrInvalidNumberOfArguments)
+	}
+	if len(arguments) > maxNumDeveloperRewardsBeneficiaries*numArgsPerDeveloperRewardsBeneficiary {
+		return nil, fmt.Errorf("%w, maximum number of beneficiaries is %d", ErrInvalidDeveloperRewardsSplit, maxNumDeveloperRewardsBeneficiaries)
+	}
+
+	shares := make([]*developerRewardsShare, 0, len(arguments)/numArgsPerDeveloperRewardsBeneficiary)
+	totalBasisPoints := uint64(0)
+	for i := 0; i < len(arguments); i += numArgsPerDeveloperRewardsBeneficiary {
+		address := arguments[i]
+		if len(address) != len(owner) {
+			return nil, fmt.Errorf("%w for beneficiary", ErrInvalidAddressLength)
+		}
+		for _, share := range shares {
+			if bytes.Equal(share.address, address) {
+				return nil, fmt.Errorf("%w, duplicated beneficiary", ErrInvalidDeveloperRewardsSplit)
+			}
+		}
+
+		basisPoints := big.NewInt(0).SetBytes(arguments[i+1])
+		if basisPoints.Sign() == 0 || basisPoints.Cmp(big.NewInt(maxDeveloperRewardsBasisPoints)) > 0 {
+			return nil, fmt.Errorf("%w, invalid share", ErrInvalidDeveloperRewardsSplit)
+		}
+		totalBasisPoints += basisPoints.Uint64()
+
+		shares = append(shares, &developerRewardsShare{
+			address:     address,
+			basisPoints: uint32(basisPoints.Uint64()),
+		})
+	}
+	if totalBasisPoints > maxDeveloperRewardsBasisPoints {
+		return nil, fmt.Errorf("%w, the shares add up to more than %d basis points", ErrInvalidDeveloperRewardsSplit, maxDeveloperRewardsBasisPoints)
+	}
+
+	return shares, nil
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (s *setDeveloperRewardsSplit) IsInterfaceNil() bool {
+	return s == nil
+}
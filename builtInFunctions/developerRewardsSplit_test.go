@@ -0,0 +1,330 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createDeveloperRewardsSplitEnableEpochsHandler() vmcommon.EnableEpochsHandler {
+	return &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return flag == DeveloperRewardsSplitFlag
+		},
+	}
+}
+
+func TestDeveloperRewardsSplit_ToBytesFromBytes(t *testing.T) {
+	t.Parallel()
+
+	shares := []*developerRewardsShare{
+		{address: []byte("beneficiary1"), basisPoints: 2500},
+		{address: []byte("beneficiary2"), basisPoints: 10000},
+	}
+	decoded, err := developerRewardsSplitFromBytes(developerRewardsSplitToBytes(shares))
+	require.Nil(t, err)
+	require.Equal(t, shares, decoded)
+
+	encoded := developerRewardsSplitToBytes(shares)
+	_, err = developerRewardsSplitFromBytes(encoded[:len(encoded)-1])
+	require.Equal(t, ErrInvalidDeveloperRewardsSplit, err)
+}
+
+func TestComputeDeveloperRewardsPayouts(t *testing.T) {
+	t.Parallel()
+
+	owner := []byte("owner")
+	shares := []*developerRewardsShare{
+		{address: []byte("beneficiary1"), basisPoints: 3333},
+		{address: []byte("beneficiary2"), basisPoints: 3333},
+		{address: []byte("beneficiary3"), basisPoints: 1},
+	}
+
+	payouts := computeDeveloperRewardsPayouts(shares, owner, big.NewInt(100))
+	require.Equal(t, []*developerRewardsPayout{
+		{address: []byte("beneficiary1"), value: big.NewInt(33)},
+		{address: []byte("beneficiary2"), value: big.NewInt(33)},
+		{address: owner, value: big.NewInt(34)},
+	}, payouts)
+
+	shares = append(shares, &developerRewardsShare{address: owner, basisPoints: 3333})
+	payouts = computeDeveloperRewardsPayouts(shares, owner, big.NewInt(100))
+	require.Equal(t, 3, len(payouts))
+	require.Equal(t, &developerRewardsPayout{address: owner, value: big.NewInt(34)}, payouts[2])
+
+	payouts = computeDeveloperRewardsPayouts(nil, owner, big.NewInt(100))
+	require.Equal(t, []*developerRewardsPayout{{address: owner, value: big.NewInt(100)}}, payouts)
+}
+
+func TestNewSetDeveloperRewardsSplitFunc(t *testing.T) {
+	t.Parallel()
+
+	instance, err := NewSetDeveloperRewardsSplitFunc(1, vmcommon.BaseOperationCost{PersistPerByte: 1}, nil)
+	require.Nil(t, instance)
+	require.Equal(t, ErrNilEnableEpochsHandler, err)
+
+	instance, err = NewSetDeveloperRewardsSplitFunc(1, vmcommon.BaseOperationCost{PersistPerByte: 1}, createDeveloperRewardsSplitEnableEpochsHandler())
+	require.Nil(t, err)
+	require.False(t, instance.IsInterfaceNil())
+	require.True(t, instance.IsActive())
+
+	instance, _ = NewSetDeveloperRewardsSplitFunc(1, vmcommon.BaseOperationCost{PersistPerByte: 1}, &mock.EnableEpochsHandlerStub{})
+	require.False(t, instance.IsActive())
+
+	instance.SetNewGasConfig(&vmcommon.GasCost{
+		BaseOperationCost: vmcommon.BaseOperationCost{PersistPerByte: 3},
+		BuiltInCost:       vmcommon.BuiltInCost{ChangeOwnerAddress: 5, SetDeveloperRewardsSplit: 37},
+	})
+	require.Equal(t, uint64(37), instance.gasCost)
+	require.Equal(t, uint64(3), instance.gasConfig.PersistPerByte)
+}
+
+func TestSetDeveloperRewardsSplit_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	owner := []byte("owner123")
+	beneficiary1 := []byte("benef001")
+	beneficiary2 := []byte("benef002")
+	contractAddress := []byte("contract")
+	createInput := func(caller []byte, args ...[]byte) *vmcommon.ContractCallInput {
+		return &vmcommon.ContractCallInput{
+			RecipientAddr: contractAddress,
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  caller,
+				CallValue:   big.NewInt(0),
+				GasProvided: 100,
+				Arguments:   args,
+			},
+		}
+	}
+
+	t.Run("invalid arguments should error", func(t *testing.T) {
+		t.Parallel()
+
+		setSplitFunc, _ := NewSetDeveloperRewardsSplitFunc(1, vmcommon.BaseOperationCost{PersistPerByte: 1}, createDeveloperRewardsSplitEnableEpochsHandler())
+		contract := mock.NewUserAccount(contractAddress)
+		contract.OwnerAddress = owner
+
+		_, err := setSplitFunc.ProcessBuiltinFunction(nil, contract, nil)
+		require.Equal(t, ErrNilVmInput, err)
+
+		vmInput := createInput(owner, beneficiary1, []byte{1})
+		vmInput.CallValue = big.NewInt(1)
+		_, err = setSplitFunc.ProcessBuiltinFunction(nil, contract, vmInput)
+		require.Equal(t, ErrBuiltInFunctionCalledWithValue, err)
+
+		tests := []struct {
+			testname    string
+			arguments   [][]byte
+			expectedErr error
+		}{
+			{testname: "odd number of arguments", arguments: [][]byte{beneficiary1}, expectedErr: ErrInvalidNumberOfArguments},
+			{testname: "invalid beneficiary", arguments: [][]byte{[]byte("short"), {1}}, expectedErr: ErrInvalidAddressLength},
+			{testname: "zero share", arguments: [][]byte{beneficiary1, {}}, expectedErr: ErrInvalidDeveloperRewardsSplit},
+			{testname: "duplicated beneficiary", arguments: [][]byte{beneficiary1, {1}, beneficiary1, {1}}, expectedErr: ErrInvalidDeveloperRewardsSplit},
+			{
+				testname:    "shares above 10000",
+				arguments:   [][]byte{beneficiary1, big.NewInt(5000).Bytes(), beneficiary2, big.NewInt(5001).Bytes()},
+				expectedErr: ErrInvalidDeveloperRewardsSplit,
+			},
+			{
+				testname:    "too many beneficiaries",
+				arguments:   make([][]byte, 2*(maxNumDeveloperRewardsBeneficiaries+1)),
+				expectedErr: ErrInvalidDeveloperRewardsSplit,
+			},
+		}
+		for _, test := range tests {
+			_, err = setSplitFunc.ProcessBuiltinFunction(nil, contract, createInput(owner, test.arguments...))
+			require.True(t, errors.Is(err, test.expectedErr), test.testname)
+		}
+
+		_, err = setSplitFunc.ProcessBuiltinFunction(nil, contract, createInput(beneficiary1, beneficiary1, []byte{1}))
+		require.True(t, errors.Is(err, ErrOperationNotPermitted))
+	})
+	t.Run("set and remove the split", func(t *testing.T) {
+		t.Parallel()
+
+		setSplitFunc, _ := NewSetDeveloperRewardsSplitFunc(1, vmcommon.BaseOperationCost{PersistPerByte: 1}, createDeveloperRewardsSplitEnableEpochsHandler())
+		contract := mock.NewUserAccount(contractAddress)
+		contract.OwnerAddress = owner
+
+		shareBytes := big.NewInt(2500).Bytes()
+		vmInput := createInput(owner, beneficiary1, shareBytes, beneficiary2, []byte{1})
+		vmInput.GasProvided = 22
+		_, err := setSplitFunc.ProcessBuiltinFunction(mock.NewUserAccount(owner), contract, vmInput)
+		require.Equal(t, ErrNotEnoughGas, err)
+
+		// the function cost and 2 stored shares of 11 bytes each
+		vmInput.GasProvided = 100
+		vmOutput, err := setSplitFunc.ProcessBuiltinFunction(mock.NewUserAccount(owner), contract, vmInput)
+		require.Nil(t, err)
+		require.Equal(t, uint64(77), vmOutput.GasRemaining)
+		require.Equal(t, []*vmcommon.LogEntry{{
+			Identifier: []byte(vmcommon.BuiltInFunctionSetDeveloperRewardsSplit),
+			Address:    contractAddress,
+			Topics:     [][]byte{beneficiary1, shareBytes, beneficiary2, {1}},
+		}}, vmOutput.Logs)
+
+		shares, err := getDeveloperRewardsSplit(contract)
+		require.Nil(t, err)
+		require.Equal(t, []*developerRewardsShare{
+			{address: beneficiary1, basisPoints: 2500},
+			{address: beneficiary2, basisPoints: 1},
+		}, shares)
+
+		vmOutput, err = setSplitFunc.ProcessBuiltinFunction(nil, contract, createInput(owner))
+		require.Nil(t, err)
+		require.Equal(t, 0, len(vmOutput.Logs[0].Topics))
+
+		shares, err = getDeveloperRewardsSplit(contract)
+		require.Nil(t, err)
+		require.Nil(t, shares)
+	})
+}
+
+func TestClaimDeveloperRewards_ProcessBuiltinFunctionWithSplit(t *testing.T) {
+	t.Parallel()
+
+	owner := bytes.Repeat([]byte{1}, 32)
+	beneficiary := bytes.Repeat([]byte{2}, 32)
+	remoteBeneficiary := bytes.Repeat([]byte{3}, 32)
+	scBeneficiary := make([]byte, 32)
+	scBeneficiary[31] = 4
+	contractAddress := make([]byte, 32)
+	contractAddress[31] = 5
+
+	createContract := func() *mock.Account {
+		contract := mock.NewUserAccount(contractAddress)
+		contract.OwnerAddress = owner
+		contract.AddToDeveloperReward(big.NewInt(101))
+		_ = contract.AccountDataHandler().SaveKeyValue([]byte(developerRewardsSplitKey), developerRewardsSplitToBytes([]*developerRewardsShare{
+			{address: beneficiary, basisPoints: 2000},
+			{address: remoteBeneficiary, basisPoints: 2000},
+			{address: scBeneficiary, basisPoints: 1000},
+		}))
+
+		return contract
+	}
+	createClaimDeveloperRewards := func(accounts vmcommon.AccountsAdapter) *claimDeveloperRewards {
+		cdr, _ := NewClaimDeveloperRewardsFunc(ClaimDeveloperRewardsFuncArgs{
+			FuncGasCost:           1,
+			GasCostPerBeneficiary: 10,
+			EnableEpochsHandler:   createDeveloperRewardsSplitEnableEpochsHandler(),
+			Accounts:              accounts,
+			ShardCoordinator: &mock.ShardCoordinatorStub{
+				ComputeIdCalled: func(address []byte) uint32 {
+					if bytes.Equal(address, remoteBeneficiary) {
+						return 1
+					}
+					return 0
+				},
+			},
+		})
+
+		return cdr
+	}
+	vmInput := &vmcommon.ContractCallInput{
+		Function:      core.BuiltInFunctionClaimDeveloperRewards,
+		RecipientAddr: contractAddress,
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  owner,
+			CallValue:   big.NewInt(0),
+			GasProvided: 100,
+		},
+	}
+	getBalance := func(accounts vmcommon.AccountsAdapter, address []byte) *big.Int {
+		account, _ := accounts.LoadAccount(address)
+		return account.(vmcommon.UserAccountHandler).GetBalance()
+	}
+	checkBeneficiariesPayouts := func(t *testing.T, accounts vmcommon.AccountsAdapter, vmOutput *vmcommon.VMOutput) {
+		require.Equal(t, big.NewInt(20), getBalance(accounts, beneficiary))
+		require.Equal(t, big.NewInt(10), getBalance(accounts, scBeneficiary))
+		require.Equal(t, big.NewInt(0), getBalance(accounts, remoteBeneficiary))
+
+		require.Equal(t, 3, len(vmOutput.OutputAccounts))
+		beneficiaryTransfer := vmOutput.OutputAccounts[string(beneficiary)].OutputTransfers[0]
+		require.Equal(t, big.NewInt(20), beneficiaryTransfer.Value)
+		require.Equal(t, uint32(2), beneficiaryTransfer.Index)
+		require.Equal(t, contractAddress, beneficiaryTransfer.SenderAddress)
+		remoteTransfer := vmOutput.OutputAccounts[string(remoteBeneficiary)].OutputTransfers[0]
+		require.Equal(t, big.NewInt(20), remoteTransfer.Value)
+		require.Equal(t, uint32(3), remoteTransfer.Index)
+		require.Equal(t, contractAddress, remoteTransfer.SenderAddress)
+		require.Nil(t, vmOutput.OutputAccounts[string(scBeneficiary)])
+
+		require.Equal(t, [][]byte{
+			big.NewInt(101).Bytes(), owner,
+			beneficiary, big.NewInt(20).Bytes(),
+			remoteBeneficiary, big.NewInt(20).Bytes(),
+			scBeneficiary, big.NewInt(10).Bytes(),
+			owner, big.NewInt(51).Bytes(),
+		}, vmOutput.Logs[0].Topics)
+	}
+
+	t.Run("intra shard", func(t *testing.T) {
+		t.Parallel()
+
+		accounts := createAccountsAdapterWithMap()
+		contract := createContract()
+		ownerAccount := mock.NewUserAccount(owner)
+		cdr := createClaimDeveloperRewards(accounts)
+
+		notEnoughGasInput := *vmInput
+		notEnoughGasInput.GasProvided = 30
+		_, err := cdr.ProcessBuiltinFunction(ownerAccount, contract, &notEnoughGasInput)
+		require.Equal(t, ErrNotEnoughGas, err)
+		require.Equal(t, big.NewInt(101), contract.DeveloperReward)
+
+		vmOutput, err := cdr.ProcessBuiltinFunction(ownerAccount, contract, vmInput)
+		require.Nil(t, err)
+		require.Equal(t, uint64(69), vmOutput.GasRemaining)
+
+		require.Equal(t, big.NewInt(51), ownerAccount.GetBalance())
+		require.Equal(t, big.NewInt(0), contract.GetDeveloperReward())
+		ownerTransfer := vmOutput.OutputAccounts[string(owner)].OutputTransfers[0]
+		require.Equal(t, big.NewInt(51), ownerTransfer.Value)
+		require.Equal(t, uint32(1), ownerTransfer.Index)
+		checkBeneficiariesPayouts(t, accounts, vmOutput)
+	})
+	t.Run("cross shard", func(t *testing.T) {
+		t.Parallel()
+
+		accounts := createAccountsAdapterWithMap()
+		contract := createContract()
+		cdr := createClaimDeveloperRewards(accounts)
+
+		vmOutput, err := cdr.ProcessBuiltinFunction(nil, contract, vmInput)
+		require.Nil(t, err)
+
+		require.Equal(t, big.NewInt(0), getBalance(accounts, owner))
+		require.Equal(t, big.NewInt(0), contract.GetDeveloperReward())
+		ownerTransfer := vmOutput.OutputAccounts[string(owner)].OutputTransfers[0]
+		require.Equal(t, big.NewInt(51), ownerTransfer.Value)
+		require.Equal(t, uint32(1), ownerTransfer.Index)
+		checkBeneficiariesPayouts(t, accounts, vmOutput)
+	})
+	t.Run("beneficiary is the contract", func(t *testing.T) {
+		t.Parallel()
+
+		accounts := createAccountsAdapterWithMap()
+		contract := mock.NewUserAccount(contractAddress)
+		contract.OwnerAddress = owner
+		contract.AddToDeveloperReward(big.NewInt(100))
+		_ = contract.AccountDataHandler().SaveKeyValue([]byte(developerRewardsSplitKey), developerRewardsSplitToBytes([]*developerRewardsShare{
+			{address: contractAddress, basisPoints: 3000},
+		}))
+		cdr := createClaimDeveloperRewards(accounts)
+
+		vmOutput, err := cdr.ProcessBuiltinFunction(nil, contract, vmInput)
+		require.Nil(t, err)
+		require.Equal(t, big.NewInt(30), contract.GetBalance())
+		require.Nil(t, vmOutput.OutputAccounts[string(contractAddress)])
+		require.Equal(t, big.NewInt(70), vmOutput.OutputAccounts[string(owner)].OutputTransfers[0].Value)
+	})
+}
@@ -0,0 +1,42 @@
+package builtInFunctions
+
+import (
+	"math/big"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// disabledESDTSupplyHandler is used for the esdt storage handlers which do not track the supply of the tokens
+type disabledESDTSupplyHandler struct {
+}
+
+// getESDTSupplyHandler returns the esdt storage handler as supply handler, if it tracks the supply of the tokens,
+// or a disabled supply handler otherwise
+func getESDTSupplyHandler(esdtStorageHandler vmcommon.ESDTNFTStorageHandler) vmcommon.ESDTSupplyHandler {
+	supplyHandler, ok := esdtStorageHandler.(vmcommon.ESDTSupplyHandler)
+	if !ok {
+		return &disabledESDTSupplyHandler{}
+	}
+
+	return supplyHandler
+}
+
+// AddToSupplySystemAcc does nothing as this is a disabled handler
+func (d *disabledESDTSupplyHandler) AddToSupplySystemAcc(_ []byte, _ *big.Int) error {
+	return nil
+}
+
+// GetESDTSupply returns empty supply counters as this is a disabled handler
+func (d *disabledESDTSupplyHandler) GetESDTSupply(_ []byte) (*vmcommon.ESDTSupply, error) {
+	return &vmcommon.ESDTSupply{
+		Supply:    big.NewInt(0),
+		Minted:    big.NewInt(0),
+		Burned:    big.NewInt(0),
+		MaxSupply: big.NewInt(0),
+	}, nil
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (d *disabledESDTSupplyHandler) IsInterfaceNil() bool {
+	return d == nil
+}
@@ -232,3 +232,167 @@ var ErrNilCrossChainTokenChecker = errors.New("nil cross chain token checker has
 
 // ErrNoWhiteListedAddressCrossChainOperations signals that no white listed address has been set for cross chain operations
 var ErrNoWhiteListedAddressCrossChainOperations = errors.New("no whitelisted address set for cross chain operation actions")
+
+// ErrUndefinedEnableEpochFlag signals that the enable epochs handler does not define the provided flag
+var ErrUndefinedEnableEpochFlag = errors.New("undefined enable epoch flag")
+
+// ErrEmptyEnableEpochFlag signals that an empty enable epoch flag has been provided
+var ErrEmptyEnableEpochFlag = errors.New("empty enable epoch flag")
+
+// ErrInvalidCrossChainAction signals that an action which cannot be executed cross chain has been provided
+var ErrInvalidCrossChainAction = errors.New("invalid cross chain action")
+
+// ErrDuplicatedCrossChainPrefixPolicy signals that more policies have been provided for the same token prefix
+var ErrDuplicatedCrossChainPrefixPolicy = errors.New("duplicated cross chain prefix policy")
+
+// ErrSelfESDTPrefixInCrossChainPolicy signals that a cross chain policy has been provided for the self esdt prefix
+var ErrSelfESDTPrefixInCrossChainPolicy = errors.New("cross chain policy provided for the self esdt prefix")
+
+// ErrInvalidBridgeOperationHash signals that an invalid bridge operation hash has been provided
+var ErrInvalidBridgeOperationHash = errors.New("invalid bridge operation hash")
+
+// ErrInsufficientBridgeEscrow signals that the quantity held in the bridge escrow is lower than the requested one
+var ErrInsufficientBridgeEscrow = errors.New("insufficient quantity in bridge escrow")
+
+// ErrNilCrossChainOperationsRegistry signals that a nil cross chain operations registry has been provided
+var ErrNilCrossChainOperationsRegistry = errors.New("nil cross chain operations registry")
+
+// ErrInvalidCrossChainOperationID signals that an invalid cross chain operation identifier has been provided
+var ErrInvalidCrossChainOperationID = errors.New("invalid cross chain operation identifier")
+
+// ErrCrossChainOperationAlreadyProcessed signals that the cross chain operation has already been processed
+var ErrCrossChainOperationAlreadyProcessed = errors.New("cross chain operation already processed")
+
+// ErrInvalidTokenName signals that an invalid token name has been provided
+var ErrInvalidTokenName = errors.New("invalid token name")
+
+// ErrInvalidTokenTicker signals that an invalid token ticker has been provided
+var ErrInvalidTokenTicker = errors.New("invalid token ticker")
+
+// ErrInvalidNumberOfDecimals signals that an invalid number of decimals has been provided
+var ErrInvalidNumberOfDecimals = errors.New("invalid number of decimals")
+
+// ErrInvalidTokenProperty signals that an invalid token property or property value has been provided
+var ErrInvalidTokenProperty = errors.New("invalid token property")
+
+// ErrTokenAlreadyIssued signals that a token with the same identifier has already been issued
+var ErrTokenAlreadyIssued = errors.New("token already issued")
+
+// ErrTokenNotIssuedNatively signals that the token was not issued by the native issuance built-in functions
+var ErrTokenNotIssuedNatively = errors.New("token was not issued natively")
+
+// ErrCallerIsNotTokenOwner signals that the caller is not the owner of the token
+var ErrCallerIsNotTokenOwner = errors.New("caller is not the token owner")
+
+// ErrTokenCannotBeUpgraded signals that the properties of a token without the upgrade property cannot be changed
+var ErrTokenCannotBeUpgraded = errors.New("token cannot be upgraded")
+
+// ErrTokenOwnerCannotBeChanged signals that the owner of a token without the change owner property cannot be changed
+var ErrTokenOwnerCannotBeChanged = errors.New("token owner cannot be changed")
+
+// ErrMaxSupplyExceeded signals that minting the given quantity would exceed the max supply of the token
+var ErrMaxSupplyExceeded = errors.New("max supply exceeded")
+
+// ErrInvalidMaxSupply signals that an invalid max supply has been provided
+var ErrInvalidMaxSupply = errors.New("invalid max supply")
+
+// ErrNilLockedBalanceHandler signals that a nil locked balance handler has been provided
+var ErrNilLockedBalanceHandler = errors.New("nil locked balance handler")
+
+// ErrNilVestingHandler signals that a nil vesting handler has been provided
+var ErrNilVestingHandler = errors.New("nil vesting handler")
+
+// ErrInvalidVestingSchedule signals that an invalid vesting schedule has been provided or found in storage
+var ErrInvalidVestingSchedule = errors.New("invalid vesting schedule")
+
+// ErrTooManyVestingSchedules signals that too many vesting schedules would be saved for the same token
+var ErrTooManyVestingSchedules = errors.New("too many vesting schedules")
+
+// ErrInsufficientUnlockedBalance signals that the transfer would spend tokens which are still locked
+var ErrInsufficientUnlockedBalance = errors.New("insufficient unlocked balance")
+
+// ErrNoUnlockedVestingSchedules signals that there are no unlocked vesting schedules to be released
+var ErrNoUnlockedVestingSchedules = errors.New("no unlocked vesting schedules")
+
+// ErrNilTransferFeeHandler signals that a nil transfer fee handler has been provided
+var ErrNilTransferFeeHandler = errors.New("nil transfer fee handler")
+
+// ErrInvalidTransferFeePolicy signals that an invalid transfer fee policy has been provided or found in storage
+var ErrInvalidTransferFeePolicy = errors.New("invalid transfer fee policy")
+
+// ErrAddressIsDenyListed signals that the sender or the destination of a transfer is on the deny list of the token
+var ErrAddressIsDenyListed = errors.New("address is deny listed for token")
+
+// ErrAddressIsNotAllowListed signals that the sender or the destination of a transfer is missing from the allow list
+// of a token which requires it
+var ErrAddressIsNotAllowListed = errors.New("address is not allow listed for token")
+
+// ErrInsufficientUnfrozenBalance signals that the operation would spend tokens which are frozen on the account
+var ErrInsufficientUnfrozenBalance = errors.New("insufficient unfrozen balance")
+
+// ErrInsufficientFrozenAmount signals that the amount to be unfrozen or wiped is greater than the frozen amount
+var ErrInsufficientFrozenAmount = errors.New("insufficient frozen amount")
+
+// ErrAccountIsFrozen signals that the account is frozen for all token movements
+var ErrAccountIsFrozen = errors.New("account is frozen")
+
+// ErrAccountIsNotFrozen signals that the account to be unfrozen is not frozen
+var ErrAccountIsNotFrozen = errors.New("account is not frozen")
+
+// ErrOnlyUserAccountsCanBeFrozen signals that a smart contract address was provided for the account-wide freeze
+var ErrOnlyUserAccountsCanBeFrozen = errors.New("only user accounts can be frozen")
+
+// ErrInvalidGuardianSet signals that an invalid guardian set has been provided
+var ErrInvalidGuardianSet = errors.New("invalid guardian set")
+
+// ErrGuardianSignatureRequired signals that the transfer of a guarded account is not covered by its spending policy
+// and needs the guardian co-signature
+var ErrGuardianSignatureRequired = errors.New("guardian signature required")
+
+// ErrInvalidGuardedSpendingPolicy signals that an invalid spending policy has been provided for a guarded account
+var ErrInvalidGuardedSpendingPolicy = errors.New("invalid guarded spending policy")
+
+// ErrNilSpendingPolicyHandler signals that a nil spending policy handler has been provided
+var ErrNilSpendingPolicyHandler = errors.New("nil spending policy handler")
+
+// ErrNoPendingOwnerAddress signals that no new owner was proposed for the smart contract
+var ErrNoPendingOwnerAddress = errors.New("no pending owner address")
+
+// ErrCallerIsNotPendingOwner signals that the ownership is accepted by another address than the proposed one
+var ErrCallerIsNotPendingOwner = errors.New("caller is not the pending owner")
+
+// ErrInvalidOwnerAddressTransferFunction signals that an unknown ownership transfer function has been provided
+var ErrInvalidOwnerAddressTransferFunction = errors.New("invalid owner address transfer function")
+
+// ErrInvalidDeveloperRewardsSplit signals that an invalid developer rewards split has been provided
+var ErrInvalidDeveloperRewardsSplit = errors.New("invalid developer rewards split")
+
+// ErrInvalidUserNameExpiryEpoch signals that an invalid username expiry epoch has been provided
+var ErrInvalidUserNameExpiryEpoch = errors.New("invalid username expiry epoch")
+
+// ErrUserNameNotSet signals that the account has no username
+var ErrUserNameNotSet = errors.New("username not set")
+
+// ErrUserNameMismatch signals that the account does not hold the given username
+var ErrUserNameMismatch = errors.New("username mismatch")
+
+// ErrUserNameExpired signals that the username of the account has expired
+var ErrUserNameExpired = errors.New("username expired")
+
+// ErrNilESDTSupplyHandler signals that a nil esdt supply handler has been provided
+var ErrNilESDTSupplyHandler = errors.New("nil esdt supply handler")
+
+// ErrNilAccessListChecker signals that a nil transfer access list checker has been provided
+var ErrNilAccessListChecker = errors.New("nil transfer access list checker")
+
+// ErrNilMultiGuardedAccountHandler signals that a nil multi guarded account handler has been provided
+var ErrNilMultiGuardedAccountHandler = errors.New("nil multi guarded account handler")
+
+// ErrUserNameAlreadySet signals that the account already holds a username which has not expired
+var ErrUserNameAlreadySet = errors.New("username already set")
+
+// ErrUserNameTransferNotApproved signals that the destination account did not approve the username transfer
+var ErrUserNameTransferNotApproved = errors.New("username transfer not approved")
+
+// ErrBlockchainHookWithoutCurrentEpoch signals that the provided blockchain hook does not provide the current epoch
+var ErrBlockchainHookWithoutCurrentEpoch = errors.New("blockchain hook does not provide the current epoch")
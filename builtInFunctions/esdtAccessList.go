@@ -0,0 +1,177 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const (
+	esdtAllowListKeyPrefix = core.ProtectedKeyPrefix + "esdtAllowList"
+	esdtDenyListKeyPrefix  = core.ProtectedKeyPrefix + "esdtDenyList"
+)
+
+var accessListedMarker = []byte{1}
+
+type esdtAccessListAddress struct {
+	baseActiveHandler
+	accounts   vmcommon.AccountsAdapter
+	keyPrefix  string
+	isDenyList bool
+	add        bool
+	function   string
+}
+
+// ArgsNewESDTAccessListAddressFunc defines the argument list for the built-in functions which add or remove
+// addresses from the allow list or the deny list of a token
+type ArgsNewESDTAccessListAddressFunc struct {
+	Accounts            vmcommon.AccountsAdapter
+	EnableEpochsHandler vmcommon.EnableEpochsHandler
+	IsDenyList          bool
+	Add                 bool
+}
+
+// NewESDTAccessListAddressFunc returns the built-in function component which adds or removes addresses from the
+// allow list or the deny list of a token. Every address is saved under its own key on the system account, so
+// checking a transfer never loads the whole list
+func NewESDTAccessListAddressFunc(args ArgsNewESDTAccessListAddressFunc) (*esdtAccessListAddress, error) {
+	if check.IfNil(args.Accounts) {
+		return nil, ErrNilAccountsAdapter
+	}
+	if check.IfNil(args.EnableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+
+	e := &esdtAccessListAddress{
+		accounts:   args.Accounts,
+		keyPrefix:  esdtAllowListKeyPrefix,
+		isDenyList: args.IsDenyList,
+		add:        args.Add,
+		function:   getESDTAccessListFunction(args.IsDenyList, args.Add),
+	}
+	if args.IsDenyList {
+		e.keyPrefix = esdtDenyListKeyPrefix
+	}
+
+	e.baseActiveHandler.activeHandler = func() bool {
+		return args.EnableEpochsHandler.IsFlagEnabled(ESDTAccessListsFlag)
+	}
+
+	return e, nil
+}
+
+func getESDTAccessListFunction(isDenyList bool, add bool) string {
+	switch {
+	case isDenyList && add:
+		return vmcommon.BuiltInFunctionESDTDenyListAddAddress
+	case isDenyList:
+		return vmcommon.BuiltInFunctionESDTDenyListDeleteAddress
+	case add:
+		return vmcommon.BuiltInFunctionESDTAllowListAddAddress
+	default:
+		return vmcommon.BuiltInFunctionESDTAllowListDeleteAddress
+	}
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (e *esdtAccessListAddress) SetNewGasConfig(_ *vmcommon.GasCost) {
+}
+
+// ProcessBuiltinFunction adds or removes the addresses provided as arguments from the access list of the token
+func (e *esdtAccessListAddress) ProcessBuiltinFunction(
+	_, dstAccount vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	err := checkBasicESDTArguments(vmInput)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(vmInput.CallerAddr, core.ESDTSCAddress) {
+		return nil, ErrAddressIsNotESDTSystemSC
+	}
+	if !vmcommon.IsSystemAccountAddress(vmInput.RecipientAddr) {
+		return nil, ErrOnlySystemAccountAccepted
+	}
+	for _, address := range vmInput.Arguments[1:] {
+		if len(address) != len(vmInput.CallerAddr) {
+			return nil, ErrInvalidAddressLength
+		}
+	}
+
+	systemAcc, err := getSystemAccountIfNeeded(vmInput, dstAccount, e.accounts)
+	if err != nil {
+		return nil, err
+	}
+
+	var marker []byte
+	if e.add {
+		marker = accessListedMarker
+	}
+
+	tokenID := vmInput.Arguments[0]
+	for _, address := range vmInput.Arguments[1:] {
+		err = systemAcc.AccountDataHandler().SaveKeyValue(getESDTAccessListKey(e.keyPrefix, tokenID, address), marker)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if e.isDenyList && e.add {
+		err = markDenyListUsed(systemAcc, tokenID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err = e.accounts.SaveAccount(systemAcc)
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}
+
+	logData := append([][]byte{systemAcc.AddressBytes()}, vmInput.Arguments[1:]...)
+	addESDTEntryInVMOutput(vmOutput, []byte(e.function), tokenID, 0, big.NewInt(0), logData...)
+
+	return vmOutput, nil
+}
+
+// markDenyListUsed sets the global metadata flag which makes the transfers of the token check the deny list. The flag
+// is never cleared, as removing an address does not tell whether the list became empty
+func markDenyListUsed(systemAcc vmcommon.UserAccountHandler, tokenID []byte) error {
+	esdtTokenKey := append([]byte(baseESDTKeyPrefix), tokenID...)
+	val, _, err := systemAcc.AccountDataHandler().RetrieveValue(esdtTokenKey)
+	if core.IsGetNodeFromDBError(err) {
+		return err
+	}
+
+	esdtMetaData := ESDTGlobalMetadataFromBytes(val)
+	if esdtMetaData.DenyListUsed {
+		return nil
+	}
+	esdtMetaData.DenyListUsed = true
+
+	return systemAcc.AccountDataHandler().SaveKeyValue(esdtTokenKey, esdtMetaData.ToBytes())
+}
+
+func isOnESDTAccessList(systemAcc vmcommon.UserAccountHandler, keyPrefix string, tokenID []byte, address []byte) (bool, error) {
+	marker, _, err := systemAcc.AccountDataHandler().RetrieveValue(getESDTAccessListKey(keyPrefix, tokenID, address))
+	if core.IsGetNodeFromDBError(err) {
+		return false, err
+	}
+
+	return bytes.Equal(marker, accessListedMarker), nil
+}
+
+func getESDTAccessListKey(keyPrefix string, tokenID []byte, address []byte) []byte {
+	key := append([]byte(keyPrefix), tokenID...)
+	return append(key, address...)
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (e *esdtAccessListAddress) IsInterfaceNil() bool {
+	return e == nil
+}
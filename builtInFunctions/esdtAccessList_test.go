@@ -0,0 +1,155 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	accessListedAddress    = bytes.Repeat([]byte{9}, 32)
+	notAccessListedAddress = bytes.Repeat([]byte{10}, 32)
+)
+
+func createMockArgsNewESDTAccessListAddressFunc(accounts vmcommon.AccountsAdapter, isDenyList bool, add bool) ArgsNewESDTAccessListAddressFunc {
+	return ArgsNewESDTAccessListAddressFunc{
+		Accounts: accounts,
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == ESDTAccessListsFlag
+			},
+		},
+		IsDenyList: isDenyList,
+		Add:        add,
+	}
+}
+
+func createESDTAccessListInput(tokenID []byte, addresses ...[]byte) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: core.ESDTSCAddress,
+			CallValue:  big.NewInt(0),
+			Arguments:  append([][]byte{tokenID}, addresses...),
+		},
+		RecipientAddr: vmcommon.SystemAccountAddress,
+	}
+}
+
+func TestNewESDTAccessListAddressFunc(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsNewESDTAccessListAddressFunc(nil, false, true)
+	accessListFunc, err := NewESDTAccessListAddressFunc(args)
+	assert.True(t, check.IfNil(accessListFunc))
+	assert.Equal(t, ErrNilAccountsAdapter, err)
+
+	args = createMockArgsNewESDTAccessListAddressFunc(&mock.AccountsStub{}, false, true)
+	args.EnableEpochsHandler = nil
+	accessListFunc, err = NewESDTAccessListAddressFunc(args)
+	assert.True(t, check.IfNil(accessListFunc))
+	assert.Equal(t, ErrNilEnableEpochsHandler, err)
+
+	expectedFunctions := map[string]ArgsNewESDTAccessListAddressFunc{
+		vmcommon.BuiltInFunctionESDTAllowListAddAddress:    createMockArgsNewESDTAccessListAddressFunc(&mock.AccountsStub{}, false, true),
+		vmcommon.BuiltInFunctionESDTAllowListDeleteAddress: createMockArgsNewESDTAccessListAddressFunc(&mock.AccountsStub{}, false, false),
+		vmcommon.BuiltInFunctionESDTDenyListAddAddress:     createMockArgsNewESDTAccessListAddressFunc(&mock.AccountsStub{}, true, true),
+		vmcommon.BuiltInFunctionESDTDenyListDeleteAddress:  createMockArgsNewESDTAccessListAddressFunc(&mock.AccountsStub{}, true, false),
+	}
+	for function, argsFunc := range expectedFunctions {
+		accessListFunc, err = NewESDTAccessListAddressFunc(argsFunc)
+		assert.Nil(t, err)
+		assert.False(t, accessListFunc.IsInterfaceNil())
+		assert.True(t, accessListFunc.IsActive())
+		assert.Equal(t, function, accessListFunc.function)
+	}
+}
+
+func TestESDTAccessListAddress_ProcessBuiltinFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	tokenID := []byte("TKN-123456")
+	accounts := createAccountsWithSystemAccount(mock.NewAccountWrapMock(vmcommon.SystemAccountAddress))
+	accessListFunc, _ := NewESDTAccessListAddressFunc(createMockArgsNewESDTAccessListAddressFunc(accounts, true, true))
+
+	_, err := accessListFunc.ProcessBuiltinFunction(nil, nil, nil)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	_, err = accessListFunc.ProcessBuiltinFunction(nil, nil, createESDTAccessListInput(tokenID))
+	assert.Equal(t, ErrInvalidArguments, err)
+
+	input := createESDTAccessListInput(tokenID, accessListedAddress)
+	input.CallerAddr = accessListedAddress
+	_, err = accessListFunc.ProcessBuiltinFunction(nil, nil, input)
+	assert.Equal(t, ErrAddressIsNotESDTSystemSC, err)
+
+	input = createESDTAccessListInput(tokenID, accessListedAddress)
+	input.RecipientAddr = accessListedAddress
+	_, err = accessListFunc.ProcessBuiltinFunction(nil, nil, input)
+	assert.Equal(t, ErrOnlySystemAccountAccepted, err)
+
+	_, err = accessListFunc.ProcessBuiltinFunction(nil, nil, createESDTAccessListInput(tokenID, accessListedAddress, []byte("short")))
+	assert.Equal(t, ErrInvalidAddressLength, err)
+
+	expectedErr := errors.New("expected error")
+	accessListFunc.accounts = &mock.AccountsStub{
+		LoadAccountCalled: func(_ []byte) (vmcommon.AccountHandler, error) {
+			return nil, expectedErr
+		},
+	}
+	_, err = accessListFunc.ProcessBuiltinFunction(nil, nil, createESDTAccessListInput(tokenID, accessListedAddress))
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestESDTAccessListAddress_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	tokenID := []byte("TKN-123456")
+	systemAcc := mock.NewAccountWrapMock(vmcommon.SystemAccountAddress)
+	accounts := createAccountsWithSystemAccount(systemAcc)
+	denyListAdd, _ := NewESDTAccessListAddressFunc(createMockArgsNewESDTAccessListAddressFunc(accounts, true, true))
+	denyListDelete, _ := NewESDTAccessListAddressFunc(createMockArgsNewESDTAccessListAddressFunc(accounts, true, false))
+	allowListAdd, _ := NewESDTAccessListAddressFunc(createMockArgsNewESDTAccessListAddressFunc(accounts, false, true))
+
+	vmOutput, err := denyListAdd.ProcessBuiltinFunction(nil, nil, createESDTAccessListInput(tokenID, accessListedAddress))
+	require.Nil(t, err)
+	require.Len(t, vmOutput.Logs, 1)
+	assert.Equal(t, []byte(vmcommon.BuiltInFunctionESDTDenyListAddAddress), vmOutput.Logs[0].Identifier)
+	assert.Equal(t, vmcommon.SystemAccountAddress, vmOutput.Logs[0].Address)
+	assert.Equal(t, [][]byte{tokenID, {}, {}, accessListedAddress}, vmOutput.Logs[0].Topics)
+
+	isDenyListed, err := isOnESDTAccessList(systemAcc, esdtDenyListKeyPrefix, tokenID, accessListedAddress)
+	assert.Nil(t, err)
+	assert.True(t, isDenyListed)
+	isAllowListed, err := isOnESDTAccessList(systemAcc, esdtAllowListKeyPrefix, tokenID, accessListedAddress)
+	assert.Nil(t, err)
+	assert.False(t, isAllowListed)
+
+	esdtTokenKey := append([]byte(baseESDTKeyPrefix), tokenID...)
+	metadataBytes, _, _ := systemAcc.AccountDataHandler().RetrieveValue(esdtTokenKey)
+	assert.True(t, ESDTGlobalMetadataFromBytes(metadataBytes).DenyListUsed)
+
+	_, err = denyListDelete.ProcessBuiltinFunction(nil, nil, createESDTAccessListInput(tokenID, accessListedAddress))
+	require.Nil(t, err)
+	isDenyListed, err = isOnESDTAccessList(systemAcc, esdtDenyListKeyPrefix, tokenID, accessListedAddress)
+	assert.Nil(t, err)
+	assert.False(t, isDenyListed)
+	metadataBytes, _, _ = systemAcc.AccountDataHandler().RetrieveValue(esdtTokenKey)
+	assert.True(t, ESDTGlobalMetadataFromBytes(metadataBytes).DenyListUsed)
+
+	_, err = allowListAdd.ProcessBuiltinFunction(nil, nil, createESDTAccessListInput(tokenID, accessListedAddress))
+	require.Nil(t, err)
+	isAllowListed, err = isOnESDTAccessList(systemAcc, esdtAllowListKeyPrefix, tokenID, accessListedAddress)
+	assert.Nil(t, err)
+	assert.True(t, isAllowListed)
+	isAllowListed, err = isOnESDTAccessList(systemAcc, esdtAllowListKeyPrefix, []byte("OTHER-123456"), accessListedAddress)
+	assert.Nil(t, err)
+	assert.False(t, isAllowListed)
+}
@@ -0,0 +1,157 @@
+package builtInFunctions
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const (
+	bridgeEscrowKeyPrefix        = core.ProtectedKeyPrefix + "bridgeEscrow"
+	bridgeOperationKeyPrefix     = core.ProtectedKeyPrefix + "bridgeOperation"
+	bridgeOperationHashLength    = 32
+	numArgsESDTBridgeDeposit     = 4
+	minNumArgsESDTBridgeWithdraw = 4
+	maxNumArgsESDTBridgeWithdraw = 5
+)
+
+var executedBridgeOperationMarker = []byte{1}
+
+// ESDTBridgeFuncArgs holds the arguments needed to create the esdt bridge deposit and withdraw built-in functions
+type ESDTBridgeFuncArgs struct {
+	FuncGasCost                   uint64
+	Marshaller                    vmcommon.Marshalizer
+	Accounts                      vmcommon.AccountsAdapter
+	GlobalSettingsHandler         vmcommon.ESDTGlobalSettingsHandler
+	EsdtStorageHandler            vmcommon.ESDTNFTStorageHandler
+	CrossChainTokenCheckerHandler CrossChainTokenCheckerHandler
+	CrossChainOperationsRegistry  CrossChainOperationsRegistryHandler
+	EnableEpochsHandler           vmcommon.EnableEpochsHandler
+	// LockedBalanceHandler is only used by the deposit function
+	LockedBalanceHandler ESDTLockedBalanceHandler
+}
+
+func checkESDTBridgeFuncArgs(args ESDTBridgeFuncArgs) error {
+	if check.IfNil(args.Marshaller) {
+		return ErrNilMarshalizer
+	}
+	if check.IfNil(args.Accounts) {
+		return ErrNilAccountsAdapter
+	}
+	if check.IfNil(args.GlobalSettingsHandler) {
+		return ErrNilGlobalSettingsHandler
+	}
+	if check.IfNil(args.EsdtStorageHandler) {
+		return ErrNilESDTNFTStorageHandler
+	}
+	if check.IfNil(args.CrossChainTokenCheckerHandler) {
+		return ErrNilCrossChainTokenChecker
+	}
+	if check.IfNil(args.CrossChainOperationsRegistry) {
+		return ErrNilCrossChainOperationsRegistry
+	}
+	if check.IfNil(args.EnableEpochsHandler) {
+		return ErrNilEnableEpochsHandler
+	}
+
+	return nil
+}
+
+func checkESDTBridgeInput(vmInput *vmcommon.ContractCallInput, minNumArgs int, maxNumArgs int, funcGasCost uint64) error {
+	if vmInput == nil {
+		return ErrNilVmInput
+	}
+	if vmInput.CallValue == nil {
+		return ErrNilValue
+	}
+	if vmInput.CallValue.Cmp(zero) != 0 {
+		return ErrBuiltInFunctionCalledWithValue
+	}
+	if len(vmInput.Arguments) < minNumArgs || len(vmInput.Arguments) > maxNumArgs {
+		return ErrInvalidNumOfArgs
+	}
+	if vmInput.GasProvided < funcGasCost {
+		return ErrNotEnoughGas
+	}
+
+	return nil
+}
+
+func getESDTBridgeQuantity(quantityBytes []byte) (*big.Int, error) {
+	if len(quantityBytes) > core.MaxLenForESDTIssueMint {
+		return nil, fmt.Errorf("%w: max length for esdt bridge quantity is %d", ErrInvalidArguments, core.MaxLenForESDTIssueMint)
+	}
+
+	quantity := big.NewInt(0).SetBytes(quantityBytes)
+	if quantity.Cmp(zero) <= 0 {
+		return nil, ErrNegativeValue
+	}
+
+	return quantity, nil
+}
+
+func getBridgeEscrowKey(tokenID []byte, nonce uint64) []byte {
+	return computeESDTNFTTokenKey(append([]byte(bridgeEscrowKeyPrefix), tokenID...), nonce)
+}
+
+// getBridgeEscrow returns the quantity of the given token held in escrow on the system account, together with the
+// token type and metadata saved at deposit time
+func getBridgeEscrow(
+	marshaller vmcommon.Marshalizer,
+	systemAcc vmcommon.UserAccountHandler,
+	escrowKey []byte,
+) (*esdt.ESDigitalToken, error) {
+	escrow := &esdt.ESDigitalToken{
+		Value: big.NewInt(0),
+	}
+
+	marshaledData, _, err := systemAcc.AccountDataHandler().RetrieveValue(escrowKey)
+	if core.IsGetNodeFromDBError(err) {
+		return nil, err
+	}
+	if err != nil || len(marshaledData) == 0 {
+		return escrow, nil
+	}
+
+	err = marshaller.Unmarshal(escrow, marshaledData)
+	if err != nil {
+		return nil, err
+	}
+
+	return escrow, nil
+}
+
+func saveBridgeEscrow(
+	marshaller vmcommon.Marshalizer,
+	systemAcc vmcommon.UserAccountHandler,
+	escrowKey []byte,
+	escrow *esdt.ESDigitalToken,
+) error {
+	if escrow.Value.Cmp(zero) == 0 {
+		return systemAcc.AccountDataHandler().SaveKeyValue(escrowKey, nil)
+	}
+
+	marshaledData, err := marshaller.Marshal(escrow)
+	if err != nil {
+		return err
+	}
+
+	return systemAcc.AccountDataHandler().SaveKeyValue(escrowKey, marshaledData)
+}
+
+func getBridgeOperationKey(operationHash []byte) []byte {
+	return append([]byte(bridgeOperationKeyPrefix), operationHash...)
+}
+
+func wasBridgeOperationExecuted(systemAcc vmcommon.UserAccountHandler, operationHash []byte) (bool, error) {
+	marker, _, err := systemAcc.AccountDataHandler().RetrieveValue(getBridgeOperationKey(operationHash))
+	if core.IsGetNodeFromDBError(err) {
+		return false, err
+	}
+
+	return err == nil && len(marker) > 0, nil
+}
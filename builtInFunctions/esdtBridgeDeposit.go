@@ -0,0 +1,217 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+type esdtBridgeDeposit struct {
+	baseActiveHandler
+	keyPrefix                     []byte
+	marshaller                    vmcommon.Marshalizer
+	accounts                      vmcommon.AccountsAdapter
+	globalSettingsHandler         vmcommon.ESDTGlobalSettingsHandler
+	enableEpochsHandler           vmcommon.EnableEpochsHandler
+	esdtStorageHandler            vmcommon.ESDTNFTStorageHandler
+	esdtSupplyHandler             vmcommon.ESDTSupplyHandler
+	crossChainTokenCheckerHandler CrossChainTokenCheckerHandler
+	lockedBalanceHandler          ESDTLockedBalanceHandler
+	funcGasCost                   uint64
+	mutExecution                  sync.RWMutex
+}
+
+// NewESDTBridgeDepositFunc returns the esdt bridge deposit built-in function component. Tokens coming from other
+// chains are burned, while the tokens of this chain are held in escrow on the system account
+func NewESDTBridgeDepositFunc(args ESDTBridgeFuncArgs) (*esdtBridgeDeposit, error) {
+	err := checkESDTBridgeFuncArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if check.IfNil(args.LockedBalanceHandler) {
+		return nil, ErrNilLockedBalanceHandler
+	}
+
+	e := &esdtBridgeDeposit{
+		keyPrefix:                     []byte(baseESDTKeyPrefix),
+		marshaller:                    args.Marshaller,
+		accounts:                      args.Accounts,
+		globalSettingsHandler:         args.GlobalSettingsHandler,
+		enableEpochsHandler:           args.EnableEpochsHandler,
+		esdtStorageHandler:            args.EsdtStorageHandler,
+		esdtSupplyHandler:             getESDTSupplyHandler(args.EsdtStorageHandler),
+		crossChainTokenCheckerHandler: args.CrossChainTokenCheckerHandler,
+		lockedBalanceHandler:          args.LockedBalanceHandler,
+		funcGasCost:                   args.FuncGasCost,
+		mutExecution:                  sync.RWMutex{},
+	}
+	e.activeHandler = func() bool {
+		return args.EnableEpochsHandler.IsFlagEnabled(ESDTBridgeFlag)
+	}
+
+	return e, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (e *esdtBridgeDeposit) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	e.mutExecution.Lock()
+	e.funcGasCost = gasCost.BuiltInCost.ESDTBridgeDeposit
+	e.mutExecution.Unlock()
+}
+
+// ProcessBuiltinFunction resolves ESDT bridge deposit function call
+// Requires 4 arguments:
+// arg0 - destination address on the other chain
+// arg1 - token identifier
+// arg2 - token nonce
+// arg3 - quantity to deposit
+func (e *esdtBridgeDeposit) ProcessBuiltinFunction(
+	acntSnd, _ vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	err := checkESDTBridgeInput(vmInput, numArgsESDTBridgeDeposit, numArgsESDTBridgeDeposit, e.funcGasCost)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
+		return nil, ErrInvalidRcvAddr
+	}
+	if check.IfNil(acntSnd) {
+		return nil, ErrNilUserAccount
+	}
+
+	destination := vmInput.Arguments[0]
+	if len(destination) == 0 {
+		return nil, fmt.Errorf("%w, empty destination address", ErrInvalidArguments)
+	}
+	tokenID := vmInput.Arguments[1]
+	nonce := getUIn46FromBytes(vmInput.Arguments[2])
+	quantity, err := getESDTBridgeQuantity(vmInput.Arguments[3])
+	if err != nil {
+		return nil, err
+	}
+
+	esdtTokenKey := append(e.keyPrefix, tokenID...)
+	esdtData, err := e.removeFromSender(acntSnd, esdtTokenKey, nonce, quantity, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+
+	isCrossChainToken := e.crossChainTokenCheckerHandler.IsCrossChainOperation(tokenID)
+	if isCrossChainToken {
+		err = e.burn(tokenID, nonce, esdtData.Type, quantity)
+	} else {
+		err = e.addToEscrow(tokenID, nonce, esdtData)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var esdtDataBytes []byte
+	if nonce > 0 {
+		esdtDataBytes, err = e.marshaller.Marshal(esdtData)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: vmInput.GasProvided - e.funcGasCost}
+	addESDTEntryInVMOutput(vmOutput, []byte(vmcommon.BuiltInFunctionESDTBridgeDeposit), tokenID, nonce, quantity, vmInput.CallerAddr, destination, esdtDataBytes)
+
+	return vmOutput, nil
+}
+
+// burn removes the deposited quantity of a token from another chain from the liquidity and the supply of this chain
+func (e *esdtBridgeDeposit) burn(tokenID []byte, nonce uint64, tokenType uint32, quantity *big.Int) error {
+	esdtTokenKey := append(e.keyPrefix, tokenID...)
+	err := e.esdtStorageHandler.AddToLiquiditySystemAcc(esdtTokenKey, tokenType, nonce, big.NewInt(0).Neg(quantity), false)
+	if err != nil {
+		return err
+	}
+
+	return e.esdtSupplyHandler.AddToSupplySystemAcc(tokenID, big.NewInt(0).Neg(quantity))
+}
+
+// removeFromSender decreases the balance of the sender and returns the deposited token data, having the value set
+// to the deposited quantity
+func (e *esdtBridgeDeposit) removeFromSender(
+	acntSnd vmcommon.UserAccountHandler,
+	esdtTokenKey []byte,
+	nonce uint64,
+	quantity *big.Int,
+	isReturnWithError bool,
+) (*esdt.ESDigitalToken, error) {
+	if nonce == 0 {
+		err := addToESDTBalance(acntSnd, esdtTokenKey, big.NewInt(0).Neg(quantity), e.marshaller, e.globalSettingsHandler, e.lockedBalanceHandler, e.enableEpochsHandler, isReturnWithError)
+		if err != nil {
+			return nil, err
+		}
+
+		return &esdt.ESDigitalToken{Value: quantity}, nil
+	}
+
+	esdtData, err := e.esdtStorageHandler.GetESDTNFTTokenOnSender(acntSnd, esdtTokenKey, nonce)
+	if err != nil {
+		return nil, err
+	}
+	if esdtData.Value.Cmp(quantity) < 0 {
+		return nil, ErrInvalidNFTQuantity
+	}
+
+	esdtData.Value.Sub(esdtData.Value, quantity)
+	properties := vmcommon.NftSaveArgs{
+		MustUpdateAllFields:         false,
+		IsReturnWithError:           isReturnWithError,
+		KeepMetaDataOnZeroLiquidity: false,
+	}
+	_, err = e.esdtStorageHandler.SaveESDTNFTToken(acntSnd.AddressBytes(), acntSnd, esdtTokenKey, nonce, esdtData, properties)
+	if err != nil {
+		return nil, err
+	}
+
+	return &esdt.ESDigitalToken{
+		Type:          esdtData.Type,
+		Value:         quantity,
+		TokenMetaData: esdtData.TokenMetaData,
+	}, nil
+}
+
+func (e *esdtBridgeDeposit) addToEscrow(tokenID []byte, nonce uint64, esdtData *esdt.ESDigitalToken) error {
+	systemAcc, err := getSystemAccount(e.accounts)
+	if err != nil {
+		return err
+	}
+
+	escrowKey := getBridgeEscrowKey(tokenID, nonce)
+	escrow, err := getBridgeEscrow(e.marshaller, systemAcc, escrowKey)
+	if err != nil {
+		return err
+	}
+
+	escrow.Type = esdtData.Type
+	escrow.TokenMetaData = esdtData.TokenMetaData
+	escrow.Value.Add(escrow.Value, esdtData.Value)
+	err = saveBridgeEscrow(e.marshaller, systemAcc, escrowKey, escrow)
+	if err != nil {
+		return err
+	}
+
+	return e.accounts.SaveAccount(systemAcc)
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (e *esdtBridgeDeposit) IsInterfaceNil() bool {
+	return e == nil
+}
@@ -0,0 +1,328 @@
+package builtInFunctions
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+type esdtBridgeWithdraw struct {
+	baseActiveHandler
+	keyPrefix                     []byte
+	marshaller                    vmcommon.Marshalizer
+	accounts                      vmcommon.AccountsAdapter
+	globalSettingsHandler         vmcommon.ESDTGlobalSettingsHandler
+	enableEpochsHandler           vmcommon.EnableEpochsHandler
+	esdtStorageHandler            vmcommon.ESDTNFTStorageHandler
+	esdtSupplyHandler             vmcommon.ESDTSupplyHandler
+	crossChainTokenCheckerHandler CrossChainTokenCheckerHandler
+	crossChainOperationsRegistry  CrossChainOperationsRegistryHandler
+	funcGasCost                   uint64
+	mutExecution                  sync.RWMutex
+}
+
+// NewESDTBridgeWithdrawFunc returns the esdt bridge withdraw built-in function component. Tokens coming from other
+// chains are minted, while the tokens of this chain are released from the escrow held on the system account
+func NewESDTBridgeWithdrawFunc(args ESDTBridgeFuncArgs) (*esdtBridgeWithdraw, error) {
+	err := checkESDTBridgeFuncArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &esdtBridgeWithdraw{
+		keyPrefix:                     []byte(baseESDTKeyPrefix),
+		marshaller:                    args.Marshaller,
+		accounts:                      args.Accounts,
+		globalSettingsHandler:         args.GlobalSettingsHandler,
+		enableEpochsHandler:           args.EnableEpochsHandler,
+		esdtStorageHandler:            args.EsdtStorageHandler,
+		esdtSupplyHandler:             getESDTSupplyHandler(args.EsdtStorageHandler),
+		crossChainTokenCheckerHandler: args.CrossChainTokenCheckerHandler,
+		crossChainOperationsRegistry:  args.CrossChainOperationsRegistry,
+		funcGasCost:                   args.FuncGasCost,
+		mutExecution:                  sync.RWMutex{},
+	}
+	e.activeHandler = func() bool {
+		return args.EnableEpochsHandler.IsFlagEnabled(ESDTBridgeFlag)
+	}
+
+	return e, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (e *esdtBridgeWithdraw) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	e.mutExecution.Lock()
+	e.funcGasCost = gasCost.BuiltInCost.ESDTBridgeWithdraw
+	e.mutExecution.Unlock()
+}
+
+// ProcessBuiltinFunction resolves ESDT bridge withdraw function call. The caller has to be a whitelisted bridge
+// address and the recipient is the account receiving the tokens
+// Requires 4 or 5 arguments:
+// arg0 - bridge operation hash, each operation can be executed only once
+// arg1 - token identifier
+// arg2 - token nonce
+// arg3 - quantity to withdraw
+// arg4 - marshalled token data, required when minting a token nonce which does not exist on this chain
+func (e *esdtBridgeWithdraw) ProcessBuiltinFunction(
+	_, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	err := checkESDTBridgeInput(vmInput, minNumArgsESDTBridgeWithdraw, maxNumArgsESDTBridgeWithdraw, e.funcGasCost)
+	if err != nil {
+		return nil, err
+	}
+	if check.IfNil(acntDst) {
+		return nil, ErrNilUserAccount
+	}
+
+	operationHash := vmInput.Arguments[0]
+	if len(operationHash) != bridgeOperationHashLength {
+		return nil, ErrInvalidBridgeOperationHash
+	}
+	tokenID := vmInput.Arguments[1]
+	nonce := getUIn46FromBytes(vmInput.Arguments[2])
+	quantity, err := getESDTBridgeQuantity(vmInput.Arguments[3])
+	if err != nil {
+		return nil, err
+	}
+
+	isCrossChainToken := e.crossChainTokenCheckerHandler.IsCrossChainOperation(tokenID)
+	err = e.checkAllowedToWithdraw(vmInput.CallerAddr, tokenID, nonce, isCrossChainToken)
+	if err != nil {
+		return nil, err
+	}
+
+	isProcessed, err := e.crossChainOperationsRegistry.IsOperationProcessed(operationHash)
+	if err != nil {
+		return nil, err
+	}
+	if isProcessed {
+		return nil, fmt.Errorf("%w: %x", ErrCrossChainOperationAlreadyProcessed, operationHash)
+	}
+
+	if isCrossChainToken {
+		err = e.mint(acntDst, tokenID, nonce, quantity, vmInput)
+	} else {
+		err = e.releaseFromEscrow(acntDst, tokenID, nonce, quantity, vmInput.ReturnCallAfterError)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = e.markOperationAsExecuted(operationHash)
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: vmInput.GasProvided - e.funcGasCost}
+	addESDTEntryInVMOutput(vmOutput, []byte(vmcommon.BuiltInFunctionESDTBridgeWithdraw), tokenID, nonce, quantity, vmInput.CallerAddr, acntDst.AddressBytes(), operationHash)
+
+	return vmOutput, nil
+}
+
+func (e *esdtBridgeWithdraw) checkAllowedToWithdraw(address []byte, tokenID []byte, nonce uint64, isCrossChainToken bool) error {
+	if !isCrossChainToken {
+		if !isCrossChainWhiteListed(e.crossChainTokenCheckerHandler, address) {
+			return ErrActionNotAllowed
+		}
+
+		return nil
+	}
+
+	action := []byte(core.ESDTRoleLocalMint)
+	if nonce > 0 {
+		action = []byte(core.ESDTRoleNFTCreate)
+	}
+	if !isCrossChainActionAllowed(e.crossChainTokenCheckerHandler, address, tokenID, action) {
+		return ErrActionNotAllowed
+	}
+
+	return nil
+}
+
+func (e *esdtBridgeWithdraw) mint(
+	acntDst vmcommon.UserAccountHandler,
+	tokenID []byte,
+	nonce uint64,
+	quantity *big.Int,
+	vmInput *vmcommon.ContractCallInput,
+) error {
+	esdtTokenKey := append(e.keyPrefix, tokenID...)
+	err := e.addMintedQuantity(acntDst, esdtTokenKey, nonce, quantity, vmInput)
+	if err != nil {
+		return err
+	}
+
+	return e.esdtSupplyHandler.AddToSupplySystemAcc(tokenID, quantity)
+}
+
+func (e *esdtBridgeWithdraw) addMintedQuantity(
+	acntDst vmcommon.UserAccountHandler,
+	esdtTokenKey []byte,
+	nonce uint64,
+	quantity *big.Int,
+	vmInput *vmcommon.ContractCallInput,
+) error {
+	if nonce == 0 {
+		return addToESDTBalance(acntDst, esdtTokenKey, quantity, e.marshaller, e.globalSettingsHandler, nil, e.enableEpochsHandler, vmInput.ReturnCallAfterError)
+	}
+
+	esdtData, isNew, err := e.esdtStorageHandler.GetESDTNFTTokenOnDestination(acntDst, esdtTokenKey, nonce)
+	if err != nil {
+		return err
+	}
+
+	mustUpdateAllFields := false
+	if isNew || esdtData.TokenMetaData == nil {
+		mustUpdateAllFields, err = e.setMetaData(esdtData, esdtTokenKey, nonce, vmInput.Arguments)
+		if err != nil {
+			return err
+		}
+	}
+
+	return e.addToDestination(acntDst, esdtTokenKey, nonce, esdtData, quantity, mustUpdateAllFields, vmInput.ReturnCallAfterError, true)
+}
+
+// setMetaData sets the token type and metadata saved on the system account or, for a token nonce which does not
+// exist on this chain, the ones provided as argument. Returns true if the metadata was taken from the argument
+func (e *esdtBridgeWithdraw) setMetaData(esdtData *esdt.ESDigitalToken, esdtTokenKey []byte, nonce uint64, arguments [][]byte) (bool, error) {
+	metaData, err := e.esdtStorageHandler.GetMetaDataFromSystemAccount(esdtTokenKey, nonce)
+	if err != nil {
+		return false, err
+	}
+	if metaData != nil && metaData.TokenMetaData != nil {
+		esdtData.Type = metaData.Type
+		esdtData.TokenMetaData = metaData.TokenMetaData
+		return false, nil
+	}
+
+	if len(arguments) < maxNumArgsESDTBridgeWithdraw {
+		return false, ErrNFTDoesNotHaveMetadata
+	}
+
+	receivedData := &esdt.ESDigitalToken{}
+	err = e.marshaller.Unmarshal(receivedData, arguments[maxNumArgsESDTBridgeWithdraw-1])
+	if err != nil {
+		return false, err
+	}
+	if receivedData.TokenMetaData == nil || receivedData.TokenMetaData.Nonce != nonce {
+		return false, fmt.Errorf("%w, invalid token metadata", ErrInvalidArguments)
+	}
+	if receivedData.Type == uint32(core.Fungible) {
+		return false, fmt.Errorf("%w, invalid esdt type %d (%s)", ErrInvalidArguments, receivedData.Type, core.ESDTType(receivedData.Type).String())
+	}
+
+	esdtData.Type = receivedData.Type
+	esdtData.TokenMetaData = receivedData.TokenMetaData
+
+	return true, nil
+}
+
+func (e *esdtBridgeWithdraw) releaseFromEscrow(
+	acntDst vmcommon.UserAccountHandler,
+	tokenID []byte,
+	nonce uint64,
+	quantity *big.Int,
+	isReturnWithError bool,
+) error {
+	systemAcc, err := getSystemAccount(e.accounts)
+	if err != nil {
+		return err
+	}
+
+	escrowKey := getBridgeEscrowKey(tokenID, nonce)
+	escrow, err := getBridgeEscrow(e.marshaller, systemAcc, escrowKey)
+	if err != nil {
+		return err
+	}
+	if escrow.Value.Cmp(quantity) < 0 {
+		return ErrInsufficientBridgeEscrow
+	}
+
+	escrow.Value.Sub(escrow.Value, quantity)
+	err = saveBridgeEscrow(e.marshaller, systemAcc, escrowKey, escrow)
+	if err != nil {
+		return err
+	}
+	err = e.accounts.SaveAccount(systemAcc)
+	if err != nil {
+		return err
+	}
+
+	esdtTokenKey := append(e.keyPrefix, tokenID...)
+	if nonce == 0 {
+		return addToESDTBalance(acntDst, esdtTokenKey, quantity, e.marshaller, e.globalSettingsHandler, nil, e.enableEpochsHandler, isReturnWithError)
+	}
+
+	esdtData, isNew, err := e.esdtStorageHandler.GetESDTNFTTokenOnDestination(acntDst, esdtTokenKey, nonce)
+	if err != nil {
+		return err
+	}
+	if isNew || esdtData.TokenMetaData == nil {
+		esdtData.Type = escrow.Type
+		esdtData.TokenMetaData = escrow.TokenMetaData
+	}
+
+	return e.addToDestination(acntDst, esdtTokenKey, nonce, esdtData, quantity, false, isReturnWithError, false)
+}
+
+func (e *esdtBridgeWithdraw) addToDestination(
+	acntDst vmcommon.UserAccountHandler,
+	esdtTokenKey []byte,
+	nonce uint64,
+	esdtData *esdt.ESDigitalToken,
+	quantity *big.Int,
+	mustUpdateAllFields bool,
+	isReturnWithError bool,
+	increaseLiquidity bool,
+) error {
+	esdtData.Value.Add(esdtData.Value, quantity)
+	properties := vmcommon.NftSaveArgs{
+		MustUpdateAllFields:         mustUpdateAllFields,
+		IsReturnWithError:           isReturnWithError,
+		KeepMetaDataOnZeroLiquidity: false,
+	}
+	_, err := e.esdtStorageHandler.SaveESDTNFTToken(acntDst.AddressBytes(), acntDst, esdtTokenKey, nonce, esdtData, properties)
+	if err != nil {
+		return err
+	}
+	if !increaseLiquidity {
+		return nil
+	}
+
+	return e.esdtStorageHandler.AddToLiquiditySystemAcc(esdtTokenKey, esdtData.Type, nonce, quantity, false)
+}
+
+// markOperationAsExecuted saves the bridge operation marker, which is not removed when the cross chain operations
+// registry is pruned, so a withdraw can never be replayed
+func (e *esdtBridgeWithdraw) markOperationAsExecuted(operationHash []byte) error {
+	systemAcc, err := getSystemAccount(e.accounts)
+	if err != nil {
+		return err
+	}
+
+	err = systemAcc.AccountDataHandler().SaveKeyValue(getBridgeOperationKey(operationHash), executedBridgeOperationMarker)
+	if err != nil {
+		return err
+	}
+
+	return e.accounts.SaveAccount(systemAcc)
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (e *esdtBridgeWithdraw) IsInterfaceNil() bool {
+	return e == nil
+}
@@ -0,0 +1,380 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	bridgeAddress         = bytes.Repeat([]byte{1}, 32)
+	bridgeUserAddress     = bytes.Repeat([]byte{2}, 32)
+	bridgeNativeToken     = []byte("sov1-TKN-abcdef")
+	bridgeCrossChainToken = []byte("sov2-TKN-abcdef")
+)
+
+func createMockESDTBridgeFuncArgs() ESDTBridgeFuncArgs {
+	systemAcc := mock.NewUserAccount(vmcommon.SystemAccountAddress)
+	accounts := &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			return systemAcc, nil
+		},
+	}
+	crossChainTokenChecker, _ := NewCrossChainTokenCheckerWithPolicies(ArgsCrossChainTokenChecker{
+		SelfESDTPrefix:       []byte("sov1"),
+		WhiteListedAddresses: map[string]struct{}{string(bridgeAddress): {}},
+		Accounts:             accounts,
+	})
+	registry, _ := NewCrossChainOperationsRegistry(accounts)
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return flag == SaveToSystemAccountFlag || flag == SendAlwaysFlag
+		},
+	}
+
+	return ESDTBridgeFuncArgs{
+		FuncGasCost:                   10,
+		Marshaller:                    &mock.MarshalizerMock{},
+		Accounts:                      accounts,
+		GlobalSettingsHandler:         &mock.GlobalSettingsHandlerStub{},
+		EsdtStorageHandler:            createNewESDTDataStorageHandlerWithArgs(&mock.GlobalSettingsHandlerStub{}, accounts, enableEpochsHandler, crossChainTokenChecker),
+		CrossChainTokenCheckerHandler: crossChainTokenChecker,
+		CrossChainOperationsRegistry:  registry,
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == ESDTBridgeFlag
+			},
+		},
+		LockedBalanceHandler: &mock.ESDTLockedBalanceHandlerStub{},
+	}
+}
+
+func createESDTBridgeInput(caller []byte, recipient []byte, args ...[]byte) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  caller,
+			CallValue:   big.NewInt(0),
+			Arguments:   args,
+			GasProvided: 100,
+		},
+		RecipientAddr: recipient,
+	}
+}
+
+func createOperationHash(value byte) []byte {
+	return bytes.Repeat([]byte{value}, bridgeOperationHashLength)
+}
+
+func setESDTBalance(t *testing.T, account vmcommon.UserAccountHandler, tokenID []byte, nonce uint64, esdtData *esdt.ESDigitalToken) {
+	marshalledData, err := (&mock.MarshalizerMock{}).Marshal(esdtData)
+	require.Nil(t, err)
+
+	tokenKey := computeESDTNFTTokenKey(append([]byte(baseESDTKeyPrefix), tokenID...), nonce)
+	require.Nil(t, account.AccountDataHandler().SaveKeyValue(tokenKey, marshalledData))
+}
+
+func getESDTBalance(t *testing.T, storageHandler vmcommon.ESDTNFTStorageHandler, account vmcommon.UserAccountHandler, tokenID []byte, nonce uint64) *big.Int {
+	esdtData, _, err := storageHandler.GetESDTNFTTokenOnDestination(account, append([]byte(baseESDTKeyPrefix), tokenID...), nonce)
+	require.Nil(t, err)
+
+	return esdtData.Value
+}
+
+func TestNewESDTBridgeFuncs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		modify      func(args *ESDTBridgeFuncArgs)
+		expectedErr error
+	}{
+		{name: "nil marshaller", modify: func(args *ESDTBridgeFuncArgs) { args.Marshaller = nil }, expectedErr: ErrNilMarshalizer},
+		{name: "nil accounts", modify: func(args *ESDTBridgeFuncArgs) { args.Accounts = nil }, expectedErr: ErrNilAccountsAdapter},
+		{name: "nil global settings handler", modify: func(args *ESDTBridgeFuncArgs) { args.GlobalSettingsHandler = nil }, expectedErr: ErrNilGlobalSettingsHandler},
+		{name: "nil storage handler", modify: func(args *ESDTBridgeFuncArgs) { args.EsdtStorageHandler = nil }, expectedErr: ErrNilESDTNFTStorageHandler},
+		{name: "nil cross chain token checker", modify: func(args *ESDTBridgeFuncArgs) { args.CrossChainTokenCheckerHandler = nil }, expectedErr: ErrNilCrossChainTokenChecker},
+		{name: "nil cross chain operations registry", modify: func(args *ESDTBridgeFuncArgs) { args.CrossChainOperationsRegistry = nil }, expectedErr: ErrNilCrossChainOperationsRegistry},
+		{name: "nil enable epochs handler", modify: func(args *ESDTBridgeFuncArgs) { args.EnableEpochsHandler = nil }, expectedErr: ErrNilEnableEpochsHandler},
+	}
+	for _, test := range tests {
+		args := createMockESDTBridgeFuncArgs()
+		test.modify(&args)
+
+		deposit, err := NewESDTBridgeDepositFunc(args)
+		assert.Nil(t, deposit, test.name)
+		assert.Equal(t, test.expectedErr, err, test.name)
+
+		withdraw, err := NewESDTBridgeWithdrawFunc(args)
+		assert.Nil(t, withdraw, test.name)
+		assert.Equal(t, test.expectedErr, err, test.name)
+	}
+
+	args := createMockESDTBridgeFuncArgs()
+	args.LockedBalanceHandler = nil
+	deposit, err := NewESDTBridgeDepositFunc(args)
+	assert.Nil(t, deposit)
+	assert.Equal(t, ErrNilLockedBalanceHandler, err)
+
+	deposit, err = NewESDTBridgeDepositFunc(createMockESDTBridgeFuncArgs())
+	assert.Nil(t, err)
+	assert.False(t, deposit.IsInterfaceNil())
+	assert.True(t, deposit.IsActive())
+
+	withdraw, err := NewESDTBridgeWithdrawFunc(createMockESDTBridgeFuncArgs())
+	assert.Nil(t, err)
+	assert.False(t, withdraw.IsInterfaceNil())
+	assert.True(t, withdraw.IsActive())
+
+	args = createMockESDTBridgeFuncArgs()
+	args.EnableEpochsHandler = &mock.EnableEpochsHandlerStub{}
+	deposit, _ = NewESDTBridgeDepositFunc(args)
+	assert.False(t, deposit.IsActive())
+	withdraw, _ = NewESDTBridgeWithdrawFunc(args)
+	assert.False(t, withdraw.IsActive())
+}
+
+func TestESDTBridgeDeposit_ProcessBuiltinFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	deposit, _ := NewESDTBridgeDepositFunc(createMockESDTBridgeFuncArgs())
+	user := mock.NewUserAccount(bridgeUserAddress)
+	destination := []byte("destination")
+
+	_, err := deposit.ProcessBuiltinFunction(user, nil, nil)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	_, err = deposit.ProcessBuiltinFunction(user, nil, createESDTBridgeInput(bridgeUserAddress, bridgeUserAddress, destination, bridgeNativeToken, []byte{}))
+	assert.Equal(t, ErrInvalidNumOfArgs, err)
+
+	input := createESDTBridgeInput(bridgeUserAddress, bridgeUserAddress, destination, bridgeNativeToken, []byte{}, big.NewInt(10).Bytes())
+	input.GasProvided = 1
+	_, err = deposit.ProcessBuiltinFunction(user, nil, input)
+	assert.Equal(t, ErrNotEnoughGas, err)
+
+	_, err = deposit.ProcessBuiltinFunction(user, nil, createESDTBridgeInput(bridgeUserAddress, bridgeAddress, destination, bridgeNativeToken, []byte{}, big.NewInt(10).Bytes()))
+	assert.Equal(t, ErrInvalidRcvAddr, err)
+
+	_, err = deposit.ProcessBuiltinFunction(nil, nil, createESDTBridgeInput(bridgeUserAddress, bridgeUserAddress, destination, bridgeNativeToken, []byte{}, big.NewInt(10).Bytes()))
+	assert.Equal(t, ErrNilUserAccount, err)
+
+	_, err = deposit.ProcessBuiltinFunction(user, nil, createESDTBridgeInput(bridgeUserAddress, bridgeUserAddress, nil, bridgeNativeToken, []byte{}, big.NewInt(10).Bytes()))
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+
+	_, err = deposit.ProcessBuiltinFunction(user, nil, createESDTBridgeInput(bridgeUserAddress, bridgeUserAddress, destination, bridgeNativeToken, []byte{}, []byte{}))
+	assert.Equal(t, ErrNegativeValue, err)
+
+	_, err = deposit.ProcessBuiltinFunction(user, nil, createESDTBridgeInput(bridgeUserAddress, bridgeUserAddress, destination, bridgeNativeToken, []byte{}, big.NewInt(10).Bytes()))
+	assert.Equal(t, ErrInsufficientFunds, err)
+
+	_, err = deposit.ProcessBuiltinFunction(user, nil, createESDTBridgeInput(bridgeUserAddress, bridgeUserAddress, destination, bridgeNativeToken, []byte{1}, big.NewInt(10).Bytes()))
+	assert.Equal(t, ErrNewNFTDataOnSenderAddress, err)
+}
+
+func TestESDTBridgeWithdraw_ProcessBuiltinFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	withdraw, _ := NewESDTBridgeWithdrawFunc(createMockESDTBridgeFuncArgs())
+	user := mock.NewUserAccount(bridgeUserAddress)
+	value := big.NewInt(10).Bytes()
+
+	_, err := withdraw.ProcessBuiltinFunction(nil, user, nil)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	_, err = withdraw.ProcessBuiltinFunction(nil, user, createESDTBridgeInput(bridgeAddress, bridgeUserAddress, createOperationHash(1), bridgeNativeToken, []byte{}))
+	assert.Equal(t, ErrInvalidNumOfArgs, err)
+
+	_, err = withdraw.ProcessBuiltinFunction(nil, nil, createESDTBridgeInput(bridgeAddress, bridgeUserAddress, createOperationHash(1), bridgeNativeToken, []byte{}, value))
+	assert.Equal(t, ErrNilUserAccount, err)
+
+	_, err = withdraw.ProcessBuiltinFunction(nil, user, createESDTBridgeInput(bridgeAddress, bridgeUserAddress, []byte("hash"), bridgeNativeToken, []byte{}, value))
+	assert.Equal(t, ErrInvalidBridgeOperationHash, err)
+
+	_, err = withdraw.ProcessBuiltinFunction(nil, user, createESDTBridgeInput(bridgeUserAddress, bridgeUserAddress, createOperationHash(1), bridgeNativeToken, []byte{}, value))
+	assert.Equal(t, ErrActionNotAllowed, err)
+
+	_, err = withdraw.ProcessBuiltinFunction(nil, user, createESDTBridgeInput(bridgeUserAddress, bridgeUserAddress, createOperationHash(1), bridgeCrossChainToken, []byte{}, value))
+	assert.Equal(t, ErrActionNotAllowed, err)
+
+	_, err = withdraw.ProcessBuiltinFunction(nil, user, createESDTBridgeInput(bridgeAddress, bridgeUserAddress, createOperationHash(1), bridgeNativeToken, []byte{}, value))
+	assert.Equal(t, ErrInsufficientBridgeEscrow, err)
+
+	_, err = withdraw.ProcessBuiltinFunction(nil, user, createESDTBridgeInput(bridgeAddress, bridgeUserAddress, createOperationHash(1), bridgeCrossChainToken, []byte{1}, value))
+	assert.Equal(t, ErrNFTDoesNotHaveMetadata, err)
+
+	invalidData, _ := (&mock.MarshalizerMock{}).Marshal(&esdt.ESDigitalToken{Type: uint32(core.SemiFungible), TokenMetaData: &esdt.MetaData{Nonce: 2}})
+	_, err = withdraw.ProcessBuiltinFunction(nil, user, createESDTBridgeInput(bridgeAddress, bridgeUserAddress, createOperationHash(1), bridgeCrossChainToken, []byte{1}, value, invalidData))
+	assert.ErrorIs(t, err, ErrInvalidArguments)
+}
+
+func TestESDTBridgeWithdraw_CheckerWithoutWhiteListShouldNotReleaseNativeTokens(t *testing.T) {
+	t.Parallel()
+
+	args := createMockESDTBridgeFuncArgs()
+	args.CrossChainTokenCheckerHandler = struct {
+		CrossChainTokenCheckerHandler
+	}{
+		CrossChainTokenCheckerHandler: &mock.CrossChainTokenCheckerMock{
+			IsWhiteListedCalled: func(address []byte) bool {
+				return true
+			},
+		},
+	}
+	withdraw, _ := NewESDTBridgeWithdrawFunc(args)
+	user := mock.NewUserAccount(bridgeUserAddress)
+
+	_, err := withdraw.ProcessBuiltinFunction(nil, user, createESDTBridgeInput(bridgeAddress, bridgeUserAddress, createOperationHash(1), bridgeNativeToken, []byte{}, big.NewInt(10).Bytes()))
+	assert.Equal(t, ErrActionNotAllowed, err)
+}
+
+func TestESDTBridge_NativeFungibleTokenIsEscrowedAndReleased(t *testing.T) {
+	t.Parallel()
+
+	args := createMockESDTBridgeFuncArgs()
+	deposit, _ := NewESDTBridgeDepositFunc(args)
+	withdraw, _ := NewESDTBridgeWithdrawFunc(args)
+	user := mock.NewUserAccount(bridgeUserAddress)
+	receiver := mock.NewUserAccount(bytes.Repeat([]byte{3}, 32))
+	setESDTBalance(t, user, bridgeNativeToken, 0, &esdt.ESDigitalToken{Value: big.NewInt(100)})
+
+	destination := []byte("destination")
+	vmOutput, err := deposit.ProcessBuiltinFunction(user, nil, createESDTBridgeInput(bridgeUserAddress, bridgeUserAddress, destination, bridgeNativeToken, []byte{}, big.NewInt(30).Bytes()))
+	require.Nil(t, err)
+	assert.Equal(t, uint64(90), vmOutput.GasRemaining)
+	assert.Equal(t, big.NewInt(70), getESDTBalance(t, args.EsdtStorageHandler, user, bridgeNativeToken, 0))
+	require.Equal(t, 1, len(vmOutput.Logs))
+	assert.Equal(t, &vmcommon.LogEntry{
+		Identifier: []byte(vmcommon.BuiltInFunctionESDTBridgeDeposit),
+		Address:    bridgeUserAddress,
+		Topics:     [][]byte{bridgeNativeToken, {}, big.NewInt(30).Bytes(), destination, nil},
+	}, vmOutput.Logs[0])
+
+	_, err = withdraw.ProcessBuiltinFunction(nil, receiver, createESDTBridgeInput(bridgeAddress, receiver.AddressBytes(), createOperationHash(1), bridgeNativeToken, []byte{}, big.NewInt(31).Bytes()))
+	assert.Equal(t, ErrInsufficientBridgeEscrow, err)
+
+	vmOutput, err = withdraw.ProcessBuiltinFunction(nil, receiver, createESDTBridgeInput(bridgeAddress, receiver.AddressBytes(), createOperationHash(1), bridgeNativeToken, []byte{}, big.NewInt(20).Bytes()))
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(20), getESDTBalance(t, args.EsdtStorageHandler, receiver, bridgeNativeToken, 0))
+	assert.Equal(t, [][]byte{bridgeNativeToken, {}, big.NewInt(20).Bytes(), receiver.AddressBytes(), createOperationHash(1)}, vmOutput.Logs[0].Topics)
+
+	_, err = withdraw.ProcessBuiltinFunction(nil, receiver, createESDTBridgeInput(bridgeAddress, receiver.AddressBytes(), createOperationHash(1), bridgeNativeToken, []byte{}, big.NewInt(10).Bytes()))
+	assert.ErrorIs(t, err, ErrCrossChainOperationAlreadyProcessed)
+
+	_, err = withdraw.ProcessBuiltinFunction(nil, receiver, createESDTBridgeInput(bridgeAddress, receiver.AddressBytes(), createOperationHash(2), bridgeNativeToken, []byte{}, big.NewInt(10).Bytes()))
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(30), getESDTBalance(t, args.EsdtStorageHandler, receiver, bridgeNativeToken, 0))
+
+	_, err = withdraw.ProcessBuiltinFunction(nil, receiver, createESDTBridgeInput(bridgeAddress, receiver.AddressBytes(), createOperationHash(3), bridgeNativeToken, []byte{}, big.NewInt(1).Bytes()))
+	assert.Equal(t, ErrInsufficientBridgeEscrow, err)
+}
+
+func TestESDTBridgeDeposit_LockedBalanceCanNotBeDeposited(t *testing.T) {
+	t.Parallel()
+
+	args := createMockESDTBridgeFuncArgs()
+	args.LockedBalanceHandler = &mock.ESDTLockedBalanceHandlerStub{
+		GetLockedBalanceCalled: func(_ vmcommon.UserAccountHandler, tokenID []byte) (*big.Int, error) {
+			assert.Equal(t, bridgeNativeToken, tokenID)
+			return big.NewInt(60), nil
+		},
+	}
+	deposit, _ := NewESDTBridgeDepositFunc(args)
+	user := mock.NewUserAccount(bridgeUserAddress)
+	setESDTBalance(t, user, bridgeNativeToken, 0, &esdt.ESDigitalToken{Value: big.NewInt(100)})
+
+	destination := []byte("destination")
+	_, err := deposit.ProcessBuiltinFunction(user, nil, createESDTBridgeInput(bridgeUserAddress, bridgeUserAddress, destination, bridgeNativeToken, []byte{}, big.NewInt(41).Bytes()))
+	assert.ErrorIs(t, err, ErrInsufficientUnlockedBalance)
+	assert.Equal(t, big.NewInt(100), getESDTBalance(t, args.EsdtStorageHandler, user, bridgeNativeToken, 0))
+
+	_, err = deposit.ProcessBuiltinFunction(user, nil, createESDTBridgeInput(bridgeUserAddress, bridgeUserAddress, destination, bridgeNativeToken, []byte{}, big.NewInt(40).Bytes()))
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(60), getESDTBalance(t, args.EsdtStorageHandler, user, bridgeNativeToken, 0))
+}
+
+func TestESDTBridgeWithdraw_OperationsCanNotBeReplayed(t *testing.T) {
+	t.Parallel()
+
+	args := createMockESDTBridgeFuncArgs()
+	deposit, _ := NewESDTBridgeDepositFunc(args)
+	withdraw, _ := NewESDTBridgeWithdrawFunc(args)
+	user := mock.NewUserAccount(bridgeUserAddress)
+	setESDTBalance(t, user, bridgeNativeToken, 0, &esdt.ESDigitalToken{Value: big.NewInt(100)})
+	_, err := deposit.ProcessBuiltinFunction(user, nil, createESDTBridgeInput(bridgeUserAddress, bridgeUserAddress, []byte("destination"), bridgeNativeToken, []byte{}, big.NewInt(100).Bytes()))
+	require.Nil(t, err)
+
+	t.Run("pruned registry", func(t *testing.T) {
+		_, err = withdraw.ProcessBuiltinFunction(nil, user, createESDTBridgeInput(bridgeAddress, bridgeUserAddress, createOperationHash(1), bridgeNativeToken, []byte{}, big.NewInt(10).Bytes()))
+		require.Nil(t, err)
+
+		require.Nil(t, args.CrossChainOperationsRegistry.PruneOperations(10))
+
+		isProcessed, errQuery := args.CrossChainOperationsRegistry.IsOperationProcessed(createOperationHash(1))
+		require.Nil(t, errQuery)
+		assert.True(t, isProcessed)
+		_, err = withdraw.ProcessBuiltinFunction(nil, user, createESDTBridgeInput(bridgeAddress, bridgeUserAddress, createOperationHash(1), bridgeNativeToken, []byte{}, big.NewInt(10).Bytes()))
+		assert.ErrorIs(t, err, ErrCrossChainOperationAlreadyProcessed)
+	})
+	t.Run("operation recorded before the registry", func(t *testing.T) {
+		systemAcc, _ := getSystemAccount(args.Accounts)
+		_ = systemAcc.AccountDataHandler().SaveKeyValue(getBridgeOperationKey(createOperationHash(2)), executedBridgeOperationMarker)
+
+		isProcessed, errQuery := args.CrossChainOperationsRegistry.IsOperationProcessed(createOperationHash(2))
+		require.Nil(t, errQuery)
+		assert.True(t, isProcessed)
+		_, err = withdraw.ProcessBuiltinFunction(nil, user, createESDTBridgeInput(bridgeAddress, bridgeUserAddress, createOperationHash(2), bridgeNativeToken, []byte{}, big.NewInt(10).Bytes()))
+		assert.ErrorIs(t, err, ErrCrossChainOperationAlreadyProcessed)
+	})
+	assert.Equal(t, big.NewInt(10), getESDTBalance(t, args.EsdtStorageHandler, user, bridgeNativeToken, 0))
+}
+
+func TestESDTBridge_CrossChainSemiFungibleTokenIsMintedAndBurned(t *testing.T) {
+	t.Parallel()
+
+	args := createMockESDTBridgeFuncArgs()
+	deposit, _ := NewESDTBridgeDepositFunc(args)
+	withdraw, _ := NewESDTBridgeWithdrawFunc(args)
+	user := mock.NewUserAccount(bridgeUserAddress)
+	tokenKey := append([]byte(baseESDTKeyPrefix), bridgeCrossChainToken...)
+
+	tokenData := &esdt.ESDigitalToken{
+		Type:  uint32(core.SemiFungible),
+		Value: big.NewInt(5),
+		TokenMetaData: &esdt.MetaData{
+			Nonce: 7,
+			Name:  []byte("name"),
+		},
+	}
+	tokenDataBytes, _ := args.Marshaller.Marshal(tokenData)
+	_, err := withdraw.ProcessBuiltinFunction(nil, user, createESDTBridgeInput(bridgeAddress, bridgeUserAddress, createOperationHash(1), bridgeCrossChainToken, []byte{7}, big.NewInt(5).Bytes(), tokenDataBytes))
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(5), getESDTBalance(t, args.EsdtStorageHandler, user, bridgeCrossChainToken, 7))
+
+	metaData, err := args.EsdtStorageHandler.GetMetaDataFromSystemAccount(tokenKey, 7)
+	require.Nil(t, err)
+	assert.Equal(t, tokenData.TokenMetaData, metaData.TokenMetaData)
+	assert.Equal(t, big.NewInt(5), metaData.Value)
+
+	_, err = withdraw.ProcessBuiltinFunction(nil, user, createESDTBridgeInput(bridgeAddress, bridgeUserAddress, createOperationHash(2), bridgeCrossChainToken, []byte{7}, big.NewInt(3).Bytes()))
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(8), getESDTBalance(t, args.EsdtStorageHandler, user, bridgeCrossChainToken, 7))
+
+	destination := []byte("destination")
+	vmOutput, err := deposit.ProcessBuiltinFunction(user, nil, createESDTBridgeInput(bridgeUserAddress, bridgeUserAddress, destination, bridgeCrossChainToken, []byte{7}, big.NewInt(6).Bytes()))
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(2), getESDTBalance(t, args.EsdtStorageHandler, user, bridgeCrossChainToken, 7))
+
+	metaData, _ = args.EsdtStorageHandler.GetMetaDataFromSystemAccount(tokenKey, 7)
+	assert.Equal(t, big.NewInt(2), metaData.Value)
+
+	depositedData := &esdt.ESDigitalToken{}
+	_ = args.Marshaller.Unmarshal(depositedData, vmOutput.Logs[0].Topics[4])
+	assert.Equal(t, big.NewInt(6), depositedData.Value)
+	assert.Equal(t, tokenData.TokenMetaData, depositedData.TokenMetaData)
+}
@@ -16,35 +16,52 @@ type esdtBurn struct {
 	marshaller            vmcommon.Marshalizer
 	keyPrefix             []byte
 	globalSettingsHandler vmcommon.ESDTGlobalSettingsHandler
+	enableEpochsHandler   vmcommon.EnableEpochsHandler
+	esdtSupplyHandler     vmcommon.ESDTSupplyHandler
+	lockedBalanceHandler  ESDTLockedBalanceHandler
 	mutExecution          sync.RWMutex
 }
 
+// ESDTBurnFuncArgs holds the arguments needed to create the esdt burn built-in function
+type ESDTBurnFuncArgs struct {
+	FuncGasCost           uint64
+	Marshaller            vmcommon.Marshalizer
+	ESDTSupplyHandler     vmcommon.ESDTSupplyHandler
+	GlobalSettingsHandler vmcommon.ESDTGlobalSettingsHandler
+	EnableEpochsHandler   vmcommon.EnableEpochsHandler
+	LockedBalanceHandler  ESDTLockedBalanceHandler
+}
+
 // NewESDTBurnFunc returns the esdt burn built-in function component
-func NewESDTBurnFunc(
-	funcGasCost uint64,
-	marshaller vmcommon.Marshalizer,
-	globalSettingsHandler vmcommon.ESDTGlobalSettingsHandler,
-	enableEpochsHandler vmcommon.EnableEpochsHandler,
-) (*esdtBurn, error) {
-	if check.IfNil(marshaller) {
+func NewESDTBurnFunc(args ESDTBurnFuncArgs) (*esdtBurn, error) {
+	if check.IfNil(args.Marshaller) {
 		return nil, ErrNilMarshalizer
 	}
-	if check.IfNil(globalSettingsHandler) {
+	if check.IfNil(args.ESDTSupplyHandler) {
+		return nil, ErrNilESDTSupplyHandler
+	}
+	if check.IfNil(args.GlobalSettingsHandler) {
 		return nil, ErrNilGlobalSettingsHandler
 	}
-	if check.IfNil(enableEpochsHandler) {
+	if check.IfNil(args.EnableEpochsHandler) {
 		return nil, ErrNilEnableEpochsHandler
 	}
+	if check.IfNil(args.LockedBalanceHandler) {
+		return nil, ErrNilLockedBalanceHandler
+	}
 
 	e := &esdtBurn{
-		funcGasCost:           funcGasCost,
-		marshaller:            marshaller,
+		funcGasCost:           args.FuncGasCost,
+		marshaller:            args.Marshaller,
 		keyPrefix:             []byte(baseESDTKeyPrefix),
-		globalSettingsHandler: globalSettingsHandler,
+		globalSettingsHandler: args.GlobalSettingsHandler,
+		enableEpochsHandler:   args.EnableEpochsHandler,
+		esdtSupplyHandler:     args.ESDTSupplyHandler,
+		lockedBalanceHandler:  args.LockedBalanceHandler,
 	}
 
 	e.baseActiveHandler.activeHandler = func() bool {
-		return enableEpochsHandler.IsFlagEnabled(GlobalMintBurnFlag)
+		return args.EnableEpochsHandler.IsFlagEnabled(GlobalMintBurnFlag)
 	}
 
 	return e, nil
@@ -93,7 +110,11 @@ func (e *esdtBurn) ProcessBuiltinFunction(
 		return nil, ErrNotEnoughGas
 	}
 
-	err = addToESDTBalance(acntSnd, esdtTokenKey, big.NewInt(0).Neg(value), e.marshaller, e.globalSettingsHandler, vmInput.ReturnCallAfterError)
+	err = addToESDTBalance(acntSnd, esdtTokenKey, big.NewInt(0).Neg(value), e.marshaller, e.globalSettingsHandler, e.lockedBalanceHandler, e.enableEpochsHandler, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+	err = e.esdtSupplyHandler.AddToSupplySystemAcc(vmInput.Arguments[0], big.NewInt(0).Neg(value))
 	if err != nil {
 		return nil, err
 	}
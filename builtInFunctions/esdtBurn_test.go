@@ -1,6 +1,7 @@
 package builtInFunctions
 
 import (
+	"errors"
 	"math/big"
 	"testing"
 
@@ -18,28 +19,73 @@ func TestNewESDTBurnFunc(t *testing.T) {
 	t.Run("nil marshaller should error", func(t *testing.T) {
 		t.Parallel()
 
-		burnFunc, err := NewESDTBurnFunc(10, nil, &mock.GlobalSettingsHandlerStub{}, &mock.EnableEpochsHandlerStub{
-			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
-				return flag == GlobalMintBurnFlag
+		burnFunc, err := NewESDTBurnFunc(ESDTBurnFuncArgs{
+			FuncGasCost:           10,
+			ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+					return flag == GlobalMintBurnFlag
+				},
 			},
+			LockedBalanceHandler: &mock.ESDTLockedBalanceHandlerStub{},
 		})
 		assert.Equal(t, ErrNilMarshalizer, err)
 		assert.True(t, check.IfNil(burnFunc))
 	})
+	t.Run("nil esdt supply handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		burnFunc, err := NewESDTBurnFunc(ESDTBurnFuncArgs{
+			FuncGasCost:           10,
+			Marshaller:            &mock.MarshalizerMock{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+		})
+		assert.Equal(t, ErrNilESDTSupplyHandler, err)
+		assert.True(t, check.IfNil(burnFunc))
+	})
 	t.Run("nil enable epochs handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		burnFunc, err := NewESDTBurnFunc(10, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, nil)
+		burnFunc, err := NewESDTBurnFunc(ESDTBurnFuncArgs{
+			FuncGasCost:           10,
+			Marshaller:            &mock.MarshalizerMock{},
+			ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+		})
 		assert.Equal(t, ErrNilEnableEpochsHandler, err)
 		assert.True(t, check.IfNil(burnFunc))
 	})
+	t.Run("nil locked balance handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		burnFunc, err := NewESDTBurnFunc(ESDTBurnFuncArgs{
+			FuncGasCost:           10,
+			Marshaller:            &mock.MarshalizerMock{},
+			ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		})
+		assert.Equal(t, ErrNilLockedBalanceHandler, err)
+		assert.True(t, check.IfNil(burnFunc))
+	})
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
-		burnFunc, err := NewESDTBurnFunc(10, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, &mock.EnableEpochsHandlerStub{
-			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
-				return flag == GlobalMintBurnFlag
+		burnFunc, err := NewESDTBurnFunc(ESDTBurnFuncArgs{
+			FuncGasCost:           10,
+			Marshaller:            &mock.MarshalizerMock{},
+			ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+				IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+					return flag == GlobalMintBurnFlag
+				},
 			},
+			LockedBalanceHandler: &mock.ESDTLockedBalanceHandlerStub{},
 		})
 		assert.Nil(t, err)
 		assert.False(t, check.IfNil(burnFunc))
@@ -50,10 +96,17 @@ func TestESDTBurn_ProcessBuiltInFunctionErrors(t *testing.T) {
 	t.Parallel()
 
 	globalSettingsHandler := &mock.GlobalSettingsHandlerStub{}
-	burnFunc, _ := NewESDTBurnFunc(10, &mock.MarshalizerMock{}, globalSettingsHandler, &mock.EnableEpochsHandlerStub{
-		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
-			return flag == GlobalMintBurnFlag
+	burnFunc, _ := NewESDTBurnFunc(ESDTBurnFuncArgs{
+		FuncGasCost:           10,
+		Marshaller:            &mock.MarshalizerMock{},
+		ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+		GlobalSettingsHandler: globalSettingsHandler,
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == GlobalMintBurnFlag
+			},
 		},
+		LockedBalanceHandler: &mock.ESDTLockedBalanceHandlerStub{},
 	})
 	_, err := burnFunc.ProcessBuiltinFunction(nil, nil, nil)
 	assert.Equal(t, err, ErrNilVmInput)
@@ -100,10 +153,17 @@ func TestESDTBurn_ProcessBuiltInFunctionSenderBurns(t *testing.T) {
 
 	marshaller := &mock.MarshalizerMock{}
 	globalSettingsHandler := &mock.GlobalSettingsHandlerStub{}
-	burnFunc, _ := NewESDTBurnFunc(10, marshaller, globalSettingsHandler, &mock.EnableEpochsHandlerStub{
-		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
-			return flag == GlobalMintBurnFlag
+	burnFunc, _ := NewESDTBurnFunc(ESDTBurnFuncArgs{
+		FuncGasCost:           10,
+		Marshaller:            marshaller,
+		ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+		GlobalSettingsHandler: globalSettingsHandler,
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == GlobalMintBurnFlag
+			},
 		},
+		LockedBalanceHandler: &mock.ESDTLockedBalanceHandlerStub{},
 	})
 
 	input := &vmcommon.ContractCallInput{
@@ -162,3 +222,51 @@ func TestESDTBurn_ProcessBuiltInFunctionSenderBurns(t *testing.T) {
 	marshaledData, _, _ = accSnd.AccountDataHandler().RetrieveValue(esdtKey)
 	assert.Equal(t, len(marshaledData), 0)
 }
+
+func TestESDTBurn_ProcessBuiltInFunctionCanNotBurnTheLockedBalance(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	burnFunc, _ := NewESDTBurnFunc(ESDTBurnFuncArgs{
+		FuncGasCost:           10,
+		Marshaller:            marshaller,
+		ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+		GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == GlobalMintBurnFlag
+			},
+		},
+		LockedBalanceHandler: &mock.ESDTLockedBalanceHandlerStub{
+			GetLockedBalanceCalled: func(_ vmcommon.UserAccountHandler, _ []byte) (*big.Int, error) {
+				return big.NewInt(60), nil
+			},
+		},
+	})
+
+	key := []byte("key")
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			GasProvided: 50,
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{key, big.NewInt(41).Bytes()},
+		},
+		RecipientAddr: core.ESDTSCAddress,
+	}
+	accSnd := mock.NewUserAccount([]byte("snd"))
+	esdtKey := append(burnFunc.keyPrefix, key...)
+	marshaledData, _ := marshaller.Marshal(&esdt.ESDigitalToken{Value: big.NewInt(100)})
+	_ = accSnd.AccountDataHandler().SaveKeyValue(esdtKey, marshaledData)
+
+	_, err := burnFunc.ProcessBuiltinFunction(accSnd, nil, input)
+	assert.True(t, errors.Is(err, ErrInsufficientUnlockedBalance))
+
+	input.Arguments = [][]byte{key, big.NewInt(40).Bytes()}
+	_, err = burnFunc.ProcessBuiltinFunction(accSnd, nil, input)
+	assert.Nil(t, err)
+
+	esdtToken := &esdt.ESDigitalToken{}
+	marshaledData, _, _ = accSnd.AccountDataHandler().RetrieveValue(esdtKey)
+	_ = marshaller.Unmarshal(esdtToken, marshaledData)
+	assert.Equal(t, big.NewInt(60), esdtToken.Value)
+}
@@ -14,7 +14,14 @@ import (
 	"github.com/multiversx/mx-chain-vm-common-go/parsers"
 )
 
-const existsOnShard = byte(1)
+const (
+	existsOnShard = byte(1)
+
+	esdtMintedSupplyKeyPrefix = core.ProtectedKeyPrefix + "esdtMintedSupply"
+	esdtBurnedSupplyKeyPrefix = core.ProtectedKeyPrefix + "esdtBurnedSupply"
+	esdtMaxSupplyKeyPrefix    = core.ProtectedKeyPrefix + "esdtMaxSupply"
+	esdtBaseSupplyKeyPrefix   = core.ProtectedKeyPrefix + "esdtBaseSupply"
+)
 
 type queryOptions struct {
 	isCustomSystemAccountSet bool
@@ -267,13 +274,13 @@ func (e *esdtDataStorage) checkFrozenPauseProperties(
 	esdtData *esdt.ESDigitalToken,
 	isReturnWithError bool,
 ) error {
-	err := checkFrozeAndPause(acnt.AddressBytes(), esdtTokenKey, esdtData, e.globalSettingsHandler, isReturnWithError)
+	err := checkFrozeAndPause(acnt, esdtTokenKey, esdtData, e.globalSettingsHandler, e.enableEpochsHandler, isReturnWithError)
 	if err != nil {
 		return err
 	}
 
 	esdtNFTTokenKey := computeESDTNFTTokenKey(esdtTokenKey, nonce)
-	err = checkFrozeAndPause(acnt.AddressBytes(), esdtNFTTokenKey, esdtData, e.globalSettingsHandler, isReturnWithError)
+	err = checkFrozeAndPause(acnt, esdtNFTTokenKey, esdtData, e.globalSettingsHandler, e.enableEpochsHandler, isReturnWithError)
 	if err != nil {
 		return err
 	}
@@ -337,6 +344,156 @@ func (e *esdtDataStorage) AddToLiquiditySystemAcc(
 	return e.marshalAndSaveData(systemAcc, esdtData, esdtNFTTokenKey)
 }
 
+// AddToSupplySystemAcc updates the supply counters of the token saved on the system account. A positive value is
+// added to the minted counter, while a negative value is added, in absolute value, to the burned counter. The counters
+// are kept per token identifier, summing up the quantities of all nonces. Minting above the max supply is not allowed
+func (e *esdtDataStorage) AddToSupplySystemAcc(tokenID []byte, value *big.Int) error {
+	if !e.enableEpochsHandler.IsFlagEnabled(ESDTSupplyTrackingFlag) || value == nil || value.Sign() == 0 {
+		return nil
+	}
+
+	systemAcc, err := e.loadSystemAccount()
+	if err != nil {
+		return err
+	}
+
+	key := getESDTMintedSupplyKey(tokenID)
+	if value.Sign() < 0 {
+		key = getESDTBurnedSupplyKey(tokenID)
+	}
+
+	counter, err := getBigIntFromKey(systemAcc, key)
+	if err != nil {
+		return err
+	}
+
+	if value.Sign() > 0 {
+		err = e.checkMaxSupply(systemAcc, tokenID, value)
+		if err != nil {
+			return err
+		}
+	}
+
+	counter.Add(counter, big.NewInt(0).Abs(value))
+	err = systemAcc.AccountDataHandler().SaveKeyValue(key, counter.Bytes())
+	if err != nil {
+		return err
+	}
+
+	return e.accounts.SaveAccount(systemAcc)
+}
+
+// GetESDTSupply returns the supply counters of the token saved on the system account. The minted and burned counters
+// only take into account the operations executed in this shard after the supply was last confirmed through
+// ESDTSetMaxSupply or, if it was never confirmed, after the activation of the supply tracking
+func (e *esdtDataStorage) GetESDTSupply(tokenID []byte) (*vmcommon.ESDTSupply, error) {
+	systemAcc, err := e.loadSystemAccount()
+	if err != nil {
+		return nil, err
+	}
+
+	supply, err := getESDTSupplyFromSystemAccount(systemAcc, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	supply.MaxSupply, err = getBigIntFromKey(systemAcc, getESDTMaxSupplyKey(tokenID))
+	if err != nil {
+		return nil, err
+	}
+
+	return supply, nil
+}
+
+func (e *esdtDataStorage) checkMaxSupply(systemAcc vmcommon.UserAccountHandler, tokenID []byte, mintedValue *big.Int) error {
+	if !e.enableEpochsHandler.IsFlagEnabled(ESDTMaxSupplyFlag) {
+		return nil
+	}
+
+	maxSupply, err := getBigIntFromKey(systemAcc, getESDTMaxSupplyKey(tokenID))
+	if err != nil {
+		return err
+	}
+	if maxSupply.Sign() == 0 {
+		return nil
+	}
+
+	supply, err := getESDTSupplyFromSystemAccount(systemAcc, tokenID)
+	if err != nil {
+		return err
+	}
+
+	newSupply := supply.Supply.Add(supply.Supply, mintedValue)
+	if newSupply.Cmp(maxSupply) > 0 {
+		return fmt.Errorf("%w for token %s, max supply %s, new supply %s", ErrMaxSupplyExceeded, tokenID, maxSupply, newSupply)
+	}
+
+	return nil
+}
+
+// getESDTSupplyFromSystemAccount returns the supply of the token as the confirmed base supply plus the quantity
+// minted and minus the quantity burned since then
+func getESDTSupplyFromSystemAccount(systemAcc vmcommon.UserAccountHandler, tokenID []byte) (*vmcommon.ESDTSupply, error) {
+	baseSupply, err := getBigIntFromKey(systemAcc, getESDTBaseSupplyKey(tokenID))
+	if err != nil {
+		return nil, err
+	}
+	minted, err := getBigIntFromKey(systemAcc, getESDTMintedSupplyKey(tokenID))
+	if err != nil {
+		return nil, err
+	}
+	burned, err := getBigIntFromKey(systemAcc, getESDTBurnedSupplyKey(tokenID))
+	if err != nil {
+		return nil, err
+	}
+
+	supply := big.NewInt(0).Add(baseSupply, minted)
+	return &vmcommon.ESDTSupply{
+		Supply: supply.Sub(supply, burned),
+		Minted: minted,
+		Burned: burned,
+	}, nil
+}
+
+// saveConfirmedESDTSupply saves the supply confirmed by the token manager as base supply and resets the minted and
+// burned counters, which start counting from the confirmed supply
+func saveConfirmedESDTSupply(systemAcc vmcommon.UserAccountHandler, tokenID []byte, supply *big.Int) error {
+	err := systemAcc.AccountDataHandler().SaveKeyValue(getESDTBaseSupplyKey(tokenID), supply.Bytes())
+	if err != nil {
+		return err
+	}
+	err = systemAcc.AccountDataHandler().SaveKeyValue(getESDTMintedSupplyKey(tokenID), nil)
+	if err != nil {
+		return err
+	}
+
+	return systemAcc.AccountDataHandler().SaveKeyValue(getESDTBurnedSupplyKey(tokenID), nil)
+}
+
+func getBigIntFromKey(systemAcc vmcommon.UserAccountHandler, key []byte) (*big.Int, error) {
+	value, _, err := systemAcc.AccountDataHandler().RetrieveValue(key)
+	if core.IsGetNodeFromDBError(err) {
+		return nil, err
+	}
+
+	return big.NewInt(0).SetBytes(value), nil
+}
+
+func getESDTMintedSupplyKey(tokenID []byte) []byte {
+	return append([]byte(esdtMintedSupplyKeyPrefix), tokenID...)
+}
+
+func getESDTBurnedSupplyKey(tokenID []byte) []byte {
+	return append([]byte(esdtBurnedSupplyKeyPrefix), tokenID...)
+}
+
+func getESDTMaxSupplyKey(tokenID []byte) []byte {
+	return append([]byte(esdtMaxSupplyKeyPrefix), tokenID...)
+}
+
+func getESDTBaseSupplyKey(tokenID []byte) []byte {
+	return append([]byte(esdtBaseSupplyKeyPrefix), tokenID...)
+}
+
 func (e *esdtDataStorage) shouldSaveMetadataInSystemAccount(esdtDataType uint32) bool {
 	if !e.enableEpochsHandler.IsFlagEnabled(SaveToSystemAccountFlag) {
 		return false
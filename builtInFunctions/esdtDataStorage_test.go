@@ -1101,6 +1101,100 @@ func TestEsdtDataStorage_AddToLiquiditySystemAcc(t *testing.T) {
 	assert.Nil(t, esdtData)
 }
 
+func TestEsdtDataStorage_AddToSupplySystemAcc(t *testing.T) {
+	t.Parallel()
+
+	tokenID := []byte("TKN-abcdef")
+	t.Run("flag not active should not update supply", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsForNewESDTDataStorage()
+		e, _ := NewESDTDataStorage(args)
+
+		err := e.AddToSupplySystemAcc(tokenID, big.NewInt(100))
+		assert.Nil(t, err)
+
+		supply, err := e.GetESDTSupply(tokenID)
+		assert.Nil(t, err)
+		assert.Equal(t, &vmcommon.ESDTSupply{Supply: big.NewInt(0), Minted: big.NewInt(0), Burned: big.NewInt(0), MaxSupply: big.NewInt(0)}, supply)
+	})
+	t.Run("load system account fails should error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		args := createMockArgsForNewESDTDataStorage()
+		args.EnableEpochsHandler = &mock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == ESDTSupplyTrackingFlag
+			},
+		}
+		args.Accounts = &mock.AccountsStub{
+			LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+				return nil, expectedErr
+			},
+		}
+		e, _ := NewESDTDataStorage(args)
+
+		err := e.AddToSupplySystemAcc(tokenID, big.NewInt(100))
+		assert.Equal(t, expectedErr, err)
+
+		supply, err := e.GetESDTSupply(tokenID)
+		assert.Nil(t, supply)
+		assert.Equal(t, expectedErr, err)
+	})
+	t.Run("should update minted and burned counters", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsForNewESDTDataStorage()
+		args.EnableEpochsHandler = &mock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == ESDTSupplyTrackingFlag
+			},
+		}
+		e, _ := NewESDTDataStorage(args)
+
+		assert.Nil(t, e.AddToSupplySystemAcc(tokenID, big.NewInt(100)))
+		assert.Nil(t, e.AddToSupplySystemAcc(tokenID, big.NewInt(50)))
+		assert.Nil(t, e.AddToSupplySystemAcc(tokenID, big.NewInt(-30)))
+		assert.Nil(t, e.AddToSupplySystemAcc(tokenID, big.NewInt(0)))
+		assert.Nil(t, e.AddToSupplySystemAcc(tokenID, nil))
+
+		supply, err := e.GetESDTSupply(tokenID)
+		assert.Nil(t, err)
+		assert.Equal(t, &vmcommon.ESDTSupply{Supply: big.NewInt(120), Minted: big.NewInt(150), Burned: big.NewInt(30), MaxSupply: big.NewInt(0)}, supply)
+
+		supply, err = e.GetESDTSupply([]byte("OTHER-abcdef"))
+		assert.Nil(t, err)
+		assert.Equal(t, &vmcommon.ESDTSupply{Supply: big.NewInt(0), Minted: big.NewInt(0), Burned: big.NewInt(0), MaxSupply: big.NewInt(0)}, supply)
+	})
+	t.Run("minting above max supply should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsForNewESDTDataStorage()
+		args.EnableEpochsHandler = &mock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == ESDTSupplyTrackingFlag || flag == ESDTMaxSupplyFlag
+			},
+		}
+		e, _ := NewESDTDataStorage(args)
+
+		systemAcc, _ := e.getSystemAccount(defaultQueryOptions())
+		_ = systemAcc.AccountDataHandler().SaveKeyValue(getESDTMaxSupplyKey(tokenID), big.NewInt(100).Bytes())
+		_ = args.Accounts.SaveAccount(systemAcc)
+
+		assert.Nil(t, e.AddToSupplySystemAcc(tokenID, big.NewInt(80)))
+		err := e.AddToSupplySystemAcc(tokenID, big.NewInt(21))
+		assert.True(t, errors.Is(err, ErrMaxSupplyExceeded))
+
+		assert.Nil(t, e.AddToSupplySystemAcc(tokenID, big.NewInt(-10)))
+		assert.Nil(t, e.AddToSupplySystemAcc(tokenID, big.NewInt(30)))
+
+		supply, err := e.GetESDTSupply(tokenID)
+		assert.Nil(t, err)
+		assert.Equal(t, &vmcommon.ESDTSupply{Supply: big.NewInt(100), Minted: big.NewInt(110), Burned: big.NewInt(10), MaxSupply: big.NewInt(100)}, supply)
+	})
+}
+
 func TestEsdtDataStorage_ShouldSaveMetadataInSystemAccount(t *testing.T) {
 	t.Parallel()
 
@@ -1309,3 +1403,17 @@ func saveESDTNFTTokenMigrateTypeAndMetadata(t *testing.T, tokenType core.ESDTTyp
 		assert.Equal(t, &esdt.ESDigitalToken{TokenMetaData: metaData}, retrievedMetaData)
 	}
 }
+
+func TestGetESDTSupplyHandler(t *testing.T) {
+	t.Parallel()
+
+	esdtStorage := createNewESDTDataStorageHandler()
+	assert.Equal(t, esdtStorage, getESDTSupplyHandler(esdtStorage))
+
+	supplyHandler := getESDTSupplyHandler(&struct{ vmcommon.ESDTNFTStorageHandler }{})
+	assert.IsType(t, &disabledESDTSupplyHandler{}, supplyHandler)
+	assert.Nil(t, supplyHandler.AddToSupplySystemAcc([]byte("TKN-abcdef"), big.NewInt(10)))
+	supply, err := supplyHandler.GetESDTSupply([]byte("TKN-abcdef"))
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(0), supply.Supply)
+}
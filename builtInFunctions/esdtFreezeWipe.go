@@ -2,17 +2,26 @@ package builtInFunctions
 
 import (
 	"bytes"
+	"fmt"
 	"math/big"
 
 	"github.com/multiversx/mx-chain-core-go/core"
 	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
 
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 )
 
+const (
+	esdtFrozenAmountKeyPrefix          = core.ProtectedKeyPrefix + "esdtFrozenAmount"
+	numArgsESDTFreezeWipeNonce         = 2
+	numArgsESDTFreezeWipePartialAmount = 3
+)
+
 type esdtFreezeWipe struct {
 	baseAlwaysActiveHandler
 	esdtStorageHandler  vmcommon.ESDTNFTStorageHandler
+	esdtSupplyHandler   vmcommon.ESDTSupplyHandler
 	enableEpochsHandler vmcommon.EnableEpochsHandler
 	marshaller          vmcommon.Marshalizer
 	keyPrefix           []byte
@@ -42,6 +51,7 @@ func NewESDTFreezeWipeFunc(
 
 	e := &esdtFreezeWipe{
 		esdtStorageHandler:  esdtStorageHandler,
+		esdtSupplyHandler:   getESDTSupplyHandler(esdtStorageHandler),
 		enableEpochsHandler: enableEpochsHandler,
 		marshaller:          marshaller,
 		keyPrefix:           []byte(baseESDTKeyPrefix),
@@ -58,6 +68,10 @@ func (e *esdtFreezeWipe) SetNewGasConfig(_ *vmcommon.GasCost) {
 }
 
 // ProcessBuiltinFunction resolves ESDT transfer function call
+// Requires 1 argument, the token key, or, after the partial freeze and wipe activation, 2 or 3 arguments:
+// arg0 - token identifier
+// arg1 - token nonce, the other nonces of the collection are not affected
+// arg2 - optional amount of a fungible token which is frozen, unfrozen or wiped
 func (e *esdtFreezeWipe) ProcessBuiltinFunction(
 	_, acntDst vmcommon.UserAccountHandler,
 	vmInput *vmcommon.ContractCallInput,
@@ -68,7 +82,7 @@ func (e *esdtFreezeWipe) ProcessBuiltinFunction(
 	if vmInput.CallValue.Cmp(zero) != 0 {
 		return nil, ErrBuiltInFunctionCalledWithValue
 	}
-	if len(vmInput.Arguments) != 1 {
+	if !e.isValidNumOfArguments(len(vmInput.Arguments)) {
 		return nil, ErrInvalidArguments
 	}
 	if !bytes.Equal(vmInput.CallerAddr, core.ESDTSCAddress) {
@@ -78,8 +92,11 @@ func (e *esdtFreezeWipe) ProcessBuiltinFunction(
 		return nil, ErrNilUserAccount
 	}
 
-	esdtTokenKey := append(e.keyPrefix, vmInput.Arguments[0]...)
-	identifier, nonce := extractTokenIdentifierAndNonceESDTWipe(e.selfESDTPrefix, vmInput.Arguments[0])
+	if len(vmInput.Arguments) == numArgsESDTFreezeWipePartialAmount {
+		return e.processPartialAmount(acntDst, vmInput)
+	}
+
+	esdtTokenKey, identifier, nonce := e.getTokenKeyIdentifierAndNonce(vmInput.Arguments)
 
 	var amount *big.Int
 	var err error
@@ -103,6 +120,107 @@ func (e *esdtFreezeWipe) ProcessBuiltinFunction(
 	return vmOutput, nil
 }
 
+func (e *esdtFreezeWipe) isValidNumOfArguments(numArgs int) bool {
+	if numArgs == 1 {
+		return true
+	}
+	if !e.enableEpochsHandler.IsFlagEnabled(ESDTPartialFreezeWipeFlag) {
+		return false
+	}
+
+	return numArgs == numArgsESDTFreezeWipeNonce || numArgs == numArgsESDTFreezeWipePartialAmount
+}
+
+func (e *esdtFreezeWipe) getTokenKeyIdentifierAndNonce(args [][]byte) ([]byte, []byte, uint64) {
+	if len(args) == 1 {
+		identifier, nonce := extractTokenIdentifierAndNonceESDTWipe(e.selfESDTPrefix, args[0])
+		return append(e.keyPrefix, args[0]...), identifier, nonce
+	}
+
+	identifier := args[0]
+	nonce := big.NewInt(0).SetBytes(args[1]).Uint64()
+	esdtTokenKey := append(e.keyPrefix, identifier...)
+	if nonce > 0 {
+		esdtTokenKey = computeESDTNFTTokenKey(esdtTokenKey, nonce)
+	}
+
+	return esdtTokenKey, identifier, nonce
+}
+
+// processPartialAmount freezes, unfreezes or wipes only the given amount of a fungible token. The log also contains
+// the amount which remains frozen on the account
+func (e *esdtFreezeWipe) processPartialAmount(acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+	identifier := vmInput.Arguments[0]
+	if len(vmInput.Arguments[1]) > 0 && big.NewInt(0).SetBytes(vmInput.Arguments[1]).Sign() != 0 {
+		return nil, fmt.Errorf("%w, partial amounts are supported only for fungible tokens", ErrInvalidArguments)
+	}
+	if len(vmInput.Arguments[2]) > core.MaxLenForESDTIssueMint {
+		return nil, fmt.Errorf("%w: max length for the amount is %d", ErrInvalidArguments, core.MaxLenForESDTIssueMint)
+	}
+	amount := big.NewInt(0).SetBytes(vmInput.Arguments[2])
+	if amount.Sign() == 0 {
+		return nil, fmt.Errorf("%w, zero amount", ErrInvalidArguments)
+	}
+
+	esdtTokenKey := append(e.keyPrefix, identifier...)
+	tokenData, err := getESDTDataFromKey(acntDst, esdtTokenKey, e.marshaller)
+	if err != nil {
+		return nil, err
+	}
+	frozenAmount, err := getFrozenESDTAmount(acntDst, esdtTokenKey)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case e.wipe:
+		err = e.wipePartialAmount(identifier, tokenData, frozenAmount, amount)
+	case e.freeze:
+		frozenAmount.Add(frozenAmount, amount)
+		if frozenAmount.Cmp(tokenData.Value) > 0 {
+			err = ErrInsufficientFunds
+		}
+	default:
+		if frozenAmount.Cmp(amount) < 0 {
+			err = ErrInsufficientFrozenAmount
+		}
+		frozenAmount.Sub(frozenAmount, amount)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = saveFrozenESDTAmount(acntDst, esdtTokenKey, tokenData, frozenAmount, e.marshaller)
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}
+	addESDTEntryInVMOutput(vmOutput, []byte(vmInput.Function), identifier, 0, amount, vmInput.CallerAddr, acntDst.AddressBytes(), frozenAmount.Bytes())
+
+	return vmOutput, nil
+}
+
+// wipePartialAmount removes the amount from the balance. Only frozen tokens can be wiped, so either the whole balance
+// or at least the wiped amount must be frozen
+func (e *esdtFreezeWipe) wipePartialAmount(identifier []byte, tokenData *esdt.ESDigitalToken, frozenAmount *big.Int, amount *big.Int) error {
+	esdtUserMetadata := ESDTUserMetadataFromBytes(tokenData.Properties)
+	if !esdtUserMetadata.Frozen && frozenAmount.Cmp(amount) < 0 {
+		return ErrInsufficientFrozenAmount
+	}
+	if tokenData.Value.Cmp(amount) < 0 {
+		return ErrInsufficientFunds
+	}
+
+	tokenData.Value.Sub(tokenData.Value, amount)
+	frozenAmount.Sub(frozenAmount, amount)
+	if frozenAmount.Sign() < 0 {
+		frozenAmount.SetUint64(0)
+	}
+
+	return e.esdtSupplyHandler.AddToSupplySystemAcc(identifier, big.NewInt(0).Neg(amount))
+}
+
 func (e *esdtFreezeWipe) wipeIfApplicable(acntDst vmcommon.UserAccountHandler, tokenKey []byte, identifier []byte, nonce uint64) (*big.Int, error) {
 	tokenData, err := getESDTDataFromKey(acntDst, tokenKey, e.marshaller)
 	if err != nil {
@@ -118,6 +236,12 @@ func (e *esdtFreezeWipe) wipeIfApplicable(acntDst vmcommon.UserAccountHandler, t
 	if err != nil {
 		return nil, err
 	}
+	if esdtUserMetadata.PartiallyFrozen {
+		err = acntDst.AccountDataHandler().SaveKeyValue(getESDTFrozenAmountKey(tokenKey), nil)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	err = e.removeLiquidity(identifier, tokenData.Type, nonce, tokenData.Value)
 	if err != nil {
@@ -125,6 +249,11 @@ func (e *esdtFreezeWipe) wipeIfApplicable(acntDst vmcommon.UserAccountHandler, t
 	}
 
 	wipedAmount := vmcommon.ZeroValueIfNil(tokenData.Value)
+	err = e.esdtSupplyHandler.AddToSupplySystemAcc(identifier, big.NewInt(0).Neg(wipedAmount))
+	if err != nil {
+		return nil, err
+	}
+
 	return wipedAmount, nil
 }
 
@@ -156,6 +285,70 @@ func (e *esdtFreezeWipe) toggleFreeze(acntDst vmcommon.UserAccountHandler, token
 	return frozenAmount, nil
 }
 
+// checkFrozenESDTAmount returns an error if the balance left on the account is lower than its frozen amount
+func checkFrozenESDTAmount(
+	account vmcommon.UserAccountHandler,
+	esdtTokenKey []byte,
+	esdtData *esdt.ESDigitalToken,
+	isReturnWithError bool,
+) error {
+	if isReturnWithError || bytes.Equal(account.AddressBytes(), core.ESDTSCAddress) {
+		return nil
+	}
+
+	esdtUserMetadata := ESDTUserMetadataFromBytes(esdtData.Properties)
+	if !esdtUserMetadata.PartiallyFrozen {
+		return nil
+	}
+
+	frozenAmount, err := getFrozenESDTAmount(account, esdtTokenKey)
+	if err != nil {
+		return err
+	}
+	if esdtData.Value.Cmp(frozenAmount) < 0 {
+		return fmt.Errorf("%w for token %s, frozen amount is %s", ErrInsufficientUnfrozenBalance, bytes.TrimPrefix(esdtTokenKey, []byte(baseESDTKeyPrefix)), frozenAmount)
+	}
+
+	return nil
+}
+
+func getFrozenESDTAmount(account vmcommon.UserAccountHandler, esdtTokenKey []byte) (*big.Int, error) {
+	frozenAmount, _, err := account.AccountDataHandler().RetrieveValue(getESDTFrozenAmountKey(esdtTokenKey))
+	if core.IsGetNodeFromDBError(err) {
+		return nil, err
+	}
+
+	return big.NewInt(0).SetBytes(frozenAmount), nil
+}
+
+func saveFrozenESDTAmount(
+	account vmcommon.UserAccountHandler,
+	esdtTokenKey []byte,
+	esdtData *esdt.ESDigitalToken,
+	frozenAmount *big.Int,
+	marshaller vmcommon.Marshalizer,
+) error {
+	esdtUserMetadata := ESDTUserMetadataFromBytes(esdtData.Properties)
+	esdtUserMetadata.PartiallyFrozen = frozenAmount.Sign() > 0
+	esdtData.Properties = esdtUserMetadata.ToBytes()
+
+	err := saveESDTData(account, esdtData, esdtTokenKey, marshaller)
+	if err != nil {
+		return err
+	}
+
+	var frozenAmountBytes []byte
+	if esdtUserMetadata.PartiallyFrozen {
+		frozenAmountBytes = frozenAmount.Bytes()
+	}
+
+	return account.AccountDataHandler().SaveKeyValue(getESDTFrozenAmountKey(esdtTokenKey), frozenAmountBytes)
+}
+
+func getESDTFrozenAmountKey(esdtTokenKey []byte) []byte {
+	return append([]byte(esdtFrozenAmountKeyPrefix), esdtTokenKey...)
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *esdtFreezeWipe) IsInterfaceNil() bool {
 	return e == nil
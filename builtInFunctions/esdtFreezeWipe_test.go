@@ -1,6 +1,7 @@
 package builtInFunctions
 
 import (
+	"errors"
 	"math/big"
 	"testing"
 
@@ -152,12 +153,19 @@ func TestEsdtFreezeWipe_WipeShouldDecreaseLiquidityIfFlagIsEnabled(t *testing.T)
 
 	balance := big.NewInt(37)
 	addToLiquiditySystemAccCalled := false
+	numSupplyUpdates := 0
 	esdtStorage := &mock.ESDTNFTStorageHandlerStub{
 		AddToLiquiditySystemAccCalled: func(_ []byte, _ uint32, _ uint64, transferValue *big.Int, _ bool) error {
 			require.Equal(t, big.NewInt(0).Neg(balance), transferValue)
 			addToLiquiditySystemAccCalled = true
 			return nil
 		},
+		AddToSupplySystemAccCalled: func(tokenID []byte, value *big.Int) error {
+			require.Equal(t, []byte("MYSFT-0a0a0a"), tokenID)
+			require.Equal(t, big.NewInt(0).Neg(balance), value)
+			numSupplyUpdates++
+			return nil
+		},
 	}
 
 	marshaller := &mock.MarshalizerMock{}
@@ -207,4 +215,141 @@ func TestEsdtFreezeWipe_WipeShouldDecreaseLiquidityIfFlagIsEnabled(t *testing.T)
 	marshaledData, _, _ = acnt.AccountDataHandler().RetrieveValue(esdtKey)
 	assert.Equal(t, 0, len(marshaledData))
 	assert.True(t, addToLiquiditySystemAccCalled)
+	assert.Equal(t, 2, numSupplyUpdates)
+}
+
+func TestESDTFreezeWipe_ProcessBuiltInFunctionPartialAmount(t *testing.T) {
+	t.Parallel()
+
+	tokenID := []byte("TKN-123456")
+	dstAddress := []byte("dst")
+	esdtKey := append([]byte(baseESDTKeyPrefix), tokenID...)
+	marshaller := &mock.MarshalizerMock{}
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return flag == ESDTPartialFreezeWipeFlag
+		},
+	}
+	supplyUpdates := make([]*big.Int, 0)
+	esdtStorage := &mock.ESDTNFTStorageHandlerStub{
+		AddToSupplySystemAccCalled: func(_ []byte, value *big.Int) error {
+			supplyUpdates = append(supplyUpdates, value)
+			return nil
+		},
+	}
+	freeze, _ := NewESDTFreezeWipeFunc(esdtStorage, enableEpochsHandler, marshaller, true, false, esdtPrefix)
+	unFreeze, _ := NewESDTFreezeWipeFunc(esdtStorage, enableEpochsHandler, marshaller, false, false, esdtPrefix)
+	wipe, _ := NewESDTFreezeWipeFunc(esdtStorage, enableEpochsHandler, marshaller, false, true, esdtPrefix)
+
+	acnt := mock.NewUserAccount(dstAddress)
+	esdtTokenBytes, _ := marshaller.Marshal(&esdt.ESDigitalToken{Value: big.NewInt(100)})
+	_ = acnt.AccountDataHandler().SaveKeyValue(esdtKey, esdtTokenBytes)
+
+	createInput := func(function string, nonce []byte, amount int64) *vmcommon.ContractCallInput {
+		return &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr: core.ESDTSCAddress,
+				CallValue:  big.NewInt(0),
+				Arguments:  [][]byte{tokenID, nonce, big.NewInt(amount).Bytes()},
+			},
+			Function:      function,
+			RecipientAddr: dstAddress,
+		}
+	}
+	requireBalances := func(balance int64, frozenAmount int64) {
+		esdtData, err := getESDTDataFromKey(acnt, esdtKey, marshaller)
+		require.Nil(t, err)
+		require.Equal(t, big.NewInt(balance), esdtData.Value)
+		require.Equal(t, frozenAmount > 0, ESDTUserMetadataFromBytes(esdtData.Properties).PartiallyFrozen)
+
+		actualFrozenAmount, err := getFrozenESDTAmount(acnt, esdtKey)
+		require.Nil(t, err)
+		require.Equal(t, big.NewInt(frozenAmount), actualFrozenAmount)
+	}
+
+	disabledFreeze, _ := NewESDTFreezeWipeFunc(esdtStorage, &mock.EnableEpochsHandlerStub{}, marshaller, true, false, esdtPrefix)
+	_, err := disabledFreeze.ProcessBuiltinFunction(nil, acnt, createInput(core.BuiltInFunctionESDTFreeze, []byte{}, 30))
+	assert.Equal(t, ErrInvalidArguments, err)
+
+	_, err = freeze.ProcessBuiltinFunction(nil, acnt, createInput(core.BuiltInFunctionESDTFreeze, []byte{1}, 30))
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
+
+	_, err = freeze.ProcessBuiltinFunction(nil, acnt, createInput(core.BuiltInFunctionESDTFreeze, []byte{}, 0))
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
+
+	vmOutput, err := freeze.ProcessBuiltinFunction(nil, acnt, createInput(core.BuiltInFunctionESDTFreeze, []byte{}, 30))
+	require.Nil(t, err)
+	require.Len(t, vmOutput.Logs, 1)
+	assert.Equal(t, [][]byte{tokenID, {}, big.NewInt(30).Bytes(), dstAddress, big.NewInt(30).Bytes()}, vmOutput.Logs[0].Topics)
+	requireBalances(100, 30)
+
+	_, err = freeze.ProcessBuiltinFunction(nil, acnt, createInput(core.BuiltInFunctionESDTFreeze, []byte{}, 80))
+	assert.Equal(t, ErrInsufficientFunds, err)
+	requireBalances(100, 30)
+
+	err = addToESDTBalance(acnt, esdtKey, big.NewInt(-80), marshaller, &mock.GlobalSettingsHandlerStub{}, &mock.ESDTLockedBalanceHandlerStub{}, &mock.EnableEpochsHandlerStub{}, false)
+	assert.True(t, errors.Is(err, ErrInsufficientUnfrozenBalance))
+	err = addToESDTBalance(acnt, esdtKey, big.NewInt(-80), marshaller, &mock.GlobalSettingsHandlerStub{}, &mock.ESDTLockedBalanceHandlerStub{}, &mock.EnableEpochsHandlerStub{}, true)
+	assert.Nil(t, err)
+	err = addToESDTBalance(acnt, esdtKey, big.NewInt(10), marshaller, &mock.GlobalSettingsHandlerStub{}, &mock.ESDTLockedBalanceHandlerStub{}, &mock.EnableEpochsHandlerStub{}, false)
+	assert.Nil(t, err)
+	requireBalances(30, 30)
+
+	_, err = unFreeze.ProcessBuiltinFunction(nil, acnt, createInput(core.BuiltInFunctionESDTUnFreeze, []byte{}, 40))
+	assert.Equal(t, ErrInsufficientFrozenAmount, err)
+
+	vmOutput, err = wipe.ProcessBuiltinFunction(nil, acnt, createInput(core.BuiltInFunctionESDTWipe, []byte{}, 20))
+	require.Nil(t, err)
+	assert.Equal(t, [][]byte{tokenID, {}, big.NewInt(20).Bytes(), dstAddress, big.NewInt(10).Bytes()}, vmOutput.Logs[0].Topics)
+	assert.Equal(t, []*big.Int{big.NewInt(-20)}, supplyUpdates)
+	requireBalances(10, 10)
+
+	_, err = wipe.ProcessBuiltinFunction(nil, acnt, createInput(core.BuiltInFunctionESDTWipe, []byte{}, 20))
+	assert.Equal(t, ErrInsufficientFrozenAmount, err)
+
+	_, err = unFreeze.ProcessBuiltinFunction(nil, acnt, createInput(core.BuiltInFunctionESDTUnFreeze, []byte{}, 10))
+	require.Nil(t, err)
+	requireBalances(10, 0)
+	frozenAmountBytes, _, _ := acnt.AccountDataHandler().RetrieveValue(getESDTFrozenAmountKey(esdtKey))
+	assert.Empty(t, frozenAmountBytes)
+
+	err = addToESDTBalance(acnt, esdtKey, big.NewInt(-10), marshaller, &mock.GlobalSettingsHandlerStub{}, &mock.ESDTLockedBalanceHandlerStub{}, &mock.EnableEpochsHandlerStub{}, false)
+	assert.Nil(t, err)
+}
+
+func TestESDTFreezeWipe_ProcessBuiltInFunctionSingleNonce(t *testing.T) {
+	t.Parallel()
+
+	tokenID := []byte("NFT-123456")
+	nonce := uint64(5)
+	marshaller := &mock.MarshalizerMock{}
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return flag == ESDTPartialFreezeWipeFlag
+		},
+	}
+	freeze, _ := NewESDTFreezeWipeFunc(createNewESDTDataStorageHandler(), enableEpochsHandler, marshaller, true, false, esdtPrefix)
+
+	acnt := mock.NewUserAccount([]byte("dst"))
+	collectionKey := append([]byte(baseESDTKeyPrefix), tokenID...)
+	nftKey := computeESDTNFTTokenKey(append([]byte(baseESDTKeyPrefix), tokenID...), nonce)
+	esdtTokenBytes, _ := marshaller.Marshal(&esdt.ESDigitalToken{Type: uint32(core.NonFungible), Value: big.NewInt(1)})
+	_ = acnt.AccountDataHandler().SaveKeyValue(nftKey, esdtTokenBytes)
+
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: core.ESDTSCAddress,
+			CallValue:  big.NewInt(0),
+			Arguments:  [][]byte{tokenID, big.NewInt(int64(nonce)).Bytes()},
+		},
+		RecipientAddr: []byte("dst"),
+	}
+	vmOutput, err := freeze.ProcessBuiltinFunction(nil, acnt, input)
+	require.Nil(t, err)
+	assert.Equal(t, [][]byte{tokenID, big.NewInt(int64(nonce)).Bytes(), big.NewInt(1).Bytes(), []byte("dst")}, vmOutput.Logs[0].Topics)
+
+	esdtData, _ := getESDTDataFromKey(acnt, nftKey, marshaller)
+	assert.True(t, ESDTUserMetadataFromBytes(esdtData.Properties).Frozen)
+	collectionData, _, _ := acnt.AccountDataHandler().RetrieveValue(collectionKey)
+	assert.Empty(t, collectionData)
 }
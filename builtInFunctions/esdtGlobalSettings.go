@@ -2,6 +2,7 @@ package builtInFunctions
 
 import (
 	"bytes"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math"
@@ -78,6 +79,8 @@ func isCorrectFunction(function string) bool {
 		return true
 	case vmcommon.BuiltInFunctionESDTSetBurnRoleForAll, vmcommon.BuiltInFunctionESDTUnSetBurnRoleForAll:
 		return true
+	case vmcommon.BuiltInFunctionESDTSetAllowListRequired, vmcommon.BuiltInFunctionESDTUnSetAllowListRequired:
+		return true
 	default:
 		return false
 	}
@@ -148,6 +151,8 @@ func (e *esdtGlobalSettings) toggleSetting(esdtTokenKey []byte, systemSCAccount
 		esdtMetaData.Paused = e.set
 	case vmcommon.BuiltInFunctionESDTUnSetBurnRoleForAll, vmcommon.BuiltInFunctionESDTSetBurnRoleForAll:
 		esdtMetaData.BurnRoleForAll = e.set
+	case vmcommon.BuiltInFunctionESDTSetAllowListRequired, vmcommon.BuiltInFunctionESDTUnSetAllowListRequired:
+		esdtMetaData.AllowListRequired = e.set
 	}
 
 	err = systemSCAccount.AccountDataHandler().SaveKeyValue(esdtTokenKey, esdtMetaData.ToBytes())
@@ -228,6 +233,48 @@ func (e *esdtGlobalSettings) IsSenderOrDestinationWithTransferRole(sender, desti
 	return false
 }
 
+// CheckTransferAccessLists returns an error if the sender or the destination is on the deny list of the token, or if
+// the token requires an allow list and any of them is missing from it. Like the other global settings, the lists are
+// not enforced if the global metadata of the token can not be loaded
+func (e *esdtGlobalSettings) CheckTransferAccessLists(esdtTokenKey []byte, sender, destination []byte) error {
+	systemAcc, err := getSystemAccount(e.accounts)
+	if err != nil {
+		return nil
+	}
+	esdtMetaData, err := e.getGlobalMetadataFromAccount(esdtTokenKey, systemAcc)
+	if err != nil {
+		return nil
+	}
+	if !esdtMetaData.DenyListUsed && !esdtMetaData.AllowListRequired {
+		return nil
+	}
+
+	tokenID := esdtTokenKey[len(baseESDTKeyPrefix):]
+	for _, address := range [][]byte{sender, destination} {
+		if esdtMetaData.DenyListUsed {
+			isDenyListed, errCheck := isOnESDTAccessList(systemAcc, esdtDenyListKeyPrefix, tokenID, address)
+			if errCheck != nil {
+				return errCheck
+			}
+			if isDenyListed {
+				return fmt.Errorf("%w, address %s, token %s", ErrAddressIsDenyListed, hex.EncodeToString(address), tokenID)
+			}
+		}
+
+		if esdtMetaData.AllowListRequired {
+			isAllowListed, errCheck := isOnESDTAccessList(systemAcc, esdtAllowListKeyPrefix, tokenID, address)
+			if errCheck != nil {
+				return errCheck
+			}
+			if !isAllowListed {
+				return fmt.Errorf("%w, address %s, token %s", ErrAddressIsNotAllowListed, hex.EncodeToString(address), tokenID)
+			}
+		}
+	}
+
+	return nil
+}
+
 // GetGlobalMetadata returns the global metadata for the esdtTokenKey
 func (e *esdtGlobalSettings) GetGlobalMetadata(esdtTokenKey []byte) (*ESDTGlobalMetadata, error) {
 	systemSCAccount, err := getSystemAccount(e.accounts)
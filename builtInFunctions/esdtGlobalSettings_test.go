@@ -443,3 +443,46 @@ func TestEsdtGlobalSettings_GetTokenType(t *testing.T) {
 		require.Equal(t, uint32(core.Fungible), val)
 	})
 }
+
+func TestEsdtGlobalSettings_CheckTransferAccessLists(t *testing.T) {
+	t.Parallel()
+
+	tokenID := []byte("TKN-123456")
+	esdtTokenKey := append([]byte(baseESDTKeyPrefix), tokenID...)
+	accounts := createAccountsWithSystemAccount(mock.NewAccountWrapMock(vmcommon.SystemAccountAddress))
+	globalSettingsFunc, _ := NewESDTGlobalSettingsFunc(accounts, &mock.MarshalizerMock{}, true, core.BuiltInFunctionESDTPause, trueHandler)
+	setAllowListRequired, _ := NewESDTGlobalSettingsFunc(accounts, &mock.MarshalizerMock{}, true, vmcommon.BuiltInFunctionESDTSetAllowListRequired, trueHandler)
+	unSetAllowListRequired, _ := NewESDTGlobalSettingsFunc(accounts, &mock.MarshalizerMock{}, false, vmcommon.BuiltInFunctionESDTUnSetAllowListRequired, trueHandler)
+	allowListAdd, _ := NewESDTAccessListAddressFunc(createMockArgsNewESDTAccessListAddressFunc(accounts, false, true))
+	denyListAdd, _ := NewESDTAccessListAddressFunc(createMockArgsNewESDTAccessListAddressFunc(accounts, true, true))
+
+	assert.Nil(t, globalSettingsFunc.CheckTransferAccessLists(esdtTokenKey, accessListedAddress, notAccessListedAddress))
+
+	_, err := setAllowListRequired.ProcessBuiltinFunction(nil, nil, createESDTAccessListInput(tokenID))
+	require.Nil(t, err)
+	err = globalSettingsFunc.CheckTransferAccessLists(esdtTokenKey, accessListedAddress, notAccessListedAddress)
+	assert.True(t, errors.Is(err, ErrAddressIsNotAllowListed))
+
+	_, err = allowListAdd.ProcessBuiltinFunction(nil, nil, createESDTAccessListInput(tokenID, accessListedAddress))
+	require.Nil(t, err)
+	err = globalSettingsFunc.CheckTransferAccessLists(esdtTokenKey, accessListedAddress, notAccessListedAddress)
+	assert.True(t, errors.Is(err, ErrAddressIsNotAllowListed))
+	err = globalSettingsFunc.CheckTransferAccessLists(esdtTokenKey, notAccessListedAddress, accessListedAddress)
+	assert.True(t, errors.Is(err, ErrAddressIsNotAllowListed))
+	assert.Nil(t, globalSettingsFunc.CheckTransferAccessLists(esdtTokenKey, accessListedAddress, accessListedAddress))
+
+	_, err = unSetAllowListRequired.ProcessBuiltinFunction(nil, nil, createESDTAccessListInput(tokenID))
+	require.Nil(t, err)
+	assert.Nil(t, globalSettingsFunc.CheckTransferAccessLists(esdtTokenKey, accessListedAddress, notAccessListedAddress))
+
+	_, err = denyListAdd.ProcessBuiltinFunction(nil, nil, createESDTAccessListInput(tokenID, notAccessListedAddress))
+	require.Nil(t, err)
+	err = globalSettingsFunc.CheckTransferAccessLists(esdtTokenKey, accessListedAddress, notAccessListedAddress)
+	assert.True(t, errors.Is(err, ErrAddressIsDenyListed))
+	err = globalSettingsFunc.CheckTransferAccessLists(esdtTokenKey, notAccessListedAddress, accessListedAddress)
+	assert.True(t, errors.Is(err, ErrAddressIsDenyListed))
+	assert.Nil(t, globalSettingsFunc.CheckTransferAccessLists(esdtTokenKey, accessListedAddress, accessListedAddress))
+
+	otherTokenKey := []byte(baseESDTKeyPrefix + "OTHER-123456")
+	assert.Nil(t, globalSettingsFunc.CheckTransferAccessLists(otherTokenKey, accessListedAddress, notAccessListedAddress))
+}
@@ -18,6 +18,8 @@ type esdtLocalBurn struct {
 	globalSettingsHandler vmcommon.ExtendedESDTGlobalSettingsHandler
 	rolesHandler          vmcommon.ESDTRoleHandler
 	enableEpochsHandler   vmcommon.EnableEpochsHandler
+	esdtSupplyHandler     vmcommon.ESDTSupplyHandler
+	lockedBalanceHandler  ESDTLockedBalanceHandler
 	funcGasCost           uint64
 	mutExecution          sync.RWMutex
 }
@@ -29,6 +31,12 @@ type ESDTLocalMintBurnFuncArgs struct {
 	GlobalSettingsHandler vmcommon.ExtendedESDTGlobalSettingsHandler
 	RolesHandler          vmcommon.ESDTRoleHandler
 	EnableEpochsHandler   vmcommon.EnableEpochsHandler
+	ESDTSupplyHandler     vmcommon.ESDTSupplyHandler
+	// LockedBalanceHandler is only used by the local burn function
+	LockedBalanceHandler ESDTLockedBalanceHandler
+	// CrossChainTokenCheckerHandler and CrossChainOperationsRegistry are only used by the local mint function
+	CrossChainTokenCheckerHandler CrossChainTokenCheckerHandler
+	CrossChainOperationsRegistry  CrossChainOperationsRegistryHandler
 }
 
 // NewESDTLocalBurnFunc returns the esdt local burn built-in function component
@@ -45,6 +53,12 @@ func NewESDTLocalBurnFunc(args ESDTLocalMintBurnFuncArgs) (*esdtLocalBurn, error
 	if check.IfNil(args.EnableEpochsHandler) {
 		return nil, ErrNilEnableEpochsHandler
 	}
+	if check.IfNil(args.ESDTSupplyHandler) {
+		return nil, ErrNilESDTSupplyHandler
+	}
+	if check.IfNil(args.LockedBalanceHandler) {
+		return nil, ErrNilLockedBalanceHandler
+	}
 
 	e := &esdtLocalBurn{
 		keyPrefix:             []byte(baseESDTKeyPrefix),
@@ -53,6 +67,8 @@ func NewESDTLocalBurnFunc(args ESDTLocalMintBurnFuncArgs) (*esdtLocalBurn, error
 		rolesHandler:          args.RolesHandler,
 		funcGasCost:           args.FuncGasCost,
 		enableEpochsHandler:   args.EnableEpochsHandler,
+		esdtSupplyHandler:     args.ESDTSupplyHandler,
+		lockedBalanceHandler:  args.LockedBalanceHandler,
 		mutExecution:          sync.RWMutex{},
 	}
 
@@ -97,7 +113,11 @@ func (e *esdtLocalBurn) ProcessBuiltinFunction(
 	}
 	value := big.NewInt(0).SetBytes(vmInput.Arguments[1])
 	esdtTokenKey := append(e.keyPrefix, tokenID...)
-	err = addToESDTBalance(acntSnd, esdtTokenKey, big.NewInt(0).Neg(value), e.marshaller, e.globalSettingsHandler, vmInput.ReturnCallAfterError)
+	err = addToESDTBalance(acntSnd, esdtTokenKey, big.NewInt(0).Neg(value), e.marshaller, e.globalSettingsHandler, e.lockedBalanceHandler, e.enableEpochsHandler, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+	err = e.esdtSupplyHandler.AddToSupplySystemAcc(tokenID, big.NewInt(0).Neg(value))
 	if err != nil {
 		return nil, err
 	}
@@ -16,11 +16,15 @@ import (
 
 func createESDTLocalMintBurnArgs() ESDTLocalMintBurnFuncArgs {
 	return ESDTLocalMintBurnFuncArgs{
-		FuncGasCost:           0,
-		Marshaller:            &mock.MarshalizerMock{},
-		GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
-		RolesHandler:          &mock.ESDTRoleHandlerStub{},
-		EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		FuncGasCost:                   0,
+		Marshaller:                    &mock.MarshalizerMock{},
+		GlobalSettingsHandler:         &mock.GlobalSettingsHandlerStub{},
+		RolesHandler:                  &mock.ESDTRoleHandlerStub{},
+		EnableEpochsHandler:           &mock.EnableEpochsHandlerStub{},
+		ESDTSupplyHandler:             &mock.ESDTNFTStorageHandlerStub{},
+		CrossChainTokenCheckerHandler: &mock.CrossChainTokenCheckerMock{},
+		CrossChainOperationsRegistry:  &mock.CrossChainOperationsRegistryStub{},
+		LockedBalanceHandler:          &mock.ESDTLockedBalanceHandlerStub{},
 	}
 }
 
@@ -72,6 +76,26 @@ func TestNewESDTLocalBurnFunc(t *testing.T) {
 			},
 			exError: ErrNilEnableEpochsHandler,
 		},
+		{
+			name: "NilESDTSupplyHandler",
+			argsFunc: func() ESDTLocalMintBurnFuncArgs {
+				args := createESDTLocalMintBurnArgs()
+				args.ESDTSupplyHandler = nil
+
+				return args
+			},
+			exError: ErrNilESDTSupplyHandler,
+		},
+		{
+			name: "NilLockedBalanceHandler",
+			argsFunc: func() ESDTLocalMintBurnFuncArgs {
+				args := createESDTLocalMintBurnArgs()
+				args.LockedBalanceHandler = nil
+
+				return args
+			},
+			exError: ErrNilLockedBalanceHandler,
+		},
 		{
 			name: "Ok",
 			argsFunc: func() ESDTLocalMintBurnFuncArgs {
@@ -217,6 +241,14 @@ func TestEsdtLocalBurn_ProcessBuiltinFunction_ShouldWork(t *testing.T) {
 			return nil
 		},
 	}
+	burnedSupply := big.NewInt(0)
+	args.ESDTSupplyHandler = &mock.ESDTNFTStorageHandlerStub{
+		AddToSupplySystemAccCalled: func(tokenID []byte, value *big.Int) error {
+			assert.Equal(t, []byte("arg1"), tokenID)
+			burnedSupply.Sub(burnedSupply, value)
+			return nil
+		},
+	}
 	esdtLocalBurnF, _ := NewESDTLocalBurnFunc(args)
 
 	sndAccout := &mock.UserAccountStub{
@@ -258,6 +290,42 @@ func TestEsdtLocalBurn_ProcessBuiltinFunction_ShouldWork(t *testing.T) {
 		},
 	}
 	require.Equal(t, expectedVMOutput, vmOutput)
+	require.Equal(t, big.NewInt(1), burnedSupply)
+}
+
+func TestEsdtLocalBurn_ProcessBuiltinFunction_CanNotBurnTheLockedBalance(t *testing.T) {
+	t.Parallel()
+
+	args := createESDTLocalMintBurnArgs()
+	args.LockedBalanceHandler = &mock.ESDTLockedBalanceHandlerStub{
+		GetLockedBalanceCalled: func(_ vmcommon.UserAccountHandler, tokenID []byte) (*big.Int, error) {
+			assert.Equal(t, []byte("arg1"), tokenID)
+			return big.NewInt(60), nil
+		},
+	}
+	esdtLocalBurnF, _ := NewESDTLocalBurnFunc(args)
+
+	sndAccount := mock.NewUserAccount([]byte("snd"))
+	esdtTokenKey := append([]byte(baseESDTKeyPrefix), []byte("arg1")...)
+	serializedEsdtData, _ := args.Marshaller.Marshal(&esdt.ESDigitalToken{Value: big.NewInt(100)})
+	_ = sndAccount.AccountDataHandler().SaveKeyValue(esdtTokenKey, serializedEsdtData)
+
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{[]byte("arg1"), big.NewInt(41).Bytes()},
+			GasProvided: 500,
+		},
+	}
+	_, err := esdtLocalBurnF.ProcessBuiltinFunction(sndAccount, nil, vmInput)
+	require.True(t, errors.Is(err, ErrInsufficientUnlockedBalance))
+
+	vmInput.Arguments = [][]byte{[]byte("arg1"), big.NewInt(40).Bytes()}
+	_, err = esdtLocalBurnF.ProcessBuiltinFunction(sndAccount, nil, vmInput)
+	require.Nil(t, err)
+
+	balance, _ := getFungibleESDTBalance(sndAccount, esdtTokenKey, args.Marshaller)
+	require.Equal(t, big.NewInt(60), balance)
 }
 
 func TestEsdtLocalBurn_ProcessBuiltinFunction_WithGlobalBurn(t *testing.T) {
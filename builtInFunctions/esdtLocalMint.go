@@ -10,15 +10,20 @@ import (
 	"github.com/multiversx/mx-chain-vm-common-go"
 )
 
+const crossChainOperationIDIndexForLocalMint = 2
+
 type esdtLocalMint struct {
 	baseAlwaysActiveHandler
-	keyPrefix             []byte
-	marshaller            vmcommon.Marshalizer
-	globalSettingsHandler vmcommon.ESDTGlobalSettingsHandler
-	rolesHandler          vmcommon.ESDTRoleHandler
-	enableEpochsHandler   vmcommon.EnableEpochsHandler
-	funcGasCost           uint64
-	mutExecution          sync.RWMutex
+	keyPrefix                     []byte
+	marshaller                    vmcommon.Marshalizer
+	globalSettingsHandler         vmcommon.ESDTGlobalSettingsHandler
+	rolesHandler                  vmcommon.ESDTRoleHandler
+	enableEpochsHandler           vmcommon.EnableEpochsHandler
+	esdtSupplyHandler             vmcommon.ESDTSupplyHandler
+	crossChainTokenCheckerHandler CrossChainTokenCheckerHandler
+	crossChainOperationsRegistry  CrossChainOperationsRegistryHandler
+	funcGasCost                   uint64
+	mutExecution                  sync.RWMutex
 }
 
 // NewESDTLocalMintFunc returns the esdt local mint built-in function component
@@ -35,15 +40,27 @@ func NewESDTLocalMintFunc(args ESDTLocalMintBurnFuncArgs) (*esdtLocalMint, error
 	if check.IfNil(args.EnableEpochsHandler) {
 		return nil, ErrNilEnableEpochsHandler
 	}
+	if check.IfNil(args.ESDTSupplyHandler) {
+		return nil, ErrNilESDTSupplyHandler
+	}
+	if check.IfNil(args.CrossChainTokenCheckerHandler) {
+		return nil, ErrNilCrossChainTokenChecker
+	}
+	if check.IfNil(args.CrossChainOperationsRegistry) {
+		return nil, ErrNilCrossChainOperationsRegistry
+	}
 
 	e := &esdtLocalMint{
-		keyPrefix:             []byte(baseESDTKeyPrefix),
-		marshaller:            args.Marshaller,
-		globalSettingsHandler: args.GlobalSettingsHandler,
-		rolesHandler:          args.RolesHandler,
-		funcGasCost:           args.FuncGasCost,
-		enableEpochsHandler:   args.EnableEpochsHandler,
-		mutExecution:          sync.RWMutex{},
+		keyPrefix:                     []byte(baseESDTKeyPrefix),
+		marshaller:                    args.Marshaller,
+		globalSettingsHandler:         args.GlobalSettingsHandler,
+		rolesHandler:                  args.RolesHandler,
+		funcGasCost:                   args.FuncGasCost,
+		enableEpochsHandler:           args.EnableEpochsHandler,
+		esdtSupplyHandler:             args.ESDTSupplyHandler,
+		crossChainTokenCheckerHandler: args.CrossChainTokenCheckerHandler,
+		crossChainOperationsRegistry:  args.CrossChainOperationsRegistry,
+		mutExecution:                  sync.RWMutex{},
 	}
 
 	return e, nil
@@ -61,6 +78,10 @@ func (e *esdtLocalMint) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 }
 
 // ProcessBuiltinFunction resolves ESDT local mint function call
+// arg0 - token identifier
+// arg1 - quantity to mint
+// arg2 - cross chain operation identifier, required for tokens from other chains after the cross chain operations
+// registry activation
 func (e *esdtLocalMint) ProcessBuiltinFunction(
 	acntSnd, _ vmcommon.UserAccountHandler,
 	vmInput *vmcommon.ContractCallInput,
@@ -87,9 +108,18 @@ func (e *esdtLocalMint) ProcessBuiltinFunction(
 		return nil, fmt.Errorf("%w max length for esdt issue is %d", ErrInvalidArguments, core.MaxLenForESDTIssueMint)
 	}
 
+	err = e.registerCrossChainOperation(tokenID, vmInput.Arguments)
+	if err != nil {
+		return nil, err
+	}
+
 	value := big.NewInt(0).SetBytes(vmInput.Arguments[1])
 	esdtTokenKey := append(e.keyPrefix, tokenID...)
-	err = addToESDTBalance(acntSnd, esdtTokenKey, big.NewInt(0).Set(value), e.marshaller, e.globalSettingsHandler, vmInput.ReturnCallAfterError)
+	err = addToESDTBalance(acntSnd, esdtTokenKey, big.NewInt(0).Set(value), e.marshaller, e.globalSettingsHandler, nil, e.enableEpochsHandler, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+	err = e.esdtSupplyHandler.AddToSupplySystemAcc(tokenID, value)
 	if err != nil {
 		return nil, err
 	}
@@ -101,6 +131,20 @@ func (e *esdtLocalMint) ProcessBuiltinFunction(
 	return vmOutput, nil
 }
 
+func (e *esdtLocalMint) registerCrossChainOperation(tokenID []byte, arguments [][]byte) error {
+	if !e.enableEpochsHandler.IsFlagEnabled(CrossChainOperationsRegistryFlag) {
+		return nil
+	}
+	if !e.crossChainTokenCheckerHandler.IsCrossChainOperation(tokenID) {
+		return nil
+	}
+	if len(arguments) <= crossChainOperationIDIndexForLocalMint {
+		return fmt.Errorf("%w for cross chain token mint, the operation identifier is required", ErrInvalidNumberOfArguments)
+	}
+
+	return e.crossChainOperationsRegistry.RegisterOperation(arguments[crossChainOperationIDIndexForLocalMint])
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (e *esdtLocalMint) IsInterfaceNil() bool {
 	return e == nil
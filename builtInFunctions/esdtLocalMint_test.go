@@ -62,6 +62,36 @@ func TestNewESDTLocalMintFunc(t *testing.T) {
 			},
 			exError: ErrNilEnableEpochsHandler,
 		},
+		{
+			name: "NilESDTSupplyHandler",
+			argsFunc: func() ESDTLocalMintBurnFuncArgs {
+				args := createESDTLocalMintBurnArgs()
+				args.ESDTSupplyHandler = nil
+
+				return args
+			},
+			exError: ErrNilESDTSupplyHandler,
+		},
+		{
+			name: "NilCrossChainTokenChecker",
+			argsFunc: func() ESDTLocalMintBurnFuncArgs {
+				args := createESDTLocalMintBurnArgs()
+				args.CrossChainTokenCheckerHandler = nil
+
+				return args
+			},
+			exError: ErrNilCrossChainTokenChecker,
+		},
+		{
+			name: "NilCrossChainOperationsRegistry",
+			argsFunc: func() ESDTLocalMintBurnFuncArgs {
+				args := createESDTLocalMintBurnArgs()
+				args.CrossChainOperationsRegistry = nil
+
+				return args
+			},
+			exError: ErrNilCrossChainOperationsRegistry,
+		},
 		{
 			name: "Ok",
 			argsFunc: func() ESDTLocalMintBurnFuncArgs {
@@ -226,6 +256,14 @@ func TestEsdtLocalMint_ProcessBuiltinFunction_ShouldWork(t *testing.T) {
 		},
 	}
 	args.FuncGasCost = 50
+	mintedSupply := big.NewInt(0)
+	args.ESDTSupplyHandler = &mock.ESDTNFTStorageHandlerStub{
+		AddToSupplySystemAccCalled: func(tokenID []byte, value *big.Int) error {
+			assert.Equal(t, []byte("arg1"), tokenID)
+			mintedSupply.Add(mintedSupply, value)
+			return nil
+		},
+	}
 	esdtLocalMintF, _ := NewESDTLocalMintFunc(args)
 
 	sndAccout := &mock.UserAccountStub{
@@ -267,6 +305,7 @@ func TestEsdtLocalMint_ProcessBuiltinFunction_ShouldWork(t *testing.T) {
 		},
 	}
 	require.Equal(t, expectedVMOutput, vmOutput)
+	require.Equal(t, big.NewInt(1), mintedSupply)
 
 	mintTooMuch := make([]byte, 101)
 	mintTooMuch[0] = 1
@@ -389,3 +428,53 @@ func TestEsdtLocalMint_ProcessBuiltinFunction_ShouldNotMintCrossChainTokenInSove
 	require.Equal(t, errNotAllowedToMint, err)
 	require.Nil(t, vmOutput)
 }
+
+func TestEsdtLocalMint_ProcessBuiltinFunction_CrossChainTokenWithOperationID(t *testing.T) {
+	t.Parallel()
+
+	args := createESDTLocalMintBurnArgs()
+	args.EnableEpochsHandler = &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return flag == CrossChainOperationsRegistryFlag
+		},
+	}
+	args.CrossChainTokenCheckerHandler = &mock.CrossChainTokenCheckerMock{
+		IsCrossChainOperationCalled: func(tokenID []byte) bool {
+			return string(tokenID) == "pref-TKNX-abcdef"
+		},
+	}
+	systemAcc := mock.NewAccountWrapMock(vmcommon.SystemAccountAddress)
+	registry, _ := NewCrossChainOperationsRegistry(createAccountsWithSystemAccount(systemAcc))
+	args.CrossChainOperationsRegistry = registry
+	esdtLocalMintF, _ := NewESDTLocalMintFunc(args)
+
+	createInput := func(arguments ...[]byte) *vmcommon.ContractCallInput {
+		return &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallValue:   big.NewInt(0),
+				Arguments:   arguments,
+				GasProvided: 500,
+			},
+		}
+	}
+	crossChainToken := []byte("pref-TKNX-abcdef")
+	operationID := []byte("operationID")
+	quantity := big.NewInt(1).Bytes()
+
+	vmOutput, err := esdtLocalMintF.ProcessBuiltinFunction(mock.NewUserAccount([]byte("addr")), nil, createInput(crossChainToken, quantity))
+	require.ErrorIs(t, err, ErrInvalidNumberOfArguments)
+	require.Nil(t, vmOutput)
+
+	vmOutput, err = esdtLocalMintF.ProcessBuiltinFunction(mock.NewUserAccount([]byte("addr")), nil, createInput(crossChainToken, quantity, operationID))
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+
+	vmOutput, err = esdtLocalMintF.ProcessBuiltinFunction(mock.NewUserAccount([]byte("addr")), nil, createInput(crossChainToken, quantity, operationID))
+	require.ErrorIs(t, err, ErrCrossChainOperationAlreadyProcessed)
+	require.Nil(t, vmOutput)
+
+	// tokens of this chain do not need an operation identifier
+	vmOutput, err = esdtLocalMintF.ProcessBuiltinFunction(mock.NewUserAccount([]byte("addr")), nil, createInput([]byte("TKNX-abcdef"), quantity))
+	require.Nil(t, err)
+	require.NotNil(t, vmOutput)
+}
@@ -2,6 +2,8 @@ package builtInFunctions
 
 const lengthOfESDTMetadata = 2
 
+const lengthOfESDTTokenProperties = 2
+
 const (
 	// MetadataPaused is the location of paused flag in the esdt global meta data
 	MetadataPaused = 1
@@ -9,24 +11,48 @@ const (
 	MetadataLimitedTransfer = 2
 	// BurnRoleForAll is the location of burn role for all flag in the esdt global meta data
 	BurnRoleForAll = 4
+	// MetadataAllowListRequired is the location of allow list required flag in the esdt global meta data
+	MetadataAllowListRequired = 8
+	// MetadataDenyListUsed is the location of deny list used flag in the esdt global meta data
+	MetadataDenyListUsed = 16
 )
 
 const (
 	// MetadataFrozen is the location of frozen flag in the esdt user meta data
 	MetadataFrozen = 1
+	// MetadataPartiallyFrozen is the location of the flag which signals that only a part of the balance is frozen
+	MetadataPartiallyFrozen = 2
+)
+
+const (
+	// PropertyCanFreeze is the location of can freeze flag in the esdt token properties
+	PropertyCanFreeze = 1
+	// PropertyCanWipe is the location of can wipe flag in the esdt token properties
+	PropertyCanWipe = 2
+	// PropertyCanPause is the location of can pause flag in the esdt token properties
+	PropertyCanPause = 4
+	// PropertyCanChangeOwner is the location of can change owner flag in the esdt token properties
+	PropertyCanChangeOwner = 8
+	// PropertyCanUpgrade is the location of can upgrade flag in the esdt token properties
+	PropertyCanUpgrade = 16
+	// PropertyCanAddSpecialRoles is the location of can add special roles flag in the esdt token properties
+	PropertyCanAddSpecialRoles = 32
 )
 
 const (
-	flagsByte     = 0
-	tokenTypeByte = 1
+	flagsByte       = 0
+	tokenTypeByte   = 1
+	numDecimalsByte = 1
 )
 
 // ESDTGlobalMetadata represents esdt global metadata saved on system account
 type ESDTGlobalMetadata struct {
-	Paused          bool
-	LimitedTransfer bool
-	BurnRoleForAll  bool
-	TokenType       byte
+	Paused            bool
+	LimitedTransfer   bool
+	BurnRoleForAll    bool
+	AllowListRequired bool
+	DenyListUsed      bool
+	TokenType         byte
 }
 
 // ESDTGlobalMetadataFromBytes creates a metadata object from bytes
@@ -36,10 +62,12 @@ func ESDTGlobalMetadataFromBytes(bytes []byte) ESDTGlobalMetadata {
 	}
 
 	return ESDTGlobalMetadata{
-		Paused:          (bytes[flagsByte] & MetadataPaused) != 0,
-		LimitedTransfer: (bytes[flagsByte] & MetadataLimitedTransfer) != 0,
-		BurnRoleForAll:  (bytes[flagsByte] & BurnRoleForAll) != 0,
-		TokenType:       bytes[tokenTypeByte],
+		Paused:            (bytes[flagsByte] & MetadataPaused) != 0,
+		LimitedTransfer:   (bytes[flagsByte] & MetadataLimitedTransfer) != 0,
+		BurnRoleForAll:    (bytes[flagsByte] & BurnRoleForAll) != 0,
+		AllowListRequired: (bytes[flagsByte] & MetadataAllowListRequired) != 0,
+		DenyListUsed:      (bytes[flagsByte] & MetadataDenyListUsed) != 0,
+		TokenType:         bytes[tokenTypeByte],
 	}
 }
 
@@ -56,6 +84,12 @@ func (metadata *ESDTGlobalMetadata) ToBytes() []byte {
 	if metadata.BurnRoleForAll {
 		bytes[flagsByte] |= BurnRoleForAll
 	}
+	if metadata.AllowListRequired {
+		bytes[flagsByte] |= MetadataAllowListRequired
+	}
+	if metadata.DenyListUsed {
+		bytes[flagsByte] |= MetadataDenyListUsed
+	}
 	bytes[tokenTypeByte] = metadata.TokenType
 
 	return bytes
@@ -63,7 +97,8 @@ func (metadata *ESDTGlobalMetadata) ToBytes() []byte {
 
 // ESDTUserMetadata represents esdt user metadata saved on every account
 type ESDTUserMetadata struct {
-	Frozen bool
+	Frozen          bool
+	PartiallyFrozen bool
 }
 
 // ESDTUserMetadataFromBytes creates a metadata object from bytes
@@ -73,7 +108,8 @@ func ESDTUserMetadataFromBytes(bytes []byte) ESDTUserMetadata {
 	}
 
 	return ESDTUserMetadata{
-		Frozen: (bytes[flagsByte] & MetadataFrozen) != 0,
+		Frozen:          (bytes[flagsByte] & MetadataFrozen) != 0,
+		PartiallyFrozen: (bytes[flagsByte] & MetadataPartiallyFrozen) != 0,
 	}
 }
 
@@ -84,6 +120,64 @@ func (metadata *ESDTUserMetadata) ToBytes() []byte {
 	if metadata.Frozen {
 		bytes[flagsByte] |= MetadataFrozen
 	}
+	if metadata.PartiallyFrozen {
+		bytes[flagsByte] |= MetadataPartiallyFrozen
+	}
+
+	return bytes
+}
+
+// ESDTTokenProperties represents the properties of a natively issued esdt token saved on system account
+type ESDTTokenProperties struct {
+	CanFreeze          bool
+	CanWipe            bool
+	CanPause           bool
+	CanChangeOwner     bool
+	CanUpgrade         bool
+	CanAddSpecialRoles bool
+	NumDecimals        byte
+}
+
+// ESDTTokenPropertiesFromBytes creates a token properties object from bytes
+func ESDTTokenPropertiesFromBytes(bytes []byte) ESDTTokenProperties {
+	if len(bytes) != lengthOfESDTTokenProperties {
+		return ESDTTokenProperties{}
+	}
+
+	return ESDTTokenProperties{
+		CanFreeze:          (bytes[flagsByte] & PropertyCanFreeze) != 0,
+		CanWipe:            (bytes[flagsByte] & PropertyCanWipe) != 0,
+		CanPause:           (bytes[flagsByte] & PropertyCanPause) != 0,
+		CanChangeOwner:     (bytes[flagsByte] & PropertyCanChangeOwner) != 0,
+		CanUpgrade:         (bytes[flagsByte] & PropertyCanUpgrade) != 0,
+		CanAddSpecialRoles: (bytes[flagsByte] & PropertyCanAddSpecialRoles) != 0,
+		NumDecimals:        bytes[numDecimalsByte],
+	}
+}
+
+// ToBytes converts the token properties to bytes
+func (properties *ESDTTokenProperties) ToBytes() []byte {
+	bytes := make([]byte, lengthOfESDTTokenProperties)
+
+	if properties.CanFreeze {
+		bytes[flagsByte] |= PropertyCanFreeze
+	}
+	if properties.CanWipe {
+		bytes[flagsByte] |= PropertyCanWipe
+	}
+	if properties.CanPause {
+		bytes[flagsByte] |= PropertyCanPause
+	}
+	if properties.CanChangeOwner {
+		bytes[flagsByte] |= PropertyCanChangeOwner
+	}
+	if properties.CanUpgrade {
+		bytes[flagsByte] |= PropertyCanUpgrade
+	}
+	if properties.CanAddSpecialRoles {
+		bytes[flagsByte] |= PropertyCanAddSpecialRoles
+	}
+	bytes[numDecimalsByte] = properties.NumDecimals
 
 	return bytes
 }
@@ -156,4 +156,65 @@ func TestESDTGlobalMetadata_FromBytes(t *testing.T) {
 	require.False(t, ESDTGlobalMetadataFromBytes([]byte{0, 0}).Paused)
 	require.True(t, ESDTGlobalMetadataFromBytes([]byte{3, 0}).Paused)
 	require.True(t, ESDTGlobalMetadataFromBytes([]byte{3, 0}).LimitedTransfer)
+	require.True(t, ESDTGlobalMetadataFromBytes([]byte{8, 0}).AllowListRequired)
+	require.False(t, ESDTGlobalMetadataFromBytes([]byte{8, 0}).DenyListUsed)
+	require.True(t, ESDTGlobalMetadataFromBytes([]byte{16, 0}).DenyListUsed)
+	require.False(t, ESDTGlobalMetadataFromBytes([]byte{16, 0}).AllowListRequired)
+}
+
+func TestESDTGlobalMetadata_ToBytesWithAccessLists(t *testing.T) {
+	t.Parallel()
+
+	esdtMetadata := &ESDTGlobalMetadata{
+		LimitedTransfer:   true,
+		AllowListRequired: true,
+		DenyListUsed:      true,
+		TokenType:         1,
+	}
+	metadataBytes := esdtMetadata.ToBytes()
+	require.Equal(t, []byte{MetadataLimitedTransfer | MetadataAllowListRequired | MetadataDenyListUsed, 1}, metadataBytes)
+	require.Equal(t, *esdtMetadata, ESDTGlobalMetadataFromBytes(metadataBytes))
+}
+
+func TestESDTTokenProperties_ToBytes(t *testing.T) {
+	t.Parallel()
+
+	properties := &ESDTTokenProperties{
+		CanFreeze:   true,
+		CanUpgrade:  true,
+		NumDecimals: 18,
+	}
+
+	require.Equal(t, []byte{PropertyCanFreeze | PropertyCanUpgrade, 18}, properties.ToBytes())
+}
+
+func TestESDTTokenPropertiesFromBytes(t *testing.T) {
+	t.Parallel()
+
+	require.Equal(t, ESDTTokenProperties{}, ESDTTokenPropertiesFromBytes(make([]byte, lengthOfESDTTokenProperties+1)))
+
+	properties := ESDTTokenProperties{
+		CanFreeze:          true,
+		CanWipe:            true,
+		CanPause:           true,
+		CanChangeOwner:     true,
+		CanUpgrade:         true,
+		CanAddSpecialRoles: true,
+		NumDecimals:        6,
+	}
+	require.Equal(t, properties, ESDTTokenPropertiesFromBytes(properties.ToBytes()))
+}
+
+func TestESDTUserMetadata_PartiallyFrozen(t *testing.T) {
+	t.Parallel()
+
+	esdtMetaData := &ESDTUserMetadata{
+		PartiallyFrozen: true,
+	}
+
+	expected := make([]byte, lengthOfESDTMetadata)
+	expected[0] = MetadataPartiallyFrozen
+	actual := esdtMetaData.ToBytes()
+	require.Equal(t, expected, actual)
+	require.Equal(t, ESDTUserMetadata{PartiallyFrozen: true}, ESDTUserMetadataFromBytes(actual))
 }
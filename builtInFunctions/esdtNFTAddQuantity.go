@@ -18,6 +18,7 @@ type esdtNFTAddQuantity struct {
 	globalSettingsHandler vmcommon.ESDTGlobalSettingsHandler
 	rolesHandler          vmcommon.ESDTRoleHandler
 	esdtStorageHandler    vmcommon.ESDTNFTStorageHandler
+	esdtSupplyHandler     vmcommon.ESDTSupplyHandler
 	enableEpochsHandler   vmcommon.EnableEpochsHandler
 	funcGasCost           uint64
 	mutExecution          sync.RWMutex
@@ -51,6 +52,7 @@ func NewESDTNFTAddQuantityFunc(
 		funcGasCost:           funcGasCost,
 		mutExecution:          sync.RWMutex{},
 		esdtStorageHandler:    esdtStorageHandler,
+		esdtSupplyHandler:     getESDTSupplyHandler(esdtStorageHandler),
 		enableEpochsHandler:   enableEpochsHandler,
 	}
 
@@ -124,6 +126,10 @@ func (e *esdtNFTAddQuantity) ProcessBuiltinFunction(
 	if err != nil {
 		return nil, err
 	}
+	err = e.esdtSupplyHandler.AddToSupplySystemAcc(vmInput.Arguments[0], value)
+	if err != nil {
+		return nil, err
+	}
 
 	vmOutput := &vmcommon.VMOutput{
 		ReturnCode:   vmcommon.Ok,
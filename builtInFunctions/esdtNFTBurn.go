@@ -13,6 +13,7 @@ type esdtNFTBurn struct {
 	baseAlwaysActiveHandler
 	keyPrefix             []byte
 	esdtStorageHandler    vmcommon.ESDTNFTStorageHandler
+	esdtSupplyHandler     vmcommon.ESDTSupplyHandler
 	globalSettingsHandler vmcommon.ExtendedESDTGlobalSettingsHandler
 	rolesHandler          vmcommon.ESDTRoleHandler
 	funcGasCost           uint64
@@ -39,6 +40,7 @@ func NewESDTNFTBurnFunc(
 	e := &esdtNFTBurn{
 		keyPrefix:             []byte(baseESDTKeyPrefix),
 		esdtStorageHandler:    esdtStorageHandler,
+		esdtSupplyHandler:     getESDTSupplyHandler(esdtStorageHandler),
 		globalSettingsHandler: globalSettingsHandler,
 		rolesHandler:          rolesHandler,
 		funcGasCost:           funcGasCost,
@@ -115,6 +117,10 @@ func (e *esdtNFTBurn) ProcessBuiltinFunction(
 	if err != nil {
 		return nil, err
 	}
+	err = e.esdtSupplyHandler.AddToSupplySystemAcc(vmInput.Arguments[0], big.NewInt(0).Neg(quantityToBurn))
+	if err != nil {
+		return nil, err
+	}
 
 	vmOutput := &vmcommon.VMOutput{
 		ReturnCode:   vmcommon.Ok,
@@ -20,7 +20,10 @@ var (
 	noncePrefix = []byte(core.ProtectedKeyPrefix + core.ESDTNFTLatestNonceIdentifier)
 )
 
-const minNumOfArgsForCrossChainMint = 10
+const (
+	minNumOfArgsForCrossChainMint   = 10
+	numOfExtraArgsForCrossChainMint = 3
+)
 
 type esdtNFTCreateInput struct {
 	esdtType              uint32
@@ -29,12 +32,14 @@ type esdtNFTCreateInput struct {
 	originalCreator       []byte
 	uris                  [][]byte
 	isCrossChainOperation bool
+	crossChainOperationID []byte
 }
 
 type esdtNFTCrossChainData struct {
 	esdtType        uint32
 	nonce           uint64
 	originalCreator []byte
+	operationID     []byte
 }
 
 type esdtNFTCreate struct {
@@ -47,9 +52,11 @@ type esdtNFTCreate struct {
 	funcGasCost                   uint64
 	gasConfig                     vmcommon.BaseOperationCost
 	esdtStorageHandler            vmcommon.ESDTNFTStorageHandler
+	esdtSupplyHandler             vmcommon.ESDTSupplyHandler
 	enableEpochsHandler           vmcommon.EnableEpochsHandler
 	mutExecution                  sync.RWMutex
 	crossChainTokenCheckerHandler CrossChainTokenCheckerHandler
+	crossChainOperationsRegistry  CrossChainOperationsRegistryHandler
 	validEsdtTypes                map[uint32]struct{}
 }
 
@@ -64,6 +71,7 @@ type ESDTNFTCreateFuncArgs struct {
 	GasConfig                     vmcommon.BaseOperationCost
 	GlobalSettingsHandler         vmcommon.GlobalMetadataHandler
 	CrossChainTokenCheckerHandler CrossChainTokenCheckerHandler
+	CrossChainOperationsRegistry  CrossChainOperationsRegistryHandler
 }
 
 // NewESDTNFTCreateFunc returns the esdt NFT create built-in function component
@@ -89,6 +97,9 @@ func NewESDTNFTCreateFunc(args ESDTNFTCreateFuncArgs) (*esdtNFTCreate, error) {
 	if check.IfNil(args.CrossChainTokenCheckerHandler) {
 		return nil, ErrNilCrossChainTokenChecker
 	}
+	if check.IfNil(args.CrossChainOperationsRegistry) {
+		return nil, ErrNilCrossChainOperationsRegistry
+	}
 
 	e := &esdtNFTCreate{
 		keyPrefix:                     []byte(baseESDTKeyPrefix),
@@ -98,10 +109,12 @@ func NewESDTNFTCreateFunc(args ESDTNFTCreateFuncArgs) (*esdtNFTCreate, error) {
 		funcGasCost:                   args.FuncGasCost,
 		gasConfig:                     args.GasConfig,
 		esdtStorageHandler:            args.EsdtStorageHandler,
+		esdtSupplyHandler:             getESDTSupplyHandler(args.EsdtStorageHandler),
 		enableEpochsHandler:           args.EnableEpochsHandler,
 		mutExecution:                  sync.RWMutex{},
 		accounts:                      args.Accounts,
 		crossChainTokenCheckerHandler: args.CrossChainTokenCheckerHandler,
+		crossChainOperationsRegistry:  args.CrossChainOperationsRegistry,
 		validEsdtTypes:                getAllESDTTypes(),
 	}
 
@@ -146,6 +159,7 @@ func (e *esdtNFTCreate) SetNewGasConfig(gasCost *vmcommon.GasCost) {
 // extraArg1 - token type
 // extraArg2 - token nonce
 // extraArg3 - creator from originating chain
+// extraArg4 - cross chain operation identifier, required after the cross chain operations registry activation
 // For ExecOnDestByCaller, last arg should be sc address caller
 func (e *esdtNFTCreate) ProcessBuiltinFunction(
 	acntSnd, _ vmcommon.UserAccountHandler,
@@ -226,6 +240,12 @@ func (e *esdtNFTCreate) ProcessBuiltinFunction(
 	if isValueLengthCheckFlagEnabled && len(vmInput.Arguments[1]) > maxLenForAddNFTQuantity {
 		return nil, fmt.Errorf("%w max length for quantity in nft create is %d", ErrInvalidArguments, maxLenForAddNFTQuantity)
 	}
+	if createInput.isCrossChainOperation && e.isCrossChainOperationsRegistryEnabled() {
+		err = e.crossChainOperationsRegistry.RegisterOperation(createInput.crossChainOperationID)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	nextNonce := createInput.nonce
 	if !createInput.isCrossChainOperation {
@@ -259,6 +279,10 @@ func (e *esdtNFTCreate) ProcessBuiltinFunction(
 	if err != nil {
 		return nil, err
 	}
+	err = e.esdtSupplyHandler.AddToSupplySystemAcc(tokenID, esdtData.Value)
+	if err != nil {
+		return nil, err
+	}
 
 	if !createInput.isCrossChainOperation {
 		err = saveLatestNonce(accountWithRoles, tokenID, nextNonce)
@@ -339,6 +363,7 @@ func (e *esdtNFTCreate) getESDTNFTCreateInput(
 	var esdtType uint32
 	var nonce uint64
 	var originalCreator []byte
+	var crossChainOperationID []byte
 	var err error
 	quantity := big.NewInt(0).SetBytes(vmInput.Arguments[1])
 
@@ -356,7 +381,8 @@ func (e *esdtNFTCreate) getESDTNFTCreateInput(
 
 		originalCreator = vmInput.CallerAddr
 	} else {
-		esdtData, err := getCrossChainESDTData(args, vmInput.CallType)
+		numExtraArgs := e.getNumOfExtraArgsForCrossChainMint()
+		esdtData, err := getCrossChainESDTData(args, vmInput.CallType, numExtraArgs)
 		if err != nil {
 			return nil, err
 		}
@@ -371,11 +397,12 @@ func (e *esdtNFTCreate) getESDTNFTCreateInput(
 			return nil, err
 		}
 
-		esdtType, nonce, originalCreator =
+		esdtType, nonce, originalCreator, crossChainOperationID =
 			esdtData.esdtType,
 			esdtData.nonce,
-			esdtData.originalCreator
-		uris = uris[:len(uris)-3]
+			esdtData.originalCreator,
+			esdtData.operationID
+		uris = uris[:len(uris)-numExtraArgs]
 	}
 
 	return &esdtNFTCreateInput{
@@ -385,34 +412,47 @@ func (e *esdtNFTCreate) getESDTNFTCreateInput(
 		originalCreator:       originalCreator,
 		uris:                  uris,
 		isCrossChainOperation: isCrossChainToken,
+		crossChainOperationID: crossChainOperationID,
 	}, nil
 }
 
-func getCrossChainESDTData(args [][]byte, callType vm.CallType) (*esdtNFTCrossChainData, error) {
-	minRequiredArgs := minNumOfArgsForCrossChainMint
+func (e *esdtNFTCreate) isCrossChainOperationsRegistryEnabled() bool {
+	return e.enableEpochsHandler.IsFlagEnabled(CrossChainOperationsRegistryFlag)
+}
+
+func (e *esdtNFTCreate) getNumOfExtraArgsForCrossChainMint() int {
+	if e.isCrossChainOperationsRegistryEnabled() {
+		return numOfExtraArgsForCrossChainMint + 1
+	}
+
+	return numOfExtraArgsForCrossChainMint
+}
+
+func getCrossChainESDTData(args [][]byte, callType vm.CallType, numExtraArgs int) (*esdtNFTCrossChainData, error) {
+	minRequiredArgs := minNumOfArgsForCrossChainMint + numExtraArgs - numOfExtraArgsForCrossChainMint
+	lastExtraArgIndex := len(args) - 1
 	if callType == vm.ExecOnDestByCaller {
 		minRequiredArgs++
+		lastExtraArgIndex--
 	}
 
 	argsLen := len(args)
 	if argsLen < minRequiredArgs {
-		return nil, fmt.Errorf("%w for cross chain token mint, received: %d, expected: %d, 2 extra arguments should be the nonce and original creator",
-			ErrInvalidNumberOfArguments, argsLen, minRequiredArgs)
+		return nil, fmt.Errorf("%w for cross chain token mint, received: %d, expected: %d, the %d extra arguments should be the type, nonce, original creator and, if required, the operation identifier",
+			ErrInvalidNumberOfArguments, argsLen, minRequiredArgs, numExtraArgs)
 	}
 
-	if !(callType == vm.ExecOnDestByCaller) {
-		return &esdtNFTCrossChainData{
-			esdtType:        uint32(getUIn46FromBytes(args[argsLen-3])),
-			nonce:           getUIn46FromBytes(args[argsLen-2]),
-			originalCreator: args[argsLen-1],
-		}, nil
+	firstExtraArgIndex := lastExtraArgIndex - numExtraArgs + 1
+	esdtData := &esdtNFTCrossChainData{
+		esdtType:        uint32(getUIn46FromBytes(args[firstExtraArgIndex])),
+		nonce:           getUIn46FromBytes(args[firstExtraArgIndex+1]),
+		originalCreator: args[firstExtraArgIndex+2],
+	}
+	if numExtraArgs > numOfExtraArgsForCrossChainMint {
+		esdtData.operationID = args[lastExtraArgIndex]
 	}
 
-	return &esdtNFTCrossChainData{
-		esdtType:        uint32(getUIn46FromBytes(args[argsLen-4])),
-		nonce:           getUIn46FromBytes(args[argsLen-3]),
-		originalCreator: args[argsLen-2],
-	}, nil
+	return esdtData, nil
 }
 
 func getUIn46FromBytes(value []byte) uint64 {
@@ -34,6 +34,7 @@ func createESDTNFTCreateArgs() ESDTNFTCreateFuncArgs {
 		GasConfig:                     vmcommon.BaseOperationCost{},
 		GlobalSettingsHandler:         &mock.GlobalSettingsHandlerStub{},
 		CrossChainTokenCheckerHandler: &mock.CrossChainTokenCheckerMock{},
+		CrossChainOperationsRegistry:  &mock.CrossChainOperationsRegistryStub{},
 	}
 }
 
@@ -101,6 +102,15 @@ func TestNewESDTNFTCreateFunc_NilArgumentsShouldErr(t *testing.T) {
 		assert.True(t, check.IfNil(nftCreate))
 		assert.Equal(t, ErrNilCrossChainTokenChecker, err)
 	})
+	t.Run("nil cross chain operations registry should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createESDTNFTCreateArgs()
+		args.CrossChainOperationsRegistry = nil
+		nftCreate, err := NewESDTNFTCreateFunc(args)
+		assert.True(t, check.IfNil(nftCreate))
+		assert.Equal(t, ErrNilCrossChainOperationsRegistry, err)
+	})
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
@@ -610,6 +620,83 @@ func TestEsdtNFTCreate_ProcessBuiltinFunctionCrossChainToken(t *testing.T) {
 	})
 }
 
+func TestEsdtNFTCreate_ProcessBuiltinFunctionCrossChainTokenWithOperationID(t *testing.T) {
+	t.Parallel()
+
+	accounts := createAccountsAdapterWithMap()
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return flag == SaveToSystemAccountFlag || flag == SendAlwaysFlag || flag == CrossChainOperationsRegistryFlag
+		},
+	}
+	crossChainTokenHandler := &mock.CrossChainTokenCheckerMock{
+		IsCrossChainOperationCalled: func(tokenID []byte) bool {
+			return true
+		},
+	}
+	esdtDtaStorage := createNewESDTDataStorageHandlerWithArgs(&mock.GlobalSettingsHandlerStub{}, accounts, enableEpochsHandler, crossChainTokenHandler)
+	ctc, _ := NewCrossChainTokenChecker(nil, getWhiteListedAddress())
+	esdtRoleHandler, _ := NewESDTRolesFunc(marshallerMock, ctc, false)
+	registry, _ := NewCrossChainOperationsRegistry(esdtDtaStorage.accounts)
+
+	args := createESDTNFTCreateArgs()
+	args.CrossChainTokenCheckerHandler = ctc
+	args.RolesHandler = esdtRoleHandler
+	args.Accounts = esdtDtaStorage.accounts
+	args.EsdtStorageHandler = esdtDtaStorage
+	args.EnableEpochsHandler = enableEpochsHandler
+	args.CrossChainOperationsRegistry = registry
+
+	nftCreate, _ := NewESDTNFTCreateFunc(args)
+	sender := mock.NewUserAccount([]byte("whiteListedAddress"))
+	token := []byte("sov1-SFT-123456")
+	operationID := []byte("operationID")
+	createInput := func(nonce int64, extraArgs ...[]byte) *vmcommon.ContractCallInput {
+		arguments := [][]byte{
+			token,
+			big.NewInt(10).Bytes(),
+			[]byte("name"),
+			big.NewInt(100).Bytes(),
+			[]byte("12345678901234567890123456789012"),
+			[]byte("attributes"),
+			[]byte("uri"),
+			big.NewInt(int64(core.SemiFungible)).Bytes(),
+			big.NewInt(nonce).Bytes(),
+			[]byte("originalCreator"),
+		}
+
+		return &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr: sender.AddressBytes(),
+				CallValue:  big.NewInt(0),
+				Arguments:  append(arguments, extraArgs...),
+			},
+			RecipientAddr: sender.AddressBytes(),
+		}
+	}
+
+	vmOutput, err := nftCreate.ProcessBuiltinFunction(sender, nil, createInput(5))
+	requireErrorIsInvalidArgsCrossChain(t, vmOutput, err)
+
+	vmOutput, err = nftCreate.ProcessBuiltinFunction(sender, nil, createInput(5, operationID))
+	require.Nil(t, err)
+	require.Equal(t, [][]byte{big.NewInt(5).Bytes()}, vmOutput.ReturnData)
+	esdtData, _ := readNFTData(t, sender, nftCreate.marshaller, token, 5, nil)
+	require.Equal(t, big.NewInt(10), esdtData.Value)
+
+	isProcessed, err := registry.IsOperationProcessed(operationID)
+	require.Nil(t, err)
+	require.True(t, isProcessed)
+
+	vmOutput, err = nftCreate.ProcessBuiltinFunction(sender, nil, createInput(6, operationID))
+	require.Nil(t, vmOutput)
+	require.ErrorIs(t, err, ErrCrossChainOperationAlreadyProcessed)
+
+	vmOutput, err = nftCreate.ProcessBuiltinFunction(sender, nil, createInput(6, []byte{}))
+	require.Nil(t, vmOutput)
+	require.ErrorIs(t, err, ErrInvalidCrossChainOperationID)
+}
+
 func processCrossChainCreate(
 	t *testing.T,
 	nftCreate *esdtNFTCreate,
@@ -29,6 +29,9 @@ type esdtNFTTransfer struct {
 	gasConfig      vmcommon.BaseOperationCost
 	mutExecution   sync.RWMutex
 	rolesHandler   vmcommon.ESDTRoleHandler
+
+	spendingPolicyHandler GuardedSpendingPolicyHandler
+	accessListChecker     ESDTTransferAccessListChecker
 }
 
 // NewESDTNFTTransferFunc returns the esdt NFT transfer built-in function component
@@ -42,6 +45,8 @@ func NewESDTNFTTransferFunc(
 	rolesHandler vmcommon.ESDTRoleHandler,
 	esdtStorageHandler vmcommon.ESDTNFTStorageHandler,
 	enableEpochsHandler vmcommon.EnableEpochsHandler,
+	spendingPolicyHandler GuardedSpendingPolicyHandler,
+	accessListChecker ESDTTransferAccessListChecker,
 ) (*esdtNFTTransfer, error) {
 	if check.IfNil(marshaller) {
 		return nil, ErrNilMarshalizer
@@ -64,17 +69,26 @@ func NewESDTNFTTransferFunc(
 	if check.IfNil(esdtStorageHandler) {
 		return nil, ErrNilESDTNFTStorageHandler
 	}
+	if check.IfNil(spendingPolicyHandler) {
+		return nil, ErrNilSpendingPolicyHandler
+	}
+	if check.IfNil(accessListChecker) {
+		return nil, ErrNilAccessListChecker
+	}
 
 	e := &esdtNFTTransfer{
-		keyPrefix:      []byte(baseESDTKeyPrefix),
-		funcGasCost:    funcGasCost,
-		accounts:       accounts,
-		gasConfig:      gasConfig,
-		mutExecution:   sync.RWMutex{},
-		payableHandler: &disabledPayableHandler{},
-		rolesHandler:   rolesHandler,
+		keyPrefix:             []byte(baseESDTKeyPrefix),
+		funcGasCost:           funcGasCost,
+		accounts:              accounts,
+		gasConfig:             gasConfig,
+		mutExecution:          sync.RWMutex{},
+		payableHandler:        &disabledPayableHandler{},
+		rolesHandler:          rolesHandler,
+		spendingPolicyHandler: spendingPolicyHandler,
+		accessListChecker:     accessListChecker,
 		baseComponentsHolder: &baseComponentsHolder{
 			esdtStorageHandler:    esdtStorageHandler,
+			esdtSupplyHandler:     getESDTSupplyHandler(esdtStorageHandler),
 			globalSettingsHandler: globalSettingsHandler,
 			shardCoordinator:      shardCoordinator,
 			enableEpochsHandler:   enableEpochsHandler,
@@ -160,6 +174,10 @@ func (e *esdtNFTTransfer) ProcessBuiltinFunction(
 		esdtTransferData.Type = uint32(core.NonFungible)
 	}
 
+	err = checkTransferAccessLists(esdtTokenKey, vmInput.CallerAddr, vmInput.RecipientAddr, e.accessListChecker, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
 	err = e.payableHandler.CheckPayable(vmInput, vmInput.RecipientAddr, core.MinLenArgumentsESDTNFTTransfer)
 	if err != nil {
 		return nil, err
@@ -245,6 +263,12 @@ func (e *esdtNFTTransfer) processNFTTransferOnSenderShard(
 		return nil, fmt.Errorf("%w: max length for a transfer value is %d", ErrInvalidArguments, core.MaxLenForESDTIssueMint)
 	}
 	quantityToTransfer := big.NewInt(0).SetBytes(vmInput.Arguments[2])
+	if !vmInput.ReturnCallAfterError {
+		err = e.spendingPolicyHandler.CheckUnguardedTransfer(acntSnd, &vmInput.VMInput, dstAddress, tickerID, quantityToTransfer)
+		if err != nil {
+			return nil, err
+		}
+	}
 	if esdtData.Value.Cmp(quantityToTransfer) < 0 {
 		return nil, ErrInvalidNFTQuantity
 	}
@@ -324,7 +348,7 @@ func (e *esdtNFTTransfer) processNFTTransferOnSenderShard(
 		tokenID = tickerID
 	}
 
-	err = checkIfTransferCanHappenWithLimitedTransfer(tokenID, esdtTokenKey, acntSnd.AddressBytes(), dstAddress, e.globalSettingsHandler, e.rolesHandler, acntSnd, userAccount, vmInput.ReturnCallAfterError)
+	err = checkIfTransferCanHappenWithLimitedTransfer(tokenID, esdtTokenKey, acntSnd.AddressBytes(), dstAddress, e.globalSettingsHandler, e.accessListChecker, e.rolesHandler, acntSnd, userAccount, vmInput.ReturnCallAfterError)
 	if err != nil {
 		return nil, err
 	}
@@ -502,7 +526,9 @@ func addNFTTransferToVMOutput(
 		CallType:      vmInput.CallType,
 		SenderAddress: vmInput.CallerAddr,
 	}
-	vmOutput.OutputAccounts = make(map[string]*vmcommon.OutputAccount)
+	if vmOutput.OutputAccounts == nil {
+		vmOutput.OutputAccounts = make(map[string]*vmcommon.OutputAccount)
+	}
 	vmOutput.OutputAccounts[string(recipient)] = &vmcommon.OutputAccount{
 		Address:         recipient,
 		OutputTransfers: []vmcommon.OutputTransfer{outTransfer},
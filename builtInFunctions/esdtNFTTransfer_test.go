@@ -35,6 +35,8 @@ func createNftTransferWithStubArguments() *esdtNFTTransfer {
 				return flag == SaveToSystemAccountFlag || flag == CheckCorrectTokenIDForTransferRoleFlag
 			},
 		},
+		&mock.GuardedSpendingPolicyHandlerStub{},
+		&mock.ESDTTransferAccessListCheckerStub{},
 	)
 
 	return nftTransfer
@@ -84,6 +86,8 @@ func createNFTTransferAndStorageHandler(selfShard, numShards uint32, globalSetti
 		},
 		esdtStorageHandler,
 		enableEpochsHandler,
+		&mock.GuardedSpendingPolicyHandlerStub{},
+		&mock.ESDTTransferAccessListCheckerStub{},
 	)
 
 	return nftTransfer, esdtStorageHandler
@@ -189,6 +193,8 @@ func TestNewESDTNFTTransferFunc(t *testing.T) {
 			&mock.ESDTRoleHandlerStub{},
 			createNewESDTDataStorageHandler(),
 			&mock.EnableEpochsHandlerStub{},
+			&mock.GuardedSpendingPolicyHandlerStub{},
+			&mock.ESDTTransferAccessListCheckerStub{},
 		)
 		assert.True(t, check.IfNil(nftTransfer))
 		assert.Equal(t, ErrNilMarshalizer, err)
@@ -206,6 +212,8 @@ func TestNewESDTNFTTransferFunc(t *testing.T) {
 			&mock.ESDTRoleHandlerStub{},
 			createNewESDTDataStorageHandler(),
 			&mock.EnableEpochsHandlerStub{},
+			&mock.GuardedSpendingPolicyHandlerStub{},
+			&mock.ESDTTransferAccessListCheckerStub{},
 		)
 		assert.True(t, check.IfNil(nftTransfer))
 		assert.Equal(t, ErrNilGlobalSettingsHandler, err)
@@ -223,6 +231,8 @@ func TestNewESDTNFTTransferFunc(t *testing.T) {
 			&mock.ESDTRoleHandlerStub{},
 			createNewESDTDataStorageHandler(),
 			&mock.EnableEpochsHandlerStub{},
+			&mock.GuardedSpendingPolicyHandlerStub{},
+			&mock.ESDTTransferAccessListCheckerStub{},
 		)
 		assert.True(t, check.IfNil(nftTransfer))
 		assert.Equal(t, ErrNilAccountsAdapter, err)
@@ -240,6 +250,8 @@ func TestNewESDTNFTTransferFunc(t *testing.T) {
 			&mock.ESDTRoleHandlerStub{},
 			createNewESDTDataStorageHandler(),
 			&mock.EnableEpochsHandlerStub{},
+			&mock.GuardedSpendingPolicyHandlerStub{},
+			&mock.ESDTTransferAccessListCheckerStub{},
 		)
 		assert.True(t, check.IfNil(nftTransfer))
 		assert.Equal(t, ErrNilShardCoordinator, err)
@@ -257,6 +269,8 @@ func TestNewESDTNFTTransferFunc(t *testing.T) {
 			nil,
 			createNewESDTDataStorageHandler(),
 			&mock.EnableEpochsHandlerStub{},
+			&mock.GuardedSpendingPolicyHandlerStub{},
+			&mock.ESDTTransferAccessListCheckerStub{},
 		)
 		assert.True(t, check.IfNil(nftTransfer))
 		assert.Equal(t, ErrNilRolesHandler, err)
@@ -274,6 +288,8 @@ func TestNewESDTNFTTransferFunc(t *testing.T) {
 			&mock.ESDTRoleHandlerStub{},
 			nil,
 			&mock.EnableEpochsHandlerStub{},
+			&mock.GuardedSpendingPolicyHandlerStub{},
+			&mock.ESDTTransferAccessListCheckerStub{},
 		)
 		assert.True(t, check.IfNil(nftTransfer))
 		assert.Equal(t, ErrNilESDTNFTStorageHandler, err)
@@ -291,10 +307,50 @@ func TestNewESDTNFTTransferFunc(t *testing.T) {
 			&mock.ESDTRoleHandlerStub{},
 			createNewESDTDataStorageHandler(),
 			nil,
+			&mock.GuardedSpendingPolicyHandlerStub{},
+			&mock.ESDTTransferAccessListCheckerStub{},
 		)
 		assert.True(t, check.IfNil(nftTransfer))
 		assert.Equal(t, ErrNilEnableEpochsHandler, err)
 	})
+	t.Run("nil spending policy handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		nftTransfer, err := NewESDTNFTTransferFunc(
+			0,
+			&mock.MarshalizerMock{},
+			&mock.GlobalSettingsHandlerStub{},
+			&mock.AccountsStub{},
+			&mock.ShardCoordinatorStub{},
+			vmcommon.BaseOperationCost{},
+			&mock.ESDTRoleHandlerStub{},
+			createNewESDTDataStorageHandler(),
+			&mock.EnableEpochsHandlerStub{},
+			nil,
+			&mock.ESDTTransferAccessListCheckerStub{},
+		)
+		assert.True(t, check.IfNil(nftTransfer))
+		assert.Equal(t, ErrNilSpendingPolicyHandler, err)
+	})
+	t.Run("nil access list checker should error", func(t *testing.T) {
+		t.Parallel()
+
+		nftTransfer, err := NewESDTNFTTransferFunc(
+			0,
+			&mock.MarshalizerMock{},
+			&mock.GlobalSettingsHandlerStub{},
+			&mock.AccountsStub{},
+			&mock.ShardCoordinatorStub{},
+			vmcommon.BaseOperationCost{},
+			&mock.ESDTRoleHandlerStub{},
+			createNewESDTDataStorageHandler(),
+			&mock.EnableEpochsHandlerStub{},
+			&mock.GuardedSpendingPolicyHandlerStub{},
+			nil,
+		)
+		assert.True(t, check.IfNil(nftTransfer))
+		assert.Equal(t, ErrNilAccessListChecker, err)
+	})
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
@@ -308,6 +364,8 @@ func TestNewESDTNFTTransferFunc(t *testing.T) {
 			&mock.ESDTRoleHandlerStub{},
 			createNewESDTDataStorageHandler(),
 			&mock.EnableEpochsHandlerStub{},
+			&mock.GuardedSpendingPolicyHandlerStub{},
+			&mock.ESDTTransferAccessListCheckerStub{},
 		)
 		assert.False(t, check.IfNil(nftTransfer))
 		assert.Nil(t, err)
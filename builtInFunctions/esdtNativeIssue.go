@@ -0,0 +1,280 @@
+package builtInFunctions
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const (
+	minLengthForTokenName       = 3
+	maxLengthForTokenName       = 20
+	maxNumDecimals              = 18
+	numRandomBytesForIdentifier = 3
+	minNumArgsESDTNativeIssue   = 4
+)
+
+type esdtNativeIssue struct {
+	baseActiveHandler
+	keyPrefix             []byte
+	marshaller            vmcommon.Marshalizer
+	accounts              vmcommon.AccountsAdapter
+	globalSettingsHandler vmcommon.GlobalMetadataHandler
+	enableEpochsHandler   vmcommon.EnableEpochsHandler
+	esdtStorageHandler    vmcommon.ESDTNFTStorageHandler
+	esdtSupplyHandler     vmcommon.ESDTSupplyHandler
+	selfESDTPrefix        []byte
+	function              string
+	funcGasCost           uint64
+	mutExecution          sync.RWMutex
+}
+
+// NewESDTNativeIssueFunc returns the esdt native issue built-in function component, which issues fungible tokens
+// or collections without the esdt system smart contract. The caller becomes the owner of the issued token
+func NewESDTNativeIssueFunc(args ESDTNativeTokenFuncArgs, function string) (*esdtNativeIssue, error) {
+	err := checkESDTNativeTokenFuncArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if function != vmcommon.BuiltInFunctionESDTNativeIssue && function != vmcommon.BuiltInFunctionESDTNativeIssueCollection {
+		return nil, ErrInvalidArguments
+	}
+
+	e := &esdtNativeIssue{
+		keyPrefix:             []byte(baseESDTKeyPrefix),
+		marshaller:            args.Marshaller,
+		accounts:              args.Accounts,
+		globalSettingsHandler: args.GlobalSettingsHandler,
+		enableEpochsHandler:   args.EnableEpochsHandler,
+		esdtStorageHandler:    args.EsdtStorageHandler,
+		esdtSupplyHandler:     getESDTSupplyHandler(args.EsdtStorageHandler),
+		selfESDTPrefix:        args.SelfESDTPrefix,
+		function:              function,
+		funcGasCost:           args.FuncGasCost,
+		mutExecution:          sync.RWMutex{},
+	}
+	e.activeHandler = func() bool {
+		return args.EnableEpochsHandler.IsFlagEnabled(ESDTNativeIssuanceFlag)
+	}
+
+	return e, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (e *esdtNativeIssue) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	e.mutExecution.Lock()
+	e.funcGasCost = gasCost.BuiltInCost.ESDTNativeIssue
+	e.mutExecution.Unlock()
+}
+
+// ProcessBuiltinFunction resolves ESDT native issue function call. The issued token identifier is returned
+// Requires at least 4 arguments:
+// arg0 - token name
+// arg1 - token ticker
+// arg2 - initial supply for fungible tokens, respectively the token type for collections
+// arg3 - number of decimals
+// arg4... - token properties as (name, value) pairs
+func (e *esdtNativeIssue) ProcessBuiltinFunction(
+	acntSnd, _ vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	err := checkESDTNativeTokenInput(acntSnd, vmInput, minNumArgsESDTNativeIssue, e.funcGasCost)
+	if err != nil {
+		return nil, err
+	}
+
+	name := vmInput.Arguments[0]
+	ticker := vmInput.Arguments[1]
+	err = checkTokenNameAndTicker(name, ticker)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenType, initialSupply, err := e.getTokenTypeAndInitialSupply(vmInput.Arguments[2])
+	if err != nil {
+		return nil, err
+	}
+
+	properties := &ESDTTokenProperties{
+		CanUpgrade:         true,
+		CanAddSpecialRoles: true,
+	}
+	properties.NumDecimals, err = getNumDecimals(vmInput.Arguments[3], tokenType)
+	if err != nil {
+		return nil, err
+	}
+	err = setESDTTokenProperties(properties, vmInput.Arguments[minNumArgsESDTNativeIssue:])
+	if err != nil {
+		return nil, err
+	}
+
+	tokenID := e.createTokenIdentifier(vmInput.CurrentTxHash, vmInput.CallerAddr, ticker)
+	err = e.saveTokenOnSystemAccount(tokenID, tokenType, vmInput.CallerAddr, properties)
+	if err != nil {
+		return nil, err
+	}
+
+	esdtTokenRoleKey := append(roleKeyPrefix, tokenID...)
+	err = saveRolesToAccount(acntSnd, esdtTokenRoleKey, &esdt.ESDTRoles{Roles: getOwnerRoles(tokenType)}, e.marshaller)
+	if err != nil {
+		return nil, err
+	}
+
+	if initialSupply.Cmp(zero) > 0 {
+		esdtTokenKey := append(e.keyPrefix, tokenID...)
+		err = addToESDTBalance(acntSnd, esdtTokenKey, big.NewInt(0).Set(initialSupply), e.marshaller, e.globalSettingsHandler, nil, e.enableEpochsHandler, vmInput.ReturnCallAfterError)
+		if err != nil {
+			return nil, err
+		}
+		err = e.esdtSupplyHandler.AddToSupplySystemAcc(tokenID, initialSupply)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	vmOutput := &vmcommon.VMOutput{
+		ReturnCode:   vmcommon.Ok,
+		GasRemaining: vmInput.GasProvided - e.funcGasCost,
+		ReturnData:   [][]byte{tokenID},
+	}
+	tokenTypeName := []byte(core.ESDTType(tokenType).String())
+	addESDTEntryInVMOutput(vmOutput, []byte(e.function), tokenID, 0, initialSupply, vmInput.CallerAddr, name, ticker, tokenTypeName)
+
+	return vmOutput, nil
+}
+
+func checkTokenNameAndTicker(name []byte, ticker []byte) error {
+	if len(name) < minLengthForTokenName || len(name) > maxLengthForTokenName {
+		return fmt.Errorf("%w, length should be between %d and %d", ErrInvalidTokenName, minLengthForTokenName, maxLengthForTokenName)
+	}
+	for _, ch := range name {
+		isLetter := (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+		isNumber := ch >= '0' && ch <= '9'
+		if !isLetter && !isNumber {
+			return fmt.Errorf("%w, should be alphanumeric", ErrInvalidTokenName)
+		}
+	}
+	if !esdt.IsTickerValid(string(ticker)) {
+		return fmt.Errorf("%w: %s", ErrInvalidTokenTicker, ticker)
+	}
+
+	return nil
+}
+
+func (e *esdtNativeIssue) getTokenTypeAndInitialSupply(arg []byte) (uint32, *big.Int, error) {
+	if e.function == vmcommon.BuiltInFunctionESDTNativeIssue {
+		if len(arg) > core.MaxLenForESDTIssueMint {
+			return 0, nil, fmt.Errorf("%w: max length for esdt issue is %d", ErrInvalidArguments, core.MaxLenForESDTIssueMint)
+		}
+
+		return uint32(core.Fungible), big.NewInt(0).SetBytes(arg), nil
+	}
+
+	tokenType, err := core.ConvertESDTTypeToUint32(string(arg))
+	if err != nil {
+		return 0, nil, err
+	}
+	if _, isCollectionType := getAllESDTTypes()[tokenType]; !isCollectionType {
+		return 0, nil, fmt.Errorf("%w, invalid collection type %s", ErrInvalidArguments, arg)
+	}
+
+	return tokenType, big.NewInt(0), nil
+}
+
+func getNumDecimals(arg []byte, tokenType uint32) (byte, error) {
+	numDecimals := big.NewInt(0).SetBytes(arg)
+	if numDecimals.Cmp(big.NewInt(maxNumDecimals)) > 0 {
+		return 0, fmt.Errorf("%w, maximum is %d", ErrInvalidNumberOfDecimals, maxNumDecimals)
+	}
+
+	hasDecimals := tokenType == uint32(core.Fungible) || tokenType == uint32(core.MetaFungible) || tokenType == uint32(core.DynamicMeta)
+	if !hasDecimals && numDecimals.Sign() != 0 {
+		return 0, fmt.Errorf("%w, only fungible and meta tokens can have decimals", ErrInvalidNumberOfDecimals)
+	}
+
+	return byte(numDecimals.Uint64()), nil
+}
+
+// createTokenIdentifier builds the identifier as prefix-TICKER-abcdef, the prefix being added only if configured.
+// The random part is derived from the transaction hash, the caller and the ticker
+func (e *esdtNativeIssue) createTokenIdentifier(txHash []byte, caller []byte, ticker []byte) []byte {
+	hasher := sha256.New()
+	_, _ = hasher.Write(txHash)
+	_, _ = hasher.Write(caller)
+	_, _ = hasher.Write(ticker)
+	hash := hasher.Sum(nil)
+	randomChars := hex.EncodeToString(hash[:numRandomBytesForIdentifier])
+
+	tokenID := fmt.Sprintf("%s-%s", ticker, randomChars)
+	if len(e.selfESDTPrefix) > 0 {
+		tokenID = fmt.Sprintf("%s-%s", e.selfESDTPrefix, tokenID)
+	}
+
+	return []byte(tokenID)
+}
+
+func (e *esdtNativeIssue) saveTokenOnSystemAccount(
+	tokenID []byte,
+	tokenType uint32,
+	owner []byte,
+	properties *ESDTTokenProperties,
+) error {
+	systemAcc, err := getSystemAccount(e.accounts)
+	if err != nil {
+		return err
+	}
+
+	existingOwner, err := getESDTTokenOwner(systemAcc, tokenID)
+	if err != nil {
+		return err
+	}
+	if len(existingOwner) > 0 {
+		return fmt.Errorf("%w: %s", ErrTokenAlreadyIssued, tokenID)
+	}
+
+	err = e.globalSettingsHandler.SetTokenType(append(e.keyPrefix, tokenID...), tokenType, systemAcc)
+	if err != nil {
+		return err
+	}
+	err = systemAcc.AccountDataHandler().SaveKeyValue(getESDTTokenOwnerKey(tokenID), owner)
+	if err != nil {
+		return err
+	}
+	err = systemAcc.AccountDataHandler().SaveKeyValue(getESDTTokenPropertiesKey(tokenID), properties.ToBytes())
+	if err != nil {
+		return err
+	}
+
+	return e.accounts.SaveAccount(systemAcc)
+}
+
+func getOwnerRoles(tokenType uint32) [][]byte {
+	if tokenType == uint32(core.Fungible) {
+		return [][]byte{[]byte(core.ESDTRoleLocalMint), []byte(core.ESDTRoleLocalBurn)}
+	}
+
+	roles := [][]byte{[]byte(core.ESDTRoleNFTCreate), []byte(core.ESDTRoleNFTBurn)}
+	if tokenType != uint32(core.NonFungibleV2) && tokenType != uint32(core.DynamicNFT) {
+		roles = append(roles, []byte(core.ESDTRoleNFTAddQuantity))
+	}
+
+	return roles
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (e *esdtNativeIssue) IsInterfaceNil() bool {
+	return e == nil
+}
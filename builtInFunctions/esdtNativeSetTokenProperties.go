@@ -0,0 +1,98 @@
+package builtInFunctions
+
+import (
+	"math/big"
+	"sync"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const minNumArgsESDTNativeSetTokenProperties = 3
+
+type esdtNativeSetTokenProperties struct {
+	baseActiveHandler
+	accounts     vmcommon.AccountsAdapter
+	funcGasCost  uint64
+	mutExecution sync.RWMutex
+}
+
+// NewESDTNativeSetTokenPropertiesFunc returns the esdt native set token properties built-in function component
+func NewESDTNativeSetTokenPropertiesFunc(args ESDTNativeTokenFuncArgs) (*esdtNativeSetTokenProperties, error) {
+	err := checkESDTNativeTokenFuncArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &esdtNativeSetTokenProperties{
+		accounts:     args.Accounts,
+		funcGasCost:  args.FuncGasCost,
+		mutExecution: sync.RWMutex{},
+	}
+	e.activeHandler = func() bool {
+		return args.EnableEpochsHandler.IsFlagEnabled(ESDTNativeIssuanceFlag)
+	}
+
+	return e, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (e *esdtNativeSetTokenProperties) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	e.mutExecution.Lock()
+	e.funcGasCost = gasCost.BuiltInCost.ESDTNativeSetTokenProperties
+	e.mutExecution.Unlock()
+}
+
+// ProcessBuiltinFunction resolves ESDT native set token properties function call. Only the owner of a natively
+// issued token which can be upgraded is allowed to change its properties
+// Requires at least 3 arguments:
+// arg0 - token identifier
+// arg1... - token properties as (name, value) pairs
+func (e *esdtNativeSetTokenProperties) ProcessBuiltinFunction(
+	acntSnd, _ vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	err := checkESDTNativeTokenInput(acntSnd, vmInput, minNumArgsESDTNativeSetTokenProperties, e.funcGasCost)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenID := vmInput.Arguments[0]
+	systemAcc, properties, err := checkESDTTokenOwner(e.accounts, tokenID, vmInput.CallerAddr)
+	if err != nil {
+		return nil, err
+	}
+	if !properties.CanUpgrade {
+		return nil, ErrTokenCannotBeUpgraded
+	}
+
+	err = setESDTTokenProperties(properties, vmInput.Arguments[1:])
+	if err != nil {
+		return nil, err
+	}
+	err = systemAcc.AccountDataHandler().SaveKeyValue(getESDTTokenPropertiesKey(tokenID), properties.ToBytes())
+	if err != nil {
+		return nil, err
+	}
+	err = e.accounts.SaveAccount(systemAcc)
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: vmInput.GasProvided - e.funcGasCost}
+	logData := append([][]byte{vmInput.CallerAddr}, vmInput.Arguments[1:]...)
+	addESDTEntryInVMOutput(vmOutput, []byte(vmcommon.BuiltInFunctionESDTNativeSetTokenProperties), tokenID, 0, big.NewInt(0), logData...)
+
+	return vmOutput, nil
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (e *esdtNativeSetTokenProperties) IsInterfaceNil() bool {
+	return e == nil
+}
@@ -0,0 +1,229 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const (
+	esdtTokenOwnerKeyPrefix      = core.ProtectedKeyPrefix + "esdtTokenOwner"
+	esdtTokenPropertiesKeyPrefix = core.ProtectedKeyPrefix + "esdtTokenProperties"
+
+	canFreezeProperty          = "canFreeze"
+	canWipeProperty            = "canWipe"
+	canPauseProperty           = "canPause"
+	canChangeOwnerProperty     = "canChangeOwner"
+	canUpgradeProperty         = "canUpgrade"
+	canAddSpecialRolesProperty = "canAddSpecialRoles"
+)
+
+// ESDTNativeTokenFuncArgs holds the arguments needed to create the esdt native token management built-in functions
+type ESDTNativeTokenFuncArgs struct {
+	FuncGasCost           uint64
+	Marshaller            vmcommon.Marshalizer
+	Accounts              vmcommon.AccountsAdapter
+	GlobalSettingsHandler vmcommon.GlobalMetadataHandler
+	EsdtStorageHandler    vmcommon.ESDTNFTStorageHandler
+	SelfESDTPrefix        []byte
+	EnableEpochsHandler   vmcommon.EnableEpochsHandler
+}
+
+func checkESDTNativeTokenFuncArgs(args ESDTNativeTokenFuncArgs) error {
+	if check.IfNil(args.Marshaller) {
+		return ErrNilMarshalizer
+	}
+	if check.IfNil(args.Accounts) {
+		return ErrNilAccountsAdapter
+	}
+	if check.IfNil(args.GlobalSettingsHandler) {
+		return ErrNilGlobalSettingsHandler
+	}
+	if check.IfNil(args.EsdtStorageHandler) {
+		return ErrNilESDTNFTStorageHandler
+	}
+	if check.IfNil(args.EnableEpochsHandler) {
+		return ErrNilEnableEpochsHandler
+	}
+	if len(args.SelfESDTPrefix) > 0 && !esdt.IsValidTokenPrefix(string(args.SelfESDTPrefix)) {
+		return fmt.Errorf("%w: %s", ErrInvalidTokenPrefix, args.SelfESDTPrefix)
+	}
+
+	return nil
+}
+
+func checkESDTNativeTokenInput(
+	acntSnd vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+	minNumArgs int,
+	funcGasCost uint64,
+) error {
+	if vmInput == nil {
+		return ErrNilVmInput
+	}
+	if vmInput.CallValue == nil {
+		return ErrNilValue
+	}
+	if vmInput.CallValue.Cmp(zero) != 0 {
+		return ErrBuiltInFunctionCalledWithValue
+	}
+	if len(vmInput.Arguments) < minNumArgs {
+		return ErrInvalidNumOfArgs
+	}
+	if !bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
+		return ErrInvalidRcvAddr
+	}
+	if check.IfNil(acntSnd) {
+		return ErrNilUserAccount
+	}
+	if vmInput.GasProvided < funcGasCost {
+		return ErrNotEnoughGas
+	}
+
+	return nil
+}
+
+// setESDTTokenProperties applies the properties provided as (name, value) pairs, the value being "true" or "false"
+func setESDTTokenProperties(properties *ESDTTokenProperties, args [][]byte) error {
+	if len(args)%2 != 0 {
+		return fmt.Errorf("%w, token properties should be provided as name and value pairs", ErrInvalidNumOfArgs)
+	}
+
+	for i := 0; i < len(args); i += 2 {
+		value, err := getESDTTokenPropertyValue(args[i+1])
+		if err != nil {
+			return err
+		}
+
+		switch string(args[i]) {
+		case canFreezeProperty:
+			properties.CanFreeze = value
+		case canWipeProperty:
+			properties.CanWipe = value
+		case canPauseProperty:
+			properties.CanPause = value
+		case canChangeOwnerProperty:
+			properties.CanChangeOwner = value
+		case canUpgradeProperty:
+			properties.CanUpgrade = value
+		case canAddSpecialRolesProperty:
+			properties.CanAddSpecialRoles = value
+		default:
+			return fmt.Errorf("%w: %s", ErrInvalidTokenProperty, args[i])
+		}
+	}
+
+	return nil
+}
+
+func getESDTTokenPropertyValue(value []byte) (bool, error) {
+	switch string(value) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	default:
+		return false, fmt.Errorf("%w, invalid value %s", ErrInvalidTokenProperty, value)
+	}
+}
+
+// getESDTTokenOwner returns the owner of a natively issued token, or nil if the token was not issued natively
+func getESDTTokenOwner(systemAcc vmcommon.UserAccountHandler, tokenID []byte) ([]byte, error) {
+	owner, _, err := systemAcc.AccountDataHandler().RetrieveValue(getESDTTokenOwnerKey(tokenID))
+	if core.IsGetNodeFromDBError(err) {
+		return nil, err
+	}
+	if err != nil || len(owner) == 0 {
+		return nil, nil
+	}
+
+	return owner, nil
+}
+
+func getESDTTokenProperties(systemAcc vmcommon.UserAccountHandler, tokenID []byte) (*ESDTTokenProperties, error) {
+	val, _, err := systemAcc.AccountDataHandler().RetrieveValue(getESDTTokenPropertiesKey(tokenID))
+	if core.IsGetNodeFromDBError(err) {
+		return nil, err
+	}
+
+	properties := ESDTTokenPropertiesFromBytes(val)
+	return &properties, nil
+}
+
+// checkESDTTokenOwner loads the system account and returns it together with the token properties if the caller is
+// the owner of the natively issued token
+func checkESDTTokenOwner(
+	accounts vmcommon.AccountsAdapter,
+	tokenID []byte,
+	caller []byte,
+) (vmcommon.UserAccountHandler, *ESDTTokenProperties, error) {
+	systemAcc, err := getSystemAccount(accounts)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	owner, err := getESDTTokenOwner(systemAcc, tokenID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(owner) == 0 {
+		return nil, nil, fmt.Errorf("%w: %s", ErrTokenNotIssuedNatively, tokenID)
+	}
+	if !bytes.Equal(owner, caller) {
+		return nil, nil, ErrCallerIsNotTokenOwner
+	}
+
+	properties, err := getESDTTokenProperties(systemAcc, tokenID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return systemAcc, properties, nil
+}
+
+// getSystemAccountForTokenManager checks the caller is allowed to manage the global settings of the token and returns
+// the system account and the remaining gas. The esdt system smart contract calls the system account and does not pay
+// gas, while the owner of a natively issued token which can be upgraded calls its own account
+func getSystemAccountForTokenManager(
+	accounts vmcommon.AccountsAdapter,
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+	minNumArgs int,
+	funcGasCost uint64,
+) (vmcommon.UserAccountHandler, uint64, error) {
+	if bytes.Equal(vmInput.CallerAddr, core.ESDTSCAddress) {
+		if !vmcommon.IsSystemAccountAddress(vmInput.RecipientAddr) {
+			return nil, 0, ErrOnlySystemAccountAccepted
+		}
+
+		systemAcc, err := getSystemAccountIfNeeded(vmInput, acntDst, accounts)
+		return systemAcc, vmInput.GasProvided, err
+	}
+
+	err := checkESDTNativeTokenInput(acntSnd, vmInput, minNumArgs, funcGasCost)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	systemAcc, properties, err := checkESDTTokenOwner(accounts, vmInput.Arguments[0], vmInput.CallerAddr)
+	if err != nil {
+		return nil, 0, err
+	}
+	if !properties.CanUpgrade {
+		return nil, 0, ErrTokenCannotBeUpgraded
+	}
+
+	return systemAcc, vmInput.GasProvided - funcGasCost, nil
+}
+
+func getESDTTokenOwnerKey(tokenID []byte) []byte {
+	return append([]byte(esdtTokenOwnerKeyPrefix), tokenID...)
+}
+
+func getESDTTokenPropertiesKey(tokenID []byte) []byte {
+	return append([]byte(esdtTokenPropertiesKeyPrefix), tokenID...)
+}
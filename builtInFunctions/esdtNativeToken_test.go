@@ -0,0 +1,356 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	nativeTokenOwner    = bytes.Repeat([]byte{3}, 32)
+	nativeTokenNewOwner = bytes.Repeat([]byte{4}, 32)
+)
+
+func createMockESDTNativeTokenFuncArgs() ESDTNativeTokenFuncArgs {
+	accounts := createAccountsWithSystemAccount(mock.NewAccountWrapMock(vmcommon.SystemAccountAddress))
+	globalSettingsHandler, _ := NewESDTGlobalSettingsFunc(accounts, &mock.MarshalizerMock{}, true, core.BuiltInFunctionESDTPause, trueHandler)
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return flag == ESDTSupplyTrackingFlag
+		},
+	}
+
+	return ESDTNativeTokenFuncArgs{
+		FuncGasCost:           10,
+		Marshaller:            &mock.MarshalizerMock{},
+		Accounts:              accounts,
+		GlobalSettingsHandler: globalSettingsHandler,
+		EsdtStorageHandler:    createNewESDTDataStorageHandlerWithArgs(globalSettingsHandler, accounts, enableEpochsHandler, &mock.CrossChainTokenCheckerMock{}),
+		SelfESDTPrefix:        []byte("sov1"),
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == ESDTNativeIssuanceFlag
+			},
+		},
+	}
+}
+
+func createESDTNativeTokenInput(caller []byte, args ...[]byte) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:    caller,
+			CallValue:     big.NewInt(0),
+			Arguments:     args,
+			GasProvided:   100,
+			CurrentTxHash: []byte("txHash"),
+		},
+		RecipientAddr: caller,
+	}
+}
+
+func issueNativeToken(t *testing.T, args ESDTNativeTokenFuncArgs, owner vmcommon.UserAccountHandler, properties ...[]byte) []byte {
+	issue, _ := NewESDTNativeIssueFunc(args, vmcommon.BuiltInFunctionESDTNativeIssue)
+	issueArgs := append([][]byte{[]byte("Token"), []byte("TKN"), big.NewInt(1000).Bytes(), {6}}, properties...)
+	vmOutput, err := issue.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(owner.AddressBytes(), issueArgs...))
+	require.Nil(t, err)
+
+	return vmOutput.ReturnData[0]
+}
+
+func TestNewESDTNativeTokenFuncs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		modify      func(args *ESDTNativeTokenFuncArgs)
+		expectedErr error
+	}{
+		{name: "nil marshaller", modify: func(args *ESDTNativeTokenFuncArgs) { args.Marshaller = nil }, expectedErr: ErrNilMarshalizer},
+		{name: "nil accounts", modify: func(args *ESDTNativeTokenFuncArgs) { args.Accounts = nil }, expectedErr: ErrNilAccountsAdapter},
+		{name: "nil global settings handler", modify: func(args *ESDTNativeTokenFuncArgs) { args.GlobalSettingsHandler = nil }, expectedErr: ErrNilGlobalSettingsHandler},
+		{name: "nil esdt storage handler", modify: func(args *ESDTNativeTokenFuncArgs) { args.EsdtStorageHandler = nil }, expectedErr: ErrNilESDTNFTStorageHandler},
+		{name: "nil enable epochs handler", modify: func(args *ESDTNativeTokenFuncArgs) { args.EnableEpochsHandler = nil }, expectedErr: ErrNilEnableEpochsHandler},
+		{name: "invalid prefix", modify: func(args *ESDTNativeTokenFuncArgs) { args.SelfESDTPrefix = []byte("PREFIX") }, expectedErr: ErrInvalidTokenPrefix},
+	}
+	for _, test := range tests {
+		args := createMockESDTNativeTokenFuncArgs()
+		test.modify(&args)
+
+		issue, err := NewESDTNativeIssueFunc(args, vmcommon.BuiltInFunctionESDTNativeIssue)
+		assert.Nil(t, issue, test.name)
+		assert.True(t, errors.Is(err, test.expectedErr), test.name)
+
+		setProperties, err := NewESDTNativeSetTokenPropertiesFunc(args)
+		assert.Nil(t, setProperties, test.name)
+		assert.True(t, errors.Is(err, test.expectedErr), test.name)
+
+		transferOwnership, err := NewESDTNativeTransferOwnershipFunc(args)
+		assert.Nil(t, transferOwnership, test.name)
+		assert.True(t, errors.Is(err, test.expectedErr), test.name)
+	}
+
+	issue, err := NewESDTNativeIssueFunc(createMockESDTNativeTokenFuncArgs(), core.BuiltInFunctionESDTLocalMint)
+	assert.Nil(t, issue)
+	assert.Equal(t, ErrInvalidArguments, err)
+
+	issue, err = NewESDTNativeIssueFunc(createMockESDTNativeTokenFuncArgs(), vmcommon.BuiltInFunctionESDTNativeIssueCollection)
+	assert.Nil(t, err)
+	assert.False(t, issue.IsInterfaceNil())
+	assert.True(t, issue.IsActive())
+
+	setProperties, err := NewESDTNativeSetTokenPropertiesFunc(createMockESDTNativeTokenFuncArgs())
+	assert.Nil(t, err)
+	assert.False(t, setProperties.IsInterfaceNil())
+	assert.True(t, setProperties.IsActive())
+
+	transferOwnership, err := NewESDTNativeTransferOwnershipFunc(createMockESDTNativeTokenFuncArgs())
+	assert.Nil(t, err)
+	assert.False(t, transferOwnership.IsInterfaceNil())
+	assert.True(t, transferOwnership.IsActive())
+
+	args := createMockESDTNativeTokenFuncArgs()
+	args.EnableEpochsHandler = &mock.EnableEpochsHandlerStub{}
+	issue, _ = NewESDTNativeIssueFunc(args, vmcommon.BuiltInFunctionESDTNativeIssue)
+	assert.False(t, issue.IsActive())
+	setProperties, _ = NewESDTNativeSetTokenPropertiesFunc(args)
+	assert.False(t, setProperties.IsActive())
+	transferOwnership, _ = NewESDTNativeTransferOwnershipFunc(args)
+	assert.False(t, transferOwnership.IsActive())
+}
+
+func TestESDTNativeIssue_ProcessBuiltinFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	issue, _ := NewESDTNativeIssueFunc(createMockESDTNativeTokenFuncArgs(), vmcommon.BuiltInFunctionESDTNativeIssue)
+	owner := mock.NewUserAccount(nativeTokenOwner)
+	validArgs := func() [][]byte {
+		return [][]byte{[]byte("Token"), []byte("TKN"), big.NewInt(1000).Bytes(), {6}}
+	}
+
+	_, err := issue.ProcessBuiltinFunction(owner, nil, nil)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	input := createESDTNativeTokenInput(nativeTokenOwner, validArgs()...)
+	input.CallValue = big.NewInt(1)
+	_, err = issue.ProcessBuiltinFunction(owner, nil, input)
+	assert.Equal(t, ErrBuiltInFunctionCalledWithValue, err)
+
+	_, err = issue.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, validArgs()[:3]...))
+	assert.Equal(t, ErrInvalidNumOfArgs, err)
+
+	input = createESDTNativeTokenInput(nativeTokenOwner, validArgs()...)
+	input.RecipientAddr = nativeTokenNewOwner
+	_, err = issue.ProcessBuiltinFunction(owner, nil, input)
+	assert.Equal(t, ErrInvalidRcvAddr, err)
+
+	_, err = issue.ProcessBuiltinFunction(nil, nil, createESDTNativeTokenInput(nativeTokenOwner, validArgs()...))
+	assert.Equal(t, ErrNilUserAccount, err)
+
+	input = createESDTNativeTokenInput(nativeTokenOwner, validArgs()...)
+	input.GasProvided = 1
+	_, err = issue.ProcessBuiltinFunction(owner, nil, input)
+	assert.Equal(t, ErrNotEnoughGas, err)
+
+	args := validArgs()
+	args[0] = []byte("To")
+	_, err = issue.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, args...))
+	assert.True(t, errors.Is(err, ErrInvalidTokenName))
+
+	args[0] = []byte("Token-1")
+	_, err = issue.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, args...))
+	assert.True(t, errors.Is(err, ErrInvalidTokenName))
+
+	args = validArgs()
+	args[1] = []byte("tkn")
+	_, err = issue.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, args...))
+	assert.True(t, errors.Is(err, ErrInvalidTokenTicker))
+
+	args = validArgs()
+	args[3] = []byte{19}
+	_, err = issue.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, args...))
+	assert.True(t, errors.Is(err, ErrInvalidNumberOfDecimals))
+
+	args = append(validArgs(), []byte(canFreezeProperty))
+	_, err = issue.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, args...))
+	assert.True(t, errors.Is(err, ErrInvalidNumOfArgs))
+
+	args = append(validArgs(), []byte("canMint"), []byte("true"))
+	_, err = issue.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, args...))
+	assert.True(t, errors.Is(err, ErrInvalidTokenProperty))
+
+	args = append(validArgs(), []byte(canFreezeProperty), []byte("yes"))
+	_, err = issue.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, args...))
+	assert.True(t, errors.Is(err, ErrInvalidTokenProperty))
+
+	_, err = issue.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, validArgs()...))
+	require.Nil(t, err)
+	_, err = issue.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, validArgs()...))
+	assert.True(t, errors.Is(err, ErrTokenAlreadyIssued))
+}
+
+func TestESDTNativeIssue_ProcessBuiltinFunctionFungible(t *testing.T) {
+	t.Parallel()
+
+	args := createMockESDTNativeTokenFuncArgs()
+	issue, _ := NewESDTNativeIssueFunc(args, vmcommon.BuiltInFunctionESDTNativeIssue)
+	owner := mock.NewUserAccount(nativeTokenOwner)
+
+	input := createESDTNativeTokenInput(nativeTokenOwner, []byte("Token"), []byte("TKN"), big.NewInt(1000).Bytes(), []byte{6}, []byte(canFreezeProperty), []byte("true"))
+	vmOutput, err := issue.ProcessBuiltinFunction(owner, nil, input)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(90), vmOutput.GasRemaining)
+
+	tokenID := vmOutput.ReturnData[0]
+	assert.True(t, bytes.HasPrefix(tokenID, []byte("sov1-TKN-")))
+	assert.True(t, vmcommon.ValidatePrefixedToken(tokenID))
+
+	esdtData, err := getESDTDataFromKey(owner, append([]byte(baseESDTKeyPrefix), tokenID...), args.Marshaller)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(1000), esdtData.Value)
+
+	supply, err := getESDTSupplyHandler(args.EsdtStorageHandler).GetESDTSupply(tokenID)
+	require.Nil(t, err)
+	assert.Equal(t, &vmcommon.ESDTSupply{Supply: big.NewInt(1000), Minted: big.NewInt(1000), Burned: big.NewInt(0), MaxSupply: big.NewInt(0)}, supply)
+
+	rolesHandler, _ := NewESDTRolesFunc(args.Marshaller, &mock.CrossChainTokenCheckerMock{}, true)
+	assert.Nil(t, rolesHandler.CheckAllowedToExecute(owner, tokenID, []byte(core.ESDTRoleLocalMint)))
+	assert.Nil(t, rolesHandler.CheckAllowedToExecute(owner, tokenID, []byte(core.ESDTRoleLocalBurn)))
+
+	tokenType, err := args.GlobalSettingsHandler.GetTokenType(append([]byte(baseESDTKeyPrefix), tokenID...))
+	require.Nil(t, err)
+	assert.Equal(t, uint32(core.Fungible), tokenType)
+
+	systemAcc, properties, err := checkESDTTokenOwner(args.Accounts, tokenID, nativeTokenOwner)
+	require.Nil(t, err)
+	require.NotNil(t, systemAcc)
+	assert.Equal(t, &ESDTTokenProperties{CanFreeze: true, CanUpgrade: true, CanAddSpecialRoles: true, NumDecimals: 6}, properties)
+
+	require.Len(t, vmOutput.Logs, 1)
+	assert.Equal(t, []byte(vmcommon.BuiltInFunctionESDTNativeIssue), vmOutput.Logs[0].Identifier)
+	assert.Equal(t, nativeTokenOwner, vmOutput.Logs[0].Address)
+	assert.Equal(t, [][]byte{tokenID, {}, big.NewInt(1000).Bytes(), []byte("Token"), []byte("TKN"), []byte(core.FungibleESDT)}, vmOutput.Logs[0].Topics)
+}
+
+func TestESDTNativeIssue_ProcessBuiltinFunctionCollection(t *testing.T) {
+	t.Parallel()
+
+	t.Run("invalid collection type should error", func(t *testing.T) {
+		t.Parallel()
+
+		issue, _ := NewESDTNativeIssueFunc(createMockESDTNativeTokenFuncArgs(), vmcommon.BuiltInFunctionESDTNativeIssueCollection)
+		owner := mock.NewUserAccount(nativeTokenOwner)
+
+		input := createESDTNativeTokenInput(nativeTokenOwner, []byte("Collection"), []byte("COL"), []byte(core.FungibleESDT), []byte{})
+		_, err := issue.ProcessBuiltinFunction(owner, nil, input)
+		assert.True(t, errors.Is(err, ErrInvalidArguments))
+
+		input = createESDTNativeTokenInput(nativeTokenOwner, []byte("Collection"), []byte("COL"), []byte(core.NonFungibleESDTv2), []byte{2})
+		_, err = issue.ProcessBuiltinFunction(owner, nil, input)
+		assert.True(t, errors.Is(err, ErrInvalidNumberOfDecimals))
+	})
+	t.Run("semi fungible collection should work", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockESDTNativeTokenFuncArgs()
+		args.SelfESDTPrefix = nil
+		issue, _ := NewESDTNativeIssueFunc(args, vmcommon.BuiltInFunctionESDTNativeIssueCollection)
+		owner := mock.NewUserAccount(nativeTokenOwner)
+
+		input := createESDTNativeTokenInput(nativeTokenOwner, []byte("Collection"), []byte("COL"), []byte(core.SemiFungibleESDT), []byte{})
+		vmOutput, err := issue.ProcessBuiltinFunction(owner, nil, input)
+		require.Nil(t, err)
+
+		tokenID := vmOutput.ReturnData[0]
+		assert.True(t, bytes.HasPrefix(tokenID, []byte("COL-")))
+		assert.True(t, vmcommon.ValidatePrefixedToken(tokenID))
+
+		roles, _, err := getESDTRolesForAcnt(args.Marshaller, owner, append(roleKeyPrefix, tokenID...))
+		require.Nil(t, err)
+		expectedRoles := [][]byte{[]byte(core.ESDTRoleNFTCreate), []byte(core.ESDTRoleNFTBurn), []byte(core.ESDTRoleNFTAddQuantity)}
+		assert.Equal(t, &esdt.ESDTRoles{Roles: expectedRoles}, roles)
+
+		tokenType, err := args.GlobalSettingsHandler.GetTokenType(append([]byte(baseESDTKeyPrefix), tokenID...))
+		require.Nil(t, err)
+		assert.Equal(t, uint32(core.SemiFungible), tokenType)
+		assert.Equal(t, []byte(core.SemiFungibleESDT), vmOutput.Logs[0].Topics[5])
+	})
+}
+
+func TestESDTNativeSetTokenProperties_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	args := createMockESDTNativeTokenFuncArgs()
+	owner := mock.NewUserAccount(nativeTokenOwner)
+	tokenID := issueNativeToken(t, args, owner)
+	setProperties, _ := NewESDTNativeSetTokenPropertiesFunc(args)
+
+	_, err := setProperties.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, tokenID, []byte(canPauseProperty)))
+	assert.Equal(t, ErrInvalidNumOfArgs, err)
+
+	_, err = setProperties.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, []byte("sov1-ABC-123456"), []byte(canPauseProperty), []byte("true")))
+	assert.True(t, errors.Is(err, ErrTokenNotIssuedNatively))
+
+	notOwner := mock.NewUserAccount(nativeTokenNewOwner)
+	_, err = setProperties.ProcessBuiltinFunction(notOwner, nil, createESDTNativeTokenInput(nativeTokenNewOwner, tokenID, []byte(canPauseProperty), []byte("true")))
+	assert.Equal(t, ErrCallerIsNotTokenOwner, err)
+
+	input := createESDTNativeTokenInput(nativeTokenOwner, tokenID, []byte(canPauseProperty), []byte("true"), []byte(canUpgradeProperty), []byte("false"))
+	vmOutput, err := setProperties.ProcessBuiltinFunction(owner, nil, input)
+	require.Nil(t, err)
+	assert.Equal(t, [][]byte{tokenID, {}, {}, []byte(canPauseProperty), []byte("true"), []byte(canUpgradeProperty), []byte("false")}, vmOutput.Logs[0].Topics)
+
+	_, properties, err := checkESDTTokenOwner(args.Accounts, tokenID, nativeTokenOwner)
+	require.Nil(t, err)
+	assert.Equal(t, &ESDTTokenProperties{CanPause: true, CanAddSpecialRoles: true, NumDecimals: 6}, properties)
+
+	_, err = setProperties.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, tokenID, []byte(canUpgradeProperty), []byte("true")))
+	assert.Equal(t, ErrTokenCannotBeUpgraded, err)
+}
+
+func TestESDTNativeTransferOwnership_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	t.Run("token without change owner property should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockESDTNativeTokenFuncArgs()
+		owner := mock.NewUserAccount(nativeTokenOwner)
+		tokenID := issueNativeToken(t, args, owner)
+		transferOwnership, _ := NewESDTNativeTransferOwnershipFunc(args)
+
+		_, err := transferOwnership.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, tokenID, nativeTokenNewOwner))
+		assert.Equal(t, ErrTokenOwnerCannotBeChanged, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockESDTNativeTokenFuncArgs()
+		owner := mock.NewUserAccount(nativeTokenOwner)
+		tokenID := issueNativeToken(t, args, owner, []byte(canChangeOwnerProperty), []byte("true"))
+		transferOwnership, _ := NewESDTNativeTransferOwnershipFunc(args)
+
+		_, err := transferOwnership.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, tokenID, nativeTokenNewOwner, []byte("extra")))
+		assert.Equal(t, ErrInvalidNumOfArgs, err)
+
+		_, err = transferOwnership.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, tokenID, []byte("short")))
+		assert.Equal(t, ErrInvalidAddressLength, err)
+
+		vmOutput, err := transferOwnership.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, tokenID, nativeTokenNewOwner))
+		require.Nil(t, err)
+		assert.Equal(t, nativeTokenOwner, vmOutput.Logs[0].Address)
+		assert.Equal(t, [][]byte{tokenID, {}, {}, nativeTokenNewOwner}, vmOutput.Logs[0].Topics)
+
+		_, _, err = checkESDTTokenOwner(args.Accounts, tokenID, nativeTokenOwner)
+		assert.Equal(t, ErrCallerIsNotTokenOwner, err)
+		_, _, err = checkESDTTokenOwner(args.Accounts, tokenID, nativeTokenNewOwner)
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,101 @@
+package builtInFunctions
+
+import (
+	"math/big"
+	"sync"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const numArgsESDTNativeTransferOwnership = 2
+
+type esdtNativeTransferOwnership struct {
+	baseActiveHandler
+	accounts     vmcommon.AccountsAdapter
+	funcGasCost  uint64
+	mutExecution sync.RWMutex
+}
+
+// NewESDTNativeTransferOwnershipFunc returns the esdt native transfer ownership built-in function component
+func NewESDTNativeTransferOwnershipFunc(args ESDTNativeTokenFuncArgs) (*esdtNativeTransferOwnership, error) {
+	err := checkESDTNativeTokenFuncArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &esdtNativeTransferOwnership{
+		accounts:     args.Accounts,
+		funcGasCost:  args.FuncGasCost,
+		mutExecution: sync.RWMutex{},
+	}
+	e.activeHandler = func() bool {
+		return args.EnableEpochsHandler.IsFlagEnabled(ESDTNativeIssuanceFlag)
+	}
+
+	return e, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (e *esdtNativeTransferOwnership) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	e.mutExecution.Lock()
+	e.funcGasCost = gasCost.BuiltInCost.ESDTNativeTransferOwnership
+	e.mutExecution.Unlock()
+}
+
+// ProcessBuiltinFunction resolves ESDT native transfer ownership function call. Only the owner of a natively issued
+// token which allows changing the owner can transfer the ownership. The roles of the previous owner are not changed
+// Requires 2 arguments:
+// arg0 - token identifier
+// arg1 - new owner address
+func (e *esdtNativeTransferOwnership) ProcessBuiltinFunction(
+	acntSnd, _ vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	err := checkESDTNativeTokenInput(acntSnd, vmInput, numArgsESDTNativeTransferOwnership, e.funcGasCost)
+	if err != nil {
+		return nil, err
+	}
+	if len(vmInput.Arguments) != numArgsESDTNativeTransferOwnership {
+		return nil, ErrInvalidNumOfArgs
+	}
+
+	tokenID := vmInput.Arguments[0]
+	newOwner := vmInput.Arguments[1]
+	if len(newOwner) != len(vmInput.CallerAddr) {
+		return nil, ErrInvalidAddressLength
+	}
+
+	systemAcc, properties, err := checkESDTTokenOwner(e.accounts, tokenID, vmInput.CallerAddr)
+	if err != nil {
+		return nil, err
+	}
+	if !properties.CanChangeOwner {
+		return nil, ErrTokenOwnerCannotBeChanged
+	}
+
+	err = systemAcc.AccountDataHandler().SaveKeyValue(getESDTTokenOwnerKey(tokenID), newOwner)
+	if err != nil {
+		return nil, err
+	}
+	err = e.accounts.SaveAccount(systemAcc)
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: vmInput.GasProvided - e.funcGasCost}
+	addESDTEntryInVMOutput(vmOutput, []byte(vmcommon.BuiltInFunctionESDTNativeTransferOwnership), tokenID, 0, big.NewInt(0), vmInput.CallerAddr, newOwner)
+
+	return vmOutput, nil
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (e *esdtNativeTransferOwnership) IsInterfaceNil() bool {
+	return e == nil
+}
@@ -0,0 +1,106 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"math/big"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const numArgsESDTReleaseVesting = 1
+
+type esdtReleaseVesting struct {
+	baseActiveHandler
+	vestingHandler ESDTVestingHandler
+	funcGasCost    uint64
+	mutExecution   sync.RWMutex
+}
+
+// NewESDTReleaseVestingFunc returns the esdt release vesting built-in function component, which removes the vesting
+// schedules of the caller that reached their unlock round
+func NewESDTReleaseVestingFunc(args ESDTVestingFuncArgs) (*esdtReleaseVesting, error) {
+	err := checkESDTVestingFuncArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &esdtReleaseVesting{
+		vestingHandler: args.VestingHandler,
+		funcGasCost:    args.FuncGasCost,
+		mutExecution:   sync.RWMutex{},
+	}
+
+	e.baseActiveHandler.activeHandler = func() bool {
+		return args.EnableEpochsHandler.IsFlagEnabled(ESDTVestingFlag)
+	}
+
+	return e, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (e *esdtReleaseVesting) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	e.mutExecution.Lock()
+	e.funcGasCost = gasCost.BuiltInCost.ESDTReleaseVesting
+	e.mutExecution.Unlock()
+}
+
+// ProcessBuiltinFunction resolves ESDT release vesting function call. An event is emitted for each released schedule
+// Requires 1 argument:
+// arg0 - token identifier
+func (e *esdtReleaseVesting) ProcessBuiltinFunction(
+	acntSnd, _ vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if vmInput.CallValue == nil {
+		return nil, ErrNilValue
+	}
+	if vmInput.CallValue.Cmp(zero) != 0 {
+		return nil, ErrBuiltInFunctionCalledWithValue
+	}
+	if len(vmInput.Arguments) != numArgsESDTReleaseVesting {
+		return nil, ErrInvalidNumOfArgs
+	}
+	if !bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) {
+		return nil, ErrInvalidRcvAddr
+	}
+	if check.IfNil(acntSnd) {
+		return nil, ErrNilUserAccount
+	}
+	if vmInput.GasProvided < e.funcGasCost {
+		return nil, ErrNotEnoughGas
+	}
+
+	tokenID := vmInput.Arguments[0]
+	released, err := e.vestingHandler.ReleaseUnlockedSchedules(acntSnd, tokenID)
+	if err != nil {
+		return nil, err
+	}
+	if len(released) == 0 {
+		return nil, ErrNoUnlockedVestingSchedules
+	}
+
+	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: vmInput.GasProvided - e.funcGasCost}
+	for _, schedule := range released {
+		unlockRound := big.NewInt(0).SetUint64(schedule.UnlockRound).Bytes()
+		addESDTEntryInVMOutput(vmOutput, []byte(vmcommon.BuiltInFunctionESDTReleaseVesting), tokenID, 0, schedule.Amount, vmInput.CallerAddr, unlockRound)
+	}
+
+	return vmOutput, nil
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (e *esdtReleaseVesting) IsInterfaceNil() bool {
+	return e == nil
+}
@@ -202,7 +202,7 @@ func (e *esdtRoles) isAllowedToExecuteCrossChain(address []byte, tokenID []byte,
 		return false
 	}
 
-	return e.crossChainTokenChecker.IsCrossChainOperationAllowed(address, tokenID)
+	return isCrossChainActionAllowed(e.crossChainTokenChecker, address, tokenID, action)
 }
 
 // IsInterfaceNil returns true if underlying object in nil
@@ -426,3 +426,41 @@ func TestEsdtRoles_isAllowedToExecuteCrossChain(t *testing.T) {
 	require.False(t, isAllowed)
 	require.Equal(t, 2, isCrossChainOperationAllowedCt)
 }
+
+func TestEsdtRoles_isAllowedToExecuteCrossChainDeniedByPrefixPolicy(t *testing.T) {
+	ctc := &mock.CrossChainTokenCheckerMock{
+		IsCrossChainActionAllowedCalled: func(address []byte, tokenID []byte, action []byte) bool {
+			return !bytes.Equal(action, []byte(core.ESDTRoleNFTAddQuantity))
+		},
+	}
+	esdtRolesF, _ := NewESDTRolesFunc(&mock.MarshalizerMock{}, ctc, false)
+
+	isAllowed := esdtRolesF.isAllowedToExecuteCrossChain([]byte("addr"), []byte("token"), []byte(core.ESDTRoleLocalBurn))
+	require.True(t, isAllowed)
+
+	isAllowed = esdtRolesF.isAllowedToExecuteCrossChain([]byte("addr"), []byte("token"), []byte(core.ESDTRoleNFTAddQuantity))
+	require.False(t, isAllowed)
+}
+
+func TestEsdtRoles_isAllowedToExecuteCrossChainWithoutPrefixPolicies(t *testing.T) {
+	ctc := struct {
+		CrossChainTokenCheckerHandler
+	}{
+		CrossChainTokenCheckerHandler: &mock.CrossChainTokenCheckerMock{
+			IsCrossChainOperationAllowedCalled: func(address []byte, tokenID []byte) bool {
+				return bytes.Equal(tokenID, []byte("token"))
+			},
+			IsCrossChainActionAllowedCalled: func(address []byte, tokenID []byte, action []byte) bool {
+				require.Fail(t, "the checker does not enforce prefix policies")
+				return false
+			},
+		},
+	}
+	esdtRolesF, _ := NewESDTRolesFunc(&mock.MarshalizerMock{}, ctc, false)
+
+	isAllowed := esdtRolesF.isAllowedToExecuteCrossChain([]byte("addr"), []byte("token"), []byte(core.ESDTRoleNFTAddQuantity))
+	require.True(t, isAllowed)
+
+	isAllowed = esdtRolesF.isAllowedToExecuteCrossChain([]byte("addr"), []byte("other"), []byte(core.ESDTRoleNFTAddQuantity))
+	require.False(t, isAllowed)
+}
@@ -0,0 +1,122 @@
+package builtInFunctions
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const numArgsESDTSetMaxSupply = 3
+
+type esdtSetMaxSupply struct {
+	baseActiveHandler
+	accounts     vmcommon.AccountsAdapter
+	funcGasCost  uint64
+	mutExecution sync.RWMutex
+}
+
+// NewESDTSetMaxSupplyFunc returns the esdt set max supply built-in function component. The max supply can be set
+// by the esdt system smart contract or by the owner of a natively issued token
+func NewESDTSetMaxSupplyFunc(
+	funcGasCost uint64,
+	accounts vmcommon.AccountsAdapter,
+	activeHandler func() bool,
+) (*esdtSetMaxSupply, error) {
+	if check.IfNil(accounts) {
+		return nil, ErrNilAccountsAdapter
+	}
+	if activeHandler == nil {
+		return nil, ErrNilActiveHandler
+	}
+
+	e := &esdtSetMaxSupply{
+		accounts:     accounts,
+		funcGasCost:  funcGasCost,
+		mutExecution: sync.RWMutex{},
+	}
+
+	e.baseActiveHandler.activeHandler = activeHandler
+
+	return e, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (e *esdtSetMaxSupply) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	e.mutExecution.Lock()
+	e.funcGasCost = gasCost.BuiltInCost.ESDTSetMaxSupply
+	e.mutExecution.Unlock()
+}
+
+// ProcessBuiltinFunction saves the max supply of the token in the system account. A zero max supply removes the cap.
+// The supply counters only track the operations executed after the activation of the supply tracking, so the caller
+// has to confirm the current supply of the token, which becomes the base of the supply counters
+// Requires 3 arguments:
+// arg0 - token identifier
+// arg1 - max supply, which can not be lower than the current supply
+// arg2 - current supply
+func (e *esdtSetMaxSupply) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if vmInput.CallValue == nil {
+		return nil, ErrNilValue
+	}
+	if vmInput.CallValue.Cmp(zero) != 0 {
+		return nil, ErrBuiltInFunctionCalledWithValue
+	}
+	if len(vmInput.Arguments) != numArgsESDTSetMaxSupply {
+		return nil, ErrInvalidNumOfArgs
+	}
+	if len(vmInput.Arguments[1]) > core.MaxLenForESDTIssueMint || len(vmInput.Arguments[2]) > core.MaxLenForESDTIssueMint {
+		return nil, fmt.Errorf("%w: max length for esdt max supply and current supply is %d", ErrInvalidArguments, core.MaxLenForESDTIssueMint)
+	}
+
+	tokenID := vmInput.Arguments[0]
+	maxSupply := big.NewInt(0).SetBytes(vmInput.Arguments[1])
+	currentSupply := big.NewInt(0).SetBytes(vmInput.Arguments[2])
+	if maxSupply.Sign() > 0 && currentSupply.Cmp(maxSupply) > 0 {
+		return nil, fmt.Errorf("%w, current supply %s is higher than %s", ErrInvalidMaxSupply, currentSupply, maxSupply)
+	}
+
+	systemAcc, gasRemaining, err := getSystemAccountForTokenManager(e.accounts, acntSnd, acntDst, vmInput, numArgsESDTSetMaxSupply, e.funcGasCost)
+	if err != nil {
+		return nil, err
+	}
+
+	err = saveConfirmedESDTSupply(systemAcc, tokenID, currentSupply)
+	if err != nil {
+		return nil, err
+	}
+	err = systemAcc.AccountDataHandler().SaveKeyValue(getESDTMaxSupplyKey(tokenID), maxSupply.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	err = e.accounts.SaveAccount(systemAcc)
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: gasRemaining}
+	addESDTEntryInVMOutput(vmOutput, []byte(vmcommon.BuiltInFunctionESDTSetMaxSupply), tokenID, 0, maxSupply, vmInput.CallerAddr)
+
+	return vmOutput, nil
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (e *esdtSetMaxSupply) IsInterfaceNil() bool {
+	return e == nil
+}
@@ -0,0 +1,154 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createMockESDTNativeTokenFuncArgsWithMaxSupply() ESDTNativeTokenFuncArgs {
+	args := createMockESDTNativeTokenFuncArgs()
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return flag == ESDTSupplyTrackingFlag || flag == ESDTMaxSupplyFlag
+		},
+	}
+	args.EsdtStorageHandler = createNewESDTDataStorageHandlerWithArgs(args.GlobalSettingsHandler, args.Accounts, enableEpochsHandler, &mock.CrossChainTokenCheckerMock{})
+
+	return args
+}
+
+func TestNewESDTSetMaxSupplyFunc(t *testing.T) {
+	t.Parallel()
+
+	args := createMockESDTNativeTokenFuncArgsWithMaxSupply()
+
+	setMaxSupply, err := NewESDTSetMaxSupplyFunc(10, nil, trueHandler)
+	assert.Nil(t, setMaxSupply)
+	assert.Equal(t, ErrNilAccountsAdapter, err)
+
+	setMaxSupply, err = NewESDTSetMaxSupplyFunc(10, args.Accounts, nil)
+	assert.Nil(t, setMaxSupply)
+	assert.Equal(t, ErrNilActiveHandler, err)
+
+	setMaxSupply, err = NewESDTSetMaxSupplyFunc(10, args.Accounts, trueHandler)
+	assert.Nil(t, err)
+	assert.False(t, setMaxSupply.IsInterfaceNil())
+	assert.True(t, setMaxSupply.IsActive())
+
+	setMaxSupply.SetNewGasConfig(&vmcommon.GasCost{BuiltInCost: vmcommon.BuiltInCost{ESDTSetMaxSupply: 20}})
+	assert.Equal(t, uint64(20), setMaxSupply.funcGasCost)
+}
+
+func TestESDTSetMaxSupply_ProcessBuiltinFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	args := createMockESDTNativeTokenFuncArgsWithMaxSupply()
+	owner := mock.NewUserAccount(nativeTokenOwner)
+	tokenID := issueNativeToken(t, args, owner)
+	setMaxSupply, _ := NewESDTSetMaxSupplyFunc(10, args.Accounts, trueHandler)
+
+	_, err := setMaxSupply.ProcessBuiltinFunction(owner, nil, nil)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	input := createESDTNativeTokenInput(nativeTokenOwner, tokenID, big.NewInt(2000).Bytes(), big.NewInt(1000).Bytes())
+	input.CallValue = big.NewInt(1)
+	_, err = setMaxSupply.ProcessBuiltinFunction(owner, nil, input)
+	assert.Equal(t, ErrBuiltInFunctionCalledWithValue, err)
+
+	_, err = setMaxSupply.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, tokenID))
+	assert.Equal(t, ErrInvalidNumOfArgs, err)
+
+	_, err = setMaxSupply.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, tokenID, big.NewInt(2000).Bytes(), make([]byte, core.MaxLenForESDTIssueMint+1)))
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
+
+	input = createESDTNativeTokenInput(core.ESDTSCAddress, tokenID, big.NewInt(2000).Bytes(), big.NewInt(1000).Bytes())
+	input.RecipientAddr = nativeTokenOwner
+	_, err = setMaxSupply.ProcessBuiltinFunction(nil, owner, input)
+	assert.Equal(t, ErrOnlySystemAccountAccepted, err)
+
+	notOwner := mock.NewUserAccount(nativeTokenNewOwner)
+	_, err = setMaxSupply.ProcessBuiltinFunction(notOwner, nil, createESDTNativeTokenInput(nativeTokenNewOwner, tokenID, big.NewInt(2000).Bytes(), big.NewInt(1000).Bytes()))
+	assert.Equal(t, ErrCallerIsNotTokenOwner, err)
+
+	_, err = setMaxSupply.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, tokenID, big.NewInt(999).Bytes(), big.NewInt(1000).Bytes()))
+	assert.True(t, errors.Is(err, ErrInvalidMaxSupply))
+
+	setProperties, _ := NewESDTNativeSetTokenPropertiesFunc(args)
+	_, err = setProperties.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, tokenID, []byte(canUpgradeProperty), []byte("false")))
+	require.Nil(t, err)
+	_, err = setMaxSupply.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, tokenID, big.NewInt(2000).Bytes(), big.NewInt(1000).Bytes()))
+	assert.Equal(t, ErrTokenCannotBeUpgraded, err)
+}
+
+func TestESDTSetMaxSupply_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	args := createMockESDTNativeTokenFuncArgsWithMaxSupply()
+	supplyHandler := args.EsdtStorageHandler.(vmcommon.ESDTSupplyHandler)
+	owner := mock.NewUserAccount(nativeTokenOwner)
+	tokenID := issueNativeToken(t, args, owner)
+	setMaxSupply, _ := NewESDTSetMaxSupplyFunc(10, args.Accounts, trueHandler)
+
+	vmOutput, err := setMaxSupply.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, tokenID, big.NewInt(1500).Bytes(), big.NewInt(1000).Bytes()))
+	require.Nil(t, err)
+	assert.Equal(t, uint64(90), vmOutput.GasRemaining)
+	require.Len(t, vmOutput.Logs, 1)
+	assert.Equal(t, []byte(vmcommon.BuiltInFunctionESDTSetMaxSupply), vmOutput.Logs[0].Identifier)
+	assert.Equal(t, [][]byte{tokenID, {}, big.NewInt(1500).Bytes()}, vmOutput.Logs[0].Topics)
+
+	supply, err := supplyHandler.GetESDTSupply(tokenID)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(1500), supply.MaxSupply)
+
+	err = supplyHandler.AddToSupplySystemAcc(tokenID, big.NewInt(501))
+	assert.True(t, errors.Is(err, ErrMaxSupplyExceeded))
+	assert.Nil(t, supplyHandler.AddToSupplySystemAcc(tokenID, big.NewInt(500)))
+
+	input := createESDTNativeTokenInput(core.ESDTSCAddress, tokenID, []byte{}, big.NewInt(1500).Bytes())
+	input.RecipientAddr = vmcommon.SystemAccountAddress
+	vmOutput, err = setMaxSupply.ProcessBuiltinFunction(nil, nil, input)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(100), vmOutput.GasRemaining)
+
+	supply, err = supplyHandler.GetESDTSupply(tokenID)
+	require.Nil(t, err)
+	assert.Equal(t, &vmcommon.ESDTSupply{Supply: big.NewInt(1500), Minted: big.NewInt(0), Burned: big.NewInt(0), MaxSupply: big.NewInt(0)}, supply)
+	assert.Nil(t, supplyHandler.AddToSupplySystemAcc(tokenID, big.NewInt(1000)))
+}
+
+func TestESDTSetMaxSupply_ProcessBuiltinFunctionSeedsTheSupplyOfOlderTokens(t *testing.T) {
+	t.Parallel()
+
+	args := createMockESDTNativeTokenFuncArgsWithMaxSupply()
+	supplyHandler := args.EsdtStorageHandler.(vmcommon.ESDTSupplyHandler)
+	setMaxSupply, _ := NewESDTSetMaxSupplyFunc(10, args.Accounts, trueHandler)
+
+	// the token was issued and minted before the supply tracking, so the counters of the shard are empty
+	tokenID := []byte("TKN-abcdef")
+	assert.Nil(t, supplyHandler.AddToSupplySystemAcc(tokenID, big.NewInt(-300)))
+
+	input := createESDTNativeTokenInput(core.ESDTSCAddress, tokenID, big.NewInt(1200).Bytes(), big.NewInt(1300).Bytes())
+	input.RecipientAddr = vmcommon.SystemAccountAddress
+	_, err := setMaxSupply.ProcessBuiltinFunction(nil, nil, input)
+	assert.True(t, errors.Is(err, ErrInvalidMaxSupply))
+
+	input.Arguments[2] = big.NewInt(1000).Bytes()
+	_, err = setMaxSupply.ProcessBuiltinFunction(nil, nil, input)
+	require.Nil(t, err)
+
+	err = supplyHandler.AddToSupplySystemAcc(tokenID, big.NewInt(201))
+	assert.True(t, errors.Is(err, ErrMaxSupplyExceeded))
+	assert.Nil(t, supplyHandler.AddToSupplySystemAcc(tokenID, big.NewInt(-50)))
+	assert.Nil(t, supplyHandler.AddToSupplySystemAcc(tokenID, big.NewInt(250)))
+
+	supply, err := supplyHandler.GetESDTSupply(tokenID)
+	require.Nil(t, err)
+	assert.Equal(t, &vmcommon.ESDTSupply{Supply: big.NewInt(1200), Minted: big.NewInt(250), Burned: big.NewInt(50), MaxSupply: big.NewInt(1200)}, supply)
+}
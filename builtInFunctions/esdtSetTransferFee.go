@@ -0,0 +1,158 @@
+package builtInFunctions
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const (
+	numArgsESDTRemoveTransferFee = 1
+	minNumArgsESDTSetTransferFee = 4
+)
+
+type esdtSetTransferFee struct {
+	baseActiveHandler
+	accounts     vmcommon.AccountsAdapter
+	funcGasCost  uint64
+	mutExecution sync.RWMutex
+}
+
+// NewESDTSetTransferFeeFunc returns the esdt set transfer fee built-in function component. The transfer fee policy
+// can be set by the esdt system smart contract or by the owner of a natively issued token
+func NewESDTSetTransferFeeFunc(
+	funcGasCost uint64,
+	accounts vmcommon.AccountsAdapter,
+	enableEpochsHandler vmcommon.EnableEpochsHandler,
+) (*esdtSetTransferFee, error) {
+	if check.IfNil(accounts) {
+		return nil, ErrNilAccountsAdapter
+	}
+	if check.IfNil(enableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+
+	e := &esdtSetTransferFee{
+		accounts:     accounts,
+		funcGasCost:  funcGasCost,
+		mutExecution: sync.RWMutex{},
+	}
+
+	e.baseActiveHandler.activeHandler = func() bool {
+		return enableEpochsHandler.IsFlagEnabled(ESDTTransferFeeFlag)
+	}
+
+	return e, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (e *esdtSetTransferFee) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	e.mutExecution.Lock()
+	e.funcGasCost = gasCost.BuiltInCost.ESDTSetTransferFee
+	e.mutExecution.Unlock()
+}
+
+// ProcessBuiltinFunction saves the transfer fee policy of the token in the system account. The policy is charged on
+// the transfers of fungible tokens only. Calling it with the token identifier only removes the policy
+// Requires 1 or at least 4 arguments:
+// arg0 - token identifier
+// arg1 - fee percentage in basis points, at most 10000
+// arg2 - fixed fee amount
+// arg3 - fee receiver address
+// arg4... - addresses exempted from paying the fee
+func (e *esdtSetTransferFee) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if vmInput.CallValue == nil {
+		return nil, ErrNilValue
+	}
+	if vmInput.CallValue.Cmp(zero) != 0 {
+		return nil, ErrBuiltInFunctionCalledWithValue
+	}
+	numArgs := len(vmInput.Arguments)
+	if numArgs != numArgsESDTRemoveTransferFee && numArgs < minNumArgsESDTSetTransferFee {
+		return nil, ErrInvalidNumOfArgs
+	}
+
+	var policyBytes []byte
+	if numArgs > numArgsESDTRemoveTransferFee {
+		policy, err := createESDTTransferFeePolicy(vmInput.Arguments[1:], len(vmInput.CallerAddr))
+		if err != nil {
+			return nil, err
+		}
+
+		policyBytes = policy.ToBytes()
+	}
+
+	systemAcc, gasRemaining, err := getSystemAccountForTokenManager(e.accounts, acntSnd, acntDst, vmInput, numArgsESDTRemoveTransferFee, e.funcGasCost)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenID := vmInput.Arguments[0]
+	err = systemAcc.AccountDataHandler().SaveKeyValue(getESDTTransferFeeKey(tokenID), policyBytes)
+	if err != nil {
+		return nil, err
+	}
+	err = e.accounts.SaveAccount(systemAcc)
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput := &vmcommon.VMOutput{ReturnCode: vmcommon.Ok, GasRemaining: gasRemaining}
+	logData := append([][]byte{vmInput.CallerAddr}, vmInput.Arguments[1:]...)
+	addESDTEntryInVMOutput(vmOutput, []byte(vmcommon.BuiltInFunctionESDTSetTransferFee), tokenID, 0, big.NewInt(0), logData...)
+
+	return vmOutput, nil
+}
+
+func createESDTTransferFeePolicy(args [][]byte, addressLength int) (*ESDTTransferFeePolicy, error) {
+	basisPoints := big.NewInt(0).SetBytes(args[0])
+	if basisPoints.Cmp(big.NewInt(maxTransferFeeBasisPoints)) > 0 {
+		return nil, fmt.Errorf("%w, fee percentage can be at most %d basis points", ErrInvalidTransferFeePolicy, maxTransferFeeBasisPoints)
+	}
+	if len(args[1]) > core.MaxLenForESDTIssueMint {
+		return nil, fmt.Errorf("%w: max length for the fixed fee is %d", ErrInvalidArguments, core.MaxLenForESDTIssueMint)
+	}
+	fixedAmount := big.NewInt(0).SetBytes(args[1])
+	if basisPoints.Sign() == 0 && fixedAmount.Sign() == 0 {
+		return nil, fmt.Errorf("%w, zero fee", ErrInvalidTransferFeePolicy)
+	}
+
+	exemptAddresses := args[3:]
+	if len(exemptAddresses) > maxNumTransferFeeExemptAddresses {
+		return nil, fmt.Errorf("%w, at most %d exempt addresses are allowed", ErrInvalidTransferFeePolicy, maxNumTransferFeeExemptAddresses)
+	}
+	for _, address := range args[2:] {
+		if len(address) != addressLength {
+			return nil, fmt.Errorf("%w, not a valid address", ErrInvalidArguments)
+		}
+	}
+
+	return &ESDTTransferFeePolicy{
+		BasisPoints:     uint32(basisPoints.Uint64()),
+		FixedAmount:     fixedAmount,
+		FeeReceiver:     args[2],
+		ExemptAddresses: exemptAddresses,
+	}, nil
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (e *esdtSetTransferFee) IsInterfaceNil() bool {
+	return e == nil
+}
@@ -0,0 +1,133 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createTransferFeeEnableEpochsHandler() vmcommon.EnableEpochsHandler {
+	return &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return flag == ESDTTransferFeeFlag
+		},
+	}
+}
+
+func TestNewESDTSetTransferFeeFunc(t *testing.T) {
+	t.Parallel()
+
+	args := createMockESDTNativeTokenFuncArgs()
+
+	setTransferFee, err := NewESDTSetTransferFeeFunc(10, nil, createTransferFeeEnableEpochsHandler())
+	assert.Nil(t, setTransferFee)
+	assert.Equal(t, ErrNilAccountsAdapter, err)
+
+	setTransferFee, err = NewESDTSetTransferFeeFunc(10, args.Accounts, nil)
+	assert.Nil(t, setTransferFee)
+	assert.Equal(t, ErrNilEnableEpochsHandler, err)
+
+	setTransferFee, err = NewESDTSetTransferFeeFunc(10, args.Accounts, &mock.EnableEpochsHandlerStub{})
+	assert.Nil(t, err)
+	assert.False(t, setTransferFee.IsInterfaceNil())
+	assert.False(t, setTransferFee.IsActive())
+
+	setTransferFee.SetNewGasConfig(&vmcommon.GasCost{BuiltInCost: vmcommon.BuiltInCost{ESDTSetTransferFee: 20}})
+	assert.Equal(t, uint64(20), setTransferFee.funcGasCost)
+}
+
+func TestESDTSetTransferFee_ProcessBuiltinFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	args := createMockESDTNativeTokenFuncArgs()
+	owner := mock.NewUserAccount(nativeTokenOwner)
+	tokenID := issueNativeToken(t, args, owner)
+	setTransferFee, _ := NewESDTSetTransferFeeFunc(10, args.Accounts, createTransferFeeEnableEpochsHandler())
+	feeArgs := func(basisPoints int64, fixedAmount []byte, addresses ...[]byte) [][]byte {
+		return append([][]byte{tokenID, big.NewInt(basisPoints).Bytes(), fixedAmount}, addresses...)
+	}
+
+	_, err := setTransferFee.ProcessBuiltinFunction(owner, nil, nil)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	input := createESDTNativeTokenInput(nativeTokenOwner, tokenID)
+	input.CallValue = big.NewInt(1)
+	_, err = setTransferFee.ProcessBuiltinFunction(owner, nil, input)
+	assert.Equal(t, ErrBuiltInFunctionCalledWithValue, err)
+
+	_, err = setTransferFee.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, feeArgs(100, []byte{1})...))
+	assert.Equal(t, ErrInvalidNumOfArgs, err)
+
+	_, err = setTransferFee.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, feeArgs(maxTransferFeeBasisPoints+1, []byte{1}, nativeTokenNewOwner)...))
+	assert.True(t, errors.Is(err, ErrInvalidTransferFeePolicy))
+
+	_, err = setTransferFee.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, feeArgs(100, make([]byte, core.MaxLenForESDTIssueMint+1), nativeTokenNewOwner)...))
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
+
+	_, err = setTransferFee.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, feeArgs(0, []byte{}, nativeTokenNewOwner)...))
+	assert.True(t, errors.Is(err, ErrInvalidTransferFeePolicy))
+
+	_, err = setTransferFee.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, feeArgs(100, []byte{1}, []byte("short"))...))
+	assert.True(t, errors.Is(err, ErrInvalidArguments))
+
+	exemptAddresses := make([][]byte, maxNumTransferFeeExemptAddresses+2)
+	for i := range exemptAddresses {
+		exemptAddresses[i] = nativeTokenNewOwner
+	}
+	_, err = setTransferFee.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, feeArgs(100, []byte{1}, exemptAddresses...)...))
+	assert.True(t, errors.Is(err, ErrInvalidTransferFeePolicy))
+
+	input = createESDTNativeTokenInput(core.ESDTSCAddress, tokenID)
+	input.RecipientAddr = nativeTokenOwner
+	_, err = setTransferFee.ProcessBuiltinFunction(nil, owner, input)
+	assert.Equal(t, ErrOnlySystemAccountAccepted, err)
+
+	notOwner := mock.NewUserAccount(nativeTokenNewOwner)
+	_, err = setTransferFee.ProcessBuiltinFunction(notOwner, nil, createESDTNativeTokenInput(nativeTokenNewOwner, feeArgs(100, []byte{1}, nativeTokenNewOwner)...))
+	assert.Equal(t, ErrCallerIsNotTokenOwner, err)
+}
+
+func TestESDTSetTransferFee_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	args := createMockESDTNativeTokenFuncArgs()
+	owner := mock.NewUserAccount(nativeTokenOwner)
+	tokenID := issueNativeToken(t, args, owner)
+	setTransferFee, _ := NewESDTSetTransferFeeFunc(10, args.Accounts, createTransferFeeEnableEpochsHandler())
+
+	feeArgs := [][]byte{tokenID, big.NewInt(250).Bytes(), big.NewInt(3).Bytes(), nativeTokenNewOwner, nativeTokenOwner}
+	vmOutput, err := setTransferFee.ProcessBuiltinFunction(owner, nil, createESDTNativeTokenInput(nativeTokenOwner, feeArgs...))
+	require.Nil(t, err)
+	assert.Equal(t, uint64(90), vmOutput.GasRemaining)
+	require.Len(t, vmOutput.Logs, 1)
+	assert.Equal(t, []byte(vmcommon.BuiltInFunctionESDTSetTransferFee), vmOutput.Logs[0].Identifier)
+	assert.Equal(t, nativeTokenOwner, vmOutput.Logs[0].Address)
+	assert.Equal(t, append([][]byte{tokenID, {}, {}}, feeArgs[1:]...), vmOutput.Logs[0].Topics)
+
+	systemAcc, _ := getSystemAccount(args.Accounts)
+	policy, err := getESDTTransferFeePolicy(systemAcc, tokenID)
+	require.Nil(t, err)
+	expectedPolicy := &ESDTTransferFeePolicy{
+		BasisPoints:     250,
+		FixedAmount:     big.NewInt(3),
+		FeeReceiver:     nativeTokenNewOwner,
+		ExemptAddresses: [][]byte{nativeTokenOwner},
+	}
+	assert.Equal(t, expectedPolicy, policy)
+
+	input := createESDTNativeTokenInput(core.ESDTSCAddress, tokenID)
+	input.RecipientAddr = vmcommon.SystemAccountAddress
+	vmOutput, err = setTransferFee.ProcessBuiltinFunction(nil, nil, input)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(100), vmOutput.GasRemaining)
+
+	policy, err = getESDTTransferFeePolicy(systemAcc, tokenID)
+	assert.Nil(t, err)
+	assert.Nil(t, policy)
+}
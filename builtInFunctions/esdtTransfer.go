@@ -22,48 +22,81 @@ type esdtTransfer struct {
 	marshaller            vmcommon.Marshalizer
 	keyPrefix             []byte
 	globalSettingsHandler vmcommon.ExtendedESDTGlobalSettingsHandler
+	esdtSupplyHandler     vmcommon.ESDTSupplyHandler
 	payableHandler        vmcommon.PayableChecker
 	shardCoordinator      vmcommon.Coordinator
 	mutExecution          sync.RWMutex
 
-	rolesHandler        vmcommon.ESDTRoleHandler
-	enableEpochsHandler vmcommon.EnableEpochsHandler
+	rolesHandler          vmcommon.ESDTRoleHandler
+	enableEpochsHandler   vmcommon.EnableEpochsHandler
+	lockedBalanceHandler  ESDTLockedBalanceHandler
+	transferFeeHandler    ESDTTransferFeeHandler
+	spendingPolicyHandler GuardedSpendingPolicyHandler
+	accessListChecker     ESDTTransferAccessListChecker
+}
+
+// ESDTTransferFuncArgs holds the arguments needed to create the esdt transfer built-in function
+type ESDTTransferFuncArgs struct {
+	FuncGasCost           uint64
+	Marshaller            vmcommon.Marshalizer
+	ESDTSupplyHandler     vmcommon.ESDTSupplyHandler
+	GlobalSettingsHandler vmcommon.ExtendedESDTGlobalSettingsHandler
+	ShardCoordinator      vmcommon.Coordinator
+	RolesHandler          vmcommon.ESDTRoleHandler
+	EnableEpochsHandler   vmcommon.EnableEpochsHandler
+	LockedBalanceHandler  ESDTLockedBalanceHandler
+	TransferFeeHandler    ESDTTransferFeeHandler
+	SpendingPolicyHandler GuardedSpendingPolicyHandler
+	AccessListChecker     ESDTTransferAccessListChecker
 }
 
 // NewESDTTransferFunc returns the esdt transfer built-in function component
-func NewESDTTransferFunc(
-	funcGasCost uint64,
-	marshaller vmcommon.Marshalizer,
-	globalSettingsHandler vmcommon.ExtendedESDTGlobalSettingsHandler,
-	shardCoordinator vmcommon.Coordinator,
-	rolesHandler vmcommon.ESDTRoleHandler,
-	enableEpochsHandler vmcommon.EnableEpochsHandler,
-) (*esdtTransfer, error) {
-	if check.IfNil(marshaller) {
+func NewESDTTransferFunc(args ESDTTransferFuncArgs) (*esdtTransfer, error) {
+	if check.IfNil(args.Marshaller) {
 		return nil, ErrNilMarshalizer
 	}
-	if check.IfNil(globalSettingsHandler) {
+	if check.IfNil(args.ESDTSupplyHandler) {
+		return nil, ErrNilESDTSupplyHandler
+	}
+	if check.IfNil(args.GlobalSettingsHandler) {
 		return nil, ErrNilGlobalSettingsHandler
 	}
-	if check.IfNil(shardCoordinator) {
+	if check.IfNil(args.ShardCoordinator) {
 		return nil, ErrNilShardCoordinator
 	}
-	if check.IfNil(rolesHandler) {
+	if check.IfNil(args.RolesHandler) {
 		return nil, ErrNilRolesHandler
 	}
-	if check.IfNil(enableEpochsHandler) {
+	if check.IfNil(args.EnableEpochsHandler) {
 		return nil, ErrNilEnableEpochsHandler
 	}
+	if check.IfNil(args.LockedBalanceHandler) {
+		return nil, ErrNilLockedBalanceHandler
+	}
+	if check.IfNil(args.TransferFeeHandler) {
+		return nil, ErrNilTransferFeeHandler
+	}
+	if check.IfNil(args.SpendingPolicyHandler) {
+		return nil, ErrNilSpendingPolicyHandler
+	}
+	if check.IfNil(args.AccessListChecker) {
+		return nil, ErrNilAccessListChecker
+	}
 
 	e := &esdtTransfer{
-		funcGasCost:           funcGasCost,
-		marshaller:            marshaller,
+		funcGasCost:           args.FuncGasCost,
+		marshaller:            args.Marshaller,
 		keyPrefix:             []byte(baseESDTKeyPrefix),
-		globalSettingsHandler: globalSettingsHandler,
+		globalSettingsHandler: args.GlobalSettingsHandler,
+		esdtSupplyHandler:     args.ESDTSupplyHandler,
 		payableHandler:        &disabledPayableHandler{},
-		shardCoordinator:      shardCoordinator,
-		rolesHandler:          rolesHandler,
-		enableEpochsHandler:   enableEpochsHandler,
+		shardCoordinator:      args.ShardCoordinator,
+		rolesHandler:          args.RolesHandler,
+		enableEpochsHandler:   args.EnableEpochsHandler,
+		lockedBalanceHandler:  args.LockedBalanceHandler,
+		transferFeeHandler:    args.TransferFeeHandler,
+		spendingPolicyHandler: args.SpendingPolicyHandler,
+		accessListChecker:     args.AccessListChecker,
 	}
 
 	return e, nil
@@ -117,20 +150,35 @@ func (e *esdtTransfer) ProcessBuiltinFunction(
 		keyToCheck = tokenID
 	}
 
-	err = checkIfTransferCanHappenWithLimitedTransfer(keyToCheck, esdtTokenKey, vmInput.CallerAddr, vmInput.RecipientAddr, e.globalSettingsHandler, e.rolesHandler, acntSnd, acntDst, vmInput.ReturnCallAfterError)
+	err = checkIfTransferCanHappenWithLimitedTransfer(keyToCheck, esdtTokenKey, vmInput.CallerAddr, vmInput.RecipientAddr, e.globalSettingsHandler, e.accessListChecker, e.rolesHandler, acntSnd, acntDst, vmInput.ReturnCallAfterError)
 	if err != nil {
 		return nil, err
 	}
 
 	// reduce balance if the sender is in shard, and it is not the  ESDTSCAddress
 	isSenderESDTSCAddr := bytes.Equal(vmInput.CallerAddr, core.ESDTSCAddress)
+	fee := big.NewInt(0)
+	var feeReceiver []byte
 	if !check.IfNil(acntSnd) && !isSenderESDTSCAddr {
 		// gas is paid only by sender
 		if vmInput.GasProvided < e.funcGasCost && !skipGasUse {
 			return nil, ErrNotEnoughGas
 		}
 
-		err = addToESDTBalance(acntSnd, esdtTokenKey, big.NewInt(0).Neg(value), e.marshaller, e.globalSettingsHandler, vmInput.ReturnCallAfterError)
+		// the transfer fee is paid by the sender on top of the transferred value
+		if !vmInput.ReturnCallAfterError {
+			fee, feeReceiver, err = e.transferFeeHandler.ComputeTransferFee(tokenID, vmInput.CallerAddr, vmInput.RecipientAddr, value)
+			if err != nil {
+				return nil, err
+			}
+			err = e.spendingPolicyHandler.CheckUnguardedTransfer(acntSnd, &vmInput.VMInput, vmInput.RecipientAddr, tokenID, value)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		valueToDeduct := big.NewInt(0).Add(value, fee)
+		err = addToESDTBalance(acntSnd, esdtTokenKey, valueToDeduct.Neg(valueToDeduct), e.marshaller, e.globalSettingsHandler, e.lockedBalanceHandler, e.enableEpochsHandler, vmInput.ReturnCallAfterError)
 		if err != nil {
 			return nil, err
 		}
@@ -138,16 +186,29 @@ func (e *esdtTransfer) ProcessBuiltinFunction(
 
 	isSCCallAfter := e.payableHandler.DetermineIsSCCallAfter(vmInput, vmInput.RecipientAddr, core.MinLenArgumentsESDTTransfer)
 	vmOutput := &vmcommon.VMOutput{GasRemaining: gasRemaining, ReturnCode: vmcommon.Ok}
+	if fee.Sign() > 0 {
+		err = e.transferFeeHandler.CollectTransferFee(acntSnd, vmInput.RecipientAddr, tokenID, fee, feeReceiver, vmOutput)
+		if err != nil {
+			return nil, err
+		}
+	}
 	if !check.IfNil(acntDst) {
 		err = e.payableHandler.CheckPayable(vmInput, vmInput.RecipientAddr, core.MinLenArgumentsESDTTransfer)
 		if err != nil {
 			return nil, err
 		}
 
-		err = addToESDTBalance(acntDst, esdtTokenKey, value, e.marshaller, e.globalSettingsHandler, vmInput.ReturnCallAfterError)
+		err = addToESDTBalance(acntDst, esdtTokenKey, value, e.marshaller, e.globalSettingsHandler, e.lockedBalanceHandler, e.enableEpochsHandler, vmInput.ReturnCallAfterError)
 		if err != nil {
 			return nil, err
 		}
+		if isSenderESDTSCAddr {
+			// tokens sent by the esdt system smart contract are not deducted from any account of this shard
+			err = e.esdtSupplyHandler.AddToSupplySystemAcc(tokenID, value)
+			if err != nil {
+				return nil, err
+			}
+		}
 
 		if isSCCallAfter {
 			vmOutput.GasRemaining, _ = vmcommon.SafeSubUint64(vmInput.GasProvided, e.funcGasCost)
@@ -244,7 +305,9 @@ func addOutputTransferToVMOutput(
 		CallType:      callType,
 		SenderAddress: senderAddress,
 	}
-	vmOutput.OutputAccounts = make(map[string]*vmcommon.OutputAccount)
+	if vmOutput.OutputAccounts == nil {
+		vmOutput.OutputAccounts = make(map[string]*vmcommon.OutputAccount)
+	}
 	vmOutput.OutputAccounts[string(recipient)] = &vmcommon.OutputAccount{
 		Address:         recipient,
 		OutputTransfers: []vmcommon.OutputTransfer{outTransfer},
@@ -258,6 +321,8 @@ func addToESDTBalance(
 	value *big.Int,
 	marshaller vmcommon.Marshalizer,
 	globalSettingsHandler vmcommon.ESDTGlobalSettingsHandler,
+	lockedBalanceHandler ESDTLockedBalanceHandler,
+	enableEpochsHandler vmcommon.EnableEpochsHandler,
 	isReturnWithError bool,
 ) error {
 	esdtData, err := getESDTDataFromKey(userAcnt, key, marshaller)
@@ -269,7 +334,7 @@ func addToESDTBalance(
 		return ErrOnlyFungibleTokensHaveBalanceTransfer
 	}
 
-	err = checkFrozeAndPause(userAcnt.AddressBytes(), key, esdtData, globalSettingsHandler, isReturnWithError)
+	err = checkFrozeAndPause(userAcnt, key, esdtData, globalSettingsHandler, enableEpochsHandler, isReturnWithError)
 	if err != nil {
 		return err
 	}
@@ -278,21 +343,32 @@ func addToESDTBalance(
 	if esdtData.Value.Cmp(zero) < 0 {
 		return ErrInsufficientFunds
 	}
+	if value.Sign() < 0 {
+		err = checkFrozenESDTAmount(userAcnt, key, esdtData, isReturnWithError)
+		if err != nil {
+			return err
+		}
+		err = checkLockedESDTAmount(lockedBalanceHandler, userAcnt, key, esdtData, isReturnWithError)
+		if err != nil {
+			return err
+		}
+	}
 
 	return saveESDTData(userAcnt, esdtData, key, marshaller)
 }
 
 func checkFrozeAndPause(
-	senderAddr []byte,
+	acnt vmcommon.UserAccountHandler,
 	key []byte,
 	esdtData *esdt.ESDigitalToken,
 	globalSettingsHandler vmcommon.ESDTGlobalSettingsHandler,
+	enableEpochsHandler vmcommon.EnableEpochsHandler,
 	isReturnWithError bool,
 ) error {
 	if isReturnWithError {
 		return nil
 	}
-	if bytes.Equal(senderAddr, core.ESDTSCAddress) {
+	if bytes.Equal(acnt.AddressBytes(), core.ESDTSCAddress) {
 		return nil
 	}
 
@@ -300,6 +376,10 @@ func checkFrozeAndPause(
 	if esdtUserMetaData.Frozen {
 		return ErrESDTIsFrozenForAccount
 	}
+	err := checkAccountIsNotFrozen(acnt, enableEpochsHandler, isReturnWithError)
+	if err != nil {
+		return err
+	}
 
 	if globalSettingsHandler.IsPaused(key) {
 		return ErrESDTTokenIsPaused
@@ -366,10 +446,15 @@ func checkIfTransferCanHappenWithLimitedTransfer(
 	tokenID []byte, esdtTokenKey []byte,
 	senderAddress, destinationAddress []byte,
 	globalSettingsHandler vmcommon.ExtendedESDTGlobalSettingsHandler,
+	accessListChecker ESDTTransferAccessListChecker,
 	roleHandler vmcommon.ESDTRoleHandler,
 	acntSnd, acntDst vmcommon.UserAccountHandler,
 	isReturnWithError bool,
 ) error {
+	err := checkTransferAccessLists(esdtTokenKey, senderAddress, destinationAddress, accessListChecker, isReturnWithError)
+	if err != nil {
+		return err
+	}
 	if isReturnWithError {
 		return nil
 	}
@@ -393,6 +478,22 @@ func checkIfTransferCanHappenWithLimitedTransfer(
 	return errDestination
 }
 
+// checkTransferAccessLists is called on both the sender and the destination shard, so a destination added to the deny
+// list while the transfer was in flight does not receive the tokens. Transfers from the esdt system smart contract
+// are not restricted
+func checkTransferAccessLists(
+	esdtTokenKey []byte,
+	senderAddress, destinationAddress []byte,
+	accessListChecker ESDTTransferAccessListChecker,
+	isReturnWithError bool,
+) error {
+	if isReturnWithError || bytes.Equal(senderAddress, core.ESDTSCAddress) {
+		return nil
+	}
+
+	return accessListChecker.CheckTransferAccessLists(esdtTokenKey, senderAddress, destinationAddress)
+}
+
 // SetPayableChecker will set the payableCheck handler to the function
 func (e *esdtTransfer) SetPayableChecker(payableHandler vmcommon.PayableChecker) error {
 	if check.IfNil(payableHandler) {
@@ -0,0 +1,227 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const (
+	esdtTransferFeeKeyPrefix         = core.ProtectedKeyPrefix + "esdtTransferFee"
+	maxTransferFeeBasisPoints        = 10000
+	maxNumTransferFeeExemptAddresses = 100
+	transferFeeBasisPointsLength     = 2
+)
+
+// ESDTTransferFeePolicy defines the fee paid by the sender on top of every transfer of a fungible token. The fee is
+// computed as the transferred value multiplied by the basis points, plus the fixed amount
+type ESDTTransferFeePolicy struct {
+	BasisPoints     uint32
+	FixedAmount     *big.Int
+	FeeReceiver     []byte
+	ExemptAddresses [][]byte
+}
+
+// ESDTTransferFeePolicyFromBytes creates a transfer fee policy object from bytes
+func ESDTTransferFeePolicyFromBytes(encoded []byte) (*ESDTTransferFeePolicy, error) {
+	if len(encoded) < transferFeeBasisPointsLength {
+		return nil, ErrInvalidTransferFeePolicy
+	}
+
+	policy := &ESDTTransferFeePolicy{
+		BasisPoints:     uint32(binary.BigEndian.Uint16(encoded[:transferFeeBasisPointsLength])),
+		ExemptAddresses: make([][]byte, 0),
+	}
+	encoded = encoded[transferFeeBasisPointsLength:]
+
+	fixedAmount, encoded, err := readLengthPrefixedField(encoded)
+	if err != nil {
+		return nil, err
+	}
+	policy.FixedAmount = big.NewInt(0).SetBytes(fixedAmount)
+
+	policy.FeeReceiver, encoded, err = readLengthPrefixedField(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	for len(encoded) > 0 {
+		var exemptAddress []byte
+		exemptAddress, encoded, err = readLengthPrefixedField(encoded)
+		if err != nil {
+			return nil, err
+		}
+
+		policy.ExemptAddresses = append(policy.ExemptAddresses, exemptAddress)
+	}
+
+	return policy, nil
+}
+
+// ToBytes converts the transfer fee policy to bytes. Every field following the basis points is prefixed by its length
+func (policy *ESDTTransferFeePolicy) ToBytes() []byte {
+	encoded := binary.BigEndian.AppendUint16(nil, uint16(policy.BasisPoints))
+	encoded = appendLengthPrefixedField(encoded, policy.FixedAmount.Bytes())
+	encoded = appendLengthPrefixedField(encoded, policy.FeeReceiver)
+	for _, exemptAddress := range policy.ExemptAddresses {
+		encoded = appendLengthPrefixedField(encoded, exemptAddress)
+	}
+
+	return encoded
+}
+
+func (policy *ESDTTransferFeePolicy) isExempt(address []byte) bool {
+	if bytes.Equal(address, policy.FeeReceiver) {
+		return true
+	}
+
+	for _, exemptAddress := range policy.ExemptAddresses {
+		if bytes.Equal(address, exemptAddress) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (policy *ESDTTransferFeePolicy) computeFee(value *big.Int) *big.Int {
+	fee := big.NewInt(0).Mul(value, big.NewInt(int64(policy.BasisPoints)))
+	fee.Div(fee, big.NewInt(maxTransferFeeBasisPoints))
+
+	return fee.Add(fee, policy.FixedAmount)
+}
+
+func appendLengthPrefixedField(encoded []byte, field []byte) []byte {
+	encoded = append(encoded, byte(len(field)))
+	return append(encoded, field...)
+}
+
+func readLengthPrefixedField(encoded []byte) ([]byte, []byte, error) {
+	if len(encoded) == 0 || len(encoded) < 1+int(encoded[0]) {
+		return nil, nil, ErrInvalidTransferFeePolicy
+	}
+
+	fieldLength := 1 + int(encoded[0])
+	return encoded[1:fieldLength], encoded[fieldLength:], nil
+}
+
+// ArgsNewESDTTransferFeeHandler defines the arguments needed to create the esdt transfer fee handler
+type ArgsNewESDTTransferFeeHandler struct {
+	Accounts            vmcommon.AccountsAdapter
+	EnableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+type esdtTransferFeeHandler struct {
+	accounts            vmcommon.AccountsAdapter
+	enableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+// NewESDTTransferFeeHandler creates the component which charges the transfer fees saved in the global token metadata.
+// The fees are paid on the sender shard and are sent to the fee receiver through an output transfer
+func NewESDTTransferFeeHandler(args ArgsNewESDTTransferFeeHandler) (*esdtTransferFeeHandler, error) {
+	if check.IfNil(args.Accounts) {
+		return nil, ErrNilAccountsAdapter
+	}
+	if check.IfNil(args.EnableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+
+	return &esdtTransferFeeHandler{
+		accounts:            args.Accounts,
+		enableEpochsHandler: args.EnableEpochsHandler,
+	}, nil
+}
+
+// ComputeTransferFee returns the fee the sender has to pay on top of the transferred value and the address receiving
+// it. Transfer fees apply to fungible tokens only: the fee is paid in the transferred token, and taking a share of
+// an NFT or of a few SFT units would split items the holder cannot divide, so the callers must not ask for the fee of
+// a token with a nonce.
+// A zero fee is returned if the token has no transfer fee policy or if the sender or the destination is exempt
+func (e *esdtTransferFeeHandler) ComputeTransferFee(tokenID []byte, sender []byte, destination []byte, value *big.Int) (*big.Int, []byte, error) {
+	if !e.enableEpochsHandler.IsFlagEnabled(ESDTTransferFeeFlag) {
+		return big.NewInt(0), nil, nil
+	}
+
+	systemAcc, err := getSystemAccount(e.accounts)
+	if err != nil {
+		return nil, nil, err
+	}
+	policy, err := getESDTTransferFeePolicy(systemAcc, tokenID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if policy == nil || policy.isExempt(sender) || policy.isExempt(destination) {
+		return big.NewInt(0), nil, nil
+	}
+
+	return policy.computeFee(value), policy.FeeReceiver, nil
+}
+
+// CollectTransferFee sends the fee, already deducted from the sender, to the fee receiver through an output transfer
+// and logs it together with the destination of the transfer, so the transferred amounts can be reconciled
+func (e *esdtTransferFeeHandler) CollectTransferFee(
+	acntSnd vmcommon.UserAccountHandler,
+	destination []byte,
+	tokenID []byte,
+	fee *big.Int,
+	feeReceiver []byte,
+	vmOutput *vmcommon.VMOutput,
+) error {
+	addFeeTransferToVMOutput(acntSnd.AddressBytes(), feeReceiver, core.BuiltInFunctionESDTTransfer, [][]byte{tokenID, fee.Bytes()}, vmOutput)
+	addESDTEntryInVMOutput(vmOutput, []byte(vmcommon.ESDTTransferFeeIdentifier), tokenID, 0, fee, acntSnd.AddressBytes(), feeReceiver, destination)
+
+	return nil
+}
+
+// addFeeTransferToVMOutput appends the fee transfer to the output account of the fee receiver, keeping the output
+// transfers already added for the transferred tokens
+func addFeeTransferToVMOutput(sender []byte, feeReceiver []byte, function string, arguments [][]byte, vmOutput *vmcommon.VMOutput) {
+	encodedTxData := function
+	for _, arg := range arguments {
+		encodedTxData += "@" + hex.EncodeToString(arg)
+	}
+
+	if vmOutput.OutputAccounts == nil {
+		vmOutput.OutputAccounts = make(map[string]*vmcommon.OutputAccount)
+	}
+	outputAccount, found := vmOutput.OutputAccounts[string(feeReceiver)]
+	if !found {
+		outputAccount = &vmcommon.OutputAccount{Address: feeReceiver}
+		vmOutput.OutputAccounts[string(feeReceiver)] = outputAccount
+	}
+
+	outputAccount.OutputTransfers = append(outputAccount.OutputTransfers, vmcommon.OutputTransfer{
+		Index:         uint32(len(outputAccount.OutputTransfers)) + 1,
+		Value:         big.NewInt(0),
+		Data:          []byte(encodedTxData),
+		CallType:      vm.DirectCall,
+		SenderAddress: sender,
+	})
+}
+
+func getESDTTransferFeePolicy(systemAcc vmcommon.UserAccountHandler, tokenID []byte) (*ESDTTransferFeePolicy, error) {
+	val, _, err := systemAcc.AccountDataHandler().RetrieveValue(getESDTTransferFeeKey(tokenID))
+	if core.IsGetNodeFromDBError(err) {
+		return nil, err
+	}
+	if err != nil || len(val) == 0 {
+		return nil, nil
+	}
+
+	return ESDTTransferFeePolicyFromBytes(val)
+}
+
+func getESDTTransferFeeKey(tokenID []byte) []byte {
+	return append([]byte(esdtTransferFeeKeyPrefix), tokenID...)
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (e *esdtTransferFeeHandler) IsInterfaceNil() bool {
+	return e == nil
+}
@@ -0,0 +1,218 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	transferFeeSender      = bytes.Repeat([]byte{5}, 32)
+	transferFeeDestination = bytes.Repeat([]byte{6}, 32)
+	transferFeeReceiver    = bytes.Repeat([]byte{7}, 32)
+	transferFeeExempt      = bytes.Repeat([]byte{8}, 32)
+)
+
+func createTransferFeeAccounts(accounts ...vmcommon.UserAccountHandler) *mock.AccountsStub {
+	accountsMap := map[string]vmcommon.UserAccountHandler{
+		string(vmcommon.SystemAccountAddress): mock.NewUserAccount(vmcommon.SystemAccountAddress),
+	}
+	for _, account := range accounts {
+		accountsMap[string(account.AddressBytes())] = account
+	}
+
+	return &mock.AccountsStub{
+		LoadAccountCalled: func(address []byte) (vmcommon.AccountHandler, error) {
+			account, found := accountsMap[string(address)]
+			if !found {
+				account = mock.NewUserAccount(address)
+				accountsMap[string(address)] = account
+			}
+
+			return account, nil
+		},
+	}
+}
+
+func createMockArgsNewESDTTransferFeeHandler(accounts vmcommon.AccountsAdapter) ArgsNewESDTTransferFeeHandler {
+	return ArgsNewESDTTransferFeeHandler{
+		Accounts: accounts,
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == ESDTTransferFeeFlag
+			},
+		},
+	}
+}
+
+func createMockESDTTransferFeePolicy() *ESDTTransferFeePolicy {
+	return &ESDTTransferFeePolicy{
+		BasisPoints:     100,
+		FixedAmount:     big.NewInt(2),
+		FeeReceiver:     transferFeeReceiver,
+		ExemptAddresses: [][]byte{transferFeeExempt},
+	}
+}
+
+func saveESDTTransferFeePolicy(t *testing.T, accounts vmcommon.AccountsAdapter, tokenID []byte, policy *ESDTTransferFeePolicy) {
+	systemAcc, err := getSystemAccount(accounts)
+	require.Nil(t, err)
+	err = systemAcc.AccountDataHandler().SaveKeyValue(getESDTTransferFeeKey(tokenID), policy.ToBytes())
+	require.Nil(t, err)
+}
+
+func TestESDTTransferFeePolicy_Encoding(t *testing.T) {
+	t.Parallel()
+
+	policy := createMockESDTTransferFeePolicy()
+	decoded, err := ESDTTransferFeePolicyFromBytes(policy.ToBytes())
+	assert.Nil(t, err)
+	assert.Equal(t, policy, decoded)
+
+	policy.ExemptAddresses = make([][]byte, 0)
+	decoded, err = ESDTTransferFeePolicyFromBytes(policy.ToBytes())
+	assert.Nil(t, err)
+	assert.Equal(t, policy, decoded)
+
+	encoded := createMockESDTTransferFeePolicy().ToBytes()
+	_, err = ESDTTransferFeePolicyFromBytes(encoded[:transferFeeBasisPointsLength-1])
+	assert.Equal(t, ErrInvalidTransferFeePolicy, err)
+
+	_, err = ESDTTransferFeePolicyFromBytes(encoded[:transferFeeBasisPointsLength])
+	assert.Equal(t, ErrInvalidTransferFeePolicy, err)
+
+	_, err = ESDTTransferFeePolicyFromBytes(encoded[:len(encoded)-1])
+	assert.Equal(t, ErrInvalidTransferFeePolicy, err)
+}
+
+func TestNewESDTTransferFeeHandler(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		modify      func(args *ArgsNewESDTTransferFeeHandler)
+		expectedErr error
+	}{
+		{name: "nil accounts", modify: func(args *ArgsNewESDTTransferFeeHandler) { args.Accounts = nil }, expectedErr: ErrNilAccountsAdapter},
+		{name: "nil enable epochs handler", modify: func(args *ArgsNewESDTTransferFeeHandler) { args.EnableEpochsHandler = nil }, expectedErr: ErrNilEnableEpochsHandler},
+		{name: "should work", modify: func(args *ArgsNewESDTTransferFeeHandler) {}, expectedErr: nil},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			args := createMockArgsNewESDTTransferFeeHandler(createTransferFeeAccounts())
+			tt.modify(&args)
+
+			transferFeeHandler, err := NewESDTTransferFeeHandler(args)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedErr != nil, check.IfNil(transferFeeHandler))
+		})
+	}
+}
+
+func TestESDTTransferFeeHandler_ComputeTransferFee(t *testing.T) {
+	t.Parallel()
+
+	tokenID := []byte("TKN-123456")
+	value := big.NewInt(1000)
+
+	t.Run("flag not active should return zero", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsNewESDTTransferFeeHandler(createTransferFeeAccounts())
+		args.EnableEpochsHandler = &mock.EnableEpochsHandlerStub{}
+		saveESDTTransferFeePolicy(t, args.Accounts, tokenID, createMockESDTTransferFeePolicy())
+		transferFeeHandler, _ := NewESDTTransferFeeHandler(args)
+
+		fee, feeReceiver, err := transferFeeHandler.ComputeTransferFee(tokenID, transferFeeSender, transferFeeDestination, value)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(0), fee)
+		assert.Nil(t, feeReceiver)
+	})
+	t.Run("no policy should return zero", func(t *testing.T) {
+		t.Parallel()
+
+		transferFeeHandler, _ := NewESDTTransferFeeHandler(createMockArgsNewESDTTransferFeeHandler(createTransferFeeAccounts()))
+
+		fee, feeReceiver, err := transferFeeHandler.ComputeTransferFee(tokenID, transferFeeSender, transferFeeDestination, value)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(0), fee)
+		assert.Nil(t, feeReceiver)
+	})
+	t.Run("exempt addresses should not pay", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsNewESDTTransferFeeHandler(createTransferFeeAccounts())
+		saveESDTTransferFeePolicy(t, args.Accounts, tokenID, createMockESDTTransferFeePolicy())
+		transferFeeHandler, _ := NewESDTTransferFeeHandler(args)
+
+		fee, _, err := transferFeeHandler.ComputeTransferFee(tokenID, transferFeeExempt, transferFeeDestination, value)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(0), fee)
+
+		fee, _, err = transferFeeHandler.ComputeTransferFee(tokenID, transferFeeSender, transferFeeExempt, value)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(0), fee)
+
+		fee, _, err = transferFeeHandler.ComputeTransferFee(tokenID, transferFeeReceiver, transferFeeDestination, value)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(0), fee)
+	})
+	t.Run("should compute the percentage and the fixed amount", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgsNewESDTTransferFeeHandler(createTransferFeeAccounts())
+		saveESDTTransferFeePolicy(t, args.Accounts, tokenID, createMockESDTTransferFeePolicy())
+		transferFeeHandler, _ := NewESDTTransferFeeHandler(args)
+
+		fee, feeReceiver, err := transferFeeHandler.ComputeTransferFee(tokenID, transferFeeSender, transferFeeDestination, value)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(12), fee)
+		assert.Equal(t, transferFeeReceiver, feeReceiver)
+
+		fee, _, err = transferFeeHandler.ComputeTransferFee(tokenID, transferFeeSender, transferFeeDestination, big.NewInt(99))
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(2), fee)
+	})
+}
+
+func TestESDTTransferFeeHandler_CollectTransferFee(t *testing.T) {
+	t.Parallel()
+
+	tokenID := []byte("TKN-123456")
+	transferFeeHandler, _ := NewESDTTransferFeeHandler(createMockArgsNewESDTTransferFeeHandler(createTransferFeeAccounts()))
+
+	vmOutput := &vmcommon.VMOutput{}
+	addOutputTransferToVMOutput(1, transferFeeSender, core.BuiltInFunctionESDTTransfer, [][]byte{tokenID, big.NewInt(1000).Bytes()}, transferFeeReceiver, 0, 0, vmOutput)
+	sender := mock.NewUserAccount(transferFeeSender)
+	err := transferFeeHandler.CollectTransferFee(sender, transferFeeReceiver, tokenID, big.NewInt(12), transferFeeReceiver, vmOutput)
+	require.Nil(t, err)
+
+	outputTransfers := vmOutput.OutputAccounts[string(transferFeeReceiver)].OutputTransfers
+	require.Len(t, outputTransfers, 2)
+	assert.Equal(t, uint32(2), outputTransfers[1].Index)
+	assert.Equal(t, transferFeeSender, outputTransfers[1].SenderAddress)
+	assert.Equal(t, []byte("ESDTTransfer@544b4e2d313233343536@0c"), outputTransfers[1].Data)
+
+	vmOutput = &vmcommon.VMOutput{}
+	err = transferFeeHandler.CollectTransferFee(sender, transferFeeDestination, tokenID, big.NewInt(12), transferFeeReceiver, vmOutput)
+	require.Nil(t, err)
+
+	outputTransfers = vmOutput.OutputAccounts[string(transferFeeReceiver)].OutputTransfers
+	require.Len(t, outputTransfers, 1)
+	assert.Equal(t, uint32(1), outputTransfers[0].Index)
+	require.Len(t, vmOutput.Logs, 1)
+	assert.Equal(t, []byte(vmcommon.ESDTTransferFeeIdentifier), vmOutput.Logs[0].Identifier)
+	assert.Equal(t, transferFeeSender, vmOutput.Logs[0].Address)
+	assert.Equal(t, [][]byte{tokenID, {}, big.NewInt(12).Bytes(), transferFeeReceiver, transferFeeDestination}, vmOutput.Logs[0].Topics)
+}
@@ -2,6 +2,7 @@ package builtInFunctions
 
 import (
 	"bytes"
+	"errors"
 	"math/big"
 	"strings"
 	"testing"
@@ -21,42 +22,185 @@ func TestNewESDTTransferFunc(t *testing.T) {
 	t.Run("nil marshaller should error", func(t *testing.T) {
 		t.Parallel()
 
-		transferFunc, err := NewESDTTransferFunc(10, nil, nil, nil, nil, nil)
+		transferFunc, err := NewESDTTransferFunc(ESDTTransferFuncArgs{
+			FuncGasCost:           10,
+			ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+			LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+			TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+			SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+			AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
+		})
 		assert.Equal(t, ErrNilMarshalizer, err)
 		assert.True(t, check.IfNil(transferFunc))
 	})
+	t.Run("nil esdt supply handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		transferFunc, err := NewESDTTransferFunc(ESDTTransferFuncArgs{
+			FuncGasCost:           10,
+			Marshaller:            &mock.MarshalizerMock{},
+			LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+			TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+			SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+			AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
+		})
+		assert.Equal(t, ErrNilESDTSupplyHandler, err)
+		assert.True(t, check.IfNil(transferFunc))
+	})
 	t.Run("nil global settings handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		transferFunc, err := NewESDTTransferFunc(10, &mock.MarshalizerMock{}, nil, nil, nil, nil)
+		transferFunc, err := NewESDTTransferFunc(ESDTTransferFuncArgs{
+			FuncGasCost:           10,
+			Marshaller:            &mock.MarshalizerMock{},
+			ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+			LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+			TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+			SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+			AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
+		})
 		assert.Equal(t, ErrNilGlobalSettingsHandler, err)
 		assert.True(t, check.IfNil(transferFunc))
 	})
 	t.Run("nil shard coordinator should error", func(t *testing.T) {
 		t.Parallel()
 
-		transferFunc, err := NewESDTTransferFunc(10, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, nil, nil, nil)
+		transferFunc, err := NewESDTTransferFunc(ESDTTransferFuncArgs{
+			FuncGasCost:           10,
+			Marshaller:            &mock.MarshalizerMock{},
+			ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+			TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+			SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+			AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
+		})
 		assert.Equal(t, ErrNilShardCoordinator, err)
 		assert.True(t, check.IfNil(transferFunc))
 	})
 	t.Run("nil roles handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		transferFunc, err := NewESDTTransferFunc(10, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, &mock.ShardCoordinatorStub{}, nil, nil)
+		transferFunc, err := NewESDTTransferFunc(ESDTTransferFuncArgs{
+			FuncGasCost:           10,
+			Marshaller:            &mock.MarshalizerMock{},
+			ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+			TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+			SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+			AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
+		})
 		assert.Equal(t, ErrNilRolesHandler, err)
 		assert.True(t, check.IfNil(transferFunc))
 	})
 	t.Run("nil enable epochs handler should error", func(t *testing.T) {
 		t.Parallel()
 
-		transferFunc, err := NewESDTTransferFunc(10, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, &mock.ShardCoordinatorStub{}, &mock.ESDTRoleHandlerStub{}, nil)
+		transferFunc, err := NewESDTTransferFunc(ESDTTransferFuncArgs{
+			FuncGasCost:           10,
+			Marshaller:            &mock.MarshalizerMock{},
+			ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			RolesHandler:          &mock.ESDTRoleHandlerStub{},
+			LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+			TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+			SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+			AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
+		})
 		assert.Equal(t, ErrNilEnableEpochsHandler, err)
 		assert.True(t, check.IfNil(transferFunc))
 	})
+	t.Run("nil locked balance handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		transferFunc, err := NewESDTTransferFunc(ESDTTransferFuncArgs{
+			FuncGasCost:           10,
+			Marshaller:            &mock.MarshalizerMock{},
+			ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			RolesHandler:          &mock.ESDTRoleHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+			SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+			AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
+		})
+		assert.Equal(t, ErrNilLockedBalanceHandler, err)
+		assert.True(t, check.IfNil(transferFunc))
+	})
+	t.Run("nil transfer fee handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		transferFunc, err := NewESDTTransferFunc(ESDTTransferFuncArgs{
+			FuncGasCost:           10,
+			Marshaller:            &mock.MarshalizerMock{},
+			ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			RolesHandler:          &mock.ESDTRoleHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+			SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+			AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
+		})
+		assert.Equal(t, ErrNilTransferFeeHandler, err)
+		assert.True(t, check.IfNil(transferFunc))
+	})
+	t.Run("nil spending policy handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		transferFunc, err := NewESDTTransferFunc(ESDTTransferFuncArgs{
+			FuncGasCost:           10,
+			Marshaller:            &mock.MarshalizerMock{},
+			ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			RolesHandler:          &mock.ESDTRoleHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+			TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+			AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
+		})
+		assert.Equal(t, ErrNilSpendingPolicyHandler, err)
+		assert.True(t, check.IfNil(transferFunc))
+	})
+	t.Run("nil access list checker should error", func(t *testing.T) {
+		t.Parallel()
+
+		transferFunc, err := NewESDTTransferFunc(ESDTTransferFuncArgs{
+			FuncGasCost:           10,
+			Marshaller:            &mock.MarshalizerMock{},
+			ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			RolesHandler:          &mock.ESDTRoleHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+			TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+			SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+		})
+		assert.Equal(t, ErrNilAccessListChecker, err)
+		assert.True(t, check.IfNil(transferFunc))
+	})
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
-		transferFunc, err := NewESDTTransferFunc(10, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, &mock.ShardCoordinatorStub{}, &mock.ESDTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{})
+		transferFunc, err := NewESDTTransferFunc(ESDTTransferFuncArgs{
+			FuncGasCost:           10,
+			Marshaller:            &mock.MarshalizerMock{},
+			ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			ShardCoordinator:      &mock.ShardCoordinatorStub{},
+			RolesHandler:          &mock.ESDTRoleHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+			TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+			SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+			AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
+		})
 		assert.Nil(t, err)
 		assert.False(t, check.IfNil(transferFunc))
 	})
@@ -65,10 +209,22 @@ func TestESDTTransfer_ProcessBuiltInFunctionErrors(t *testing.T) {
 	t.Parallel()
 
 	shardC := &mock.ShardCoordinatorStub{}
-	transferFunc, _ := NewESDTTransferFunc(10, &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, shardC, &mock.ESDTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
-			return flag == CheckCorrectTokenIDForTransferRoleFlag
+	transferFunc, _ := NewESDTTransferFunc(ESDTTransferFuncArgs{
+		FuncGasCost:           10,
+		Marshaller:            &mock.MarshalizerMock{},
+		ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+		GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+		ShardCoordinator:      shardC,
+		RolesHandler:          &mock.ESDTRoleHandlerStub{},
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == CheckCorrectTokenIDForTransferRoleFlag
+			},
 		},
+		LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+		TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+		SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+		AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
 	})
 	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
 	_, err := transferFunc.ProcessBuiltinFunction(nil, nil, nil)
@@ -123,7 +279,19 @@ func TestESDTTransfer_ProcessBuiltInFunctionSingleShard(t *testing.T) {
 			return flag == CheckCorrectTokenIDForTransferRoleFlag
 		},
 	}
-	transferFunc, _ := NewESDTTransferFunc(10, marshaller, &mock.GlobalSettingsHandlerStub{}, &mock.ShardCoordinatorStub{}, esdtRoleHandler, enableEpochsHandler)
+	transferFunc, _ := NewESDTTransferFunc(ESDTTransferFuncArgs{
+		FuncGasCost:           10,
+		Marshaller:            marshaller,
+		ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+		GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+		ShardCoordinator:      &mock.ShardCoordinatorStub{},
+		RolesHandler:          esdtRoleHandler,
+		EnableEpochsHandler:   enableEpochsHandler,
+		LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+		TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+		SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+		AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
+	})
 	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
 
 	input := &vmcommon.ContractCallInput{
@@ -157,14 +325,262 @@ func TestESDTTransfer_ProcessBuiltInFunctionSingleShard(t *testing.T) {
 	assert.True(t, esdtToken.Value.Cmp(big.NewInt(10)) == 0)
 }
 
+func TestESDTTransfer_ProcessBuiltInFunctionLockedBalance(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	lockedBalanceHandler := &mock.ESDTLockedBalanceHandlerStub{
+		GetLockedBalanceCalled: func(account vmcommon.UserAccountHandler, tokenID []byte) (*big.Int, error) {
+			return big.NewInt(95), nil
+		},
+	}
+	transferFunc, _ := NewESDTTransferFunc(ESDTTransferFuncArgs{
+		FuncGasCost:           10,
+		Marshaller:            marshaller,
+		ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+		GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+		ShardCoordinator:      &mock.ShardCoordinatorStub{},
+		RolesHandler:          &mock.ESDTRoleHandlerStub{},
+		EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		LockedBalanceHandler:  lockedBalanceHandler,
+		TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+		SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+		AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
+	})
+	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
+
+	key := []byte("key")
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			GasProvided: 50,
+			CallValue:   big.NewInt(0),
+			Arguments:   [][]byte{key, big.NewInt(10).Bytes()},
+		},
+	}
+	accSnd := mock.NewUserAccount([]byte("snd"))
+	accDst := mock.NewUserAccount([]byte("dst"))
+
+	esdtKey := append(transferFunc.keyPrefix, key...)
+	marshaledData, _ := marshaller.Marshal(&esdt.ESDigitalToken{Value: big.NewInt(100)})
+	_ = accSnd.AccountDataHandler().SaveKeyValue(esdtKey, marshaledData)
+
+	_, err := transferFunc.ProcessBuiltinFunction(accSnd, accDst, input)
+	assert.True(t, errors.Is(err, ErrInsufficientUnlockedBalance))
+
+	// failed transactions are reverted by the caller
+	_ = accSnd.AccountDataHandler().SaveKeyValue(esdtKey, marshaledData)
+	input.Arguments[1] = big.NewInt(5).Bytes()
+	_, err = transferFunc.ProcessBuiltinFunction(accSnd, accDst, input)
+	assert.Nil(t, err)
+}
+
+func TestESDTTransfer_ProcessBuiltInFunctionTransferFee(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	feeReceiver := []byte("feeReceiver")
+	collectCalled := false
+	transferFeeHandler := &mock.ESDTTransferFeeHandlerStub{
+		ComputeTransferFeeCalled: func(tokenID []byte, sender []byte, destination []byte, value *big.Int) (*big.Int, []byte, error) {
+			return big.NewInt(3), feeReceiver, nil
+		},
+		CollectTransferFeeCalled: func(acntSnd vmcommon.UserAccountHandler, destination []byte, tokenID []byte, fee *big.Int, receiver []byte, _ *vmcommon.VMOutput) error {
+			collectCalled = true
+			assert.Equal(t, []byte("snd"), acntSnd.AddressBytes())
+			assert.Equal(t, []byte("dst"), destination)
+			assert.Equal(t, big.NewInt(3), fee)
+			assert.Equal(t, feeReceiver, receiver)
+			return nil
+		},
+	}
+	transferFunc, _ := NewESDTTransferFunc(ESDTTransferFuncArgs{
+		FuncGasCost:           10,
+		Marshaller:            marshaller,
+		ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+		GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+		ShardCoordinator:      &mock.ShardCoordinatorStub{},
+		RolesHandler:          &mock.ESDTRoleHandlerStub{},
+		EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+		TransferFeeHandler:    transferFeeHandler,
+		SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+		AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
+	})
+	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
+
+	key := []byte("key")
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			GasProvided: 50,
+			CallValue:   big.NewInt(0),
+			CallerAddr:  []byte("snd"),
+			Arguments:   [][]byte{key, big.NewInt(98).Bytes()},
+		},
+		RecipientAddr: []byte("dst"),
+	}
+	accSnd := mock.NewUserAccount([]byte("snd"))
+	accDst := mock.NewUserAccount([]byte("dst"))
+
+	esdtKey := append(transferFunc.keyPrefix, key...)
+	marshaledData, _ := marshaller.Marshal(&esdt.ESDigitalToken{Value: big.NewInt(100)})
+	_ = accSnd.AccountDataHandler().SaveKeyValue(esdtKey, marshaledData)
+
+	_, err := transferFunc.ProcessBuiltinFunction(accSnd, accDst, input)
+	assert.Equal(t, ErrInsufficientFunds, err)
+	assert.False(t, collectCalled)
+
+	// failed transactions are reverted by the caller
+	_ = accSnd.AccountDataHandler().SaveKeyValue(esdtKey, marshaledData)
+	input.Arguments[1] = big.NewInt(10).Bytes()
+	_, err = transferFunc.ProcessBuiltinFunction(accSnd, accDst, input)
+	assert.Nil(t, err)
+	assert.True(t, collectCalled)
+
+	senderBalance, _ := getFungibleESDTBalance(accSnd, esdtKey, marshaller)
+	assert.Equal(t, big.NewInt(87), senderBalance)
+	destinationBalance, _ := getFungibleESDTBalance(accDst, esdtKey, marshaller)
+	assert.Equal(t, big.NewInt(10), destinationBalance)
+}
+
+func TestESDTTransfer_ProcessBuiltInFunctionGuardedSpendingPolicy(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	key := []byte("key")
+	spendingPolicyHandler := &mock.GuardedSpendingPolicyHandlerStub{
+		CheckUnguardedTransferCalled: func(acntSnd vmcommon.UserAccountHandler, _ *vmcommon.VMInput, destination []byte, tokenID []byte, value *big.Int) error {
+			assert.Equal(t, []byte("snd"), acntSnd.AddressBytes())
+			assert.Equal(t, []byte("dst"), destination)
+			assert.Equal(t, key, tokenID)
+			if value.Cmp(big.NewInt(10)) > 0 {
+				return ErrGuardianSignatureRequired
+			}
+			return nil
+		},
+	}
+	transferFunc, _ := NewESDTTransferFunc(ESDTTransferFuncArgs{
+		FuncGasCost:           10,
+		Marshaller:            marshaller,
+		ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+		GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+		ShardCoordinator:      &mock.ShardCoordinatorStub{},
+		RolesHandler:          &mock.ESDTRoleHandlerStub{},
+		EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+		TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+		SpendingPolicyHandler: spendingPolicyHandler,
+		AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
+	})
+	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
+
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			GasProvided: 50,
+			CallValue:   big.NewInt(0),
+			CallerAddr:  []byte("snd"),
+			Arguments:   [][]byte{key, big.NewInt(11).Bytes()},
+		},
+		RecipientAddr: []byte("dst"),
+	}
+	accSnd := mock.NewUserAccount([]byte("snd"))
+	accDst := mock.NewUserAccount([]byte("dst"))
+
+	esdtKey := append(transferFunc.keyPrefix, key...)
+	marshaledData, _ := marshaller.Marshal(&esdt.ESDigitalToken{Value: big.NewInt(100)})
+	_ = accSnd.AccountDataHandler().SaveKeyValue(esdtKey, marshaledData)
+
+	_, err := transferFunc.ProcessBuiltinFunction(accSnd, accDst, input)
+	assert.Equal(t, ErrGuardianSignatureRequired, err)
+
+	// failed transactions are reverted by the caller
+	_ = accSnd.AccountDataHandler().SaveKeyValue(esdtKey, marshaledData)
+	input.Arguments[1] = big.NewInt(10).Bytes()
+	_, err = transferFunc.ProcessBuiltinFunction(accSnd, accDst, input)
+	assert.Nil(t, err)
+}
+
+func TestESDTTransfer_ProcessBuiltInFunctionAccessLists(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	key := []byte("key")
+	deniedAddress := []byte("dst")
+	accessListChecker := &mock.ESDTTransferAccessListCheckerStub{
+		CheckTransferAccessListsCalled: func(esdtTokenKey []byte, sender, destination []byte) error {
+			assert.Equal(t, []byte(baseESDTKeyPrefix+string(key)), esdtTokenKey)
+			if bytes.Equal(sender, deniedAddress) || bytes.Equal(destination, deniedAddress) {
+				return ErrAddressIsDenyListed
+			}
+			return nil
+		},
+	}
+	transferFunc, _ := NewESDTTransferFunc(ESDTTransferFuncArgs{
+		FuncGasCost:           10,
+		Marshaller:            marshaller,
+		ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+		GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+		ShardCoordinator:      &mock.ShardCoordinatorStub{},
+		RolesHandler:          &mock.ESDTRoleHandlerStub{},
+		EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+		TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+		SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+		AccessListChecker:     accessListChecker,
+	})
+	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
+
+	input := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			GasProvided: 50,
+			CallValue:   big.NewInt(0),
+			CallerAddr:  []byte("snd"),
+			Arguments:   [][]byte{key, big.NewInt(10).Bytes()},
+		},
+		RecipientAddr: deniedAddress,
+	}
+	accSnd := mock.NewUserAccount([]byte("snd"))
+	accDst := mock.NewUserAccount(deniedAddress)
+
+	esdtKey := append(transferFunc.keyPrefix, key...)
+	marshaledData, _ := marshaller.Marshal(&esdt.ESDigitalToken{Value: big.NewInt(100)})
+	_ = accSnd.AccountDataHandler().SaveKeyValue(esdtKey, marshaledData)
+
+	_, err := transferFunc.ProcessBuiltinFunction(accSnd, accDst, input)
+	assert.Equal(t, ErrAddressIsDenyListed, err)
+
+	_, err = transferFunc.ProcessBuiltinFunction(nil, accDst, input)
+	assert.Equal(t, ErrAddressIsDenyListed, err)
+
+	input.ReturnCallAfterError = true
+	_, err = transferFunc.ProcessBuiltinFunction(nil, accDst, input)
+	assert.Nil(t, err)
+
+	input.ReturnCallAfterError = false
+	input.CallerAddr = core.ESDTSCAddress
+	_, err = transferFunc.ProcessBuiltinFunction(nil, accDst, input)
+	assert.Nil(t, err)
+}
+
 func TestESDTTransfer_ProcessBuiltInFunctionSenderInShard(t *testing.T) {
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	transferFunc, _ := NewESDTTransferFunc(10, marshaller, &mock.GlobalSettingsHandlerStub{}, &mock.ShardCoordinatorStub{}, &mock.ESDTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
-			return flag == CheckCorrectTokenIDForTransferRoleFlag
+	transferFunc, _ := NewESDTTransferFunc(ESDTTransferFuncArgs{
+		FuncGasCost:           10,
+		Marshaller:            marshaller,
+		ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+		GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+		ShardCoordinator:      &mock.ShardCoordinatorStub{},
+		RolesHandler:          &mock.ESDTRoleHandlerStub{},
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == CheckCorrectTokenIDForTransferRoleFlag
+			},
 		},
+		LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+		TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+		SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+		AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
 	})
 	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
 
@@ -195,10 +611,22 @@ func TestESDTTransfer_ProcessBuiltInFunctionDestInShard(t *testing.T) {
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	transferFunc, _ := NewESDTTransferFunc(10, marshaller, &mock.GlobalSettingsHandlerStub{}, &mock.ShardCoordinatorStub{}, &mock.ESDTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
-			return flag == CheckCorrectTokenIDForTransferRoleFlag
+	transferFunc, _ := NewESDTTransferFunc(ESDTTransferFuncArgs{
+		FuncGasCost:           10,
+		Marshaller:            marshaller,
+		ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+		GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+		ShardCoordinator:      &mock.ShardCoordinatorStub{},
+		RolesHandler:          &mock.ESDTRoleHandlerStub{},
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == CheckCorrectTokenIDForTransferRoleFlag
+			},
 		},
+		LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+		TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+		SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+		AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
 	})
 	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
 
@@ -227,10 +655,22 @@ func TestESDTTransfer_ProcessBuiltInFunctionTooLongValue(t *testing.T) {
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	transferFunc, _ := NewESDTTransferFunc(10, marshaller, &mock.GlobalSettingsHandlerStub{}, &mock.ShardCoordinatorStub{}, &mock.ESDTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
-			return flag == CheckCorrectTokenIDForTransferRoleFlag
+	transferFunc, _ := NewESDTTransferFunc(ESDTTransferFuncArgs{
+		FuncGasCost:           10,
+		Marshaller:            marshaller,
+		ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+		GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+		ShardCoordinator:      &mock.ShardCoordinatorStub{},
+		RolesHandler:          &mock.ESDTRoleHandlerStub{},
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == CheckCorrectTokenIDForTransferRoleFlag
+			},
 		},
+		LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+		TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+		SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+		AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
 	})
 	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
 
@@ -267,10 +707,22 @@ func TestESDTTransfer_SndDstFrozen(t *testing.T) {
 	marshaller := &mock.MarshalizerMock{}
 	accountStub := &mock.AccountsStub{}
 	esdtGlobalSettingsFunc, _ := NewESDTGlobalSettingsFunc(accountStub, marshaller, true, core.BuiltInFunctionESDTPause, trueHandler)
-	transferFunc, _ := NewESDTTransferFunc(10, marshaller, esdtGlobalSettingsFunc, &mock.ShardCoordinatorStub{}, &mock.ESDTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
-			return flag == CheckCorrectTokenIDForTransferRoleFlag
+	transferFunc, _ := NewESDTTransferFunc(ESDTTransferFuncArgs{
+		FuncGasCost:           10,
+		Marshaller:            marshaller,
+		ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+		GlobalSettingsHandler: esdtGlobalSettingsFunc,
+		ShardCoordinator:      &mock.ShardCoordinatorStub{},
+		RolesHandler:          &mock.ESDTRoleHandlerStub{},
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == CheckCorrectTokenIDForTransferRoleFlag
+			},
 		},
+		LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+		TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+		SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+		AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
 	})
 	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
 
@@ -355,10 +807,22 @@ func TestESDTTransfer_SndDstWithLimitedTransfer(t *testing.T) {
 		},
 	}
 	esdtGlobalSettingsFunc, _ := NewESDTGlobalSettingsFunc(accountStub, marshaller, true, core.BuiltInFunctionESDTSetLimitedTransfer, trueHandler)
-	transferFunc, _ := NewESDTTransferFunc(10, marshaller, esdtGlobalSettingsFunc, &mock.ShardCoordinatorStub{}, rolesHandler, &mock.EnableEpochsHandlerStub{
-		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
-			return flag == CheckCorrectTokenIDForTransferRoleFlag
+	transferFunc, _ := NewESDTTransferFunc(ESDTTransferFuncArgs{
+		FuncGasCost:           10,
+		Marshaller:            marshaller,
+		ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+		GlobalSettingsHandler: esdtGlobalSettingsFunc,
+		ShardCoordinator:      &mock.ShardCoordinatorStub{},
+		RolesHandler:          rolesHandler,
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == CheckCorrectTokenIDForTransferRoleFlag
+			},
 		},
+		LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+		TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+		SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+		AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
 	})
 	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
 
@@ -434,10 +898,22 @@ func TestESDTTransfer_ProcessBuiltInFunctionOnAsyncCallBack(t *testing.T) {
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	transferFunc, _ := NewESDTTransferFunc(10, marshaller, &mock.GlobalSettingsHandlerStub{}, &mock.ShardCoordinatorStub{}, &mock.ESDTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
-			return flag == CheckCorrectTokenIDForTransferRoleFlag
+	transferFunc, _ := NewESDTTransferFunc(ESDTTransferFuncArgs{
+		FuncGasCost:           10,
+		Marshaller:            marshaller,
+		ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+		GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+		ShardCoordinator:      &mock.ShardCoordinatorStub{},
+		RolesHandler:          &mock.ESDTRoleHandlerStub{},
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == CheckCorrectTokenIDForTransferRoleFlag
+			},
 		},
+		LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+		TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+		SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+		AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
 	})
 	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
 
@@ -481,10 +957,30 @@ func TestESDTTransfer_ProcessBuiltInFunctionSenderESDTSystemSC(t *testing.T) {
 	t.Parallel()
 
 	marshaller := &mock.MarshalizerMock{}
-	transferFunc, _ := NewESDTTransferFunc(10, marshaller, &mock.GlobalSettingsHandlerStub{}, &mock.ShardCoordinatorStub{}, &mock.ESDTRoleHandlerStub{}, &mock.EnableEpochsHandlerStub{
-		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
-			return flag == CheckCorrectTokenIDForTransferRoleFlag
+	mintedSupply := big.NewInt(0)
+	esdtStorageHandler := &mock.ESDTNFTStorageHandlerStub{
+		AddToSupplySystemAccCalled: func(tokenID []byte, value *big.Int) error {
+			assert.Equal(t, []byte("key"), tokenID)
+			mintedSupply.Add(mintedSupply, value)
+			return nil
+		},
+	}
+	transferFunc, _ := NewESDTTransferFunc(ESDTTransferFuncArgs{
+		FuncGasCost:           10,
+		Marshaller:            marshaller,
+		ESDTSupplyHandler:     esdtStorageHandler,
+		GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+		ShardCoordinator:      &mock.ShardCoordinatorStub{},
+		RolesHandler:          &mock.ESDTRoleHandlerStub{},
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == CheckCorrectTokenIDForTransferRoleFlag
+			},
 		},
+		LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+		TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+		SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+		AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
 	})
 	_ = transferFunc.SetPayableChecker(&mock.PayableHandlerStub{})
 
@@ -509,4 +1005,5 @@ func TestESDTTransfer_ProcessBuiltInFunctionSenderESDTSystemSC(t *testing.T) {
 	_ = marshaller.Unmarshal(esdtToken, marshaledData)
 	assert.True(t, esdtToken.Value.Cmp(big.NewInt(10)) == 0)
 	assert.Equal(t, uint64(0), vmOutput.GasRemaining)
+	assert.Equal(t, big.NewInt(10), mintedSupply)
 }
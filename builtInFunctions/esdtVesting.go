@@ -0,0 +1,242 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const (
+	esdtVestingKeyPrefix       = core.ProtectedKeyPrefix + "esdtVesting"
+	vestingUnlockRoundLength   = 8
+	maxNumVestingSchedules     = 100
+	vestingScheduleHeaderBytes = vestingUnlockRoundLength + 1
+)
+
+// maxVestingRoundsAhead bounds the unlock round of a new schedule to about 4 years of 6 seconds rounds, so that the
+// schedules sent by anyone to an account can not keep its schedule slots taken for longer
+const maxVestingRoundsAhead = 21_024_000
+
+// VestingSchedule defines a quantity of a fungible token which is locked until the given round
+type VestingSchedule struct {
+	UnlockRound uint64
+	Amount      *big.Int
+}
+
+type esdtVestingHandler struct {
+	vmcommon.BlockchainDataProvider
+	enableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+// NewESDTVestingHandler creates the component which manages the vesting schedules saved on the accounts holding
+// the tokens. The locked balance is computed against the round provided by the blockchain hook
+func NewESDTVestingHandler(enableEpochsHandler vmcommon.EnableEpochsHandler) (*esdtVestingHandler, error) {
+	if check.IfNil(enableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+
+	return &esdtVestingHandler{
+		BlockchainDataProvider: NewBlockchainDataProvider(),
+		enableEpochsHandler:    enableEpochsHandler,
+	}, nil
+}
+
+// GetLockedBalance returns the quantity of the token which cannot be spent yet by the account
+func (e *esdtVestingHandler) GetLockedBalance(account vmcommon.UserAccountHandler, tokenID []byte) (*big.Int, error) {
+	lockedBalance := big.NewInt(0)
+	if !e.enableEpochsHandler.IsFlagEnabled(ESDTVestingFlag) {
+		return lockedBalance, nil
+	}
+
+	schedules, err := getVestingSchedules(account, tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentRound := e.CurrentRound()
+	for _, schedule := range schedules {
+		if schedule.UnlockRound > currentRound {
+			lockedBalance.Add(lockedBalance, schedule.Amount)
+		}
+	}
+
+	return lockedBalance, nil
+}
+
+// AddVestingSchedules locks the given quantities on the account, which must already hold them. The quantities locked
+// until the same round are kept in a single schedule and no schedule can be locked for more than maxVestingRoundsAhead
+// rounds
+func (e *esdtVestingHandler) AddVestingSchedules(account vmcommon.UserAccountHandler, tokenID []byte, newSchedules []*VestingSchedule) error {
+	maxUnlockRound := e.CurrentRound() + maxVestingRoundsAhead
+	for _, schedule := range newSchedules {
+		if schedule.UnlockRound > maxUnlockRound {
+			return fmt.Errorf("%w, unlock round %d is after the maximum unlock round %d", ErrInvalidVestingSchedule, schedule.UnlockRound, maxUnlockRound)
+		}
+	}
+
+	schedules, err := getVestingSchedules(account, tokenID)
+	if err != nil {
+		return err
+	}
+
+	schedules = mergeVestingSchedules(schedules, newSchedules)
+	if len(schedules) > maxNumVestingSchedules {
+		return fmt.Errorf("%w, maximum is %d", ErrTooManyVestingSchedules, maxNumVestingSchedules)
+	}
+
+	return saveVestingSchedules(account, tokenID, schedules)
+}
+
+// ReleaseUnlockedSchedules removes the schedules which reached their unlock round and returns them
+func (e *esdtVestingHandler) ReleaseUnlockedSchedules(account vmcommon.UserAccountHandler, tokenID []byte) ([]*VestingSchedule, error) {
+	schedules, err := getVestingSchedules(account, tokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	currentRound := e.CurrentRound()
+	released := make([]*VestingSchedule, 0, len(schedules))
+	remaining := make([]*VestingSchedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		if schedule.UnlockRound <= currentRound {
+			released = append(released, schedule)
+			continue
+		}
+
+		remaining = append(remaining, schedule)
+	}
+	if len(released) == 0 {
+		return released, nil
+	}
+
+	err = saveVestingSchedules(account, tokenID, remaining)
+	if err != nil {
+		return nil, err
+	}
+
+	return released, nil
+}
+
+// mergeVestingSchedules adds the new schedules to the existing ones, summing up the quantities locked until the same round
+func mergeVestingSchedules(schedules []*VestingSchedule, newSchedules []*VestingSchedule) []*VestingSchedule {
+	for _, newSchedule := range newSchedules {
+		isMerged := false
+		for _, schedule := range schedules {
+			if schedule.UnlockRound == newSchedule.UnlockRound {
+				schedule.Amount = big.NewInt(0).Add(schedule.Amount, newSchedule.Amount)
+				isMerged = true
+				break
+			}
+		}
+		if isMerged {
+			continue
+		}
+
+		schedules = append(schedules, &VestingSchedule{
+			UnlockRound: newSchedule.UnlockRound,
+			Amount:      big.NewInt(0).Set(newSchedule.Amount),
+		})
+	}
+
+	return schedules
+}
+
+// checkLockedESDTAmount returns an error if the balance left on the account is lower than its locked balance
+func checkLockedESDTAmount(
+	lockedBalanceHandler ESDTLockedBalanceHandler,
+	account vmcommon.UserAccountHandler,
+	esdtTokenKey []byte,
+	esdtData *esdt.ESDigitalToken,
+	isReturnWithError bool,
+) error {
+	if isReturnWithError || bytes.Equal(account.AddressBytes(), core.ESDTSCAddress) {
+		return nil
+	}
+	if check.IfNil(lockedBalanceHandler) {
+		return ErrNilLockedBalanceHandler
+	}
+
+	tokenID := bytes.TrimPrefix(esdtTokenKey, []byte(baseESDTKeyPrefix))
+	lockedBalance, err := lockedBalanceHandler.GetLockedBalance(account, tokenID)
+	if err != nil {
+		return err
+	}
+	if esdtData.Value.Cmp(lockedBalance) < 0 {
+		return fmt.Errorf("%w for token %s, locked balance is %s", ErrInsufficientUnlockedBalance, tokenID, lockedBalance)
+	}
+
+	return nil
+}
+
+func getVestingSchedules(account vmcommon.UserAccountHandler, tokenID []byte) ([]*VestingSchedule, error) {
+	marshalledSchedules, _, err := account.AccountDataHandler().RetrieveValue(getESDTVestingKey(tokenID))
+	if core.IsGetNodeFromDBError(err) {
+		return nil, err
+	}
+	if err != nil || len(marshalledSchedules) == 0 {
+		return make([]*VestingSchedule, 0), nil
+	}
+
+	return decodeVestingSchedules(marshalledSchedules)
+}
+
+func saveVestingSchedules(account vmcommon.UserAccountHandler, tokenID []byte, schedules []*VestingSchedule) error {
+	return account.AccountDataHandler().SaveKeyValue(getESDTVestingKey(tokenID), encodeVestingSchedules(schedules))
+}
+
+// encodeVestingSchedules writes each schedule as the unlock round on 8 bytes, followed by the length of the amount
+// and the amount bytes
+func encodeVestingSchedules(schedules []*VestingSchedule) []byte {
+	if len(schedules) == 0 {
+		return nil
+	}
+
+	encoded := make([]byte, 0, len(schedules)*vestingScheduleHeaderBytes)
+	for _, schedule := range schedules {
+		amount := schedule.Amount.Bytes()
+		encoded = binary.BigEndian.AppendUint64(encoded, schedule.UnlockRound)
+		encoded = append(encoded, byte(len(amount)))
+		encoded = append(encoded, amount...)
+	}
+
+	return encoded
+}
+
+func decodeVestingSchedules(encoded []byte) ([]*VestingSchedule, error) {
+	schedules := make([]*VestingSchedule, 0)
+	for len(encoded) > 0 {
+		if len(encoded) < vestingScheduleHeaderBytes {
+			return nil, ErrInvalidVestingSchedule
+		}
+
+		unlockRound := binary.BigEndian.Uint64(encoded[:vestingUnlockRoundLength])
+		amountLength := int(encoded[vestingUnlockRoundLength])
+		encoded = encoded[vestingScheduleHeaderBytes:]
+		if len(encoded) < amountLength {
+			return nil, ErrInvalidVestingSchedule
+		}
+
+		schedules = append(schedules, &VestingSchedule{
+			UnlockRound: unlockRound,
+			Amount:      big.NewInt(0).SetBytes(encoded[:amountLength]),
+		})
+		encoded = encoded[amountLength:]
+	}
+
+	return schedules, nil
+}
+
+func getESDTVestingKey(tokenID []byte) []byte {
+	return append([]byte(esdtVestingKeyPrefix), tokenID...)
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (e *esdtVestingHandler) IsInterfaceNil() bool {
+	return e == nil
+}
@@ -0,0 +1,282 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const (
+	minNumArgsESDTVestingTransfer = 4
+	numArgsPerVestingSchedule     = 2
+)
+
+// ESDTVestingFuncArgs holds the arguments needed to create the esdt vesting built-in functions
+type ESDTVestingFuncArgs struct {
+	FuncGasCost           uint64
+	Marshaller            vmcommon.Marshalizer
+	GlobalSettingsHandler vmcommon.ExtendedESDTGlobalSettingsHandler
+	RolesHandler          vmcommon.ESDTRoleHandler
+	VestingHandler        ESDTVestingHandler
+	EnableEpochsHandler   vmcommon.EnableEpochsHandler
+	// AccessListChecker, TransferFeeHandler and SpendingPolicyHandler are only used by the vesting transfer function
+	AccessListChecker     ESDTTransferAccessListChecker
+	TransferFeeHandler    ESDTTransferFeeHandler
+	SpendingPolicyHandler GuardedSpendingPolicyHandler
+}
+
+func checkESDTVestingFuncArgs(args ESDTVestingFuncArgs) error {
+	if check.IfNil(args.Marshaller) {
+		return ErrNilMarshalizer
+	}
+	if check.IfNil(args.GlobalSettingsHandler) {
+		return ErrNilGlobalSettingsHandler
+	}
+	if check.IfNil(args.RolesHandler) {
+		return ErrNilRolesHandler
+	}
+	if check.IfNil(args.VestingHandler) {
+		return ErrNilVestingHandler
+	}
+	if check.IfNil(args.EnableEpochsHandler) {
+		return ErrNilEnableEpochsHandler
+	}
+
+	return nil
+}
+
+type esdtVestingTransfer struct {
+	baseActiveHandler
+	keyPrefix             []byte
+	marshaller            vmcommon.Marshalizer
+	globalSettingsHandler vmcommon.ExtendedESDTGlobalSettingsHandler
+	enableEpochsHandler   vmcommon.EnableEpochsHandler
+	rolesHandler          vmcommon.ESDTRoleHandler
+	vestingHandler        ESDTVestingHandler
+	accessListChecker     ESDTTransferAccessListChecker
+	transferFeeHandler    ESDTTransferFeeHandler
+	spendingPolicyHandler GuardedSpendingPolicyHandler
+	funcGasCost           uint64
+	mutExecution          sync.RWMutex
+}
+
+// NewESDTVestingTransferFunc returns the esdt vesting transfer built-in function component, which transfers fungible
+// tokens to a user account and locks them there according to the provided vesting schedules
+func NewESDTVestingTransferFunc(args ESDTVestingFuncArgs) (*esdtVestingTransfer, error) {
+	err := checkESDTVestingFuncArgs(args)
+	if err != nil {
+		return nil, err
+	}
+	if check.IfNil(args.AccessListChecker) {
+		return nil, ErrNilAccessListChecker
+	}
+	if check.IfNil(args.TransferFeeHandler) {
+		return nil, ErrNilTransferFeeHandler
+	}
+	if check.IfNil(args.SpendingPolicyHandler) {
+		return nil, ErrNilSpendingPolicyHandler
+	}
+
+	e := &esdtVestingTransfer{
+		keyPrefix:             []byte(baseESDTKeyPrefix),
+		marshaller:            args.Marshaller,
+		globalSettingsHandler: args.GlobalSettingsHandler,
+		enableEpochsHandler:   args.EnableEpochsHandler,
+		rolesHandler:          args.RolesHandler,
+		vestingHandler:        args.VestingHandler,
+		accessListChecker:     args.AccessListChecker,
+		transferFeeHandler:    args.TransferFeeHandler,
+		spendingPolicyHandler: args.SpendingPolicyHandler,
+		funcGasCost:           args.FuncGasCost,
+		mutExecution:          sync.RWMutex{},
+	}
+
+	e.baseActiveHandler.activeHandler = func() bool {
+		return args.EnableEpochsHandler.IsFlagEnabled(ESDTVestingFlag)
+	}
+
+	return e, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (e *esdtVestingTransfer) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	e.mutExecution.Lock()
+	e.funcGasCost = gasCost.BuiltInCost.ESDTVestingTransfer
+	e.mutExecution.Unlock()
+}
+
+// ProcessBuiltinFunction resolves ESDT vesting transfer function calls. The tokens, together with the transfer fee of
+// the token, are deducted on the sender shard and are added and locked on the receiver shard. Schedules having the
+// unlock round already passed are spendable right away. Vesting transfers move fungible tokens only, so the transfer
+// fee policy of the token always applies
+// Requires at least 4 arguments:
+// arg0 - token identifier
+// arg1 - quantity to transfer
+// arg2... - vesting schedules as (unlock round, locked quantity) pairs, the locked quantities not exceeding arg1
+func (e *esdtVestingTransfer) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	e.mutExecution.RLock()
+	defer e.mutExecution.RUnlock()
+
+	err := checkBasicESDTArguments(vmInput)
+	if err != nil {
+		return nil, err
+	}
+	if len(vmInput.Arguments) < minNumArgsESDTVestingTransfer || len(vmInput.Arguments)%numArgsPerVestingSchedule != 0 {
+		return nil, ErrInvalidNumOfArgs
+	}
+	if bytes.Equal(vmInput.CallerAddr, vmInput.RecipientAddr) || vmcommon.IsSmartContractAddress(vmInput.RecipientAddr) {
+		return nil, ErrInvalidRcvAddr
+	}
+
+	tokenID := vmInput.Arguments[0]
+	value, err := getVestingQuantity(vmInput.Arguments[1])
+	if err != nil {
+		return nil, err
+	}
+	schedules, err := getVestingSchedulesFromArguments(vmInput.Arguments[2:], value)
+	if err != nil {
+		return nil, err
+	}
+
+	esdtTokenKey := append(e.keyPrefix, tokenID...)
+	err = checkIfTransferCanHappenWithLimitedTransfer(tokenID, esdtTokenKey, vmInput.CallerAddr, vmInput.RecipientAddr, e.globalSettingsHandler, e.accessListChecker, e.rolesHandler, acntSnd, acntDst, vmInput.ReturnCallAfterError)
+	if err != nil {
+		return nil, err
+	}
+
+	fee := big.NewInt(0)
+	var feeReceiver []byte
+	if !check.IfNil(acntSnd) {
+		if vmInput.GasProvided < e.funcGasCost {
+			return nil, ErrNotEnoughGas
+		}
+
+		// the transfer fee is paid by the sender on top of the transferred value
+		if !vmInput.ReturnCallAfterError {
+			fee, feeReceiver, err = e.transferFeeHandler.ComputeTransferFee(tokenID, vmInput.CallerAddr, vmInput.RecipientAddr, value)
+			if err != nil {
+				return nil, err
+			}
+			err = e.spendingPolicyHandler.CheckUnguardedTransfer(acntSnd, &vmInput.VMInput, vmInput.RecipientAddr, tokenID, value)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		valueToDeduct := big.NewInt(0).Add(value, fee)
+		err = addToESDTBalance(acntSnd, esdtTokenKey, valueToDeduct.Neg(valueToDeduct), e.marshaller, e.globalSettingsHandler, e.vestingHandler, e.enableEpochsHandler, vmInput.ReturnCallAfterError)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	vmOutput := &vmcommon.VMOutput{
+		ReturnCode:   vmcommon.Ok,
+		GasRemaining: computeGasRemaining(acntSnd, vmInput.GasProvided, e.funcGasCost),
+	}
+	if fee.Sign() > 0 {
+		err = e.transferFeeHandler.CollectTransferFee(acntSnd, vmInput.RecipientAddr, tokenID, fee, feeReceiver, vmOutput)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !check.IfNil(acntDst) {
+		err = addToESDTBalance(acntDst, esdtTokenKey, value, e.marshaller, e.globalSettingsHandler, e.vestingHandler, e.enableEpochsHandler, vmInput.ReturnCallAfterError)
+		if err != nil {
+			return nil, err
+		}
+		err = e.vestingHandler.AddVestingSchedules(acntDst, tokenID, schedules)
+		if err != nil {
+			return nil, err
+		}
+	} else if vmcommon.IsSmartContractAddress(vmInput.CallerAddr) {
+		// cross-shard vesting transfer called by a smart contract
+		addOutputTransferToVMOutput(
+			1,
+			vmInput.CallerAddr,
+			vmcommon.BuiltInFunctionESDTVestingTransfer,
+			vmInput.Arguments,
+			vmInput.RecipientAddr,
+			vmInput.GasLocked,
+			vmInput.CallType,
+			vmOutput)
+	}
+
+	logArgs := [][]byte{vmInput.CallerAddr, vmInput.RecipientAddr}
+	for _, schedule := range schedules {
+		logArgs = append(logArgs, big.NewInt(0).SetUint64(schedule.UnlockRound).Bytes(), schedule.Amount.Bytes())
+	}
+	addESDTEntryInVMOutput(vmOutput, []byte(vmcommon.BuiltInFunctionESDTVestingTransfer), tokenID, 0, value, logArgs...)
+
+	return vmOutput, nil
+}
+
+func getVestingQuantity(arg []byte) (*big.Int, error) {
+	if len(arg) > core.MaxLenForESDTIssueMint {
+		return nil, fmt.Errorf("%w: max length for esdt vesting quantity is %d", ErrInvalidArguments, core.MaxLenForESDTIssueMint)
+	}
+
+	quantity := big.NewInt(0).SetBytes(arg)
+	if quantity.Sign() == 0 {
+		return nil, ErrNegativeValue
+	}
+
+	return quantity, nil
+}
+
+func getVestingSchedulesFromArguments(args [][]byte, value *big.Int) ([]*VestingSchedule, error) {
+	numSchedules := len(args) / numArgsPerVestingSchedule
+	if numSchedules > maxNumVestingSchedules {
+		return nil, fmt.Errorf("%w, maximum is %d", ErrTooManyVestingSchedules, maxNumVestingSchedules)
+	}
+
+	totalLocked := big.NewInt(0)
+	schedules := make([]*VestingSchedule, 0, numSchedules)
+	for i := 0; i < len(args); i += numArgsPerVestingSchedule {
+		if len(args[i]) > vestingUnlockRoundLength {
+			return nil, fmt.Errorf("%w, invalid unlock round", ErrInvalidVestingSchedule)
+		}
+
+		amount, err := getVestingQuantity(args[i+1])
+		if err != nil {
+			return nil, err
+		}
+
+		totalLocked.Add(totalLocked, amount)
+		schedules = append(schedules, &VestingSchedule{
+			UnlockRound: getUIn46FromBytes(args[i]),
+			Amount:      amount,
+		})
+	}
+	if totalLocked.Cmp(value) > 0 {
+		return nil, fmt.Errorf("%w, locked quantity %s is higher than the transferred quantity %s", ErrInvalidVestingSchedule, totalLocked, value)
+	}
+
+	return schedules, nil
+}
+
+func getFungibleESDTBalance(account vmcommon.UserAccountHandler, esdtTokenKey []byte, marshaller vmcommon.Marshalizer) (*big.Int, error) {
+	esdtData, err := getESDTDataFromKey(account, esdtTokenKey, marshaller)
+	if err != nil {
+		return nil, err
+	}
+
+	return esdtData.Value, nil
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (e *esdtVestingTransfer) IsInterfaceNil() bool {
+	return e == nil
+}
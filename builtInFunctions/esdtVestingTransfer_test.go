@@ -0,0 +1,353 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	vestingTokenID  = []byte("TKN-123456")
+	vestingSender   = []byte("vesting-sender-address-12345678")
+	vestingReceiver = []byte("vesting-receiver-address-123456")
+)
+
+func createMockESDTVestingFuncArgs(currentRound *uint64) ESDTVestingFuncArgs {
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return flag == ESDTVestingFlag
+		},
+	}
+	vestingHandler, _ := NewESDTVestingHandler(enableEpochsHandler)
+	_ = vestingHandler.SetBlockchainHook(&mock.BlockDataHandlerStub{
+		CurrentRoundCalled: func() uint64 {
+			return *currentRound
+		},
+	})
+
+	return ESDTVestingFuncArgs{
+		FuncGasCost:           10,
+		Marshaller:            &mock.MarshalizerMock{},
+		GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+		RolesHandler:          &mock.ESDTRoleHandlerStub{},
+		VestingHandler:        vestingHandler,
+		EnableEpochsHandler:   enableEpochsHandler,
+		AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
+		TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+		SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+	}
+}
+
+func createESDTVestingInput(caller []byte, recipient []byte, args ...[]byte) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  caller,
+			CallValue:   big.NewInt(0),
+			Arguments:   args,
+			GasProvided: 100,
+		},
+		RecipientAddr: recipient,
+	}
+}
+
+func setFungibleESDTBalance(t *testing.T, account vmcommon.UserAccountHandler, tokenID []byte, value int64) {
+	marshalledData, _ := (&mock.MarshalizerMock{}).Marshal(&esdt.ESDigitalToken{Value: big.NewInt(value)})
+	err := account.AccountDataHandler().SaveKeyValue(append([]byte(baseESDTKeyPrefix), tokenID...), marshalledData)
+	require.Nil(t, err)
+}
+
+func requireFungibleESDTBalance(t *testing.T, account vmcommon.UserAccountHandler, tokenID []byte, expected int64) {
+	balance, err := getFungibleESDTBalance(account, append([]byte(baseESDTKeyPrefix), tokenID...), &mock.MarshalizerMock{})
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(expected), balance)
+}
+
+func TestNewESDTVestingFuncs(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		modify      func(args *ESDTVestingFuncArgs)
+		expectedErr error
+	}{
+		{name: "nil marshaller", modify: func(args *ESDTVestingFuncArgs) { args.Marshaller = nil }, expectedErr: ErrNilMarshalizer},
+		{name: "nil global settings handler", modify: func(args *ESDTVestingFuncArgs) { args.GlobalSettingsHandler = nil }, expectedErr: ErrNilGlobalSettingsHandler},
+		{name: "nil roles handler", modify: func(args *ESDTVestingFuncArgs) { args.RolesHandler = nil }, expectedErr: ErrNilRolesHandler},
+		{name: "nil vesting handler", modify: func(args *ESDTVestingFuncArgs) { args.VestingHandler = nil }, expectedErr: ErrNilVestingHandler},
+		{name: "nil enable epochs handler", modify: func(args *ESDTVestingFuncArgs) { args.EnableEpochsHandler = nil }, expectedErr: ErrNilEnableEpochsHandler},
+	}
+	for _, test := range tests {
+		currentRound := uint64(0)
+		args := createMockESDTVestingFuncArgs(&currentRound)
+		test.modify(&args)
+
+		vestingTransfer, err := NewESDTVestingTransferFunc(args)
+		assert.True(t, check.IfNil(vestingTransfer), test.name)
+		assert.Equal(t, test.expectedErr, err, test.name)
+
+		releaseVesting, err := NewESDTReleaseVestingFunc(args)
+		assert.True(t, check.IfNil(releaseVesting), test.name)
+		assert.Equal(t, test.expectedErr, err, test.name)
+	}
+
+	currentRound := uint64(0)
+	args := createMockESDTVestingFuncArgs(&currentRound)
+	args.AccessListChecker = nil
+	vestingTransfer, err := NewESDTVestingTransferFunc(args)
+	assert.True(t, check.IfNil(vestingTransfer))
+	assert.Equal(t, ErrNilAccessListChecker, err)
+
+	args = createMockESDTVestingFuncArgs(&currentRound)
+	args.TransferFeeHandler = nil
+	vestingTransfer, err = NewESDTVestingTransferFunc(args)
+	assert.True(t, check.IfNil(vestingTransfer))
+	assert.Equal(t, ErrNilTransferFeeHandler, err)
+
+	args = createMockESDTVestingFuncArgs(&currentRound)
+	args.SpendingPolicyHandler = nil
+	vestingTransfer, err = NewESDTVestingTransferFunc(args)
+	assert.True(t, check.IfNil(vestingTransfer))
+	assert.Equal(t, ErrNilSpendingPolicyHandler, err)
+
+	args = createMockESDTVestingFuncArgs(&currentRound)
+	vestingTransfer, err = NewESDTVestingTransferFunc(args)
+	require.Nil(t, err)
+	assert.True(t, vestingTransfer.IsActive())
+	vestingTransfer.SetNewGasConfig(&vmcommon.GasCost{BuiltInCost: vmcommon.BuiltInCost{ESDTVestingTransfer: 20}})
+	assert.Equal(t, uint64(20), vestingTransfer.funcGasCost)
+
+	releaseVesting, err := NewESDTReleaseVestingFunc(args)
+	require.Nil(t, err)
+	assert.True(t, releaseVesting.IsActive())
+	releaseVesting.SetNewGasConfig(&vmcommon.GasCost{BuiltInCost: vmcommon.BuiltInCost{ESDTReleaseVesting: 30}})
+	assert.Equal(t, uint64(30), releaseVesting.funcGasCost)
+}
+
+func TestESDTVestingTransfer_ProcessBuiltinFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	currentRound := uint64(0)
+	vestingTransfer, _ := NewESDTVestingTransferFunc(createMockESDTVestingFuncArgs(&currentRound))
+	sender := mock.NewUserAccount(vestingSender)
+	receiver := mock.NewUserAccount(vestingReceiver)
+	setFungibleESDTBalance(t, sender, vestingTokenID, 100)
+
+	_, err := vestingTransfer.ProcessBuiltinFunction(sender, receiver, nil)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	input := createESDTVestingInput(vestingSender, vestingReceiver, vestingTokenID, big.NewInt(10).Bytes(), big.NewInt(10).Bytes())
+	_, err = vestingTransfer.ProcessBuiltinFunction(sender, receiver, input)
+	assert.Equal(t, ErrInvalidNumOfArgs, err)
+
+	input = createESDTVestingInput(vestingSender, vestingSender, vestingTokenID, big.NewInt(10).Bytes(), big.NewInt(10).Bytes(), big.NewInt(10).Bytes())
+	_, err = vestingTransfer.ProcessBuiltinFunction(sender, sender, input)
+	assert.Equal(t, ErrInvalidRcvAddr, err)
+
+	scAddress := make([]byte, len(vestingReceiver))
+	input = createESDTVestingInput(vestingSender, scAddress, vestingTokenID, big.NewInt(10).Bytes(), big.NewInt(10).Bytes(), big.NewInt(10).Bytes())
+	_, err = vestingTransfer.ProcessBuiltinFunction(sender, nil, input)
+	assert.Equal(t, ErrInvalidRcvAddr, err)
+
+	input = createESDTVestingInput(vestingSender, vestingReceiver, vestingTokenID, big.NewInt(10).Bytes(), big.NewInt(10).Bytes(), big.NewInt(11).Bytes())
+	_, err = vestingTransfer.ProcessBuiltinFunction(sender, receiver, input)
+	assert.True(t, errors.Is(err, ErrInvalidVestingSchedule))
+
+	input = createESDTVestingInput(vestingSender, vestingReceiver, vestingTokenID, big.NewInt(10).Bytes(), make([]byte, vestingUnlockRoundLength+1), big.NewInt(10).Bytes())
+	_, err = vestingTransfer.ProcessBuiltinFunction(sender, receiver, input)
+	assert.True(t, errors.Is(err, ErrInvalidVestingSchedule))
+
+	input = createESDTVestingInput(vestingSender, vestingReceiver, vestingTokenID, big.NewInt(10).Bytes(), big.NewInt(10).Bytes(), []byte{})
+	_, err = vestingTransfer.ProcessBuiltinFunction(sender, receiver, input)
+	assert.Equal(t, ErrNegativeValue, err)
+
+	input = createESDTVestingInput(vestingSender, vestingReceiver, vestingTokenID, big.NewInt(10).Bytes(), big.NewInt(10).Bytes(), big.NewInt(10).Bytes())
+	input.GasProvided = 1
+	_, err = vestingTransfer.ProcessBuiltinFunction(sender, receiver, input)
+	assert.Equal(t, ErrNotEnoughGas, err)
+
+	input = createESDTVestingInput(vestingSender, vestingReceiver, vestingTokenID, big.NewInt(101).Bytes(), big.NewInt(10).Bytes(), big.NewInt(10).Bytes())
+	_, err = vestingTransfer.ProcessBuiltinFunction(sender, receiver, input)
+	assert.Equal(t, ErrInsufficientFunds, err)
+}
+
+func TestESDTVestingTransfer_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	currentRound := uint64(5)
+	args := createMockESDTVestingFuncArgs(&currentRound)
+	vestingTransfer, _ := NewESDTVestingTransferFunc(args)
+	releaseVesting, _ := NewESDTReleaseVestingFunc(args)
+	sender := mock.NewUserAccount(vestingSender)
+	receiver := mock.NewUserAccount(vestingReceiver)
+	setFungibleESDTBalance(t, sender, vestingTokenID, 100)
+
+	input := createESDTVestingInput(vestingSender, vestingReceiver, vestingTokenID, big.NewInt(100).Bytes(),
+		big.NewInt(10).Bytes(), big.NewInt(40).Bytes(),
+		big.NewInt(20).Bytes(), big.NewInt(50).Bytes())
+	vmOutput, err := vestingTransfer.ProcessBuiltinFunction(sender, receiver, input)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(90), vmOutput.GasRemaining)
+	requireFungibleESDTBalance(t, sender, vestingTokenID, 0)
+	requireFungibleESDTBalance(t, receiver, vestingTokenID, 100)
+	require.Len(t, vmOutput.Logs, 1)
+	assert.Equal(t, []byte(vmcommon.BuiltInFunctionESDTVestingTransfer), vmOutput.Logs[0].Identifier)
+	assert.Equal(t, vestingSender, vmOutput.Logs[0].Address)
+	assert.Equal(t, [][]byte{vestingTokenID, {}, big.NewInt(100).Bytes(), vestingReceiver, {10}, {40}, {20}, {50}}, vmOutput.Logs[0].Topics)
+
+	_, err = releaseVesting.ProcessBuiltinFunction(receiver, nil, createESDTVestingInput(vestingReceiver, vestingReceiver, vestingTokenID))
+	assert.Equal(t, ErrNoUnlockedVestingSchedules, err)
+
+	// only the not locked quantity can be transferred back
+	input = createESDTVestingInput(vestingReceiver, vestingSender, vestingTokenID, big.NewInt(11).Bytes(), big.NewInt(1).Bytes(), big.NewInt(1).Bytes())
+	_, err = vestingTransfer.ProcessBuiltinFunction(receiver, sender, input)
+	assert.True(t, errors.Is(err, ErrInsufficientUnlockedBalance))
+	// failed transactions are reverted by the caller
+	setFungibleESDTBalance(t, receiver, vestingTokenID, 100)
+
+	currentRound = 10
+	input = createESDTVestingInput(vestingReceiver, vestingSender, vestingTokenID, big.NewInt(50).Bytes(), big.NewInt(1).Bytes(), big.NewInt(1).Bytes())
+	_, err = vestingTransfer.ProcessBuiltinFunction(receiver, sender, input)
+	require.Nil(t, err)
+	requireFungibleESDTBalance(t, receiver, vestingTokenID, 50)
+
+	vmOutput, err = releaseVesting.ProcessBuiltinFunction(receiver, nil, createESDTVestingInput(vestingReceiver, vestingReceiver, vestingTokenID))
+	require.Nil(t, err)
+	assert.Equal(t, uint64(90), vmOutput.GasRemaining)
+	require.Len(t, vmOutput.Logs, 1)
+	assert.Equal(t, []byte(vmcommon.BuiltInFunctionESDTReleaseVesting), vmOutput.Logs[0].Identifier)
+	assert.Equal(t, vestingReceiver, vmOutput.Logs[0].Address)
+	assert.Equal(t, [][]byte{vestingTokenID, {}, big.NewInt(40).Bytes(), {10}}, vmOutput.Logs[0].Topics)
+
+	lockedBalance, err := args.VestingHandler.GetLockedBalance(receiver, vestingTokenID)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(50), lockedBalance)
+}
+
+func TestESDTVestingTransfer_ProcessBuiltinFunctionWithTransferFee(t *testing.T) {
+	t.Parallel()
+
+	currentRound := uint64(5)
+	feeReceiver := []byte("fee-receiver-address-1234567890")
+	args := createMockESDTVestingFuncArgs(&currentRound)
+	args.TransferFeeHandler = &mock.ESDTTransferFeeHandlerStub{
+		ComputeTransferFeeCalled: func(tokenID []byte, sender []byte, destination []byte, value *big.Int) (*big.Int, []byte, error) {
+			return big.NewInt(0).Div(value, big.NewInt(10)), feeReceiver, nil
+		},
+		CollectTransferFeeCalled: func(acntSnd vmcommon.UserAccountHandler, destination []byte, tokenID []byte, fee *big.Int, receiver []byte, vmOutput *vmcommon.VMOutput) error {
+			assert.Equal(t, vestingReceiver, destination)
+			assert.Equal(t, big.NewInt(5), fee)
+			assert.Equal(t, feeReceiver, receiver)
+			addFeeTransferToVMOutput(acntSnd.AddressBytes(), receiver, core.BuiltInFunctionESDTTransfer, [][]byte{tokenID, fee.Bytes()}, vmOutput)
+			return nil
+		},
+	}
+	vestingTransfer, _ := NewESDTVestingTransferFunc(args)
+	sender := mock.NewUserAccount(vestingSender)
+	receiver := mock.NewUserAccount(vestingReceiver)
+	setFungibleESDTBalance(t, sender, vestingTokenID, 100)
+
+	input := createESDTVestingInput(vestingSender, vestingReceiver, vestingTokenID, big.NewInt(50).Bytes(), big.NewInt(0).Bytes(), big.NewInt(1).Bytes())
+	vmOutput, err := vestingTransfer.ProcessBuiltinFunction(sender, receiver, input)
+	require.Nil(t, err)
+	requireFungibleESDTBalance(t, sender, vestingTokenID, 45)
+	requireFungibleESDTBalance(t, receiver, vestingTokenID, 50)
+	require.Len(t, vmOutput.OutputAccounts[string(feeReceiver)].OutputTransfers, 1)
+
+	input = createESDTVestingInput(vestingSender, vestingReceiver, vestingTokenID, big.NewInt(45).Bytes(), big.NewInt(0).Bytes(), big.NewInt(1).Bytes())
+	_, err = vestingTransfer.ProcessBuiltinFunction(sender, receiver, input)
+	assert.Equal(t, ErrInsufficientFunds, err)
+}
+
+func TestESDTVestingTransfer_ProcessBuiltinFunctionChecksTheSpendingPolicy(t *testing.T) {
+	t.Parallel()
+
+	currentRound := uint64(5)
+	numChecks := 0
+	args := createMockESDTVestingFuncArgs(&currentRound)
+	args.SpendingPolicyHandler = &mock.GuardedSpendingPolicyHandlerStub{
+		CheckUnguardedTransferCalled: func(acntSnd vmcommon.UserAccountHandler, vmInput *vmcommon.VMInput, destination []byte, tokenID []byte, value *big.Int) error {
+			numChecks++
+			assert.Equal(t, vestingReceiver, destination)
+			assert.Equal(t, vestingTokenID, tokenID)
+			if value.Cmp(big.NewInt(10)) > 0 {
+				return ErrGuardianSignatureRequired
+			}
+			return nil
+		},
+	}
+	vestingTransfer, _ := NewESDTVestingTransferFunc(args)
+	sender := mock.NewUserAccount(vestingSender)
+	receiver := mock.NewUserAccount(vestingReceiver)
+	setFungibleESDTBalance(t, sender, vestingTokenID, 100)
+
+	input := createESDTVestingInput(vestingSender, vestingReceiver, vestingTokenID, big.NewInt(50).Bytes(), big.NewInt(0).Bytes(), big.NewInt(1).Bytes())
+	_, err := vestingTransfer.ProcessBuiltinFunction(sender, receiver, input)
+	assert.Equal(t, ErrGuardianSignatureRequired, err)
+	requireFungibleESDTBalance(t, sender, vestingTokenID, 100)
+
+	input = createESDTVestingInput(vestingSender, vestingReceiver, vestingTokenID, big.NewInt(10).Bytes(), big.NewInt(0).Bytes(), big.NewInt(1).Bytes())
+	_, err = vestingTransfer.ProcessBuiltinFunction(sender, receiver, input)
+	require.Nil(t, err)
+	requireFungibleESDTBalance(t, sender, vestingTokenID, 90)
+
+	_, err = vestingTransfer.ProcessBuiltinFunction(nil, receiver, input)
+	require.Nil(t, err)
+	assert.Equal(t, 2, numChecks)
+}
+
+func TestESDTVestingTransfer_ProcessBuiltinFunctionCrossShard(t *testing.T) {
+	t.Parallel()
+
+	currentRound := uint64(5)
+	args := createMockESDTVestingFuncArgs(&currentRound)
+	vestingTransfer, _ := NewESDTVestingTransferFunc(args)
+	receiver := mock.NewUserAccount(vestingReceiver)
+
+	input := createESDTVestingInput(vestingSender, vestingReceiver, vestingTokenID, big.NewInt(100).Bytes(), big.NewInt(10).Bytes(), big.NewInt(40).Bytes())
+	vmOutput, err := vestingTransfer.ProcessBuiltinFunction(nil, receiver, input)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(0), vmOutput.GasRemaining)
+	requireFungibleESDTBalance(t, receiver, vestingTokenID, 100)
+
+	lockedBalance, err := args.VestingHandler.GetLockedBalance(receiver, vestingTokenID)
+	require.Nil(t, err)
+	assert.Equal(t, big.NewInt(40), lockedBalance)
+}
+
+func TestESDTReleaseVesting_ProcessBuiltinFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	currentRound := uint64(0)
+	releaseVesting, _ := NewESDTReleaseVestingFunc(createMockESDTVestingFuncArgs(&currentRound))
+	account := mock.NewUserAccount(vestingReceiver)
+
+	_, err := releaseVesting.ProcessBuiltinFunction(account, nil, nil)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	input := createESDTVestingInput(vestingReceiver, vestingReceiver, vestingTokenID)
+	input.CallValue = big.NewInt(1)
+	_, err = releaseVesting.ProcessBuiltinFunction(account, nil, input)
+	assert.Equal(t, ErrBuiltInFunctionCalledWithValue, err)
+
+	_, err = releaseVesting.ProcessBuiltinFunction(account, nil, createESDTVestingInput(vestingReceiver, vestingReceiver))
+	assert.Equal(t, ErrInvalidNumOfArgs, err)
+
+	_, err = releaseVesting.ProcessBuiltinFunction(account, nil, createESDTVestingInput(vestingReceiver, vestingSender, vestingTokenID))
+	assert.Equal(t, ErrInvalidRcvAddr, err)
+
+	_, err = releaseVesting.ProcessBuiltinFunction(nil, nil, createESDTVestingInput(vestingReceiver, vestingReceiver, vestingTokenID))
+	assert.Equal(t, ErrNilUserAccount, err)
+
+	input = createESDTVestingInput(vestingReceiver, vestingReceiver, vestingTokenID)
+	input.GasProvided = 1
+	_, err = releaseVesting.ProcessBuiltinFunction(account, nil, input)
+	assert.Equal(t, ErrNotEnoughGas, err)
+}
@@ -0,0 +1,235 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createESDTVestingHandlerAtRound(t *testing.T, currentRound *uint64) *esdtVestingHandler {
+	vestingHandler, err := NewESDTVestingHandler(&mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return flag == ESDTVestingFlag
+		},
+	})
+	require.Nil(t, err)
+
+	err = vestingHandler.SetBlockchainHook(&mock.BlockDataHandlerStub{
+		CurrentRoundCalled: func() uint64 {
+			return *currentRound
+		},
+	})
+	require.Nil(t, err)
+
+	return vestingHandler
+}
+
+func TestNewESDTVestingHandler(t *testing.T) {
+	t.Parallel()
+
+	vestingHandler, err := NewESDTVestingHandler(nil)
+	assert.True(t, check.IfNil(vestingHandler))
+	assert.Equal(t, ErrNilEnableEpochsHandler, err)
+
+	vestingHandler, err = NewESDTVestingHandler(&mock.EnableEpochsHandlerStub{})
+	assert.False(t, check.IfNil(vestingHandler))
+	assert.Nil(t, err)
+}
+
+func TestESDTVestingHandler_GetLockedBalance(t *testing.T) {
+	t.Parallel()
+
+	tokenID := []byte("TKN-123456")
+	schedules := []*VestingSchedule{{UnlockRound: 10, Amount: big.NewInt(40)}, {UnlockRound: 20, Amount: big.NewInt(60)}}
+
+	t.Run("flag not active should return zero", func(t *testing.T) {
+		t.Parallel()
+
+		vestingHandler, _ := NewESDTVestingHandler(&mock.EnableEpochsHandlerStub{})
+		account := mock.NewUserAccount([]byte("account"))
+		require.Nil(t, vestingHandler.AddVestingSchedules(account, tokenID, schedules))
+
+		lockedBalance, err := vestingHandler.GetLockedBalance(account, tokenID)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(0), lockedBalance)
+	})
+	t.Run("should sum the schedules not reaching the unlock round", func(t *testing.T) {
+		t.Parallel()
+
+		currentRound := uint64(5)
+		vestingHandler := createESDTVestingHandlerAtRound(t, &currentRound)
+		account := mock.NewUserAccount([]byte("account"))
+		require.Nil(t, vestingHandler.AddVestingSchedules(account, tokenID, schedules))
+
+		lockedBalance, err := vestingHandler.GetLockedBalance(account, tokenID)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(100), lockedBalance)
+
+		currentRound = 10
+		lockedBalance, err = vestingHandler.GetLockedBalance(account, tokenID)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(60), lockedBalance)
+
+		currentRound = 20
+		lockedBalance, err = vestingHandler.GetLockedBalance(account, tokenID)
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(0), lockedBalance)
+
+		lockedBalance, err = vestingHandler.GetLockedBalance(account, []byte("OTHER-123456"))
+		assert.Nil(t, err)
+		assert.Equal(t, big.NewInt(0), lockedBalance)
+	})
+	t.Run("corrupted schedules should error", func(t *testing.T) {
+		t.Parallel()
+
+		currentRound := uint64(5)
+		vestingHandler := createESDTVestingHandlerAtRound(t, &currentRound)
+		account := mock.NewUserAccount([]byte("account"))
+		_ = account.AccountDataHandler().SaveKeyValue(getESDTVestingKey(tokenID), []byte{1, 2, 3})
+
+		lockedBalance, err := vestingHandler.GetLockedBalance(account, tokenID)
+		assert.Nil(t, lockedBalance)
+		assert.Equal(t, ErrInvalidVestingSchedule, err)
+	})
+}
+
+func TestESDTVestingHandler_AddVestingSchedulesTooMany(t *testing.T) {
+	t.Parallel()
+
+	vestingHandler, _ := NewESDTVestingHandler(&mock.EnableEpochsHandlerStub{})
+	account := mock.NewUserAccount([]byte("account"))
+	tokenID := []byte("TKN-123456")
+
+	schedules := make([]*VestingSchedule, 0, maxNumVestingSchedules)
+	for i := 0; i < maxNumVestingSchedules; i++ {
+		schedules = append(schedules, &VestingSchedule{UnlockRound: uint64(i), Amount: big.NewInt(1)})
+	}
+	require.Nil(t, vestingHandler.AddVestingSchedules(account, tokenID, schedules))
+
+	err := vestingHandler.AddVestingSchedules(account, tokenID, []*VestingSchedule{{UnlockRound: maxNumVestingSchedules, Amount: big.NewInt(1)}})
+	assert.True(t, errors.Is(err, ErrTooManyVestingSchedules))
+}
+
+func TestESDTVestingHandler_AddVestingSchedulesShouldNotLetSmallSchedulesTakeAllSlots(t *testing.T) {
+	t.Parallel()
+
+	currentRound := uint64(100)
+	vestingHandler := createESDTVestingHandlerAtRound(t, &currentRound)
+	account := mock.NewUserAccount([]byte("account"))
+	tokenID := []byte("TKN-123456")
+
+	err := vestingHandler.AddVestingSchedules(account, tokenID, []*VestingSchedule{{UnlockRound: currentRound + maxVestingRoundsAhead + 1, Amount: big.NewInt(1)}})
+	assert.True(t, errors.Is(err, ErrInvalidVestingSchedule))
+
+	farUnlockRound := currentRound + maxVestingRoundsAhead
+	for i := 0; i < 2*maxNumVestingSchedules; i++ {
+		err = vestingHandler.AddVestingSchedules(account, tokenID, []*VestingSchedule{{UnlockRound: farUnlockRound, Amount: big.NewInt(1)}})
+		require.Nil(t, err)
+	}
+
+	schedules, err := getVestingSchedules(account, tokenID)
+	require.Nil(t, err)
+	assert.Equal(t, []*VestingSchedule{{UnlockRound: farUnlockRound, Amount: big.NewInt(2 * maxNumVestingSchedules)}}, schedules)
+
+	err = vestingHandler.AddVestingSchedules(account, tokenID, []*VestingSchedule{{UnlockRound: 200, Amount: big.NewInt(50)}})
+	assert.Nil(t, err)
+
+	lockedBalance, err := vestingHandler.GetLockedBalance(account, tokenID)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(2*maxNumVestingSchedules+50), lockedBalance)
+}
+
+func TestESDTVestingHandler_ReleaseUnlockedSchedules(t *testing.T) {
+	t.Parallel()
+
+	currentRound := uint64(5)
+	vestingHandler := createESDTVestingHandlerAtRound(t, &currentRound)
+	account := mock.NewUserAccount([]byte("account"))
+	tokenID := []byte("TKN-123456")
+	schedules := []*VestingSchedule{{UnlockRound: 10, Amount: big.NewInt(40)}, {UnlockRound: 20, Amount: big.NewInt(60)}}
+	require.Nil(t, vestingHandler.AddVestingSchedules(account, tokenID, schedules))
+
+	released, err := vestingHandler.ReleaseUnlockedSchedules(account, tokenID)
+	assert.Nil(t, err)
+	assert.Empty(t, released)
+
+	currentRound = 15
+	released, err = vestingHandler.ReleaseUnlockedSchedules(account, tokenID)
+	assert.Nil(t, err)
+	assert.Equal(t, schedules[:1], released)
+
+	remaining, err := getVestingSchedules(account, tokenID)
+	assert.Nil(t, err)
+	assert.Equal(t, schedules[1:], remaining)
+
+	currentRound = 20
+	released, err = vestingHandler.ReleaseUnlockedSchedules(account, tokenID)
+	assert.Nil(t, err)
+	assert.Equal(t, schedules[1:], released)
+
+	marshalledSchedules, _, _ := account.AccountDataHandler().RetrieveValue(getESDTVestingKey(tokenID))
+	assert.Empty(t, marshalledSchedules)
+}
+
+func TestVestingSchedulesEncoding(t *testing.T) {
+	t.Parallel()
+
+	schedules := []*VestingSchedule{
+		{UnlockRound: 0, Amount: big.NewInt(1)},
+		{UnlockRound: 1 << 40, Amount: big.NewInt(0).Exp(big.NewInt(10), big.NewInt(30), nil)},
+	}
+	decoded, err := decodeVestingSchedules(encodeVestingSchedules(schedules))
+	assert.Nil(t, err)
+	assert.Equal(t, schedules, decoded)
+
+	encoded := encodeVestingSchedules(schedules)
+	_, err = decodeVestingSchedules(encoded[:len(encoded)-1])
+	assert.Equal(t, ErrInvalidVestingSchedule, err)
+
+	_, err = decodeVestingSchedules(encoded[:vestingScheduleHeaderBytes-1])
+	assert.Equal(t, ErrInvalidVestingSchedule, err)
+}
+
+func TestCheckLockedESDTAmount(t *testing.T) {
+	t.Parallel()
+
+	account := mock.NewUserAccount([]byte("account"))
+	tokenID := []byte("TKN-123456")
+	esdtTokenKey := append([]byte(baseESDTKeyPrefix), tokenID...)
+	expectedErr := errors.New("expected error")
+	lockedBalanceHandler := &mock.ESDTLockedBalanceHandlerStub{
+		GetLockedBalanceCalled: func(_ vmcommon.UserAccountHandler, providedTokenID []byte) (*big.Int, error) {
+			assert.Equal(t, tokenID, providedTokenID)
+			return big.NewInt(50), nil
+		},
+	}
+	esdtDataWithBalance := func(balance int64) *esdt.ESDigitalToken {
+		return &esdt.ESDigitalToken{Value: big.NewInt(balance)}
+	}
+
+	assert.Nil(t, checkLockedESDTAmount(&mock.ESDTLockedBalanceHandlerStub{}, account, esdtTokenKey, esdtDataWithBalance(0), false))
+	assert.Nil(t, checkLockedESDTAmount(lockedBalanceHandler, account, esdtTokenKey, esdtDataWithBalance(50), false))
+	assert.Nil(t, checkLockedESDTAmount(lockedBalanceHandler, account, esdtTokenKey, esdtDataWithBalance(49), true))
+	assert.Nil(t, checkLockedESDTAmount(lockedBalanceHandler, mock.NewUserAccount(core.ESDTSCAddress), esdtTokenKey, esdtDataWithBalance(49), false))
+
+	err := checkLockedESDTAmount(lockedBalanceHandler, account, esdtTokenKey, esdtDataWithBalance(49), false)
+	assert.True(t, errors.Is(err, ErrInsufficientUnlockedBalance))
+
+	err = checkLockedESDTAmount(nil, account, esdtTokenKey, esdtDataWithBalance(49), false)
+	assert.Equal(t, ErrNilLockedBalanceHandler, err)
+
+	err = checkLockedESDTAmount(&mock.ESDTLockedBalanceHandlerStub{
+		GetLockedBalanceCalled: func(_ vmcommon.UserAccountHandler, _ []byte) (*big.Int, error) {
+			return nil, expectedErr
+		},
+	}, account, esdtTokenKey, esdtDataWithBalance(49), false)
+	assert.Equal(t, expectedErr, err)
+}
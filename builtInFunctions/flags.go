@@ -28,6 +28,22 @@ const (
 	MigrateDataTrieFlag                         core.EnableEpochFlag = "MigrateDataTrieFlag"
 	DynamicEsdtFlag                             core.EnableEpochFlag = "DynamicEsdtFlag"
 	EGLDInESDTMultiTransferFlag                 core.EnableEpochFlag = "EGLDInESDTMultiTransferFlag"
+	CrossChainOperationsRegistryFlag            core.EnableEpochFlag = "CrossChainOperationsRegistryFlag"
+	ESDTSupplyTrackingFlag                      core.EnableEpochFlag = "ESDTSupplyTrackingFlag"
+	ESDTMaxSupplyFlag                           core.EnableEpochFlag = "ESDTMaxSupplyFlag"
+	ESDTVestingFlag                             core.EnableEpochFlag = "ESDTVestingFlag"
+	ESDTTransferFeeFlag                         core.EnableEpochFlag = "ESDTTransferFeeFlag"
+	ESDTAccessListsFlag                         core.EnableEpochFlag = "ESDTAccessListsFlag"
+	ESDTPartialFreezeWipeFlag                   core.EnableEpochFlag = "ESDTPartialFreezeWipeFlag"
+	AccountFreezeFlag                           core.EnableEpochFlag = "AccountFreezeFlag"
+	MultiGuardianFlag                           core.EnableEpochFlag = "MultiGuardianFlag"
+	GuardedSpendingPolicyFlag                   core.EnableEpochFlag = "GuardedSpendingPolicyFlag"
+	TwoStepOwnershipTransferFlag                core.EnableEpochFlag = "TwoStepOwnershipTransferFlag"
+	DeveloperRewardsSplitFlag                   core.EnableEpochFlag = "DeveloperRewardsSplitFlag"
+	UserNameLifecycleFlag                       core.EnableEpochFlag = "UserNameLifecycleFlag"
+	CrossChainWhiteListFlag                     core.EnableEpochFlag = "CrossChainWhiteListFlag"
+	ESDTBridgeFlag                              core.EnableEpochFlag = "ESDTBridgeFlag"
+	ESDTNativeIssuanceFlag                      core.EnableEpochFlag = "ESDTNativeIssuanceFlag"
 )
 
 // allFlags must have all flags used by mx-chain-vm-common-go in the current version
@@ -56,4 +72,20 @@ var allFlags = []core.EnableEpochFlag{
 	MigrateDataTrieFlag,
 	DynamicEsdtFlag,
 	EGLDInESDTMultiTransferFlag,
+	CrossChainOperationsRegistryFlag,
+	ESDTSupplyTrackingFlag,
+	ESDTMaxSupplyFlag,
+	ESDTVestingFlag,
+	ESDTTransferFeeFlag,
+	ESDTAccessListsFlag,
+	ESDTPartialFreezeWipeFlag,
+	AccountFreezeFlag,
+	MultiGuardianFlag,
+	GuardedSpendingPolicyFlag,
+	TwoStepOwnershipTransferFlag,
+	DeveloperRewardsSplitFlag,
+	UserNameLifecycleFlag,
+	CrossChainWhiteListFlag,
+	ESDTBridgeFlag,
+	ESDTNativeIssuanceFlag,
 }
@@ -0,0 +1,175 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const accountFrozenKey = core.ProtectedKeyPrefix + "accountFrozen"
+
+var accountFrozenValue = []byte{1}
+
+type freezeAccount struct {
+	baseActiveHandler
+	authorityAddress []byte
+	freeze           bool
+	function         string
+	funcGasCost      uint64
+	mutExecution     sync.RWMutex
+}
+
+// ArgsNewFreezeAccountFunc defines the argument list for the built-in functions which freeze and unfreeze a whole
+// account
+type ArgsNewFreezeAccountFunc struct {
+	FuncGasCost         uint64
+	AuthorityAddress    []byte
+	Freeze              bool
+	EnableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+// NewFreezeAccountFunc returns the built-in function component which freezes or unfreezes an account for every
+// ESDT, NFT and EGLD movement. The flag is saved under a protected key of the account, which only this built-in
+// function can write, so checking a transfer does not load any of the token keys of the account
+func NewFreezeAccountFunc(args ArgsNewFreezeAccountFunc) (*freezeAccount, error) {
+	if check.IfNil(args.EnableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+
+	f := &freezeAccount{
+		authorityAddress: args.AuthorityAddress,
+		freeze:           args.Freeze,
+		function:         vmcommon.BuiltInFunctionUnFreezeAccount,
+		funcGasCost:      args.FuncGasCost,
+		mutExecution:     sync.RWMutex{},
+	}
+	if args.Freeze {
+		f.function = vmcommon.BuiltInFunctionFreezeAccount
+	}
+
+	f.baseActiveHandler.activeHandler = func() bool {
+		return args.EnableEpochsHandler.IsFlagEnabled(AccountFreezeFlag)
+	}
+
+	return f, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (f *freezeAccount) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	f.mutExecution.Lock()
+	f.funcGasCost = gasCost.BuiltInCost.FreezeAccount
+	f.mutExecution.Unlock()
+}
+
+// ProcessBuiltinFunction sets or clears the frozen flag of the destination account. Only the configured authority
+// address can call it
+func (f *freezeAccount) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	f.mutExecution.RLock()
+	defer f.mutExecution.RUnlock()
+
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if vmInput.CallValue == nil {
+		return nil, ErrNilValue
+	}
+	if vmInput.CallValue.Cmp(zero) != 0 {
+		return nil, ErrBuiltInFunctionCalledWithValue
+	}
+	if len(vmInput.Arguments) != 0 {
+		return nil, ErrInvalidArguments
+	}
+	if len(f.authorityAddress) == 0 || !bytes.Equal(vmInput.CallerAddr, f.authorityAddress) {
+		return nil, ErrAddressIsNotAllowed
+	}
+	if core.IsSmartContractAddress(vmInput.RecipientAddr) {
+		return nil, ErrOnlyUserAccountsCanBeFrozen
+	}
+	if !check.IfNil(acntSnd) && vmInput.GasProvided < f.funcGasCost {
+		return nil, ErrNotEnoughGas
+	}
+
+	vmOutput := &vmcommon.VMOutput{
+		ReturnCode:   vmcommon.Ok,
+		GasRemaining: computeGasRemaining(acntSnd, vmInput.GasProvided, f.funcGasCost),
+	}
+	if check.IfNil(acntDst) {
+		return vmOutput, nil
+	}
+
+	isFrozen, err := isAccountFrozen(acntDst)
+	if err != nil {
+		return nil, err
+	}
+	if f.freeze && isFrozen {
+		return nil, ErrAccountIsFrozen
+	}
+	if !f.freeze && !isFrozen {
+		return nil, ErrAccountIsNotFrozen
+	}
+
+	var frozenValue []byte
+	if f.freeze {
+		frozenValue = accountFrozenValue
+	}
+	err = acntDst.AccountDataHandler().SaveKeyValue([]byte(accountFrozenKey), frozenValue)
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput.Logs = []*vmcommon.LogEntry{{
+		Address:    vmInput.CallerAddr,
+		Identifier: []byte(f.function),
+		Topics:     [][]byte{acntDst.AddressBytes()},
+	}}
+
+	return vmOutput, nil
+}
+
+// checkAccountIsNotFrozen returns an error if the whole account was frozen by the authority address
+func checkAccountIsNotFrozen(
+	acnt vmcommon.UserAccountHandler,
+	enableEpochsHandler vmcommon.EnableEpochsHandler,
+	isReturnWithError bool,
+) error {
+	if isReturnWithError || check.IfNil(acnt) {
+		return nil
+	}
+	if !enableEpochsHandler.IsFlagEnabled(AccountFreezeFlag) {
+		return nil
+	}
+
+	isFrozen, err := isAccountFrozen(acnt)
+	if err != nil {
+		return err
+	}
+	if isFrozen {
+		return ErrAccountIsFrozen
+	}
+
+	return nil
+}
+
+func isAccountFrozen(acnt vmcommon.UserAccountHandler) (bool, error) {
+	frozenValue, _, err := acnt.AccountDataHandler().RetrieveValue([]byte(accountFrozenKey))
+	if core.IsGetNodeFromDBError(err) {
+		return false, err
+	}
+
+	return bytes.Equal(frozenValue, accountFrozenValue), nil
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (f *freezeAccount) IsInterfaceNil() bool {
+	return f == nil
+}
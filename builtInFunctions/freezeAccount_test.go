@@ -0,0 +1,180 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	freezeAuthorityAddress = bytes.Repeat([]byte{7}, 32)
+	accountToFreeze        = bytes.Repeat([]byte{8}, 32)
+)
+
+func createMockArgsNewFreezeAccountFunc(freeze bool) ArgsNewFreezeAccountFunc {
+	return ArgsNewFreezeAccountFunc{
+		FuncGasCost:      10,
+		AuthorityAddress: freezeAuthorityAddress,
+		Freeze:           freeze,
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == AccountFreezeFlag
+			},
+		},
+	}
+}
+
+func createFreezeAccountInput(caller []byte, recipient []byte) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  caller,
+			CallValue:   big.NewInt(0),
+			GasProvided: 100,
+		},
+		RecipientAddr: recipient,
+	}
+}
+
+func TestNewFreezeAccountFunc(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsNewFreezeAccountFunc(true)
+	args.EnableEpochsHandler = nil
+	freezeFunc, err := NewFreezeAccountFunc(args)
+	assert.True(t, check.IfNil(freezeFunc))
+	assert.Equal(t, ErrNilEnableEpochsHandler, err)
+
+	freezeFunc, err = NewFreezeAccountFunc(createMockArgsNewFreezeAccountFunc(true))
+	assert.Nil(t, err)
+	assert.False(t, freezeFunc.IsInterfaceNil())
+	assert.True(t, freezeFunc.IsActive())
+	assert.Equal(t, vmcommon.BuiltInFunctionFreezeAccount, freezeFunc.function)
+
+	unFreezeFunc, err := NewFreezeAccountFunc(createMockArgsNewFreezeAccountFunc(false))
+	assert.Nil(t, err)
+	assert.Equal(t, vmcommon.BuiltInFunctionUnFreezeAccount, unFreezeFunc.function)
+
+	unFreezeFunc.SetNewGasConfig(&vmcommon.GasCost{BuiltInCost: vmcommon.BuiltInCost{FreezeAccount: 20}})
+	assert.Equal(t, uint64(20), unFreezeFunc.funcGasCost)
+}
+
+func TestFreezeAccount_ProcessBuiltinFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	freezeFunc, _ := NewFreezeAccountFunc(createMockArgsNewFreezeAccountFunc(true))
+
+	_, err := freezeFunc.ProcessBuiltinFunction(nil, nil, nil)
+	assert.Equal(t, ErrNilVmInput, err)
+
+	input := createFreezeAccountInput(freezeAuthorityAddress, accountToFreeze)
+	input.CallValue = big.NewInt(1)
+	_, err = freezeFunc.ProcessBuiltinFunction(nil, nil, input)
+	assert.Equal(t, ErrBuiltInFunctionCalledWithValue, err)
+
+	input = createFreezeAccountInput(freezeAuthorityAddress, accountToFreeze)
+	input.Arguments = [][]byte{accountToFreeze}
+	_, err = freezeFunc.ProcessBuiltinFunction(nil, nil, input)
+	assert.Equal(t, ErrInvalidArguments, err)
+
+	_, err = freezeFunc.ProcessBuiltinFunction(nil, nil, createFreezeAccountInput(accountToFreeze, accountToFreeze))
+	assert.Equal(t, ErrAddressIsNotAllowed, err)
+
+	args := createMockArgsNewFreezeAccountFunc(true)
+	args.AuthorityAddress = nil
+	notConfiguredFunc, _ := NewFreezeAccountFunc(args)
+	_, err = notConfiguredFunc.ProcessBuiltinFunction(nil, nil, createFreezeAccountInput(nil, accountToFreeze))
+	assert.Equal(t, ErrAddressIsNotAllowed, err)
+
+	scAddress := make([]byte, 32)
+	_, err = freezeFunc.ProcessBuiltinFunction(nil, nil, createFreezeAccountInput(freezeAuthorityAddress, scAddress))
+	assert.Equal(t, ErrOnlyUserAccountsCanBeFrozen, err)
+
+	input = createFreezeAccountInput(freezeAuthorityAddress, accountToFreeze)
+	input.GasProvided = 1
+	_, err = freezeFunc.ProcessBuiltinFunction(mock.NewUserAccount(freezeAuthorityAddress), nil, input)
+	assert.Equal(t, ErrNotEnoughGas, err)
+
+	unFreezeFunc, _ := NewFreezeAccountFunc(createMockArgsNewFreezeAccountFunc(false))
+	_, err = unFreezeFunc.ProcessBuiltinFunction(nil, mock.NewUserAccount(accountToFreeze), createFreezeAccountInput(freezeAuthorityAddress, accountToFreeze))
+	assert.Equal(t, ErrAccountIsNotFrozen, err)
+}
+
+func TestFreezeAccount_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	args := createMockArgsNewFreezeAccountFunc(true)
+	enableEpochsHandler := args.EnableEpochsHandler
+	freezeFunc, _ := NewFreezeAccountFunc(args)
+	unFreezeFunc, _ := NewFreezeAccountFunc(createMockArgsNewFreezeAccountFunc(false))
+	authority := mock.NewUserAccount(freezeAuthorityAddress)
+	account := mock.NewUserAccount(accountToFreeze)
+	account.SetCodeMetadata((&vmcommon.CodeMetadata{Guarded: true}).ToBytes())
+
+	vmOutput, err := freezeFunc.ProcessBuiltinFunction(authority, nil, createFreezeAccountInput(freezeAuthorityAddress, accountToFreeze))
+	require.Nil(t, err)
+	assert.Equal(t, uint64(90), vmOutput.GasRemaining)
+	assert.Empty(t, vmOutput.Logs)
+
+	vmOutput, err = freezeFunc.ProcessBuiltinFunction(nil, account, createFreezeAccountInput(freezeAuthorityAddress, accountToFreeze))
+	require.Nil(t, err)
+	assert.Equal(t, uint64(0), vmOutput.GasRemaining)
+	require.Len(t, vmOutput.Logs, 1)
+	assert.Equal(t, []byte(vmcommon.BuiltInFunctionFreezeAccount), vmOutput.Logs[0].Identifier)
+	assert.Equal(t, freezeAuthorityAddress, vmOutput.Logs[0].Address)
+	assert.Equal(t, [][]byte{accountToFreeze}, vmOutput.Logs[0].Topics)
+	assert.Equal(t, vmcommon.CodeMetadata{Guarded: true}, getCodeMetaData(account))
+	frozenValue, _, _ := account.AccountDataHandler().RetrieveValue([]byte(accountFrozenKey))
+	assert.Equal(t, accountFrozenValue, frozenValue)
+
+	_, err = freezeFunc.ProcessBuiltinFunction(nil, account, createFreezeAccountInput(freezeAuthorityAddress, accountToFreeze))
+	assert.Equal(t, ErrAccountIsFrozen, err)
+
+	esdtKey := []byte(baseESDTKeyPrefix + "TKN-123456")
+	err = addToESDTBalance(account, esdtKey, big.NewInt(1), &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, &mock.ESDTLockedBalanceHandlerStub{}, enableEpochsHandler, false)
+	assert.Equal(t, ErrAccountIsFrozen, err)
+	err = addToESDTBalance(account, esdtKey, big.NewInt(1), &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, &mock.ESDTLockedBalanceHandlerStub{}, enableEpochsHandler, true)
+	assert.Nil(t, err)
+	err = checkFrozeAndPause(account, esdtKey, &esdt.ESDigitalToken{Value: big.NewInt(1)}, &mock.GlobalSettingsHandlerStub{}, enableEpochsHandler, false)
+	assert.Equal(t, ErrAccountIsFrozen, err)
+	err = checkFrozeAndPause(account, esdtKey, &esdt.ESDigitalToken{Value: big.NewInt(1)}, &mock.GlobalSettingsHandlerStub{}, &mock.EnableEpochsHandlerStub{}, false)
+	assert.Nil(t, err)
+
+	vmOutput, err = unFreezeFunc.ProcessBuiltinFunction(authority, account, createFreezeAccountInput(freezeAuthorityAddress, accountToFreeze))
+	require.Nil(t, err)
+	assert.Equal(t, uint64(90), vmOutput.GasRemaining)
+	assert.Equal(t, []byte(vmcommon.BuiltInFunctionUnFreezeAccount), vmOutput.Logs[0].Identifier)
+	frozenValue, _, _ = account.AccountDataHandler().RetrieveValue([]byte(accountFrozenKey))
+	assert.Empty(t, frozenValue)
+
+	err = addToESDTBalance(account, esdtKey, big.NewInt(1), &mock.MarshalizerMock{}, &mock.GlobalSettingsHandlerStub{}, &mock.ESDTLockedBalanceHandlerStub{}, enableEpochsHandler, false)
+	assert.Nil(t, err)
+}
+
+func TestCheckAccountIsNotFrozen(t *testing.T) {
+	t.Parallel()
+
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return flag == AccountFreezeFlag
+		},
+	}
+	account := mock.NewUserAccount(accountToFreeze)
+	_ = account.AccountDataHandler().SaveKeyValue([]byte(accountFrozenKey), accountFrozenValue)
+
+	assert.Equal(t, ErrAccountIsFrozen, checkAccountIsNotFrozen(account, enableEpochsHandler, false))
+	assert.Nil(t, checkAccountIsNotFrozen(account, enableEpochsHandler, true))
+	assert.Nil(t, checkAccountIsNotFrozen(nil, enableEpochsHandler, false))
+	assert.Nil(t, checkAccountIsNotFrozen(account, &mock.EnableEpochsHandlerStub{}, false))
+
+	account.SetCodeMetadata([]byte{16, 0})
+	_ = account.AccountDataHandler().SaveKeyValue([]byte(accountFrozenKey), nil)
+	assert.Nil(t, checkAccountIsNotFrozen(account, enableEpochsHandler, false))
+}
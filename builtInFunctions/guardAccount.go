@@ -19,7 +19,7 @@ func NewGuardAccountFunc(args GuardAccountArgs) (*guardAccountFunc, error) {
 }
 
 // ProcessBuiltinFunction will set the frozen bit in
-// user's code metadata, if it has at least one enabled guardian
+// user's code metadata, if it has at least one enabled guardian or an enabled guardian set
 func (fa *guardAccountFunc) ProcessBuiltinFunction(
 	acntSnd, _ vmcommon.UserAccountHandler,
 	vmInput *vmcommon.ContractCallInput,
@@ -27,7 +27,7 @@ func (fa *guardAccountFunc) ProcessBuiltinFunction(
 	fa.mutExecution.Lock()
 	defer fa.mutExecution.Unlock()
 
-	err := fa.checkGuardAccountArgs(acntSnd, vmInput)
+	guardianSet, err := fa.checkGuardAccountArgs(acntSnd, vmInput)
 	if err != nil {
 		return nil, err
 	}
@@ -43,6 +43,9 @@ func (fa *guardAccountFunc) ProcessBuiltinFunction(
 		Address:    acntSnd.AddressBytes(),
 		Identifier: []byte(core.BuiltInFunctionGuardAccount),
 	}
+	if guardianSet != nil {
+		entry.Topics = guardianSetToTopics(guardianSet)
+	}
 
 	return &vmcommon.VMOutput{
 		ReturnCode:   vmcommon.Ok,
@@ -202,4 +202,40 @@ func TestGuardAccountFunc_ProcessBuiltinFunction(t *testing.T) {
 		requireAccountFrozen(t, account, true)
 		require.True(t, cleanCalled)
 	})
+
+	t.Run("guard account with guardian set should work", func(t *testing.T) {
+		guardianSet := &vmcommon.GuardianSet{
+			Guardians:  [][]byte{generateRandomByteArray(pubKeyLen), generateRandomByteArray(pubKeyLen)},
+			Threshold:  2,
+			ServiceUID: []byte("uid"),
+		}
+		args.MultiGuardedAccountHandler = &mock.GuardedAccountHandlerStub{
+			GetActiveGuardianCalled: func(handler vmcommon.UserAccountHandler) ([]byte, error) {
+				return nil, errors.New("should not have been called")
+			},
+			GetActiveGuardianSetCalled: func(uah vmcommon.UserAccountHandler) (*vmcommon.GuardianSet, error) {
+				return guardianSet, nil
+			},
+		}
+		args.EnableEpochsHandler = &mock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == SetGuardianFlag || flag == MultiGuardianFlag
+			},
+		}
+		guardAccountFunc, _ := NewGuardAccountFunc(args)
+		address := generateRandomByteArray(pubKeyLen)
+		account := mock.NewUserAccount(address)
+		vmInput.CallerAddr = account.Address
+		vmInput.RecipientAddr = account.Address
+
+		output, err := guardAccountFunc.ProcessBuiltinFunction(account, account, vmInput)
+		require.Nil(t, err)
+		entry := &vmcommon.LogEntry{
+			Address:    address,
+			Identifier: []byte(core.BuiltInFunctionGuardAccount),
+			Topics:     append([][]byte{{2}, []byte("uid")}, guardianSet.Guardians...),
+		}
+		requireVMOutputOk(t, output, vmInput.GasProvided, args.FuncGasCost, entry)
+		requireAccountFrozen(t, account, true)
+	})
 }
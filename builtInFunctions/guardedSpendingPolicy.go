@@ -0,0 +1,333 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const (
+	guardedSpendingPolicyKey          = core.ProtectedKeyPrefix + "guardedSpendingPolicy"
+	guardedSpentAmountKeyPrefix       = core.ProtectedKeyPrefix + "guardedSpentAmount"
+	maxNumGuardedSpendingLimits       = 20
+	maxNumGuardedWhitelistedReceivers = 20
+	spendingPeriodLength              = 8
+)
+
+// GuardedSpendingLimit defines the quantity of a token which a guarded account can spend in a period without the
+// guardian co-signature. The EGLD limit uses the EGLD identifier of the multi transfer
+type GuardedSpendingLimit struct {
+	TokenID []byte
+	Amount  *big.Int
+}
+
+// GuardedSpendingPolicy defines the transfers a guarded account can make without the guardian co-signature: the
+// transfers to the whitelisted receivers and the transfers within the limits, which are reset every period of rounds
+type GuardedSpendingPolicy struct {
+	PeriodInRounds       uint64
+	Limits               []*GuardedSpendingLimit
+	WhitelistedReceivers [][]byte
+}
+
+// GuardedSpendingPolicyFromBytes creates a guarded spending policy object from bytes
+func GuardedSpendingPolicyFromBytes(encoded []byte) (*GuardedSpendingPolicy, error) {
+	if len(encoded) < spendingPeriodLength+1 {
+		return nil, ErrInvalidGuardedSpendingPolicy
+	}
+
+	policy := &GuardedSpendingPolicy{
+		PeriodInRounds:       binary.BigEndian.Uint64(encoded[:spendingPeriodLength]),
+		Limits:               make([]*GuardedSpendingLimit, 0),
+		WhitelistedReceivers: make([][]byte, 0),
+	}
+	numLimits := int(encoded[spendingPeriodLength])
+	encoded = encoded[spendingPeriodLength+1:]
+
+	var err error
+	for i := 0; i < numLimits; i++ {
+		limit := &GuardedSpendingLimit{}
+		limit.TokenID, encoded, err = readLengthPrefixedField(encoded)
+		if err != nil {
+			return nil, ErrInvalidGuardedSpendingPolicy
+		}
+
+		var amount []byte
+		amount, encoded, err = readLengthPrefixedField(encoded)
+		if err != nil {
+			return nil, ErrInvalidGuardedSpendingPolicy
+		}
+		limit.Amount = big.NewInt(0).SetBytes(amount)
+
+		policy.Limits = append(policy.Limits, limit)
+	}
+
+	for len(encoded) > 0 {
+		var receiver []byte
+		receiver, encoded, err = readLengthPrefixedField(encoded)
+		if err != nil {
+			return nil, ErrInvalidGuardedSpendingPolicy
+		}
+
+		policy.WhitelistedReceivers = append(policy.WhitelistedReceivers, receiver)
+	}
+
+	return policy, nil
+}
+
+// ToBytes converts the guarded spending policy to bytes. The period is followed by the number of limits, the token
+// identifiers and amounts of the limits and the whitelisted receivers, every one of them prefixed by its length
+func (policy *GuardedSpendingPolicy) ToBytes() []byte {
+	encoded := binary.BigEndian.AppendUint64(nil, policy.PeriodInRounds)
+	encoded = append(encoded, byte(len(policy.Limits)))
+	for _, limit := range policy.Limits {
+		encoded = appendLengthPrefixedField(encoded, limit.TokenID)
+		encoded = appendLengthPrefixedField(encoded, limit.Amount.Bytes())
+	}
+	for _, receiver := range policy.WhitelistedReceivers {
+		encoded = appendLengthPrefixedField(encoded, receiver)
+	}
+
+	return encoded
+}
+
+func (policy *GuardedSpendingPolicy) isWhitelisted(address []byte) bool {
+	for _, receiver := range policy.WhitelistedReceivers {
+		if bytes.Equal(address, receiver) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (policy *GuardedSpendingPolicy) getLimit(tokenID []byte) *big.Int {
+	for _, limit := range policy.Limits {
+		if bytes.Equal(tokenID, limit.TokenID) {
+			return limit.Amount
+		}
+	}
+
+	return nil
+}
+
+type guardedSpendingPolicyHandler struct {
+	vmcommon.BlockchainDataProvider
+	enableEpochsHandler        vmcommon.EnableEpochsHandler
+	guardedAccountHandler      vmcommon.GuardedAccountHandler
+	multiGuardedAccountHandler vmcommon.MultiGuardedAccountHandler
+}
+
+// NewGuardedSpendingPolicyHandler creates the component which lets guarded accounts transfer without the guardian
+// co-signature within their spending policy. The spending periods are measured against the round provided by the
+// blockchain hook. The multi guarded account handler is optional, the guardian sets are not checked without it
+func NewGuardedSpendingPolicyHandler(
+	enableEpochsHandler vmcommon.EnableEpochsHandler,
+	guardedAccountHandler vmcommon.GuardedAccountHandler,
+	multiGuardedAccountHandler vmcommon.MultiGuardedAccountHandler,
+) (*guardedSpendingPolicyHandler, error) {
+	if check.IfNil(enableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+	if check.IfNil(guardedAccountHandler) {
+		return nil, ErrNilGuardedAccountHandler
+	}
+
+	return &guardedSpendingPolicyHandler{
+		BlockchainDataProvider:     NewBlockchainDataProvider(),
+		enableEpochsHandler:        enableEpochsHandler,
+		guardedAccountHandler:      guardedAccountHandler,
+		multiGuardedAccountHandler: multiGuardedAccountHandler,
+	}, nil
+}
+
+// CheckUnguardedTransfer returns an error if the sender is a guarded account, the transaction is not co-signed by the
+// guardian and the transfer is not covered by the spending policy of the account. The transfers counted against a
+// limit are added to the amount spent in the current period
+func (g *guardedSpendingPolicyHandler) CheckUnguardedTransfer(
+	acntSnd vmcommon.UserAccountHandler,
+	vmInput *vmcommon.VMInput,
+	destination []byte,
+	tokenID []byte,
+	value *big.Int,
+) error {
+	if !g.enableEpochsHandler.IsFlagEnabled(GuardedSpendingPolicyFlag) {
+		return nil
+	}
+	if check.IfNil(acntSnd) || vmInput.ReturnCallAfterError || !getCodeMetaData(acntSnd).Guarded {
+		return nil
+	}
+	isCoSigned, err := g.isCoSignedByGuardian(acntSnd, vmInput)
+	if err != nil || isCoSigned {
+		return err
+	}
+
+	policy, err := getGuardedSpendingPolicy(acntSnd)
+	if err != nil {
+		return err
+	}
+	if policy == nil {
+		return ErrGuardianSignatureRequired
+	}
+	if policy.isWhitelisted(destination) {
+		return nil
+	}
+
+	periodStart, spent, err := g.addToSpentAmount(acntSnd, policy, tokenID, value)
+	if err != nil {
+		return err
+	}
+
+	return saveGuardedSpentAmount(acntSnd, tokenID, periodStart, spent)
+}
+
+// isCoSignedByGuardian verifies the co-signers against the active guardian set, if the account has one, or the
+// transaction guardian against the active guardian otherwise
+func (g *guardedSpendingPolicyHandler) isCoSignedByGuardian(acntSnd vmcommon.UserAccountHandler, vmInput *vmcommon.VMInput) (bool, error) {
+	if g.enableEpochsHandler.IsFlagEnabled(MultiGuardianFlag) && !check.IfNil(g.multiGuardedAccountHandler) {
+		guardianSet, err := g.multiGuardedAccountHandler.GetActiveGuardianSet(acntSnd)
+		if err != nil {
+			return false, err
+		}
+		if guardianSet != nil {
+			if len(vmInput.TxCoSigners) == 0 {
+				return false, nil
+			}
+			return g.multiGuardedAccountHandler.VerifyCoSigners(acntSnd, vmInput.TxCoSigners) == nil, nil
+		}
+	}
+
+	if len(vmInput.TxGuardian) == 0 {
+		return false, nil
+	}
+	activeGuardian, err := g.guardedAccountHandler.GetActiveGuardian(acntSnd)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(activeGuardian, vmInput.TxGuardian), nil
+}
+
+// IsUnguardedTransferAllowed returns true if the transfers of the guarded account to the destination are covered by
+// its spending policy. It is called by the transaction processor before accepting a transaction which is not
+// co-signed by the guardian, so it does not record anything: the amounts are counted against the limits when the
+// transfer built-in functions are executed
+func (g *guardedSpendingPolicyHandler) IsUnguardedTransferAllowed(
+	acntSnd vmcommon.UserAccountHandler,
+	destination []byte,
+	transfers []*vmcommon.ESDTTransfer,
+) (bool, error) {
+	if !g.enableEpochsHandler.IsFlagEnabled(GuardedSpendingPolicyFlag) {
+		return false, nil
+	}
+	if check.IfNil(acntSnd) {
+		return false, ErrNilUserAccount
+	}
+
+	policy, err := getGuardedSpendingPolicy(acntSnd)
+	if err != nil || policy == nil {
+		return false, err
+	}
+	if policy.isWhitelisted(destination) {
+		return true, nil
+	}
+
+	tokenIDs := make([][]byte, 0, len(transfers))
+	values := make(map[string]*big.Int)
+	for _, transfer := range transfers {
+		value, found := values[string(transfer.ESDTTokenName)]
+		if !found {
+			value = big.NewInt(0)
+			values[string(transfer.ESDTTokenName)] = value
+			tokenIDs = append(tokenIDs, transfer.ESDTTokenName)
+		}
+		value.Add(value, transfer.ESDTValue)
+	}
+
+	for _, tokenID := range tokenIDs {
+		_, _, err = g.addToSpentAmount(acntSnd, policy, tokenID, values[string(tokenID)])
+		if errors.Is(err, ErrGuardianSignatureRequired) {
+			return false, nil
+		}
+		if err != nil {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// addToSpentAmount returns the first round of the current spending period and the amount spent in it including the
+// value, or an error if the value is not covered by the limit of the token
+func (g *guardedSpendingPolicyHandler) addToSpentAmount(
+	acntSnd vmcommon.UserAccountHandler,
+	policy *GuardedSpendingPolicy,
+	tokenID []byte,
+	value *big.Int,
+) (uint64, *big.Int, error) {
+	limit := policy.getLimit(tokenID)
+	if limit == nil {
+		return 0, nil, fmt.Errorf("%w, no spending limit for %s", ErrGuardianSignatureRequired, tokenID)
+	}
+
+	periodStart, spent, err := getGuardedSpentAmount(acntSnd, tokenID)
+	if err != nil {
+		return 0, nil, err
+	}
+	currentRound := g.CurrentRound()
+	if currentRound >= periodStart+policy.PeriodInRounds {
+		periodStart = currentRound
+		spent = big.NewInt(0)
+	}
+
+	spent.Add(spent, value)
+	if spent.Cmp(limit) > 0 {
+		return 0, nil, fmt.Errorf("%w, spending limit exceeded for %s", ErrGuardianSignatureRequired, tokenID)
+	}
+
+	return periodStart, spent, nil
+}
+
+func getGuardedSpendingPolicy(account vmcommon.UserAccountHandler) (*GuardedSpendingPolicy, error) {
+	val, _, err := account.AccountDataHandler().RetrieveValue([]byte(guardedSpendingPolicyKey))
+	if core.IsGetNodeFromDBError(err) {
+		return nil, err
+	}
+	if err != nil || len(val) == 0 {
+		return nil, nil
+	}
+
+	return GuardedSpendingPolicyFromBytes(val)
+}
+
+// getGuardedSpentAmount returns the first round of the spending period and the amount spent since then
+func getGuardedSpentAmount(account vmcommon.UserAccountHandler, tokenID []byte) (uint64, *big.Int, error) {
+	val, _, err := account.AccountDataHandler().RetrieveValue(getGuardedSpentAmountKey(tokenID))
+	if core.IsGetNodeFromDBError(err) {
+		return 0, nil, err
+	}
+	if len(val) < spendingPeriodLength {
+		return 0, big.NewInt(0), nil
+	}
+
+	return binary.BigEndian.Uint64(val[:spendingPeriodLength]), big.NewInt(0).SetBytes(val[spendingPeriodLength:]), nil
+}
+
+func saveGuardedSpentAmount(account vmcommon.UserAccountHandler, tokenID []byte, periodStart uint64, spent *big.Int) error {
+	val := binary.BigEndian.AppendUint64(nil, periodStart)
+	return account.AccountDataHandler().SaveKeyValue(getGuardedSpentAmountKey(tokenID), append(val, spent.Bytes()...))
+}
+
+func getGuardedSpentAmountKey(tokenID []byte) []byte {
+	return append([]byte(guardedSpentAmountKeyPrefix), tokenID...)
+}
+
+// IsInterfaceNil returns true if underlying object is nil
+func (g *guardedSpendingPolicyHandler) IsInterfaceNil() bool {
+	return g == nil
+}
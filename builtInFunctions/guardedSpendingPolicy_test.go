@@ -0,0 +1,268 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var spendingPolicyReceiver = bytes.Repeat([]byte{7}, 32)
+
+func createGuardedSpendingPolicyEnableEpochsHandler() vmcommon.EnableEpochsHandler {
+	return &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return flag == GuardedSpendingPolicyFlag
+		},
+	}
+}
+
+func createTestGuardedSpendingPolicy() *GuardedSpendingPolicy {
+	return &GuardedSpendingPolicy{
+		PeriodInRounds: 100,
+		Limits: []*GuardedSpendingLimit{
+			{TokenID: []byte(vmcommon.EGLDIdentifier), Amount: big.NewInt(10)},
+			{TokenID: []byte("TKN-123456"), Amount: big.NewInt(0)},
+		},
+		WhitelistedReceivers: [][]byte{spendingPolicyReceiver},
+	}
+}
+
+func createGuardedAccountWithSpendingPolicy(policy *GuardedSpendingPolicy) *mock.Account {
+	account := mock.NewUserAccount(bytes.Repeat([]byte{1}, 32))
+	account.SetCodeMetadata((&vmcommon.CodeMetadata{Guarded: true}).ToBytes())
+	if policy != nil {
+		_ = account.AccountDataHandler().SaveKeyValue([]byte(guardedSpendingPolicyKey), policy.ToBytes())
+	}
+
+	return account
+}
+
+func TestGuardedSpendingPolicy_ToBytesFromBytes(t *testing.T) {
+	t.Parallel()
+
+	policy := createTestGuardedSpendingPolicy()
+	decoded, err := GuardedSpendingPolicyFromBytes(policy.ToBytes())
+	require.Nil(t, err)
+	assert.Equal(t, policy, decoded)
+
+	_, err = GuardedSpendingPolicyFromBytes([]byte{1, 2})
+	assert.Equal(t, ErrInvalidGuardedSpendingPolicy, err)
+
+	encoded := policy.ToBytes()
+	_, err = GuardedSpendingPolicyFromBytes(encoded[:len(encoded)-1])
+	assert.Equal(t, ErrInvalidGuardedSpendingPolicy, err)
+
+	encoded = policy.ToBytes()
+	encoded[spendingPeriodLength] = 5
+	_, err = GuardedSpendingPolicyFromBytes(encoded)
+	assert.Equal(t, ErrInvalidGuardedSpendingPolicy, err)
+}
+
+func TestNewGuardedSpendingPolicyHandler(t *testing.T) {
+	t.Parallel()
+
+	handler, err := NewGuardedSpendingPolicyHandler(nil, &mock.GuardedAccountHandlerStub{}, nil)
+	assert.True(t, check.IfNil(handler))
+	assert.Equal(t, ErrNilEnableEpochsHandler, err)
+
+	handler, err = NewGuardedSpendingPolicyHandler(createGuardedSpendingPolicyEnableEpochsHandler(), nil, nil)
+	assert.True(t, check.IfNil(handler))
+	assert.Equal(t, ErrNilGuardedAccountHandler, err)
+
+	handler, err = NewGuardedSpendingPolicyHandler(createGuardedSpendingPolicyEnableEpochsHandler(), &mock.GuardedAccountHandlerStub{}, nil)
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(handler))
+}
+
+func TestGuardedSpendingPolicyHandler_CheckUnguardedTransfer(t *testing.T) {
+	t.Parallel()
+
+	egld := []byte(vmcommon.EGLDIdentifier)
+	destination := bytes.Repeat([]byte{8}, 32)
+
+	t.Run("transfers not checked", func(t *testing.T) {
+		t.Parallel()
+
+		handler, _ := NewGuardedSpendingPolicyHandler(&mock.EnableEpochsHandlerStub{}, &mock.GuardedAccountHandlerStub{}, nil)
+		account := createGuardedAccountWithSpendingPolicy(nil)
+		assert.Nil(t, handler.CheckUnguardedTransfer(account, &vmcommon.VMInput{}, destination, egld, big.NewInt(100)))
+
+		handler, _ = NewGuardedSpendingPolicyHandler(createGuardedSpendingPolicyEnableEpochsHandler(), &mock.GuardedAccountHandlerStub{}, nil)
+		assert.Nil(t, handler.CheckUnguardedTransfer(nil, &vmcommon.VMInput{}, destination, egld, big.NewInt(100)))
+		assert.Nil(t, handler.CheckUnguardedTransfer(account, &vmcommon.VMInput{ReturnCallAfterError: true}, destination, egld, big.NewInt(100)))
+
+		notGuardedAccount := mock.NewUserAccount(bytes.Repeat([]byte{1}, 32))
+		assert.Nil(t, handler.CheckUnguardedTransfer(notGuardedAccount, &vmcommon.VMInput{}, destination, egld, big.NewInt(100)))
+	})
+	t.Run("co-signed by the active guardian", func(t *testing.T) {
+		t.Parallel()
+
+		guardedAccountHandler := &mock.GuardedAccountHandlerStub{
+			GetActiveGuardianCalled: func(_ vmcommon.UserAccountHandler) ([]byte, error) {
+				return []byte("guardian"), nil
+			},
+		}
+		handler, _ := NewGuardedSpendingPolicyHandler(createGuardedSpendingPolicyEnableEpochsHandler(), guardedAccountHandler, nil)
+		account := createGuardedAccountWithSpendingPolicy(nil)
+		assert.Nil(t, handler.CheckUnguardedTransfer(account, &vmcommon.VMInput{TxGuardian: []byte("guardian")}, destination, egld, big.NewInt(100)))
+
+		err := handler.CheckUnguardedTransfer(account, &vmcommon.VMInput{TxGuardian: []byte("other")}, destination, egld, big.NewInt(100))
+		assert.Equal(t, ErrGuardianSignatureRequired, err)
+		err = handler.CheckUnguardedTransfer(account, &vmcommon.VMInput{TxCoSigners: [][]byte{[]byte("guardian")}}, destination, egld, big.NewInt(100))
+		assert.Equal(t, ErrGuardianSignatureRequired, err)
+	})
+	t.Run("co-signed by the active guardian set", func(t *testing.T) {
+		t.Parallel()
+
+		enableEpochsHandler := &mock.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == GuardedSpendingPolicyFlag || flag == MultiGuardianFlag
+			},
+		}
+		multiGuardedAccountHandler := &mock.GuardedAccountHandlerStub{
+			GetActiveGuardianSetCalled: func(_ vmcommon.UserAccountHandler) (*vmcommon.GuardianSet, error) {
+				return &vmcommon.GuardianSet{Threshold: 1, Guardians: [][]byte{[]byte("guardian")}}, nil
+			},
+			VerifyCoSignersCalled: func(_ vmcommon.UserAccountHandler, txCoSigners [][]byte) error {
+				if len(txCoSigners) == 1 && bytes.Equal(txCoSigners[0], []byte("guardian")) {
+					return nil
+				}
+				return ErrGuardianSignatureRequired
+			},
+		}
+		handler, _ := NewGuardedSpendingPolicyHandler(enableEpochsHandler, &mock.GuardedAccountHandlerStub{}, multiGuardedAccountHandler)
+		account := createGuardedAccountWithSpendingPolicy(nil)
+		assert.Nil(t, handler.CheckUnguardedTransfer(account, &vmcommon.VMInput{TxCoSigners: [][]byte{[]byte("guardian")}}, destination, egld, big.NewInt(100)))
+
+		err := handler.CheckUnguardedTransfer(account, &vmcommon.VMInput{TxCoSigners: [][]byte{[]byte("other")}}, destination, egld, big.NewInt(100))
+		assert.Equal(t, ErrGuardianSignatureRequired, err)
+		err = handler.CheckUnguardedTransfer(account, &vmcommon.VMInput{TxGuardian: []byte("guardian")}, destination, egld, big.NewInt(100))
+		assert.Equal(t, ErrGuardianSignatureRequired, err)
+	})
+	t.Run("guarded account without policy should error", func(t *testing.T) {
+		t.Parallel()
+
+		handler, _ := NewGuardedSpendingPolicyHandler(createGuardedSpendingPolicyEnableEpochsHandler(), &mock.GuardedAccountHandlerStub{}, nil)
+		account := createGuardedAccountWithSpendingPolicy(nil)
+		err := handler.CheckUnguardedTransfer(account, &vmcommon.VMInput{}, destination, egld, big.NewInt(1))
+		assert.Equal(t, ErrGuardianSignatureRequired, err)
+	})
+	t.Run("whitelisted receiver and tokens without limit", func(t *testing.T) {
+		t.Parallel()
+
+		handler, _ := NewGuardedSpendingPolicyHandler(createGuardedSpendingPolicyEnableEpochsHandler(), &mock.GuardedAccountHandlerStub{}, nil)
+		account := createGuardedAccountWithSpendingPolicy(createTestGuardedSpendingPolicy())
+		assert.Nil(t, handler.CheckUnguardedTransfer(account, &vmcommon.VMInput{}, spendingPolicyReceiver, []byte("OTHER-123456"), big.NewInt(1000)))
+
+		err := handler.CheckUnguardedTransfer(account, &vmcommon.VMInput{}, destination, []byte("OTHER-123456"), big.NewInt(1))
+		assert.True(t, errors.Is(err, ErrGuardianSignatureRequired))
+
+		err = handler.CheckUnguardedTransfer(account, &vmcommon.VMInput{}, destination, []byte("TKN-123456"), big.NewInt(1))
+		assert.True(t, errors.Is(err, ErrGuardianSignatureRequired))
+	})
+	t.Run("limit is reset every period", func(t *testing.T) {
+		t.Parallel()
+
+		currentRound := uint64(1000)
+		handler, _ := NewGuardedSpendingPolicyHandler(createGuardedSpendingPolicyEnableEpochsHandler(), &mock.GuardedAccountHandlerStub{}, nil)
+		_ = handler.SetBlockchainHook(&mock.BlockDataHandlerStub{
+			CurrentRoundCalled: func() uint64 {
+				return currentRound
+			},
+		})
+		account := createGuardedAccountWithSpendingPolicy(createTestGuardedSpendingPolicy())
+
+		assert.Nil(t, handler.CheckUnguardedTransfer(account, &vmcommon.VMInput{}, destination, egld, big.NewInt(6)))
+		currentRound = 1099
+		err := handler.CheckUnguardedTransfer(account, &vmcommon.VMInput{}, destination, egld, big.NewInt(5))
+		assert.True(t, errors.Is(err, ErrGuardianSignatureRequired))
+		assert.Nil(t, handler.CheckUnguardedTransfer(account, &vmcommon.VMInput{}, destination, egld, big.NewInt(4)))
+
+		periodStart, spent, err := getGuardedSpentAmount(account, egld)
+		require.Nil(t, err)
+		assert.Equal(t, uint64(1000), periodStart)
+		assert.Equal(t, big.NewInt(10), spent)
+
+		currentRound = 1100
+		assert.Nil(t, handler.CheckUnguardedTransfer(account, &vmcommon.VMInput{}, destination, egld, big.NewInt(10)))
+		periodStart, spent, err = getGuardedSpentAmount(account, egld)
+		require.Nil(t, err)
+		assert.Equal(t, uint64(1100), periodStart)
+		assert.Equal(t, big.NewInt(10), spent)
+	})
+}
+
+func TestGuardedSpendingPolicyHandler_IsUnguardedTransferAllowed(t *testing.T) {
+	t.Parallel()
+
+	egld := []byte(vmcommon.EGLDIdentifier)
+	destination := bytes.Repeat([]byte{8}, 32)
+	egldTransfer := func(value int64) *vmcommon.ESDTTransfer {
+		return &vmcommon.ESDTTransfer{ESDTTokenName: egld, ESDTValue: big.NewInt(value)}
+	}
+
+	t.Run("flag not active", func(t *testing.T) {
+		t.Parallel()
+
+		handler, _ := NewGuardedSpendingPolicyHandler(&mock.EnableEpochsHandlerStub{}, &mock.GuardedAccountHandlerStub{}, nil)
+		account := createGuardedAccountWithSpendingPolicy(createTestGuardedSpendingPolicy())
+		isAllowed, err := handler.IsUnguardedTransferAllowed(account, destination, []*vmcommon.ESDTTransfer{egldTransfer(1)})
+		assert.Nil(t, err)
+		assert.False(t, isAllowed)
+	})
+	t.Run("nil account should error", func(t *testing.T) {
+		t.Parallel()
+
+		handler, _ := NewGuardedSpendingPolicyHandler(createGuardedSpendingPolicyEnableEpochsHandler(), &mock.GuardedAccountHandlerStub{}, nil)
+		isAllowed, err := handler.IsUnguardedTransferAllowed(nil, destination, []*vmcommon.ESDTTransfer{egldTransfer(1)})
+		assert.Equal(t, ErrNilUserAccount, err)
+		assert.False(t, isAllowed)
+	})
+	t.Run("account without policy", func(t *testing.T) {
+		t.Parallel()
+
+		handler, _ := NewGuardedSpendingPolicyHandler(createGuardedSpendingPolicyEnableEpochsHandler(), &mock.GuardedAccountHandlerStub{}, nil)
+		account := createGuardedAccountWithSpendingPolicy(nil)
+		isAllowed, err := handler.IsUnguardedTransferAllowed(account, destination, []*vmcommon.ESDTTransfer{egldTransfer(1)})
+		assert.Nil(t, err)
+		assert.False(t, isAllowed)
+	})
+	t.Run("transfers within the policy are allowed without recording them", func(t *testing.T) {
+		t.Parallel()
+
+		handler, _ := NewGuardedSpendingPolicyHandler(createGuardedSpendingPolicyEnableEpochsHandler(), &mock.GuardedAccountHandlerStub{}, nil)
+		account := createGuardedAccountWithSpendingPolicy(createTestGuardedSpendingPolicy())
+
+		otherToken := &vmcommon.ESDTTransfer{ESDTTokenName: []byte("OTHER-123456"), ESDTValue: big.NewInt(1000)}
+		isAllowed, err := handler.IsUnguardedTransferAllowed(account, spendingPolicyReceiver, []*vmcommon.ESDTTransfer{otherToken})
+		assert.Nil(t, err)
+		assert.True(t, isAllowed)
+
+		isAllowed, err = handler.IsUnguardedTransferAllowed(account, destination, []*vmcommon.ESDTTransfer{egldTransfer(4), egldTransfer(6)})
+		assert.Nil(t, err)
+		assert.True(t, isAllowed)
+		isAllowed, err = handler.IsUnguardedTransferAllowed(account, destination, []*vmcommon.ESDTTransfer{egldTransfer(4), egldTransfer(7)})
+		assert.Nil(t, err)
+		assert.False(t, isAllowed)
+		isAllowed, err = handler.IsUnguardedTransferAllowed(account, destination, []*vmcommon.ESDTTransfer{egldTransfer(1), otherToken})
+		assert.Nil(t, err)
+		assert.False(t, isAllowed)
+
+		_, spent, err := getGuardedSpentAmount(account, egld)
+		require.Nil(t, err)
+		assert.Equal(t, big.NewInt(0), spent)
+
+		assert.Nil(t, handler.CheckUnguardedTransfer(account, &vmcommon.VMInput{}, destination, egld, big.NewInt(8)))
+		isAllowed, err = handler.IsUnguardedTransferAllowed(account, destination, []*vmcommon.ESDTTransfer{egldTransfer(3)})
+		assert.Nil(t, err)
+		assert.False(t, isAllowed)
+	})
+}
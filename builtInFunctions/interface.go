@@ -1,8 +1,85 @@
 package builtInFunctions
 
+import (
+	"math/big"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
 // CrossChainTokenCheckerHandler should check if token is from another chain/sovereign shard
 type CrossChainTokenCheckerHandler interface {
 	IsCrossChainOperation(tokenID []byte) bool
 	IsCrossChainOperationAllowed(address []byte, tokenID []byte) bool
 	IsInterfaceNil() bool
 }
+
+// CrossChainActionCheckerHandler is implemented by the cross chain token checkers which enforce per token prefix
+// policies. It is kept apart from CrossChainTokenCheckerHandler so that the existing checkers remain valid: the
+// built-in functions fall back to IsCrossChainOperationAllowed for the checkers which do not implement it
+type CrossChainActionCheckerHandler interface {
+	IsCrossChainActionAllowed(address []byte, tokenID []byte, action []byte) bool
+}
+
+// CrossChainWhiteListCheckerHandler is implemented by the cross chain token checkers which expose their whitelist.
+// The bridge does not release the escrowed native tokens if the checker does not implement it
+type CrossChainWhiteListCheckerHandler interface {
+	IsWhiteListed(address []byte) bool
+}
+
+// CrossChainOperationsRegistryHandler keeps track of the processed cross chain operations
+type CrossChainOperationsRegistryHandler interface {
+	RegisterOperation(operationID []byte) error
+	IsOperationProcessed(operationID []byte) (bool, error)
+	PruneOperations(epoch uint32) error
+	IsInterfaceNil() bool
+}
+
+// BlockChainEpochHook defines the component able to provide the current epoch
+type BlockChainEpochHook interface {
+	CurrentEpoch() uint32
+	IsInterfaceNil() bool
+}
+
+type blockChainEpochHookSetter interface {
+	SetBlockChainEpochHook(epochHook BlockChainEpochHook) error
+}
+
+// ESDTLockedBalanceHandler provides the part of an esdt balance which is locked by vesting schedules
+type ESDTLockedBalanceHandler interface {
+	GetLockedBalance(account vmcommon.UserAccountHandler, tokenID []byte) (*big.Int, error)
+	IsInterfaceNil() bool
+}
+
+// ESDTVestingHandler manages the vesting schedules saved on the accounts holding the tokens
+type ESDTVestingHandler interface {
+	ESDTLockedBalanceHandler
+	AddVestingSchedules(account vmcommon.UserAccountHandler, tokenID []byte, schedules []*VestingSchedule) error
+	ReleaseUnlockedSchedules(account vmcommon.UserAccountHandler, tokenID []byte) ([]*VestingSchedule, error)
+}
+
+// ESDTTransferAccessListChecker checks the parties of a transfer against the allow and deny lists of the token
+type ESDTTransferAccessListChecker interface {
+	CheckTransferAccessLists(esdtTokenKey []byte, sender, destination []byte) error
+	IsInterfaceNil() bool
+}
+
+// ESDTTransferFeeHandler computes and collects the transfer fees configured for fungible tokens
+type ESDTTransferFeeHandler interface {
+	ComputeTransferFee(tokenID []byte, sender []byte, destination []byte, value *big.Int) (*big.Int, []byte, error)
+	CollectTransferFee(acntSnd vmcommon.UserAccountHandler, destination []byte, tokenID []byte, fee *big.Int, feeReceiver []byte, vmOutput *vmcommon.VMOutput) error
+	IsInterfaceNil() bool
+}
+
+// GuardedSpendingPolicyHandler checks the transfers of guarded accounts which are not co-signed by the guardian
+// against the spending policy of the account, recording the amounts spent in the current period
+type GuardedSpendingPolicyHandler interface {
+	CheckUnguardedTransfer(acntSnd vmcommon.UserAccountHandler, vmInput *vmcommon.VMInput, destination []byte, tokenID []byte, value *big.Int) error
+	IsInterfaceNil() bool
+}
+
+// UnguardedTransferChecker is queried by the transaction processor to find out if the transfers of a guarded account
+// are covered by its spending policy, in which case the transaction is accepted without the guardian co-signature
+type UnguardedTransferChecker interface {
+	IsUnguardedTransferAllowed(acntSnd vmcommon.UserAccountHandler, destination []byte, transfers []*vmcommon.ESDTTransfer) (bool, error)
+	IsInterfaceNil() bool
+}
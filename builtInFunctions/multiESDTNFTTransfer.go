@@ -23,6 +23,11 @@ type esdtNFTMultiTransfer struct {
 	mutExecution   sync.RWMutex
 	rolesHandler   vmcommon.ESDTRoleHandler
 	baseTokenID    []byte
+
+	lockedBalanceHandler  ESDTLockedBalanceHandler
+	transferFeeHandler    ESDTTransferFeeHandler
+	spendingPolicyHandler GuardedSpendingPolicyHandler
+	accessListChecker     ESDTTransferAccessListChecker
 }
 
 const argumentsPerTransfer = uint64(3)
@@ -38,6 +43,10 @@ func NewESDTNFTMultiTransferFunc(
 	enableEpochsHandler vmcommon.EnableEpochsHandler,
 	roleHandler vmcommon.ESDTRoleHandler,
 	esdtStorageHandler vmcommon.ESDTNFTStorageHandler,
+	lockedBalanceHandler ESDTLockedBalanceHandler,
+	transferFeeHandler ESDTTransferFeeHandler,
+	spendingPolicyHandler GuardedSpendingPolicyHandler,
+	accessListChecker ESDTTransferAccessListChecker,
 ) (*esdtNFTMultiTransfer, error) {
 	if check.IfNil(marshaller) {
 		return nil, ErrNilMarshalizer
@@ -60,6 +69,18 @@ func NewESDTNFTMultiTransferFunc(
 	if check.IfNil(esdtStorageHandler) {
 		return nil, ErrNilESDTNFTStorageHandler
 	}
+	if check.IfNil(lockedBalanceHandler) {
+		return nil, ErrNilLockedBalanceHandler
+	}
+	if check.IfNil(transferFeeHandler) {
+		return nil, ErrNilTransferFeeHandler
+	}
+	if check.IfNil(spendingPolicyHandler) {
+		return nil, ErrNilSpendingPolicyHandler
+	}
+	if check.IfNil(accessListChecker) {
+		return nil, ErrNilAccessListChecker
+	}
 
 	e := &esdtNFTMultiTransfer{
 		keyPrefix:      []byte(baseESDTKeyPrefix),
@@ -71,12 +92,17 @@ func NewESDTNFTMultiTransferFunc(
 		rolesHandler:   roleHandler,
 		baseComponentsHolder: &baseComponentsHolder{
 			esdtStorageHandler:    esdtStorageHandler,
+			esdtSupplyHandler:     getESDTSupplyHandler(esdtStorageHandler),
 			globalSettingsHandler: globalSettingsHandler,
 			shardCoordinator:      shardCoordinator,
 			enableEpochsHandler:   enableEpochsHandler,
 			marshaller:            marshaller,
 		},
-		baseTokenID: []byte(vmcommon.EGLDIdentifier),
+		baseTokenID:           []byte(vmcommon.EGLDIdentifier),
+		lockedBalanceHandler:  lockedBalanceHandler,
+		transferFeeHandler:    transferFeeHandler,
+		spendingPolicyHandler: spendingPolicyHandler,
+		accessListChecker:     accessListChecker,
 	}
 
 	e.baseActiveHandler.activeHandler = func() bool {
@@ -172,6 +198,12 @@ func (e *esdtNFTMultiTransfer) ProcessBuiltinFunction(
 		nonce := big.NewInt(0).SetBytes(vmInput.Arguments[tokenStartIndex+1]).Uint64()
 
 		esdtTokenKey := append(e.keyPrefix, tokenID...)
+		if !bytes.Equal(e.baseTokenID, tokenID) {
+			err = checkTransferAccessLists(esdtTokenKey, vmInput.CallerAddr, vmInput.RecipientAddr, e.accessListChecker, vmInput.ReturnCallAfterError)
+			if err != nil {
+				return nil, fmt.Errorf("%w for token %s", err, string(tokenID))
+			}
+		}
 
 		value := big.NewInt(0)
 		if nonce > 0 {
@@ -205,9 +237,15 @@ func (e *esdtNFTMultiTransfer) ProcessBuiltinFunction(
 			value.Set(transferredValue)
 
 			if bytes.Equal(e.baseTokenID, tokenID) {
-				err = acntDst.AddToBalance(transferredValue)
+				err = checkAccountIsNotFrozen(acntDst, e.enableEpochsHandler, vmInput.ReturnCallAfterError)
+				if err == nil {
+					err = acntDst.AddToBalance(transferredValue)
+				}
 			} else {
-				err = addToESDTBalance(acntDst, esdtTokenKey, transferredValue, e.marshaller, e.globalSettingsHandler, vmInput.ReturnCallAfterError)
+				err = addToESDTBalance(acntDst, esdtTokenKey, transferredValue, e.marshaller, e.globalSettingsHandler, e.lockedBalanceHandler, e.enableEpochsHandler, vmInput.ReturnCallAfterError)
+				if err == nil && isSenderESDTSCAddr {
+					err = e.esdtSupplyHandler.AddToSupplySystemAcc(tokenID, transferredValue)
+				}
 			}
 
 			if err != nil {
@@ -332,12 +370,18 @@ func (e *esdtNFTMultiTransfer) processESDTNFTMultiTransferOnSenderShard(
 			listTransferData[i].ESDTTokenType = uint32(core.NonFungible)
 		}
 
+		err = e.spendingPolicyHandler.CheckUnguardedTransfer(acntSnd, &vmInput.VMInput, dstAddress, listTransferData[i].ESDTTokenName, listTransferData[i].ESDTValue)
+		if err != nil {
+			return nil, fmt.Errorf("%w for token %s", err, string(listTransferData[i].ESDTTokenName))
+		}
+
 		listEsdtData[i], err = e.transferOneTokenOnSenderShard(
 			acntSnd,
 			acntDst,
 			dstAddress,
 			listTransferData[i],
-			vmInput.ReturnCallAfterError)
+			vmInput.ReturnCallAfterError,
+			vmOutput)
 		if core.IsGetNodeFromDBError(err) {
 			return nil, err
 		}
@@ -391,6 +435,7 @@ func (e *esdtNFTMultiTransfer) transferBaseToken(
 	acntSnd vmcommon.UserAccountHandler,
 	acntDst vmcommon.UserAccountHandler,
 	transferData *vmcommon.ESDTTransfer,
+	isReturnCallWithError bool,
 ) (*esdt.ESDigitalToken, error) {
 	if !e.enableEpochsHandler.IsFlagEnabled(EGLDInESDTMultiTransferFlag) {
 		// do not enable this flag on SovereignShards - there is no need for that, as base token is already an ESDT
@@ -403,14 +448,22 @@ func (e *esdtNFTMultiTransfer) transferBaseToken(
 	}
 
 	if !check.IfNil(acntSnd) {
-		err := acntSnd.SubFromBalance(transferData.ESDTValue)
+		err := checkAccountIsNotFrozen(acntSnd, e.enableEpochsHandler, isReturnCallWithError)
+		if err != nil {
+			return nil, err
+		}
+		err = acntSnd.SubFromBalance(transferData.ESDTValue)
 		if err != nil {
 			return nil, err
 		}
 	}
 
 	if !check.IfNil(acntDst) {
-		err := acntDst.AddToBalance(transferData.ESDTValue)
+		err := checkAccountIsNotFrozen(acntDst, e.enableEpochsHandler, isReturnCallWithError)
+		if err != nil {
+			return nil, err
+		}
+		err = acntDst.AddToBalance(transferData.ESDTValue)
 		if err != nil {
 			return nil, err
 		}
@@ -429,13 +482,14 @@ func (e *esdtNFTMultiTransfer) transferOneTokenOnSenderShard(
 	dstAddress []byte,
 	transferData *vmcommon.ESDTTransfer,
 	isReturnCallWithError bool,
+	vmOutput *vmcommon.VMOutput,
 ) (*esdt.ESDigitalToken, error) {
 	if transferData.ESDTValue.Cmp(zero) <= 0 {
 		return nil, ErrInvalidNFTQuantity
 	}
 
 	if bytes.Equal(transferData.ESDTTokenName, e.baseTokenID) {
-		return e.transferBaseToken(acntSnd, acntDst, transferData)
+		return e.transferBaseToken(acntSnd, acntDst, transferData, isReturnCallWithError)
 	}
 
 	esdtTokenKey := append(e.keyPrefix, transferData.ESDTTokenName...)
@@ -444,10 +498,29 @@ func (e *esdtNFTMultiTransfer) transferOneTokenOnSenderShard(
 		return nil, err
 	}
 
-	if esdtData.Value.Cmp(transferData.ESDTValue) < 0 {
+	fee := big.NewInt(0)
+	var feeReceiver []byte
+	if !isReturnCallWithError && transferData.ESDTTokenNonce == 0 {
+		fee, feeReceiver, err = e.transferFeeHandler.ComputeTransferFee(transferData.ESDTTokenName, acntSnd.AddressBytes(), dstAddress, transferData.ESDTValue)
+		if err != nil {
+			return nil, err
+		}
+	}
+	quantityToDeduct := big.NewInt(0).Add(transferData.ESDTValue, fee)
+	if esdtData.Value.Cmp(quantityToDeduct) < 0 {
 		return nil, computeInsufficientQuantityESDTError(transferData.ESDTTokenName, transferData.ESDTTokenNonce)
 	}
-	esdtData.Value.Sub(esdtData.Value, transferData.ESDTValue)
+	esdtData.Value.Sub(esdtData.Value, quantityToDeduct)
+	if transferData.ESDTTokenNonce == 0 {
+		err = checkFrozenESDTAmount(acntSnd, esdtTokenKey, esdtData, isReturnCallWithError)
+		if err != nil {
+			return nil, err
+		}
+		err = checkLockedESDTAmount(e.lockedBalanceHandler, acntSnd, esdtTokenKey, esdtData, isReturnCallWithError)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	properties := vmcommon.NftSaveArgs{
 		MustUpdateAllFields:         false,
@@ -466,7 +539,7 @@ func (e *esdtNFTMultiTransfer) transferOneTokenOnSenderShard(
 		tokenID = transferData.ESDTTokenName
 	}
 
-	err = checkIfTransferCanHappenWithLimitedTransfer(tokenID, esdtTokenKey, acntSnd.AddressBytes(), dstAddress, e.globalSettingsHandler, e.rolesHandler, acntSnd, acntDst, isReturnCallWithError)
+	err = checkIfTransferCanHappenWithLimitedTransfer(tokenID, esdtTokenKey, acntSnd.AddressBytes(), dstAddress, e.globalSettingsHandler, e.accessListChecker, e.rolesHandler, acntSnd, acntDst, isReturnCallWithError)
 	if err != nil {
 		return nil, err
 	}
@@ -497,6 +570,13 @@ func (e *esdtNFTMultiTransfer) transferOneTokenOnSenderShard(
 		}
 	}
 
+	if fee.Sign() > 0 {
+		err = e.transferFeeHandler.CollectTransferFee(acntSnd, dstAddress, transferData.ESDTTokenName, fee, feeReceiver, vmOutput)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return esdtData, nil
 }
 
@@ -36,6 +36,10 @@ func createESDTNFTMultiTransferWithStubArguments() *esdtNFTMultiTransfer {
 		enableEpochsHandler,
 		&mock.ESDTRoleHandlerStub{},
 		createNewESDTDataStorageHandler(),
+		&mock.ESDTLockedBalanceHandlerStub{},
+		&mock.ESDTTransferFeeHandlerStub{},
+		&mock.GuardedSpendingPolicyHandlerStub{},
+		&mock.ESDTTransferAccessListCheckerStub{},
 	)
 
 	return multiTransfer
@@ -104,6 +108,10 @@ func createESDTNFTMultiTransferWithMockArgumentsWithLogEventFlag(selfShard uint3
 			},
 		},
 		createNewESDTDataStorageHandlerWithArgs(globalSettingsHandler, accounts, enableEpochsHandler, &mock.CrossChainTokenCheckerMock{}),
+		&mock.ESDTLockedBalanceHandlerStub{},
+		&mock.ESDTTransferFeeHandlerStub{},
+		&mock.GuardedSpendingPolicyHandlerStub{},
+		&mock.ESDTTransferAccessListCheckerStub{},
 	)
 
 	return multiTransfer
@@ -155,6 +163,10 @@ func TestNewESDTNFTMultiTransferFunc(t *testing.T) {
 			&mock.EnableEpochsHandlerStub{},
 			&mock.ESDTRoleHandlerStub{},
 			createNewESDTDataStorageHandler(),
+			&mock.ESDTLockedBalanceHandlerStub{},
+			&mock.ESDTTransferFeeHandlerStub{},
+			&mock.GuardedSpendingPolicyHandlerStub{},
+			&mock.ESDTTransferAccessListCheckerStub{},
 		)
 		assert.True(t, check.IfNil(multiTransfer))
 		assert.Equal(t, ErrNilMarshalizer, err)
@@ -172,6 +184,10 @@ func TestNewESDTNFTMultiTransferFunc(t *testing.T) {
 			&mock.EnableEpochsHandlerStub{},
 			&mock.ESDTRoleHandlerStub{},
 			createNewESDTDataStorageHandler(),
+			&mock.ESDTLockedBalanceHandlerStub{},
+			&mock.ESDTTransferFeeHandlerStub{},
+			&mock.GuardedSpendingPolicyHandlerStub{},
+			&mock.ESDTTransferAccessListCheckerStub{},
 		)
 		assert.True(t, check.IfNil(multiTransfer))
 		assert.Equal(t, ErrNilGlobalSettingsHandler, err)
@@ -189,6 +205,10 @@ func TestNewESDTNFTMultiTransferFunc(t *testing.T) {
 			&mock.EnableEpochsHandlerStub{},
 			&mock.ESDTRoleHandlerStub{},
 			createNewESDTDataStorageHandler(),
+			&mock.ESDTLockedBalanceHandlerStub{},
+			&mock.ESDTTransferFeeHandlerStub{},
+			&mock.GuardedSpendingPolicyHandlerStub{},
+			&mock.ESDTTransferAccessListCheckerStub{},
 		)
 		assert.True(t, check.IfNil(multiTransfer))
 		assert.Equal(t, ErrNilAccountsAdapter, err)
@@ -206,6 +226,10 @@ func TestNewESDTNFTMultiTransferFunc(t *testing.T) {
 			&mock.EnableEpochsHandlerStub{},
 			&mock.ESDTRoleHandlerStub{},
 			createNewESDTDataStorageHandler(),
+			&mock.ESDTLockedBalanceHandlerStub{},
+			&mock.ESDTTransferFeeHandlerStub{},
+			&mock.GuardedSpendingPolicyHandlerStub{},
+			&mock.ESDTTransferAccessListCheckerStub{},
 		)
 		assert.True(t, check.IfNil(multiTransfer))
 		assert.Equal(t, ErrNilShardCoordinator, err)
@@ -223,6 +247,10 @@ func TestNewESDTNFTMultiTransferFunc(t *testing.T) {
 			nil,
 			&mock.ESDTRoleHandlerStub{},
 			createNewESDTDataStorageHandler(),
+			&mock.ESDTLockedBalanceHandlerStub{},
+			&mock.ESDTTransferFeeHandlerStub{},
+			&mock.GuardedSpendingPolicyHandlerStub{},
+			&mock.ESDTTransferAccessListCheckerStub{},
 		)
 		assert.True(t, check.IfNil(multiTransfer))
 		assert.Equal(t, ErrNilEnableEpochsHandler, err)
@@ -240,6 +268,10 @@ func TestNewESDTNFTMultiTransferFunc(t *testing.T) {
 			&mock.EnableEpochsHandlerStub{},
 			nil,
 			createNewESDTDataStorageHandler(),
+			&mock.ESDTLockedBalanceHandlerStub{},
+			&mock.ESDTTransferFeeHandlerStub{},
+			&mock.GuardedSpendingPolicyHandlerStub{},
+			&mock.ESDTTransferAccessListCheckerStub{},
 		)
 		assert.True(t, check.IfNil(multiTransfer))
 		assert.Equal(t, ErrNilRolesHandler, err)
@@ -257,10 +289,98 @@ func TestNewESDTNFTMultiTransferFunc(t *testing.T) {
 			&mock.EnableEpochsHandlerStub{},
 			&mock.ESDTRoleHandlerStub{},
 			nil,
+			&mock.ESDTLockedBalanceHandlerStub{},
+			&mock.ESDTTransferFeeHandlerStub{},
+			&mock.GuardedSpendingPolicyHandlerStub{},
+			&mock.ESDTTransferAccessListCheckerStub{},
 		)
 		assert.True(t, check.IfNil(multiTransfer))
 		assert.Equal(t, ErrNilESDTNFTStorageHandler, err)
 	})
+	t.Run("nil locked balance handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		multiTransfer, err := NewESDTNFTMultiTransferFunc(
+			0,
+			&mock.MarshalizerMock{},
+			&mock.GlobalSettingsHandlerStub{},
+			&mock.AccountsStub{},
+			&mock.ShardCoordinatorStub{},
+			vmcommon.BaseOperationCost{},
+			&mock.EnableEpochsHandlerStub{},
+			&mock.ESDTRoleHandlerStub{},
+			createNewESDTDataStorageHandler(),
+			nil,
+			&mock.ESDTTransferFeeHandlerStub{},
+			&mock.GuardedSpendingPolicyHandlerStub{},
+			&mock.ESDTTransferAccessListCheckerStub{},
+		)
+		assert.True(t, check.IfNil(multiTransfer))
+		assert.Equal(t, ErrNilLockedBalanceHandler, err)
+	})
+	t.Run("nil transfer fee handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		multiTransfer, err := NewESDTNFTMultiTransferFunc(
+			0,
+			&mock.MarshalizerMock{},
+			&mock.GlobalSettingsHandlerStub{},
+			&mock.AccountsStub{},
+			&mock.ShardCoordinatorStub{},
+			vmcommon.BaseOperationCost{},
+			&mock.EnableEpochsHandlerStub{},
+			&mock.ESDTRoleHandlerStub{},
+			createNewESDTDataStorageHandler(),
+			&mock.ESDTLockedBalanceHandlerStub{},
+			nil,
+			&mock.GuardedSpendingPolicyHandlerStub{},
+			&mock.ESDTTransferAccessListCheckerStub{},
+		)
+		assert.True(t, check.IfNil(multiTransfer))
+		assert.Equal(t, ErrNilTransferFeeHandler, err)
+	})
+	t.Run("nil spending policy handler should error", func(t *testing.T) {
+		t.Parallel()
+
+		multiTransfer, err := NewESDTNFTMultiTransferFunc(
+			0,
+			&mock.MarshalizerMock{},
+			&mock.GlobalSettingsHandlerStub{},
+			&mock.AccountsStub{},
+			&mock.ShardCoordinatorStub{},
+			vmcommon.BaseOperationCost{},
+			&mock.EnableEpochsHandlerStub{},
+			&mock.ESDTRoleHandlerStub{},
+			createNewESDTDataStorageHandler(),
+			&mock.ESDTLockedBalanceHandlerStub{},
+			&mock.ESDTTransferFeeHandlerStub{},
+			nil,
+			&mock.ESDTTransferAccessListCheckerStub{},
+		)
+		assert.True(t, check.IfNil(multiTransfer))
+		assert.Equal(t, ErrNilSpendingPolicyHandler, err)
+	})
+	t.Run("nil access list checker should error", func(t *testing.T) {
+		t.Parallel()
+
+		multiTransfer, err := NewESDTNFTMultiTransferFunc(
+			0,
+			&mock.MarshalizerMock{},
+			&mock.GlobalSettingsHandlerStub{},
+			&mock.AccountsStub{},
+			&mock.ShardCoordinatorStub{},
+			vmcommon.BaseOperationCost{},
+			&mock.EnableEpochsHandlerStub{},
+			&mock.ESDTRoleHandlerStub{},
+			createNewESDTDataStorageHandler(),
+			&mock.ESDTLockedBalanceHandlerStub{},
+			&mock.ESDTTransferFeeHandlerStub{},
+			&mock.GuardedSpendingPolicyHandlerStub{},
+			nil,
+		)
+		assert.True(t, check.IfNil(multiTransfer))
+		assert.Equal(t, ErrNilAccessListChecker, err)
+	})
 	t.Run("should work", func(t *testing.T) {
 		t.Parallel()
 
@@ -274,6 +394,10 @@ func TestNewESDTNFTMultiTransferFunc(t *testing.T) {
 			&mock.EnableEpochsHandlerStub{},
 			&mock.ESDTRoleHandlerStub{},
 			createNewESDTDataStorageHandler(),
+			&mock.ESDTLockedBalanceHandlerStub{},
+			&mock.ESDTTransferFeeHandlerStub{},
+			&mock.GuardedSpendingPolicyHandlerStub{},
+			&mock.ESDTTransferAccessListCheckerStub{},
 		)
 		assert.False(t, check.IfNil(multiTransfer))
 		assert.Nil(t, err)
@@ -429,6 +553,51 @@ func TestESDTNFTMultiTransfer_ProcessBuiltinFunctionOnSameShardWithScCall(t *tes
 	require.Equal(t, []byte(scCallArg), args[0])
 }
 
+func TestESDTNFTMultiTransfer_ProcessBuiltinFunctionTransferFeeOnlyForFungibleTokens(t *testing.T) {
+	t.Parallel()
+
+	multiTransfer := createESDTNFTMultiTransferWithMockArguments(0, 1, &mock.GlobalSettingsHandlerStub{})
+	feeReceiver := bytes.Repeat([]byte{3}, 32)
+	tokensWithFee := make([][]byte, 0)
+	multiTransfer.transferFeeHandler = &mock.ESDTTransferFeeHandlerStub{
+		ComputeTransferFeeCalled: func(tokenID []byte, _ []byte, _ []byte, _ *big.Int) (*big.Int, []byte, error) {
+			tokensWithFee = append(tokensWithFee, tokenID)
+			return big.NewInt(2), feeReceiver, nil
+		},
+	}
+	_ = multiTransfer.SetPayableChecker(&mock.PayableHandlerStub{})
+	senderAddress := bytes.Repeat([]byte{2}, 32)
+	destinationAddress := bytes.Repeat([]byte{0}, 32)
+	destinationAddress[25] = 1
+	sender, _ := multiTransfer.accounts.LoadAccount(senderAddress)
+	destination, _ := multiTransfer.accounts.LoadAccount(destinationAddress)
+
+	nft := []byte("nft")
+	fungible := []byte("fungible")
+	nftNonce := uint64(1)
+	createESDTNFTToken(nft, core.NonFungible, nftNonce, big.NewInt(1), multiTransfer.marshaller, sender.(vmcommon.UserAccountHandler))
+	createESDTNFTToken(fungible, core.Fungible, 0, big.NewInt(3), multiTransfer.marshaller, sender.(vmcommon.UserAccountHandler))
+
+	quantityBytes := big.NewInt(1).Bytes()
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallValue:   big.NewInt(0),
+			CallerAddr:  senderAddress,
+			Arguments:   [][]byte{destinationAddress, big.NewInt(2).Bytes(), nft, big.NewInt(int64(nftNonce)).Bytes(), quantityBytes, fungible, big.NewInt(0).Bytes(), quantityBytes},
+			GasProvided: 100000,
+		},
+		RecipientAddr: senderAddress,
+	}
+	vmOutput, err := multiTransfer.ProcessBuiltinFunction(sender.(vmcommon.UserAccountHandler), destination.(vmcommon.UserAccountHandler), vmInput)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+
+	assert.Equal(t, [][]byte{fungible}, tokensWithFee)
+	testNFTTokenShouldExist(t, multiTransfer.marshaller, destination, nft, nftNonce, big.NewInt(1))
+	testNFTTokenShouldExist(t, multiTransfer.marshaller, destination, fungible, 0, big.NewInt(1))
+	testNFTTokenShouldExist(t, multiTransfer.marshaller, sender, fungible, 0, big.NewInt(0))
+}
+
 func TestESDTNFTMultiTransfer_ProcessBuiltinFunctionOnSameShardShouldCheckTokenValueLength(t *testing.T) {
 	t.Parallel()
 
@@ -779,6 +948,40 @@ func TestESDTNFTMultiTransfer_ProcessBuiltinFunctionOnCrossShardsOneTransfer(t *
 	testNFTTokenShouldExist(t, multiTransferDestinationShard.marshaller, destination, token1, tokenNonce, expectedTokens1)
 }
 
+func TestESDTNFTMultiTransfer_ProcessBuiltinFunctionOnCrossShardsAccessLists(t *testing.T) {
+	t.Parallel()
+
+	multiTransferDestinationShard := createESDTNFTMultiTransferWithMockArguments(1, 2, &mock.GlobalSettingsHandlerStub{})
+	multiTransferDestinationShard.accessListChecker = &mock.ESDTTransferAccessListCheckerStub{
+		CheckTransferAccessListsCalled: func(esdtTokenKey []byte, sender, destination []byte) error {
+			return ErrAddressIsDenyListed
+		},
+	}
+	_ = multiTransferDestinationShard.SetPayableChecker(&mock.PayableHandlerStub{})
+
+	senderAddress := bytes.Repeat([]byte{2}, 32)
+	destinationAddress := bytes.Repeat([]byte{1}, 32)
+	destination, err := multiTransferDestinationShard.accounts.LoadAccount(destinationAddress)
+	require.Nil(t, err)
+
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallValue:  big.NewInt(0),
+			CallerAddr: senderAddress,
+			Arguments:  [][]byte{big.NewInt(1).Bytes(), []byte("token1"), {}, big.NewInt(10).Bytes()},
+		},
+		RecipientAddr: destinationAddress,
+	}
+
+	_, err = multiTransferDestinationShard.ProcessBuiltinFunction(nil, destination.(vmcommon.UserAccountHandler), vmInput)
+	assert.True(t, errors.Is(err, ErrAddressIsDenyListed))
+
+	vmInput.ReturnCallAfterError = true
+	vmOutput, err := multiTransferDestinationShard.ProcessBuiltinFunction(nil, destination.(vmcommon.UserAccountHandler), vmInput)
+	require.Nil(t, err)
+	require.Equal(t, vmcommon.Ok, vmOutput.ReturnCode)
+}
+
 func TestESDTNFTMultiTransfer_ProcessBuiltinFunctionOnCrossShardsDestinationHoldsNFT(t *testing.T) {
 	t.Parallel()
 
@@ -1516,3 +1719,42 @@ func TestESDTNFTMultiTransfer_ProcessBuiltinFunctionOnCrossShardsWithEGLD(t *tes
 	require.Equal(t, 1, len(args))
 	require.Equal(t, []byte(scCallArg), args[0])
 }
+
+func TestESDTNFTMultiTransfer_ProcessBuiltinFunctionWithEGLDFrozenAccount(t *testing.T) {
+	t.Parallel()
+
+	multiTransfer := createESDTNFTMultiTransferWithMockArguments(1, 2, &mock.GlobalSettingsHandlerStub{})
+	multiTransfer.enableEpochsHandler = &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return true
+		},
+	}
+
+	senderAddress := bytes.Repeat([]byte{1}, 32)
+	destinationAddress := bytes.Repeat([]byte{0}, 32)
+	destinationAddress[25] = 1
+	sender, err := multiTransfer.accounts.LoadAccount(senderAddress)
+	require.Nil(t, err)
+	userAccount := sender.(vmcommon.UserAccountHandler)
+	_ = userAccount.AddToBalance(big.NewInt(3))
+	_ = userAccount.AccountDataHandler().SaveKeyValue([]byte(accountFrozenKey), accountFrozenValue)
+
+	vmInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallValue:   big.NewInt(0),
+			CallerAddr:  senderAddress,
+			Arguments:   [][]byte{destinationAddress, big.NewInt(1).Bytes(), []byte(vmcommon.EGLDIdentifier), big.NewInt(0).Bytes(), big.NewInt(1).Bytes()},
+			GasProvided: 1000000,
+		},
+		RecipientAddr: senderAddress,
+	}
+
+	_, err = multiTransfer.ProcessBuiltinFunction(userAccount, nil, vmInput)
+	require.ErrorIs(t, err, ErrAccountIsFrozen)
+	require.Equal(t, big.NewInt(3), userAccount.GetBalance())
+
+	_ = userAccount.AccountDataHandler().SaveKeyValue([]byte(accountFrozenKey), nil)
+	_, err = multiTransfer.ProcessBuiltinFunction(userAccount, nil, vmInput)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(2), userAccount.GetBalance())
+}
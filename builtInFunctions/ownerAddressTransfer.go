@@ -0,0 +1,208 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const pendingOwnerAddressKey = core.ProtectedKeyPrefix + "pendingOwnerAddress"
+
+type ownerAddressTransfer struct {
+	baseActiveHandler
+	function     string
+	gasCost      uint64
+	mutExecution sync.RWMutex
+
+	enableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+// NewOwnerAddressTransferFunc creates one of the built-in functions of the two-step ownership transfer of a smart
+// contract: ProposeOwnerAddress, AcceptOwnerAddress or CancelOwnerAddressProposal. The proposed owner is saved in the
+// protected storage of the contract until it is accepted or cancelled
+func NewOwnerAddressTransferFunc(
+	function string,
+	gasCost uint64,
+	enableEpochsHandler vmcommon.EnableEpochsHandler,
+) (*ownerAddressTransfer, error) {
+	if check.IfNil(enableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+	switch function {
+	case vmcommon.BuiltInFunctionProposeOwnerAddress, vmcommon.BuiltInFunctionAcceptOwnerAddress, vmcommon.BuiltInFunctionCancelOwnerAddressProposal:
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrInvalidOwnerAddressTransferFunction, function)
+	}
+
+	o := &ownerAddressTransfer{
+		function:            function,
+		gasCost:             gasCost,
+		enableEpochsHandler: enableEpochsHandler,
+	}
+	o.activeHandler = func() bool {
+		return enableEpochsHandler.IsFlagEnabled(TwoStepOwnershipTransferFlag)
+	}
+
+	return o, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (o *ownerAddressTransfer) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	o.mutExecution.Lock()
+	o.gasCost = gasCost.BuiltInCost.OwnerAddressTransfer
+	o.mutExecution.Unlock()
+}
+
+// ProcessBuiltinFunction processes one step of the ownership transfer. The current owner proposes the new owner or
+// cancels the proposal, the proposed owner accepts the ownership with its own transaction
+func (o *ownerAddressTransfer) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	o.mutExecution.RLock()
+	defer o.mutExecution.RUnlock()
+
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if vmInput.CallValue == nil {
+		return nil, ErrNilValue
+	}
+	if vmInput.CallValue.Cmp(zero) != 0 {
+		return nil, ErrBuiltInFunctionCalledWithValue
+	}
+	err := o.checkArguments(vmInput)
+	if err != nil {
+		return nil, err
+	}
+	if vmInput.GasProvided < o.gasCost {
+		return nil, ErrNotEnoughGas
+	}
+
+	vmOutput := &vmcommon.VMOutput{
+		ReturnCode:   vmcommon.Ok,
+		GasRemaining: computeGasRemaining(acntSnd, vmInput.GasProvided, o.gasCost),
+	}
+	if check.IfNil(acntDst) {
+		addOutputTransferForOwnerCallThroughSC(o.enableEpochsHandler, o.function, acntDst, vmInput, vmOutput)
+		return vmOutput, nil
+	}
+
+	var topic []byte
+	switch o.function {
+	case vmcommon.BuiltInFunctionProposeOwnerAddress:
+		topic, err = proposeOwnerAddress(acntDst, vmInput)
+	case vmcommon.BuiltInFunctionAcceptOwnerAddress:
+		topic, err = acceptOwnerAddress(acntDst, vmInput)
+	default:
+		topic, err = cancelOwnerAddressProposal(acntDst, vmInput)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	vmOutput.Logs = []*vmcommon.LogEntry{{
+		Identifier: []byte(o.function),
+		Address:    vmInput.RecipientAddr,
+		Topics:     [][]byte{topic},
+	}}
+
+	return vmOutput, nil
+}
+
+func (o *ownerAddressTransfer) checkArguments(vmInput *vmcommon.ContractCallInput) error {
+	if o.function != vmcommon.BuiltInFunctionProposeOwnerAddress {
+		if len(vmInput.Arguments) != 0 {
+			return ErrInvalidArguments
+		}
+		return nil
+	}
+
+	if len(vmInput.Arguments) != 1 {
+		return ErrInvalidArguments
+	}
+	if len(vmInput.Arguments[0]) != len(vmInput.CallerAddr) {
+		return ErrInvalidAddressLength
+	}
+
+	return nil
+}
+
+func proposeOwnerAddress(acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) ([]byte, error) {
+	if !bytes.Equal(vmInput.CallerAddr, acntDst.GetOwnerAddress()) {
+		return nil, fmt.Errorf("%w not the owner of the account", ErrOperationNotPermitted)
+	}
+
+	pendingOwner := vmInput.Arguments[0]
+	err := acntDst.AccountDataHandler().SaveKeyValue([]byte(pendingOwnerAddressKey), pendingOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	return pendingOwner, nil
+}
+
+func acceptOwnerAddress(acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) ([]byte, error) {
+	pendingOwner, err := getPendingOwnerAddress(acntDst)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(vmInput.CallerAddr, pendingOwner) {
+		return nil, ErrCallerIsNotPendingOwner
+	}
+
+	err = acntDst.ChangeOwnerAddress(acntDst.GetOwnerAddress(), pendingOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	err = acntDst.AccountDataHandler().SaveKeyValue([]byte(pendingOwnerAddressKey), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return pendingOwner, nil
+}
+
+func cancelOwnerAddressProposal(acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) ([]byte, error) {
+	if !bytes.Equal(vmInput.CallerAddr, acntDst.GetOwnerAddress()) {
+		return nil, fmt.Errorf("%w not the owner of the account", ErrOperationNotPermitted)
+	}
+
+	pendingOwner, err := getPendingOwnerAddress(acntDst)
+	if err != nil {
+		return nil, err
+	}
+
+	err = acntDst.AccountDataHandler().SaveKeyValue([]byte(pendingOwnerAddressKey), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return pendingOwner, nil
+}
+
+func getPendingOwnerAddress(acntDst vmcommon.UserAccountHandler) ([]byte, error) {
+	pendingOwner, _, err := acntDst.AccountDataHandler().RetrieveValue([]byte(pendingOwnerAddressKey))
+	if core.IsGetNodeFromDBError(err) {
+		return nil, err
+	}
+	if len(pendingOwner) == 0 {
+		return nil, ErrNoPendingOwnerAddress
+	}
+
+	return pendingOwner, nil
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (o *ownerAddressTransfer) IsInterfaceNil() bool {
+	return o == nil
+}
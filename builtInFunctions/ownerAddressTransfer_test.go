@@ -0,0 +1,189 @@
+package builtInFunctions
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createOwnerAddressTransferEnableEpochsHandler() vmcommon.EnableEpochsHandler {
+	return &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return flag == TwoStepOwnershipTransferFlag || flag == IsChangeOwnerAddressCrossShardThroughSCFlag
+		},
+	}
+}
+
+func createOwnerAddressTransferInput(function string, caller []byte, args ...[]byte) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		Function:      function,
+		RecipientAddr: []byte("contract"),
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  caller,
+			CallValue:   big.NewInt(0),
+			GasProvided: 10,
+			Arguments:   args,
+		},
+	}
+}
+
+func TestNewOwnerAddressTransferFunc(t *testing.T) {
+	t.Parallel()
+
+	instance, err := NewOwnerAddressTransferFunc(vmcommon.BuiltInFunctionProposeOwnerAddress, 1, nil)
+	require.Nil(t, instance)
+	require.Equal(t, ErrNilEnableEpochsHandler, err)
+
+	instance, err = NewOwnerAddressTransferFunc(core.BuiltInFunctionChangeOwnerAddress, 1, createOwnerAddressTransferEnableEpochsHandler())
+	require.Nil(t, instance)
+	require.True(t, errors.Is(err, ErrInvalidOwnerAddressTransferFunction))
+
+	instance, err = NewOwnerAddressTransferFunc(vmcommon.BuiltInFunctionAcceptOwnerAddress, 1, createOwnerAddressTransferEnableEpochsHandler())
+	require.Nil(t, err)
+	require.False(t, instance.IsInterfaceNil())
+	require.True(t, instance.IsActive())
+
+	instance, _ = NewOwnerAddressTransferFunc(vmcommon.BuiltInFunctionAcceptOwnerAddress, 1, &mock.EnableEpochsHandlerStub{})
+	require.False(t, instance.IsActive())
+
+	instance.SetNewGasConfig(&vmcommon.GasCost{BuiltInCost: vmcommon.BuiltInCost{ChangeOwnerAddress: 5, OwnerAddressTransfer: 37}})
+	require.Equal(t, uint64(37), instance.gasCost)
+}
+
+func TestOwnerAddressTransfer_ProcessBuiltinFunctionErrors(t *testing.T) {
+	t.Parallel()
+
+	owner := []byte("owner123")
+	pendingOwner := []byte("other123")
+	proposeFunc, _ := NewOwnerAddressTransferFunc(vmcommon.BuiltInFunctionProposeOwnerAddress, 1, createOwnerAddressTransferEnableEpochsHandler())
+	acceptFunc, _ := NewOwnerAddressTransferFunc(vmcommon.BuiltInFunctionAcceptOwnerAddress, 1, createOwnerAddressTransferEnableEpochsHandler())
+	cancelFunc, _ := NewOwnerAddressTransferFunc(vmcommon.BuiltInFunctionCancelOwnerAddressProposal, 1, createOwnerAddressTransferEnableEpochsHandler())
+	contract := mock.NewUserAccount([]byte("contract"))
+	contract.OwnerAddress = owner
+
+	_, err := proposeFunc.ProcessBuiltinFunction(nil, contract, nil)
+	require.Equal(t, ErrNilVmInput, err)
+
+	vmInput := createOwnerAddressTransferInput(vmcommon.BuiltInFunctionProposeOwnerAddress, owner, pendingOwner)
+	vmInput.CallValue = big.NewInt(1)
+	_, err = proposeFunc.ProcessBuiltinFunction(nil, contract, vmInput)
+	require.Equal(t, ErrBuiltInFunctionCalledWithValue, err)
+
+	_, err = proposeFunc.ProcessBuiltinFunction(nil, contract, createOwnerAddressTransferInput(vmcommon.BuiltInFunctionProposeOwnerAddress, owner))
+	require.Equal(t, ErrInvalidArguments, err)
+
+	_, err = proposeFunc.ProcessBuiltinFunction(nil, contract, createOwnerAddressTransferInput(vmcommon.BuiltInFunctionProposeOwnerAddress, owner, []byte("short address")))
+	require.Equal(t, ErrInvalidAddressLength, err)
+
+	_, err = acceptFunc.ProcessBuiltinFunction(nil, contract, createOwnerAddressTransferInput(vmcommon.BuiltInFunctionAcceptOwnerAddress, pendingOwner, pendingOwner))
+	require.Equal(t, ErrInvalidArguments, err)
+
+	vmInput = createOwnerAddressTransferInput(vmcommon.BuiltInFunctionProposeOwnerAddress, owner, pendingOwner)
+	vmInput.GasProvided = 0
+	_, err = proposeFunc.ProcessBuiltinFunction(nil, contract, vmInput)
+	require.Equal(t, ErrNotEnoughGas, err)
+
+	_, err = proposeFunc.ProcessBuiltinFunction(nil, contract, createOwnerAddressTransferInput(vmcommon.BuiltInFunctionProposeOwnerAddress, pendingOwner, owner))
+	require.True(t, errors.Is(err, ErrOperationNotPermitted))
+
+	_, err = acceptFunc.ProcessBuiltinFunction(nil, contract, createOwnerAddressTransferInput(vmcommon.BuiltInFunctionAcceptOwnerAddress, pendingOwner))
+	require.Equal(t, ErrNoPendingOwnerAddress, err)
+
+	_, err = cancelFunc.ProcessBuiltinFunction(nil, contract, createOwnerAddressTransferInput(vmcommon.BuiltInFunctionCancelOwnerAddressProposal, owner))
+	require.Equal(t, ErrNoPendingOwnerAddress, err)
+
+	_, err = proposeFunc.ProcessBuiltinFunction(nil, contract, createOwnerAddressTransferInput(vmcommon.BuiltInFunctionProposeOwnerAddress, owner, pendingOwner))
+	require.Nil(t, err)
+
+	_, err = acceptFunc.ProcessBuiltinFunction(nil, contract, createOwnerAddressTransferInput(vmcommon.BuiltInFunctionAcceptOwnerAddress, owner))
+	require.Equal(t, ErrCallerIsNotPendingOwner, err)
+
+	_, err = cancelFunc.ProcessBuiltinFunction(nil, contract, createOwnerAddressTransferInput(vmcommon.BuiltInFunctionCancelOwnerAddressProposal, pendingOwner))
+	require.True(t, errors.Is(err, ErrOperationNotPermitted))
+	require.Equal(t, owner, contract.OwnerAddress)
+}
+
+func TestOwnerAddressTransfer_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	owner := []byte("owner123")
+	pendingOwner := []byte("other123")
+	contractAddress := []byte("contract")
+
+	t.Run("propose and accept", func(t *testing.T) {
+		t.Parallel()
+
+		proposeFunc, _ := NewOwnerAddressTransferFunc(vmcommon.BuiltInFunctionProposeOwnerAddress, 1, createOwnerAddressTransferEnableEpochsHandler())
+		acceptFunc, _ := NewOwnerAddressTransferFunc(vmcommon.BuiltInFunctionAcceptOwnerAddress, 1, createOwnerAddressTransferEnableEpochsHandler())
+		contract := mock.NewUserAccount(contractAddress)
+		contract.OwnerAddress = owner
+
+		vmInput := createOwnerAddressTransferInput(vmcommon.BuiltInFunctionProposeOwnerAddress, owner, pendingOwner)
+		vmOutput, err := proposeFunc.ProcessBuiltinFunction(mock.NewUserAccount(owner), contract, vmInput)
+		require.Nil(t, err)
+		require.Equal(t, uint64(9), vmOutput.GasRemaining)
+		require.Equal(t, owner, contract.OwnerAddress)
+		require.Equal(t, []*vmcommon.LogEntry{{
+			Identifier: []byte(vmcommon.BuiltInFunctionProposeOwnerAddress),
+			Address:    contractAddress,
+			Topics:     [][]byte{pendingOwner},
+		}}, vmOutput.Logs)
+
+		vmInput = createOwnerAddressTransferInput(vmcommon.BuiltInFunctionAcceptOwnerAddress, pendingOwner)
+		vmOutput, err = acceptFunc.ProcessBuiltinFunction(nil, contract, vmInput)
+		require.Nil(t, err)
+		require.Equal(t, uint64(0), vmOutput.GasRemaining)
+		require.Equal(t, pendingOwner, contract.OwnerAddress)
+		require.Equal(t, []*vmcommon.LogEntry{{
+			Identifier: []byte(vmcommon.BuiltInFunctionAcceptOwnerAddress),
+			Address:    contractAddress,
+			Topics:     [][]byte{pendingOwner},
+		}}, vmOutput.Logs)
+
+		_, err = getPendingOwnerAddress(contract)
+		require.Equal(t, ErrNoPendingOwnerAddress, err)
+	})
+	t.Run("propose and cancel", func(t *testing.T) {
+		t.Parallel()
+
+		proposeFunc, _ := NewOwnerAddressTransferFunc(vmcommon.BuiltInFunctionProposeOwnerAddress, 1, createOwnerAddressTransferEnableEpochsHandler())
+		cancelFunc, _ := NewOwnerAddressTransferFunc(vmcommon.BuiltInFunctionCancelOwnerAddressProposal, 1, createOwnerAddressTransferEnableEpochsHandler())
+		contract := mock.NewUserAccount(contractAddress)
+		contract.OwnerAddress = owner
+
+		_, err := proposeFunc.ProcessBuiltinFunction(nil, contract, createOwnerAddressTransferInput(vmcommon.BuiltInFunctionProposeOwnerAddress, owner, pendingOwner))
+		require.Nil(t, err)
+
+		vmOutput, err := cancelFunc.ProcessBuiltinFunction(nil, contract, createOwnerAddressTransferInput(vmcommon.BuiltInFunctionCancelOwnerAddressProposal, owner))
+		require.Nil(t, err)
+		require.Equal(t, owner, contract.OwnerAddress)
+		require.Equal(t, []*vmcommon.LogEntry{{
+			Identifier: []byte(vmcommon.BuiltInFunctionCancelOwnerAddressProposal),
+			Address:    contractAddress,
+			Topics:     [][]byte{pendingOwner},
+		}}, vmOutput.Logs)
+
+		_, err = getPendingOwnerAddress(contract)
+		require.Equal(t, ErrNoPendingOwnerAddress, err)
+	})
+	t.Run("cross shard call through a smart contract", func(t *testing.T) {
+		t.Parallel()
+
+		acceptFunc, _ := NewOwnerAddressTransferFunc(vmcommon.BuiltInFunctionAcceptOwnerAddress, 1, createOwnerAddressTransferEnableEpochsHandler())
+		scPendingOwner := make([]byte, 32)
+
+		vmOutput, err := acceptFunc.ProcessBuiltinFunction(mock.NewUserAccount(scPendingOwner), nil, createOwnerAddressTransferInput(vmcommon.BuiltInFunctionAcceptOwnerAddress, scPendingOwner))
+		require.Nil(t, err)
+		require.Equal(t, 1, len(vmOutput.OutputAccounts))
+
+		outputTransfer := vmOutput.OutputAccounts[string(contractAddress)].OutputTransfers[0]
+		require.Equal(t, []byte(vmcommon.BuiltInFunctionAcceptOwnerAddress), outputTransfer.Data)
+		require.Equal(t, vm.DirectCall, outputTransfer.CallType)
+	})
+}
@@ -0,0 +1,139 @@
+package builtInFunctions
+
+import (
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+type renewUserName struct {
+	baseActiveHandler
+	gasCost         uint64
+	mapDnsAddresses map[string]struct{}
+	epochHook       BlockChainEpochHook
+	mutExecution    sync.RWMutex
+}
+
+// NewRenewUserNameFunc returns the built-in function which extends the expiry epoch of the username of an account
+func NewRenewUserNameFunc(
+	gasCost uint64,
+	mapDnsAddresses map[string]struct{},
+	enableEpochsHandler vmcommon.EnableEpochsHandler,
+) (*renewUserName, error) {
+	if mapDnsAddresses == nil {
+		return nil, ErrNilDnsAddresses
+	}
+	if check.IfNil(enableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+
+	r := &renewUserName{
+		gasCost:         gasCost,
+		mapDnsAddresses: make(map[string]struct{}, len(mapDnsAddresses)),
+		epochHook:       &disabledBlockchainHook{},
+	}
+	for key := range mapDnsAddresses {
+		r.mapDnsAddresses[key] = struct{}{}
+	}
+	r.activeHandler = func() bool {
+		return enableEpochsHandler.IsFlagEnabled(UserNameLifecycleFlag)
+	}
+
+	return r, nil
+}
+
+// SetBlockChainEpochHook sets the component providing the current epoch
+func (r *renewUserName) SetBlockChainEpochHook(epochHook BlockChainEpochHook) error {
+	if check.IfNil(epochHook) {
+		return ErrNilBlockchainHook
+	}
+
+	r.mutExecution.Lock()
+	r.epochHook = epochHook
+	r.mutExecution.Unlock()
+
+	return nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (r *renewUserName) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	r.mutExecution.Lock()
+	r.gasCost = gasCost.BuiltInCost.RenewUserName
+	r.mutExecution.Unlock()
+}
+
+// ProcessBuiltinFunction sets the new expiry epoch of the username of the destination account. Only a username which
+// expires and has not expired yet can be renewed. The new expiry epoch has to be after the current epoch and after the
+// current expiry epoch
+func (r *renewUserName) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	r.mutExecution.RLock()
+	defer r.mutExecution.RUnlock()
+
+	err := inputCheckForUserNameCall(acntSnd, vmInput, r.mapDnsAddresses, r.gasCost, 1)
+	if err != nil {
+		return nil, err
+	}
+	expiryEpoch, err := parseUserNameExpiryEpoch(vmInput.Arguments[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if check.IfNil(acntDst) {
+		return createCrossShardUserNameCall(vmInput, vmInput.Function, vmInput.GasProvided-r.gasCost)
+	}
+
+	userName := acntDst.GetUserName()
+	if len(userName) == 0 {
+		return nil, ErrUserNameNotSet
+	}
+	currentExpiryEpoch, err := getUserNameExpiryEpoch(acntDst)
+	if err != nil {
+		return nil, err
+	}
+	// a username which never expires stays so, while an expired username has to be set again through SetUserName
+	if currentExpiryEpoch == 0 {
+		return nil, ErrInvalidUserNameExpiryEpoch
+	}
+	currentEpoch := r.epochHook.CurrentEpoch()
+	if isUserNameExpired(currentExpiryEpoch, currentEpoch) {
+		return nil, ErrUserNameExpired
+	}
+	if expiryEpoch <= currentEpoch || expiryEpoch <= currentExpiryEpoch {
+		return nil, ErrInvalidUserNameExpiryEpoch
+	}
+
+	encodedExpiryEpoch := userNameExpiryEpochToBytes(expiryEpoch)
+	err = acntDst.AccountDataHandler().SaveKeyValue([]byte(userNameExpiryEpochKey), encodedExpiryEpoch)
+	if err != nil {
+		return nil, err
+	}
+
+	gasRemaining := vmInput.GasProvided
+	if !check.IfNil(acntSnd) {
+		gasRemaining = vmInput.GasProvided - r.gasCost
+	}
+	vmOutput := &vmcommon.VMOutput{
+		GasRemaining: gasRemaining,
+		ReturnCode:   vmcommon.Ok,
+	}
+	vmOutput.Logs = []*vmcommon.LogEntry{{
+		Identifier: []byte(vmInput.Function),
+		Address:    vmInput.RecipientAddr,
+		Topics:     [][]byte{userName, encodedExpiryEpoch},
+	}}
+
+	return vmOutput, nil
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (r *renewUserName) IsInterfaceNil() bool {
+	return r == nil
+}
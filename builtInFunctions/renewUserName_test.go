@@ -0,0 +1,112 @@
+package builtInFunctions
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createUserNameLifecycleEnableEpochsHandler() vmcommon.EnableEpochsHandler {
+	return &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return flag == UserNameLifecycleFlag
+		},
+	}
+}
+
+func createUserNameLifecycleInput(function string, holder []byte, args ...[]byte) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		Function:      function,
+		RecipientAddr: holder,
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  []byte("dns"),
+			CallValue:   big.NewInt(0),
+			GasProvided: 10,
+			Arguments:   args,
+		},
+	}
+}
+
+func TestNewRenewUserNameFunc(t *testing.T) {
+	t.Parallel()
+
+	dnsAddresses := map[string]struct{}{"dns": {}}
+	instance, err := NewRenewUserNameFunc(1, nil, createUserNameLifecycleEnableEpochsHandler())
+	require.Nil(t, instance)
+	require.Equal(t, ErrNilDnsAddresses, err)
+
+	instance, err = NewRenewUserNameFunc(1, dnsAddresses, nil)
+	require.Nil(t, instance)
+	require.Equal(t, ErrNilEnableEpochsHandler, err)
+
+	instance, err = NewRenewUserNameFunc(1, dnsAddresses, createUserNameLifecycleEnableEpochsHandler())
+	require.Nil(t, err)
+	require.False(t, instance.IsInterfaceNil())
+	require.True(t, instance.IsActive())
+	require.Equal(t, ErrNilBlockchainHook, instance.SetBlockChainEpochHook(nil))
+
+	instance, _ = NewRenewUserNameFunc(1, dnsAddresses, &mock.EnableEpochsHandlerStub{})
+	require.False(t, instance.IsActive())
+
+	instance.SetNewGasConfig(&vmcommon.GasCost{BuiltInCost: vmcommon.BuiltInCost{SaveUserName: 5, RenewUserName: 37}})
+	require.Equal(t, uint64(37), instance.gasCost)
+}
+
+func TestRenewUserName_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	holderAddress := []byte("holder01")
+	renewFunc, _ := NewRenewUserNameFunc(1, map[string]struct{}{"dns": {}}, createUserNameLifecycleEnableEpochsHandler())
+	_ = renewFunc.SetBlockChainEpochHook(&mock.BlockChainEpochHookStub{
+		CurrentEpochCalled: func() uint32 {
+			return 100
+		},
+	})
+	holder := mock.NewUserAccount(holderAddress)
+
+	vmInput := createUserNameLifecycleInput(vmcommon.BuiltInFunctionRenewUserName, holderAddress, big.NewInt(200).Bytes())
+	vmInput.CallerAddr = []byte("other")
+	_, err := renewFunc.ProcessBuiltinFunction(nil, holder, vmInput)
+	require.Equal(t, ErrCallerIsNotTheDNSAddress, err)
+
+	_, err = renewFunc.ProcessBuiltinFunction(nil, holder, createUserNameLifecycleInput(vmcommon.BuiltInFunctionRenewUserName, holderAddress, big.NewInt(200).Bytes()))
+	require.Equal(t, ErrUserNameNotSet, err)
+
+	holder.SetUserName([]byte("name.elrond"))
+	_, err = renewFunc.ProcessBuiltinFunction(nil, holder, createUserNameLifecycleInput(vmcommon.BuiltInFunctionRenewUserName, holderAddress, big.NewInt(200).Bytes()))
+	require.Equal(t, ErrInvalidUserNameExpiryEpoch, err)
+	expiryEpoch, _ := getUserNameExpiryEpoch(holder)
+	require.Equal(t, uint32(0), expiryEpoch)
+
+	_ = holder.AccountDataHandler().SaveKeyValue([]byte(userNameExpiryEpochKey), userNameExpiryEpochToBytes(100))
+	_, err = renewFunc.ProcessBuiltinFunction(nil, holder, createUserNameLifecycleInput(vmcommon.BuiltInFunctionRenewUserName, holderAddress, big.NewInt(200).Bytes()))
+	require.Equal(t, ErrUserNameExpired, err)
+
+	_ = holder.AccountDataHandler().SaveKeyValue([]byte(userNameExpiryEpochKey), userNameExpiryEpochToBytes(120))
+	_, err = renewFunc.ProcessBuiltinFunction(nil, holder, createUserNameLifecycleInput(vmcommon.BuiltInFunctionRenewUserName, holderAddress, big.NewInt(100).Bytes()))
+	require.Equal(t, ErrInvalidUserNameExpiryEpoch, err)
+
+	vmOutput, err := renewFunc.ProcessBuiltinFunction(mock.NewUserAccount([]byte("dns")), holder, createUserNameLifecycleInput(vmcommon.BuiltInFunctionRenewUserName, holderAddress, big.NewInt(200).Bytes()))
+	require.Nil(t, err)
+	require.Equal(t, uint64(9), vmOutput.GasRemaining)
+	require.Equal(t, []*vmcommon.LogEntry{{
+		Identifier: []byte(vmcommon.BuiltInFunctionRenewUserName),
+		Address:    holderAddress,
+		Topics:     [][]byte{[]byte("name.elrond"), {0, 0, 0, 200}},
+	}}, vmOutput.Logs)
+
+	expiryEpoch, _ = getUserNameExpiryEpoch(holder)
+	require.Equal(t, uint32(200), expiryEpoch)
+
+	_, err = renewFunc.ProcessBuiltinFunction(nil, holder, createUserNameLifecycleInput(vmcommon.BuiltInFunctionRenewUserName, holderAddress, big.NewInt(150).Bytes()))
+	require.Equal(t, ErrInvalidUserNameExpiryEpoch, err)
+
+	vmOutput, err = renewFunc.ProcessBuiltinFunction(mock.NewUserAccount([]byte("dns")), nil, createUserNameLifecycleInput(vmcommon.BuiltInFunctionRenewUserName, holderAddress, big.NewInt(300).Bytes()))
+	require.Nil(t, err)
+	require.Equal(t, 1, len(vmOutput.OutputAccounts))
+	require.Equal(t, uint64(9), vmOutput.OutputAccounts[string(holderAddress)].OutputTransfers[0].GasLimit)
+}
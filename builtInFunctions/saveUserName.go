@@ -1,7 +1,9 @@
 package builtInFunctions
 
 import (
+	"encoding/binary"
 	"encoding/hex"
+	"math"
 	"math/big"
 	"sync"
 
@@ -11,6 +13,11 @@ import (
 	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
 )
 
+const (
+	userNameExpiryEpochKey           = core.ProtectedKeyPrefix + "userNameExpiryEpoch"
+	userNameExpiryEpochEncodedLength = 4
+)
+
 type saveUserName struct {
 	baseAlwaysActiveHandler
 	gasCost             uint64
@@ -129,10 +136,23 @@ func (s *saveUserName) ProcessBuiltinFunction(
 		addressesToCheck = s.mapDnsAddresses
 	}
 
-	err := inputCheckForUserNameCall(acntSnd, vmInput, addressesToCheck, s.gasCost, 1)
+	numArgs := 1
+	isUserNameLifecycleEnabled := s.enableEpochsHandler.IsFlagEnabled(UserNameLifecycleFlag)
+	if isUserNameLifecycleEnabled && vmInput != nil && len(vmInput.Arguments) == 2 {
+		numArgs = 2
+	}
+	err := inputCheckForUserNameCall(acntSnd, vmInput, addressesToCheck, s.gasCost, numArgs)
 	if err != nil {
 		return nil, err
 	}
+	var expiryEpoch []byte
+	if numArgs == 2 {
+		epoch, errParse := parseUserNameExpiryEpoch(vmInput.Arguments[1])
+		if errParse != nil {
+			return nil, errParse
+		}
+		expiryEpoch = userNameExpiryEpochToBytes(epoch)
+	}
 
 	if check.IfNil(acntDst) {
 		gasLimit := vmInput.GasProvided
@@ -149,6 +169,12 @@ func (s *saveUserName) ProcessBuiltinFunction(
 	}
 
 	acntDst.SetUserName(vmInput.Arguments[0])
+	if isUserNameLifecycleEnabled {
+		err = acntDst.AccountDataHandler().SaveKeyValue([]byte(userNameExpiryEpochKey), expiryEpoch)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	gasRemaining := vmInput.GasProvided - s.gasCost
 	if s.enableEpochsHandler.IsFlagEnabled(ChangeUsernameFlag) && check.IfNil(acntSnd) {
@@ -164,6 +190,37 @@ func (s *saveUserName) ProcessBuiltinFunction(
 	return vmOutput, nil
 }
 
+// parseUserNameExpiryEpoch returns the expiry epoch, the first epoch in which the username is no longer valid
+func parseUserNameExpiryEpoch(arg []byte) (uint32, error) {
+	expiryEpoch := big.NewInt(0).SetBytes(arg)
+	if expiryEpoch.Sign() == 0 || !expiryEpoch.IsUint64() || expiryEpoch.Uint64() > math.MaxUint32 {
+		return 0, ErrInvalidUserNameExpiryEpoch
+	}
+
+	return uint32(expiryEpoch.Uint64()), nil
+}
+
+func userNameExpiryEpochToBytes(expiryEpoch uint32) []byte {
+	return binary.BigEndian.AppendUint32(make([]byte, 0, userNameExpiryEpochEncodedLength), expiryEpoch)
+}
+
+// getUserNameExpiryEpoch returns the expiry epoch of the username of the account, 0 if the username does not expire
+func getUserNameExpiryEpoch(account vmcommon.UserAccountHandler) (uint32, error) {
+	val, _, err := account.AccountDataHandler().RetrieveValue([]byte(userNameExpiryEpochKey))
+	if core.IsGetNodeFromDBError(err) {
+		return 0, err
+	}
+	if len(val) != userNameExpiryEpochEncodedLength {
+		return 0, nil
+	}
+
+	return binary.BigEndian.Uint32(val), nil
+}
+
+func isUserNameExpired(expiryEpoch uint32, currentEpoch uint32) bool {
+	return expiryEpoch != 0 && currentEpoch >= expiryEpoch
+}
+
 // IsInterfaceNil returns true if underlying object in nil
 func (s *saveUserName) IsInterfaceNil() bool {
 	return s == nil
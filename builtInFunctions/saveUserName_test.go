@@ -126,4 +126,19 @@ func TestSaveUserName_ProcessBuiltinFunction(t *testing.T) {
 	require.Equal(t, acc.GetUserName(), vmInput.Arguments[0])
 	require.Equal(t, vmOutput.GasRemaining, vmInput.GasProvided-coa.gasCost)
 
+	vmInput.Arguments = [][]byte{[]byte("abcdabcd"), {}}
+	_, err = coa.ProcessBuiltinFunction(nil, acc, vmInput)
+	require.Equal(t, ErrInvalidUserNameExpiryEpoch, err)
+
+	vmInput.Arguments = [][]byte{[]byte("abcdabcd"), big.NewInt(150).Bytes()}
+	_, err = coa.ProcessBuiltinFunction(nil, acc, vmInput)
+	require.Nil(t, err)
+	expiryEpoch, _ := getUserNameExpiryEpoch(acc)
+	require.Equal(t, uint32(150), expiryEpoch)
+
+	vmInput.Arguments = [][]byte{[]byte("abcdabcd")}
+	_, err = coa.ProcessBuiltinFunction(nil, acc, vmInput)
+	require.Nil(t, err)
+	expiryEpoch, _ = getUserNameExpiryEpoch(acc)
+	require.Equal(t, uint32(0), expiryEpoch)
 }
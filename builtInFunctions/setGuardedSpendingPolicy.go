@@ -0,0 +1,191 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const minNoOfArgsSetGuardedSpendingPolicy = 2
+
+type setGuardedSpendingPolicy struct {
+	baseActiveHandler
+	*baseAccountGuarder
+}
+
+// NewSetGuardedSpendingPolicyFunc will instantiate a new set guarded spending policy built-in function
+func NewSetGuardedSpendingPolicyFunc(args SetGuardianArgs) (*setGuardedSpendingPolicy, error) {
+	base, err := newBaseAccountGuarder(args.BaseAccountGuarderArgs)
+	if err != nil {
+		return nil, err
+	}
+	setPolicyFunc := &setGuardedSpendingPolicy{
+		baseAccountGuarder: base,
+	}
+	setPolicyFunc.activeHandler = func() bool {
+		return args.EnableEpochsHandler.IsFlagEnabled(GuardedSpendingPolicyFlag)
+	}
+
+	return setPolicyFunc, nil
+}
+
+// ProcessBuiltinFunction will process the set guarded spending policy built-in function call. The call has to be
+// co-signed by the active guardian, or by the threshold of the active guardian set. No arguments remove the policy,
+// otherwise the arguments are:
+// arg0 - length of the spending period in rounds
+// arg1 - number of spending limits N
+// arg2...arg2N+1 - token identifier and amount of every limit, EGLD using the EGLD-000000 identifier
+// the rest - whitelisted receivers
+func (sp *setGuardedSpendingPolicy) ProcessBuiltinFunction(
+	acntSnd, _ vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	if check.IfNil(acntSnd) {
+		return nil, fmt.Errorf("%w for sender", ErrNilUserAccount)
+	}
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	numArgs := len(vmInput.Arguments)
+	if numArgs != 0 && numArgs < minNoOfArgsSetGuardedSpendingPolicy {
+		return nil, fmt.Errorf("%w, expected 0 or at least %d, got %d ", ErrInvalidNumberOfArguments, minNoOfArgsSetGuardedSpendingPolicy, numArgs)
+	}
+
+	senderAddr := acntSnd.AddressBytes()
+	senderIsNotCaller := !bytes.Equal(senderAddr, vmInput.CallerAddr)
+	if senderIsNotCaller {
+		return nil, ErrOperationNotPermitted
+	}
+	sp.mutExecution.RLock()
+	defer sp.mutExecution.RUnlock()
+
+	err := sp.checkBaseAccountGuarderArgs(
+		senderAddr,
+		vmInput.RecipientAddr,
+		vmInput.CallValue,
+		vmInput.GasProvided,
+		vmInput.Arguments,
+		uint32(numArgs),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var policyBytes []byte
+	if numArgs > 0 {
+		policy, errCreate := createGuardedSpendingPolicy(senderAddr, vmInput.Arguments)
+		if errCreate != nil {
+			return nil, errCreate
+		}
+		policyBytes = policy.ToBytes()
+	}
+
+	err = sp.checkCoSignedByGuardian(acntSnd, &vmInput.VMInput)
+	if err != nil {
+		return nil, err
+	}
+
+	err = acntSnd.AccountDataHandler().SaveKeyValue([]byte(guardedSpendingPolicyKey), policyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &vmcommon.LogEntry{
+		Address:    senderAddr,
+		Identifier: []byte(vmcommon.BuiltInFunctionSetGuardedSpendingPolicy),
+	}
+	if len(policyBytes) > 0 {
+		entry.Topics = [][]byte{policyBytes}
+	}
+
+	return &vmcommon.VMOutput{
+		ReturnCode:   vmcommon.Ok,
+		GasRemaining: vmInput.GasProvided - sp.funcGasCost,
+		Logs:         []*vmcommon.LogEntry{entry},
+	}, nil
+}
+
+// checkCoSignedByGuardian verifies the co-signers against the active guardian set, if the account has one, or the
+// transaction guardian against the active guardian otherwise
+func (sp *setGuardedSpendingPolicy) checkCoSignedByGuardian(acntSnd vmcommon.UserAccountHandler, vmInput *vmcommon.VMInput) error {
+	guardianSet, err := sp.getActiveGuardianSet(acntSnd)
+	if err != nil {
+		return err
+	}
+	if guardianSet != nil {
+		return sp.multiGuardedAccountHandler.VerifyCoSigners(acntSnd, vmInput.TxCoSigners)
+	}
+
+	activeGuardian, err := sp.guardedAccountHandler.GetActiveGuardian(acntSnd)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(activeGuardian, vmInput.TxGuardian) {
+		return ErrGuardianSignatureRequired
+	}
+
+	return nil
+}
+
+func createGuardedSpendingPolicy(senderAddr []byte, arguments [][]byte) (*GuardedSpendingPolicy, error) {
+	policy := &GuardedSpendingPolicy{
+		PeriodInRounds:       big.NewInt(0).SetBytes(arguments[0]).Uint64(),
+		Limits:               make([]*GuardedSpendingLimit, 0),
+		WhitelistedReceivers: make([][]byte, 0),
+	}
+	if policy.PeriodInRounds == 0 {
+		return nil, fmt.Errorf("%w, the spending period cannot be 0", ErrInvalidGuardedSpendingPolicy)
+	}
+
+	numLimits := big.NewInt(0).SetBytes(arguments[1]).Uint64()
+	if numLimits > maxNumGuardedSpendingLimits {
+		return nil, fmt.Errorf("%w, maximum number of limits is %d", ErrInvalidGuardedSpendingPolicy, maxNumGuardedSpendingLimits)
+	}
+	receiversStartIndex := 2 + 2*numLimits
+	if uint64(len(arguments)) < receiversStartIndex {
+		return nil, ErrInvalidNumberOfArguments
+	}
+
+	for i := uint64(2); i < receiversStartIndex; i += 2 {
+		tokenID, amount := arguments[i], arguments[i+1]
+		if len(tokenID) == 0 || policy.getLimit(tokenID) != nil {
+			return nil, fmt.Errorf("%w, invalid token for limit", ErrInvalidGuardedSpendingPolicy)
+		}
+		if len(amount) > core.MaxLenForESDTIssueMint {
+			return nil, fmt.Errorf("%w, invalid amount for limit", ErrInvalidGuardedSpendingPolicy)
+		}
+
+		policy.Limits = append(policy.Limits, &GuardedSpendingLimit{
+			TokenID: tokenID,
+			Amount:  big.NewInt(0).SetBytes(amount),
+		})
+	}
+
+	receivers := arguments[receiversStartIndex:]
+	if len(receivers) > maxNumGuardedWhitelistedReceivers {
+		return nil, fmt.Errorf("%w, maximum number of whitelisted receivers is %d", ErrInvalidGuardedSpendingPolicy, maxNumGuardedWhitelistedReceivers)
+	}
+	for _, receiver := range receivers {
+		if len(receiver) != len(senderAddr) {
+			return nil, fmt.Errorf("%w for whitelisted receiver", ErrInvalidAddress)
+		}
+		policy.WhitelistedReceivers = append(policy.WhitelistedReceivers, receiver)
+	}
+
+	if len(policy.Limits) == 0 && len(policy.WhitelistedReceivers) == 0 {
+		return nil, fmt.Errorf("%w, the policy has no limits and no whitelisted receivers", ErrInvalidGuardedSpendingPolicy)
+	}
+
+	return policy, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (sp *setGuardedSpendingPolicy) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	sp.mutExecution.Lock()
+	sp.funcGasCost = gasCost.BuiltInCost.SetGuardedSpendingPolicy
+	sp.mutExecution.Unlock()
+}
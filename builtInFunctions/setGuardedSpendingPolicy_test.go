@@ -0,0 +1,232 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	mockvm "github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createSetGuardedSpendingPolicyFuncMockArgs(activeGuardian []byte) SetGuardianArgs {
+	args := createSetGuardianFuncMockArgs()
+	args.EnableEpochsHandler = &mockvm.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return flag == SetGuardianFlag || flag == GuardedSpendingPolicyFlag
+		},
+	}
+	args.GuardedAccountHandler = &mockvm.GuardedAccountHandlerStub{
+		GetActiveGuardianCalled: func(_ vmcommon.UserAccountHandler) ([]byte, error) {
+			return activeGuardian, nil
+		},
+	}
+
+	return args
+}
+
+func TestNewSetGuardedSpendingPolicyFunc(t *testing.T) {
+	t.Parallel()
+
+	args := createSetGuardedSpendingPolicyFuncMockArgs(nil)
+	args.EnableEpochsHandler = nil
+	instance, err := NewSetGuardedSpendingPolicyFunc(args)
+	require.Nil(t, instance)
+	require.Equal(t, ErrNilEnableEpochsHandler, err)
+
+	instance, err = NewSetGuardedSpendingPolicyFunc(createSetGuardedSpendingPolicyFuncMockArgs(nil))
+	require.Nil(t, err)
+	require.False(t, instance.IsInterfaceNil())
+	require.True(t, instance.IsActive())
+
+	instance, _ = NewSetGuardedSpendingPolicyFunc(createSetGuardianFuncMockArgs())
+	require.False(t, instance.IsActive())
+
+	newGasCost := &vmcommon.GasCost{BuiltInCost: vmcommon.BuiltInCost{SetGuardian: 5, SetGuardedSpendingPolicy: 7}}
+	instance.SetNewGasConfig(newGasCost)
+	require.Equal(t, uint64(7), instance.funcGasCost)
+}
+
+func TestSetGuardedSpendingPolicy_ProcessBuiltinFunctionCheckArguments(t *testing.T) {
+	t.Parallel()
+
+	account := mockvm.NewUserAccount(userAddress)
+	receiver := generateRandomByteArray(pubKeyLen)
+	setPolicyFunc, _ := NewSetGuardedSpendingPolicyFunc(createSetGuardedSpendingPolicyFuncMockArgs(nil))
+
+	tests := []struct {
+		testname    string
+		account     vmcommon.UserAccountHandler
+		arguments   [][]byte
+		expectedErr error
+	}{
+		{
+			testname:    "nil sender",
+			arguments:   [][]byte{{100}, {}, receiver},
+			expectedErr: ErrNilUserAccount,
+		},
+		{
+			testname:    "one argument",
+			account:     account,
+			arguments:   [][]byte{{100}},
+			expectedErr: ErrInvalidNumberOfArguments,
+		},
+		{
+			testname:    "zero period",
+			account:     account,
+			arguments:   [][]byte{{}, {}, receiver},
+			expectedErr: ErrInvalidGuardedSpendingPolicy,
+		},
+		{
+			testname:    "missing limit amount",
+			account:     account,
+			arguments:   [][]byte{{100}, {1}, []byte("TKN-123456")},
+			expectedErr: ErrInvalidNumberOfArguments,
+		},
+		{
+			testname:    "too many limits",
+			account:     account,
+			arguments:   [][]byte{{100}, {maxNumGuardedSpendingLimits + 1}},
+			expectedErr: ErrInvalidGuardedSpendingPolicy,
+		},
+		{
+			testname:    "duplicated token",
+			account:     account,
+			arguments:   [][]byte{{100}, {2}, []byte("TKN-123456"), {1}, []byte("TKN-123456"), {2}},
+			expectedErr: ErrInvalidGuardedSpendingPolicy,
+		},
+		{
+			testname:    "empty token",
+			account:     account,
+			arguments:   [][]byte{{100}, {1}, {}, {1}},
+			expectedErr: ErrInvalidGuardedSpendingPolicy,
+		},
+		{
+			testname:    "amount too long",
+			account:     account,
+			arguments:   [][]byte{{100}, {1}, []byte("TKN-123456"), bytes.Repeat([]byte{1}, core.MaxLenForESDTIssueMint+1)},
+			expectedErr: ErrInvalidGuardedSpendingPolicy,
+		},
+		{
+			testname:    "invalid receiver address",
+			account:     account,
+			arguments:   [][]byte{{100}, {}, []byte("short")},
+			expectedErr: ErrInvalidAddress,
+		},
+		{
+			testname:    "too many receivers",
+			account:     account,
+			arguments:   append([][]byte{{100}, {}}, make([][]byte, maxNumGuardedWhitelistedReceivers+1)...),
+			expectedErr: ErrInvalidGuardedSpendingPolicy,
+		},
+		{
+			testname:    "empty policy",
+			account:     account,
+			arguments:   [][]byte{{100}, {}},
+			expectedErr: ErrInvalidGuardedSpendingPolicy,
+		},
+	}
+
+	for _, test := range tests {
+		output, err := setPolicyFunc.ProcessBuiltinFunction(test.account, nil, getDefaultVmInput(test.arguments))
+		require.Nil(t, output, test.testname)
+		require.True(t, errors.Is(err, test.expectedErr), test.testname)
+	}
+
+	vmInput := getDefaultVmInput([][]byte{{100}, {}, receiver})
+	vmInput.CallValue = big.NewInt(1)
+	_, err := setPolicyFunc.ProcessBuiltinFunction(account, nil, vmInput)
+	require.Equal(t, ErrBuiltInFunctionCalledWithValue, err)
+}
+
+func TestSetGuardedSpendingPolicy_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	guardian := generateRandomByteArray(pubKeyLen)
+	receiver := generateRandomByteArray(pubKeyLen)
+	arguments := [][]byte{{100}, {1}, []byte(vmcommon.EGLDIdentifier), {10}, receiver}
+	expectedPolicy := &GuardedSpendingPolicy{
+		PeriodInRounds:       100,
+		Limits:               []*GuardedSpendingLimit{{TokenID: []byte(vmcommon.EGLDIdentifier), Amount: big.NewInt(10)}},
+		WhitelistedReceivers: [][]byte{receiver},
+	}
+
+	t.Run("not co-signed by the guardian should error", func(t *testing.T) {
+		t.Parallel()
+
+		setPolicyFunc, _ := NewSetGuardedSpendingPolicyFunc(createSetGuardedSpendingPolicyFuncMockArgs(guardian))
+		vmInput := getDefaultVmInput(arguments)
+		vmInput.TxGuardian = generateRandomByteArray(pubKeyLen)
+
+		output, err := setPolicyFunc.ProcessBuiltinFunction(mockvm.NewUserAccount(userAddress), nil, vmInput)
+		require.Nil(t, output)
+		require.Equal(t, ErrGuardianSignatureRequired, err)
+	})
+	t.Run("guardian set verifies the co-signers", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		coSigners := [][]byte{guardian}
+		args := createSetGuardedSpendingPolicyFuncMockArgs(guardian)
+		args.EnableEpochsHandler = &mockvm.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == SetGuardianFlag || flag == GuardedSpendingPolicyFlag || flag == MultiGuardianFlag
+			},
+		}
+		args.MultiGuardedAccountHandler = &mockvm.GuardedAccountHandlerStub{
+			GetActiveGuardianSetCalled: func(_ vmcommon.UserAccountHandler) (*vmcommon.GuardianSet, error) {
+				return &vmcommon.GuardianSet{Threshold: 1, Guardians: coSigners}, nil
+			},
+			VerifyCoSignersCalled: func(_ vmcommon.UserAccountHandler, txCoSigners [][]byte) error {
+				require.Equal(t, coSigners, txCoSigners)
+				return expectedErr
+			},
+		}
+		setPolicyFunc, _ := NewSetGuardedSpendingPolicyFunc(args)
+		vmInput := getDefaultVmInput(arguments)
+		vmInput.TxCoSigners = coSigners
+
+		output, err := setPolicyFunc.ProcessBuiltinFunction(mockvm.NewUserAccount(userAddress), nil, vmInput)
+		require.Nil(t, output)
+		require.Equal(t, expectedErr, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		args := createSetGuardedSpendingPolicyFuncMockArgs(guardian)
+		setPolicyFunc, _ := NewSetGuardedSpendingPolicyFunc(args)
+		account := mockvm.NewUserAccount(userAddress)
+		vmInput := getDefaultVmInput(arguments)
+		vmInput.TxGuardian = guardian
+
+		output, err := setPolicyFunc.ProcessBuiltinFunction(account, nil, vmInput)
+		require.Nil(t, err)
+		policy, err := getGuardedSpendingPolicy(account)
+		require.Nil(t, err)
+		require.Equal(t, expectedPolicy, policy)
+
+		entry := &vmcommon.LogEntry{
+			Address:    userAddress,
+			Identifier: []byte(vmcommon.BuiltInFunctionSetGuardedSpendingPolicy),
+			Topics:     [][]byte{expectedPolicy.ToBytes()},
+		}
+		requireVMOutputOk(t, output, vmInput.GasProvided, args.FuncGasCost, entry)
+
+		vmInput = getDefaultVmInput(nil)
+		vmInput.TxGuardian = guardian
+		output, err = setPolicyFunc.ProcessBuiltinFunction(account, nil, vmInput)
+		require.Nil(t, err)
+		policy, err = getGuardedSpendingPolicy(account)
+		require.Nil(t, err)
+		require.Nil(t, policy)
+
+		entry = &vmcommon.LogEntry{
+			Address:    userAddress,
+			Identifier: []byte(vmcommon.BuiltInFunctionSetGuardedSpendingPolicy),
+		}
+		requireVMOutputOk(t, output, vmInput.GasProvided, args.FuncGasCost, entry)
+	})
+}
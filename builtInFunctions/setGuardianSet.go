@@ -0,0 +1,151 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const (
+	minNoOfArgsSetGuardianSet = 4
+	minNumGuardiansInSet      = 2
+	maxNumGuardiansInSet      = 10
+)
+
+type setGuardianSet struct {
+	baseActiveHandler
+	*baseAccountGuarder
+}
+
+// NewSetGuardianSetFunc will instantiate a new set guardian set built-in function
+func NewSetGuardianSetFunc(args SetGuardianArgs) (*setGuardianSet, error) {
+	if check.IfNil(args.MultiGuardedAccountHandler) {
+		return nil, ErrNilMultiGuardedAccountHandler
+	}
+	base, err := newBaseAccountGuarder(args.BaseAccountGuarderArgs)
+	if err != nil {
+		return nil, err
+	}
+	setGuardianSetFunc := &setGuardianSet{
+		baseAccountGuarder: base,
+	}
+	setGuardianSetFunc.activeHandler = func() bool {
+		return args.EnableEpochsHandler.IsFlagEnabled(MultiGuardianFlag)
+	}
+
+	return setGuardianSetFunc, nil
+}
+
+// ProcessBuiltinFunction will process the set guardian set built-in function call
+// Requires at least 4 arguments:
+// arg0 - number of guardians which must co-sign the guarded transactions
+// arg1 - guardian service UID
+// arg2... - guardian addresses
+func (sg *setGuardianSet) ProcessBuiltinFunction(
+	acntSnd, _ vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	if check.IfNil(acntSnd) {
+		return nil, fmt.Errorf("%w for sender", ErrNilUserAccount)
+	}
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if len(vmInput.Arguments) < minNoOfArgsSetGuardianSet {
+		return nil, fmt.Errorf("%w, expected at least %d, got %d ", ErrInvalidNumberOfArguments, minNoOfArgsSetGuardianSet, len(vmInput.Arguments))
+	}
+
+	senderAddr := acntSnd.AddressBytes()
+	senderIsNotCaller := !bytes.Equal(senderAddr, vmInput.CallerAddr)
+	if senderIsNotCaller {
+		return nil, ErrOperationNotPermitted
+	}
+	sg.mutExecution.RLock()
+	defer sg.mutExecution.RUnlock()
+
+	err := sg.checkBaseAccountGuarderArgs(
+		senderAddr,
+		vmInput.RecipientAddr,
+		vmInput.CallValue,
+		vmInput.GasProvided,
+		vmInput.Arguments,
+		uint32(len(vmInput.Arguments)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	guardianSet, err := createGuardianSet(senderAddr, vmInput.Arguments)
+	if err != nil {
+		return nil, err
+	}
+
+	err = sg.multiGuardedAccountHandler.SetGuardianSet(acntSnd, guardianSet, vmInput.TxCoSigners)
+	if err != nil {
+		return nil, err
+	}
+
+	entry := &vmcommon.LogEntry{
+		Address:    acntSnd.AddressBytes(),
+		Identifier: []byte(vmcommon.BuiltInFunctionSetGuardianSet),
+		Topics:     guardianSetToTopics(guardianSet),
+	}
+
+	return &vmcommon.VMOutput{
+		ReturnCode:   vmcommon.Ok,
+		GasRemaining: vmInput.GasProvided - sg.funcGasCost,
+		Logs:         []*vmcommon.LogEntry{entry},
+	}, nil
+}
+
+func createGuardianSet(senderAddr []byte, arguments [][]byte) (*vmcommon.GuardianSet, error) {
+	guardianServiceUID := arguments[1]
+	if len(guardianServiceUID) > serviceUIDMaxLen {
+		return nil, fmt.Errorf("%w for guardian service", ErrInvalidServiceUID)
+	}
+
+	guardians := arguments[2:]
+	if len(guardians) < minNumGuardiansInSet || len(guardians) > maxNumGuardiansInSet {
+		return nil, fmt.Errorf("%w, a guardian set has between %d and %d guardians", ErrInvalidGuardianSet, minNumGuardiansInSet, maxNumGuardiansInSet)
+	}
+
+	threshold := big.NewInt(0).SetBytes(arguments[0])
+	if threshold.Sign() == 0 || threshold.Cmp(big.NewInt(int64(len(guardians)))) > 0 {
+		return nil, fmt.Errorf("%w, threshold must be between 1 and the number of guardians", ErrInvalidGuardianSet)
+	}
+
+	uniqueGuardians := make(map[string]struct{}, len(guardians))
+	for _, guardianAddr := range guardians {
+		isGuardianAddrLenOk := len(guardianAddr) == len(senderAddr)
+		isGuardianAddrSC := core.IsSmartContractAddress(guardianAddr)
+		if !isGuardianAddrLenOk || isGuardianAddrSC {
+			return nil, fmt.Errorf("%w for guardian", ErrInvalidAddress)
+		}
+		if bytes.Equal(senderAddr, guardianAddr) {
+			return nil, ErrCannotSetOwnAddressAsGuardian
+		}
+
+		_, isDuplicate := uniqueGuardians[string(guardianAddr)]
+		if isDuplicate {
+			return nil, fmt.Errorf("%w, duplicated guardian", ErrInvalidGuardianSet)
+		}
+		uniqueGuardians[string(guardianAddr)] = struct{}{}
+	}
+
+	return &vmcommon.GuardianSet{
+		Guardians:  guardians,
+		Threshold:  uint32(threshold.Uint64()),
+		ServiceUID: guardianServiceUID,
+	}, nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (sg *setGuardianSet) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	sg.mutExecution.Lock()
+	sg.funcGasCost = gasCost.BuiltInCost.SetGuardianSet
+	sg.mutExecution.Unlock()
+}
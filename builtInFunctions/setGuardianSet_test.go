@@ -0,0 +1,193 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	mockvm "github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createSetGuardianSetFuncMockArgs() SetGuardianArgs {
+	args := createSetGuardianFuncMockArgs()
+	args.EnableEpochsHandler = &mockvm.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return flag == SetGuardianFlag || flag == MultiGuardianFlag
+		},
+	}
+
+	return args
+}
+
+func TestNewSetGuardianSetFunc(t *testing.T) {
+	t.Parallel()
+
+	args := createSetGuardianSetFuncMockArgs()
+	args.EnableEpochsHandler = nil
+	instance, err := NewSetGuardianSetFunc(args)
+	require.Nil(t, instance)
+	require.Equal(t, ErrNilEnableEpochsHandler, err)
+
+	args = createSetGuardianSetFuncMockArgs()
+	args.MultiGuardedAccountHandler = nil
+	instance, err = NewSetGuardianSetFunc(args)
+	require.Nil(t, instance)
+	require.Equal(t, ErrNilMultiGuardedAccountHandler, err)
+
+	instance, err = NewSetGuardianSetFunc(createSetGuardianSetFuncMockArgs())
+	require.Nil(t, err)
+	require.False(t, instance.IsInterfaceNil())
+	require.True(t, instance.IsActive())
+
+	instance, _ = NewSetGuardianSetFunc(createSetGuardianFuncMockArgs())
+	require.False(t, instance.IsActive())
+
+	newGasCost := &vmcommon.GasCost{BuiltInCost: vmcommon.BuiltInCost{SetGuardian: 5, SetGuardianSet: 7}}
+	instance.SetNewGasConfig(newGasCost)
+	require.Equal(t, uint64(7), instance.funcGasCost)
+}
+
+func TestSetGuardianSet_ProcessBuiltinFunctionCheckArguments(t *testing.T) {
+	t.Parallel()
+
+	account := mockvm.NewUserAccount(userAddress)
+	guardian1 := generateRandomByteArray(pubKeyLen)
+	guardian2 := generateRandomByteArray(pubKeyLen)
+	setGuardianSetFunc, _ := NewSetGuardianSetFunc(createSetGuardianSetFuncMockArgs())
+
+	tests := []struct {
+		testname    string
+		account     vmcommon.UserAccountHandler
+		arguments   [][]byte
+		expectedErr error
+	}{
+		{
+			testname:    "nil sender",
+			arguments:   [][]byte{{1}, {}, guardian1, guardian2},
+			expectedErr: ErrNilUserAccount,
+		},
+		{
+			testname:    "one guardian",
+			account:     account,
+			arguments:   [][]byte{{1}, {}, guardian1},
+			expectedErr: ErrInvalidNumberOfArguments,
+		},
+		{
+			testname:    "zero threshold",
+			account:     account,
+			arguments:   [][]byte{{}, {}, guardian1, guardian2},
+			expectedErr: ErrInvalidGuardianSet,
+		},
+		{
+			testname:    "threshold above the number of guardians",
+			account:     account,
+			arguments:   [][]byte{{3}, {}, guardian1, guardian2},
+			expectedErr: ErrInvalidGuardianSet,
+		},
+		{
+			testname:    "duplicated guardian",
+			account:     account,
+			arguments:   [][]byte{{2}, {}, guardian1, guardian1},
+			expectedErr: ErrInvalidGuardianSet,
+		},
+		{
+			testname:    "own address as guardian",
+			account:     account,
+			arguments:   [][]byte{{1}, {}, guardian1, userAddress},
+			expectedErr: ErrCannotSetOwnAddressAsGuardian,
+		},
+		{
+			testname:    "invalid guardian address",
+			account:     account,
+			arguments:   [][]byte{{1}, {}, guardian1, []byte("short")},
+			expectedErr: ErrInvalidAddress,
+		},
+		{
+			testname:    "invalid service UID",
+			account:     account,
+			arguments:   [][]byte{{1}, bytes.Repeat([]byte{1}, serviceUIDMaxLen+1), guardian1, guardian2},
+			expectedErr: ErrInvalidServiceUID,
+		},
+		{
+			testname:    "too many guardians",
+			account:     account,
+			arguments:   append([][]byte{{1}, {}}, make([][]byte, maxNumGuardiansInSet+1)...),
+			expectedErr: ErrInvalidGuardianSet,
+		},
+	}
+
+	for _, test := range tests {
+		output, err := setGuardianSetFunc.ProcessBuiltinFunction(test.account, nil, getDefaultVmInput(test.arguments))
+		require.Nil(t, output, test.testname)
+		require.True(t, errors.Is(err, test.expectedErr), test.testname)
+	}
+
+	vmInput := getDefaultVmInput([][]byte{{1}, {}, guardian1, guardian2})
+	vmInput.CallValue = big.NewInt(1)
+	_, err := setGuardianSetFunc.ProcessBuiltinFunction(account, nil, vmInput)
+	require.Equal(t, ErrBuiltInFunctionCalledWithValue, err)
+}
+
+func TestSetGuardianSet_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	guardians := [][]byte{generateRandomByteArray(pubKeyLen), generateRandomByteArray(pubKeyLen), generateRandomByteArray(pubKeyLen)}
+	coSigners := [][]byte{guardians[0], guardians[2]}
+	serviceUID := []byte("uid")
+	expectedSet := &vmcommon.GuardianSet{
+		Guardians:  guardians,
+		Threshold:  2,
+		ServiceUID: serviceUID,
+	}
+	vmInput := getDefaultVmInput(append([][]byte{{2}, serviceUID}, guardians...))
+	vmInput.TxCoSigners = coSigners
+	account := mockvm.NewUserAccount(userAddress)
+
+	t.Run("guarded account handler error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		args := createSetGuardianSetFuncMockArgs()
+		args.MultiGuardedAccountHandler = &mockvm.GuardedAccountHandlerStub{
+			SetGuardianSetCalled: func(_ vmcommon.UserAccountHandler, _ *vmcommon.GuardianSet, _ [][]byte) error {
+				return expectedErr
+			},
+		}
+		setGuardianSetFunc, _ := NewSetGuardianSetFunc(args)
+
+		output, err := setGuardianSetFunc.ProcessBuiltinFunction(account, nil, vmInput)
+		require.Nil(t, output)
+		require.Equal(t, expectedErr, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		var savedSet *vmcommon.GuardianSet
+		var savedCoSigners [][]byte
+		args := createSetGuardianSetFuncMockArgs()
+		args.MultiGuardedAccountHandler = &mockvm.GuardedAccountHandlerStub{
+			SetGuardianSetCalled: func(_ vmcommon.UserAccountHandler, guardianSet *vmcommon.GuardianSet, txCoSigners [][]byte) error {
+				savedSet = guardianSet
+				savedCoSigners = txCoSigners
+				return nil
+			},
+		}
+		setGuardianSetFunc, _ := NewSetGuardianSetFunc(args)
+
+		output, err := setGuardianSetFunc.ProcessBuiltinFunction(account, nil, vmInput)
+		require.Nil(t, err)
+		require.Equal(t, expectedSet, savedSet)
+		require.Equal(t, coSigners, savedCoSigners)
+
+		entry := &vmcommon.LogEntry{
+			Address:    userAddress,
+			Identifier: []byte(vmcommon.BuiltInFunctionSetGuardianSet),
+			Topics:     append([][]byte{{2}, serviceUID}, guardians...),
+		}
+		requireVMOutputOk(t, output, vmInput.GasProvided, args.FuncGasCost, entry)
+	})
+}
@@ -0,0 +1,304 @@
+package builtInFunctions
+
+import (
+	"bytes"
+	"encoding/binary"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const userNameTransferPendingKey = core.ProtectedKeyPrefix + "userNameTransferPending"
+
+type transferUserName struct {
+	baseActiveHandler
+	gasCost         uint64
+	mapDnsAddresses map[string]struct{}
+	epochHook       BlockChainEpochHook
+	mutExecution    sync.RWMutex
+}
+
+// NewTransferUserNameFunc returns the built-in function which moves a username, together with its expiry epoch, from
+// the account holding it to another account which approved the transfer
+func NewTransferUserNameFunc(
+	gasCost uint64,
+	mapDnsAddresses map[string]struct{},
+	enableEpochsHandler vmcommon.EnableEpochsHandler,
+) (*transferUserName, error) {
+	if mapDnsAddresses == nil {
+		return nil, ErrNilDnsAddresses
+	}
+	if check.IfNil(enableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+
+	t := &transferUserName{
+		gasCost:         gasCost,
+		mapDnsAddresses: make(map[string]struct{}, len(mapDnsAddresses)),
+		epochHook:       &disabledBlockchainHook{},
+	}
+	for key := range mapDnsAddresses {
+		t.mapDnsAddresses[key] = struct{}{}
+	}
+	t.activeHandler = func() bool {
+		return enableEpochsHandler.IsFlagEnabled(UserNameLifecycleFlag)
+	}
+
+	return t, nil
+}
+
+// SetBlockChainEpochHook sets the component providing the current epoch
+func (t *transferUserName) SetBlockChainEpochHook(epochHook BlockChainEpochHook) error {
+	if check.IfNil(epochHook) {
+		return ErrNilBlockchainHook
+	}
+
+	t.mutExecution.Lock()
+	t.epochHook = epochHook
+	t.mutExecution.Unlock()
+
+	return nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (t *transferUserName) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	t.mutExecution.Lock()
+	t.gasCost = gasCost.BuiltInCost.TransferUserName
+	t.mutExecution.Unlock()
+}
+
+// ProcessBuiltinFunction moves the username in two steps. Called by the DNS address with the username and the address
+// of the new holder, it removes the username, which must not be expired, from the destination account, records the
+// pending transfer on it and sends the username, together with its stored expiry epoch, to the new holder as the
+// callback of the removal. On the new holder, the username is set only if the new holder approved the transfer through
+// ApproveUserNameTransfer and holds no username which has not expired. If the new holder rejects the username, it is
+// set back on the previous holder, from the pending transfer, when the callback returns with error
+func (t *transferUserName) ProcessBuiltinFunction(
+	acntSnd, acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	t.mutExecution.RLock()
+	defer t.mutExecution.RUnlock()
+
+	if vmInput == nil {
+		return nil, ErrNilVmInput
+	}
+	if vmInput.ReturnCallAfterError {
+		return t.restoreUserName(acntDst, vmInput)
+	}
+	if vmInput.CallType == vm.AsynchronousCallBack {
+		return t.receiveUserName(acntDst, vmInput)
+	}
+
+	err := inputCheckForUserNameCall(acntSnd, vmInput, t.mapDnsAddresses, t.gasCost, 2)
+	if err != nil {
+		return nil, err
+	}
+	userName, newHolder := vmInput.Arguments[0], vmInput.Arguments[1]
+	if len(newHolder) != len(vmInput.RecipientAddr) {
+		return nil, ErrInvalidAddressLength
+	}
+	if len(userName) == 0 || bytes.Equal(newHolder, vmInput.RecipientAddr) {
+		return nil, ErrInvalidArguments
+	}
+
+	gasLimit := vmInput.GasProvided
+	if !check.IfNil(acntSnd) {
+		gasLimit = vmInput.GasProvided - t.gasCost
+	}
+	if check.IfNil(acntDst) {
+		return createCrossShardUserNameCall(vmInput, vmInput.Function, gasLimit)
+	}
+	// the callback setting the username on the new holder is charged as well
+	if gasLimit < t.gasCost {
+		return nil, ErrNotEnoughGas
+	}
+
+	if !bytes.Equal(acntDst.GetUserName(), userName) {
+		return nil, ErrUserNameMismatch
+	}
+	expiryEpoch, err := getUserNameExpiryEpoch(acntDst)
+	if err != nil {
+		return nil, err
+	}
+	if isUserNameExpired(expiryEpoch, t.epochHook.CurrentEpoch()) {
+		return nil, ErrUserNameExpired
+	}
+
+	err = setUserNameWithExpiryEpoch(acntDst, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+	pendingTransfer := createPendingUserNameTransfer(userName, newHolder, expiryEpoch)
+	err = acntDst.AccountDataHandler().SaveKeyValue([]byte(userNameTransferPendingKey), pendingTransfer)
+	if err != nil {
+		return nil, err
+	}
+
+	// the username is sent by its previous holder, so that a failed callback returns to it
+	receiveInput := &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr: vmInput.RecipientAddr,
+			Arguments:  [][]byte{userName, newHolder, userNameExpiryEpochToBytes(expiryEpoch)},
+			GasLocked:  vmInput.GasLocked,
+		},
+		RecipientAddr: newHolder,
+	}
+	vmOutput, err := createCrossShardUserNameCall(receiveInput, vmInput.Function, gasLimit)
+	if err != nil {
+		return nil, err
+	}
+	vmOutput.OutputAccounts[string(newHolder)].OutputTransfers[0].CallType = vm.AsynchronousCallBack
+
+	vmOutput.Logs = []*vmcommon.LogEntry{{
+		Identifier: []byte(vmInput.Function),
+		Address:    vmInput.RecipientAddr,
+		Topics:     [][]byte{userName, newHolder},
+	}}
+
+	return vmOutput, nil
+}
+
+// receiveUserName sets the username on the new holder. It accepts only the callback issued by the removal of the
+// username from the previous holder, with the arguments being the username, the address of the new holder and the
+// expiry epoch read from the account of the previous holder
+func (t *transferUserName) receiveUserName(
+	acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	err := checkTransferUserNameCallbackInput(acntDst, vmInput, t.gasCost)
+	if err != nil {
+		return nil, err
+	}
+	userName, newHolder, encodedExpiryEpoch := vmInput.Arguments[0], vmInput.Arguments[1], vmInput.Arguments[2]
+	if !bytes.Equal(newHolder, vmInput.RecipientAddr) {
+		return nil, ErrInvalidArguments
+	}
+	if len(encodedExpiryEpoch) != userNameExpiryEpochEncodedLength {
+		return nil, ErrInvalidUserNameExpiryEpoch
+	}
+
+	isApproved, err := isUserNameTransferApproved(acntDst, vmInput.CallerAddr, userName)
+	if err != nil {
+		return nil, err
+	}
+	if !isApproved {
+		return nil, ErrUserNameTransferNotApproved
+	}
+	err = checkUserNameCanBeReceived(acntDst, t.epochHook.CurrentEpoch())
+	if err != nil {
+		return nil, err
+	}
+
+	err = setUserNameWithExpiryEpoch(acntDst, userName, binary.BigEndian.Uint32(encodedExpiryEpoch))
+	if err != nil {
+		return nil, err
+	}
+	err = acntDst.AccountDataHandler().SaveKeyValue([]byte(userNameTransferApprovalKey), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vmcommon.VMOutput{GasRemaining: vmInput.GasProvided - t.gasCost, ReturnCode: vmcommon.Ok}, nil
+}
+
+// restoreUserName sets the username back on the previous holder, with the expiry epoch recorded when the username was
+// removed, after the new holder rejected it
+func (t *transferUserName) restoreUserName(
+	acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+) (*vmcommon.VMOutput, error) {
+	err := checkTransferUserNameCallbackInput(acntDst, vmInput, 0)
+	if err != nil {
+		return nil, err
+	}
+	userName, newHolder := vmInput.Arguments[0], vmInput.Arguments[1]
+	if !bytes.Equal(newHolder, vmInput.CallerAddr) {
+		return nil, ErrInvalidArguments
+	}
+
+	pendingTransfer, _, err := acntDst.AccountDataHandler().RetrieveValue([]byte(userNameTransferPendingKey))
+	if core.IsGetNodeFromDBError(err) {
+		return nil, err
+	}
+	pendingUserName, pendingNewHolder, expiryEpoch, ok := parsePendingUserNameTransfer(pendingTransfer, len(newHolder))
+	if !ok || !bytes.Equal(pendingUserName, userName) || !bytes.Equal(pendingNewHolder, newHolder) {
+		return nil, ErrUserNameMismatch
+	}
+	if len(acntDst.GetUserName()) > 0 {
+		return nil, ErrUserNameAlreadySet
+	}
+
+	err = setUserNameWithExpiryEpoch(acntDst, userName, expiryEpoch)
+	if err != nil {
+		return nil, err
+	}
+	err = acntDst.AccountDataHandler().SaveKeyValue([]byte(userNameTransferPendingKey), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &vmcommon.VMOutput{GasRemaining: vmInput.GasProvided, ReturnCode: vmcommon.Ok}, nil
+}
+
+func checkTransferUserNameCallbackInput(
+	acntDst vmcommon.UserAccountHandler,
+	vmInput *vmcommon.ContractCallInput,
+	gasCost uint64,
+) error {
+	if vmInput.CallValue.Cmp(zero) != 0 {
+		return ErrBuiltInFunctionCalledWithValue
+	}
+	if vmInput.GasProvided < gasCost {
+		return ErrNotEnoughGas
+	}
+	if check.IfNil(acntDst) {
+		return ErrNilUserAccount
+	}
+	if len(vmInput.Arguments) != 3 || len(vmInput.Arguments[0]) == 0 {
+		return ErrInvalidArguments
+	}
+
+	return nil
+}
+
+// createPendingUserNameTransfer encodes the transfer as the new holder address, the expiry epoch and the username
+func createPendingUserNameTransfer(userName []byte, newHolder []byte, expiryEpoch uint32) []byte {
+	pendingTransfer := make([]byte, 0, len(newHolder)+userNameExpiryEpochEncodedLength+len(userName))
+	pendingTransfer = append(pendingTransfer, newHolder...)
+	pendingTransfer = binary.BigEndian.AppendUint32(pendingTransfer, expiryEpoch)
+	return append(pendingTransfer, userName...)
+}
+
+func parsePendingUserNameTransfer(pendingTransfer []byte, addressLength int) ([]byte, []byte, uint32, bool) {
+	if len(pendingTransfer) <= addressLength+userNameExpiryEpochEncodedLength {
+		return nil, nil, 0, false
+	}
+
+	newHolder := pendingTransfer[:addressLength]
+	expiryEpoch := binary.BigEndian.Uint32(pendingTransfer[addressLength : addressLength+userNameExpiryEpochEncodedLength])
+	userName := pendingTransfer[addressLength+userNameExpiryEpochEncodedLength:]
+	return userName, newHolder, expiryEpoch, true
+}
+
+func setUserNameWithExpiryEpoch(account vmcommon.UserAccountHandler, userName []byte, expiryEpoch uint32) error {
+	var encodedExpiryEpoch []byte
+	if expiryEpoch != 0 {
+		encodedExpiryEpoch = userNameExpiryEpochToBytes(expiryEpoch)
+	}
+
+	account.SetUserName(userName)
+	return account.AccountDataHandler().SaveKeyValue([]byte(userNameExpiryEpochKey), encodedExpiryEpoch)
+}
+
+// IsInterfaceNil returns true if underlying object in nil
+func (t *transferUserName) IsInterfaceNil() bool {
+	return t == nil
+}
@@ -0,0 +1,240 @@
+package builtInFunctions
+
+import (
+	"encoding/hex"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/vm"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTransferUserNameFunc(t *testing.T) {
+	t.Parallel()
+
+	dnsAddresses := map[string]struct{}{"dns": {}}
+	instance, err := NewTransferUserNameFunc(1, nil, createUserNameLifecycleEnableEpochsHandler())
+	require.Nil(t, instance)
+	require.Equal(t, ErrNilDnsAddresses, err)
+
+	instance, err = NewTransferUserNameFunc(1, dnsAddresses, nil)
+	require.Nil(t, instance)
+	require.Equal(t, ErrNilEnableEpochsHandler, err)
+
+	instance, err = NewTransferUserNameFunc(1, dnsAddresses, createUserNameLifecycleEnableEpochsHandler())
+	require.Nil(t, err)
+	require.False(t, instance.IsInterfaceNil())
+	require.True(t, instance.IsActive())
+	require.Equal(t, ErrNilBlockchainHook, instance.SetBlockChainEpochHook(nil))
+
+	instance, _ = NewTransferUserNameFunc(1, dnsAddresses, &mock.EnableEpochsHandlerStub{})
+	require.False(t, instance.IsActive())
+
+	instance.SetNewGasConfig(&vmcommon.GasCost{BuiltInCost: vmcommon.BuiltInCost{SaveUserName: 5, TransferUserName: 37}})
+	require.Equal(t, uint64(37), instance.gasCost)
+}
+
+func TestTransferUserName_ProcessBuiltinFunction(t *testing.T) {
+	t.Parallel()
+
+	userName := []byte("name.elrond")
+	holderAddress := []byte("holder01")
+	newHolderAddress := []byte("holder02")
+	createTransferFunc := func(currentEpoch uint32) *transferUserName {
+		transferFunc, _ := NewTransferUserNameFunc(1, map[string]struct{}{"dns": {}}, createUserNameLifecycleEnableEpochsHandler())
+		_ = transferFunc.SetBlockChainEpochHook(&mock.BlockChainEpochHookStub{
+			CurrentEpochCalled: func() uint32 {
+				return currentEpoch
+			},
+		})
+		return transferFunc
+	}
+	createHolder := func(expiryEpoch uint32) *mock.Account {
+		holder := mock.NewUserAccount(holderAddress)
+		holder.SetUserName(userName)
+		_ = holder.AccountDataHandler().SaveKeyValue([]byte(userNameExpiryEpochKey), userNameExpiryEpochToBytes(expiryEpoch))
+		return holder
+	}
+
+	t.Run("invalid arguments should error", func(t *testing.T) {
+		t.Parallel()
+
+		transferFunc := createTransferFunc(100)
+		holder := createHolder(200)
+
+		_, err := transferFunc.ProcessBuiltinFunction(nil, holder, createUserNameLifecycleInput(vmcommon.BuiltInFunctionTransferUserName, holderAddress, userName))
+		require.Equal(t, ErrInvalidArguments, err)
+
+		_, err = transferFunc.ProcessBuiltinFunction(nil, holder, createUserNameLifecycleInput(vmcommon.BuiltInFunctionTransferUserName, holderAddress, userName, []byte("short")))
+		require.Equal(t, ErrInvalidAddressLength, err)
+
+		_, err = transferFunc.ProcessBuiltinFunction(nil, holder, createUserNameLifecycleInput(vmcommon.BuiltInFunctionTransferUserName, holderAddress, userName, holderAddress))
+		require.Equal(t, ErrInvalidArguments, err)
+
+		_, err = transferFunc.ProcessBuiltinFunction(nil, holder, createUserNameLifecycleInput(vmcommon.BuiltInFunctionTransferUserName, holderAddress, []byte("other.elrond"), newHolderAddress))
+		require.Equal(t, ErrUserNameMismatch, err)
+		require.Equal(t, userName, holder.GetUserName())
+	})
+	t.Run("expired username should error", func(t *testing.T) {
+		t.Parallel()
+
+		transferFunc := createTransferFunc(200)
+		holder := createHolder(200)
+
+		_, err := transferFunc.ProcessBuiltinFunction(nil, holder, createUserNameLifecycleInput(vmcommon.BuiltInFunctionTransferUserName, holderAddress, userName, newHolderAddress))
+		require.Equal(t, ErrUserNameExpired, err)
+		require.Equal(t, userName, holder.GetUserName())
+	})
+	t.Run("should move the username with its expiry epoch", func(t *testing.T) {
+		t.Parallel()
+
+		transferFunc := createTransferFunc(100)
+		holder := createHolder(200)
+
+		vmInput := createUserNameLifecycleInput(vmcommon.BuiltInFunctionTransferUserName, holderAddress, userName, newHolderAddress)
+		vmOutput, err := transferFunc.ProcessBuiltinFunction(mock.NewUserAccount([]byte("dns")), holder, vmInput)
+		require.Nil(t, err)
+		require.Equal(t, 0, len(holder.GetUserName()))
+		expiryEpoch, _ := getUserNameExpiryEpoch(holder)
+		require.Equal(t, uint32(0), expiryEpoch)
+
+		require.Equal(t, 1, len(vmOutput.OutputAccounts))
+		outputTransfer := vmOutput.OutputAccounts[string(newHolderAddress)].OutputTransfers[0]
+		expectedData := vmcommon.BuiltInFunctionTransferUserName + "@" + hex.EncodeToString(userName) + "@" +
+			hex.EncodeToString(newHolderAddress) + "@" + hex.EncodeToString(userNameExpiryEpochToBytes(200))
+		require.Equal(t, []byte(expectedData), outputTransfer.Data)
+		require.Equal(t, holderAddress, outputTransfer.SenderAddress)
+		require.Equal(t, vm.AsynchronousCallBack, outputTransfer.CallType)
+		require.Equal(t, uint64(9), outputTransfer.GasLimit)
+		pendingTransfer, _, _ := holder.AccountDataHandler().RetrieveValue([]byte(userNameTransferPendingKey))
+		require.Equal(t, createPendingUserNameTransfer(userName, newHolderAddress, 200), pendingTransfer)
+		require.Equal(t, []*vmcommon.LogEntry{{
+			Identifier: []byte(vmcommon.BuiltInFunctionTransferUserName),
+			Address:    holderAddress,
+			Topics:     [][]byte{userName, newHolderAddress},
+		}}, vmOutput.Logs)
+	})
+	t.Run("cross shard call from the DNS address", func(t *testing.T) {
+		t.Parallel()
+
+		transferFunc := createTransferFunc(100)
+		vmInput := createUserNameLifecycleInput(vmcommon.BuiltInFunctionTransferUserName, holderAddress, userName, newHolderAddress)
+		vmOutput, err := transferFunc.ProcessBuiltinFunction(mock.NewUserAccount([]byte("dns")), nil, vmInput)
+		require.Nil(t, err)
+
+		outputTransfer := vmOutput.OutputAccounts[string(holderAddress)].OutputTransfers[0]
+		expectedData := vmcommon.BuiltInFunctionTransferUserName + "@" + hex.EncodeToString(userName) + "@" + hex.EncodeToString(newHolderAddress)
+		require.Equal(t, []byte(expectedData), outputTransfer.Data)
+	})
+	t.Run("receiving without approval should error", func(t *testing.T) {
+		t.Parallel()
+
+		transferFunc := createTransferFunc(100)
+		newHolder := mock.NewUserAccount(newHolderAddress)
+		vmInput := createReceiveInput(userName, newHolderAddress, 200)
+
+		_, err := transferFunc.ProcessBuiltinFunction(nil, newHolder, vmInput)
+		require.Equal(t, ErrUserNameTransferNotApproved, err)
+
+		_ = newHolder.AccountDataHandler().SaveKeyValue([]byte(userNameTransferApprovalKey), createUserNameTransferApproval([]byte("holder03"), userName))
+		_, err = transferFunc.ProcessBuiltinFunction(nil, newHolder, vmInput)
+		require.Equal(t, ErrUserNameTransferNotApproved, err)
+
+		require.Equal(t, 0, len(newHolder.GetUserName()))
+	})
+	t.Run("receiving outside of the removal callback should error", func(t *testing.T) {
+		t.Parallel()
+
+		transferFunc := createTransferFunc(100)
+		newHolder := mock.NewUserAccount(newHolderAddress)
+		_ = newHolder.AccountDataHandler().SaveKeyValue([]byte(userNameTransferApprovalKey), createUserNameTransferApproval(holderAddress, userName))
+
+		vmInput := createReceiveInput(userName, newHolderAddress, 200)
+		vmInput.CallType = vm.AsynchronousCall
+		_, err := transferFunc.ProcessBuiltinFunction(nil, newHolder, vmInput)
+		require.Equal(t, ErrCallerIsNotTheDNSAddress, err)
+
+		vmInput.CallType = vm.DirectCall
+		_, err = transferFunc.ProcessBuiltinFunction(nil, newHolder, vmInput)
+		require.Equal(t, ErrCallerIsNotTheDNSAddress, err)
+
+		vmInput = createReceiveInput(userName, newHolderAddress, 200)
+		vmInput.GasProvided = 0
+		_, err = transferFunc.ProcessBuiltinFunction(nil, newHolder, vmInput)
+		require.Equal(t, ErrNotEnoughGas, err)
+		require.Equal(t, 0, len(newHolder.GetUserName()))
+	})
+	t.Run("receiving on an account holding a valid username should error", func(t *testing.T) {
+		t.Parallel()
+
+		transferFunc := createTransferFunc(100)
+		newHolder := mock.NewUserAccount(newHolderAddress)
+		newHolder.SetUserName([]byte("other.elrond"))
+		_ = newHolder.AccountDataHandler().SaveKeyValue([]byte(userNameTransferApprovalKey), createUserNameTransferApproval(holderAddress, userName))
+
+		_, err := transferFunc.ProcessBuiltinFunction(nil, newHolder, createReceiveInput(userName, newHolderAddress, 200))
+		require.Equal(t, ErrUserNameAlreadySet, err)
+		require.Equal(t, []byte("other.elrond"), newHolder.GetUserName())
+	})
+	t.Run("receiving with approval should set the username", func(t *testing.T) {
+		t.Parallel()
+
+		transferFunc := createTransferFunc(100)
+		newHolder := mock.NewUserAccount(newHolderAddress)
+		_ = newHolder.AccountDataHandler().SaveKeyValue([]byte(userNameTransferApprovalKey), createUserNameTransferApproval(holderAddress, userName))
+
+		vmOutput, err := transferFunc.ProcessBuiltinFunction(nil, newHolder, createReceiveInput(userName, newHolderAddress, 200))
+		require.Nil(t, err)
+		require.Equal(t, uint64(9), vmOutput.GasRemaining)
+		require.Equal(t, userName, newHolder.GetUserName())
+		expiryEpoch, _ := getUserNameExpiryEpoch(newHolder)
+		require.Equal(t, uint32(200), expiryEpoch)
+		isApproved, _ := isUserNameTransferApproved(newHolder, holderAddress, userName)
+		require.False(t, isApproved)
+	})
+	t.Run("return after error should restore the username on the previous holder", func(t *testing.T) {
+		t.Parallel()
+
+		transferFunc := createTransferFunc(100)
+		holder := createHolder(200)
+		vmInput := createUserNameLifecycleInput(vmcommon.BuiltInFunctionTransferUserName, holderAddress, userName, newHolderAddress)
+		_, err := transferFunc.ProcessBuiltinFunction(nil, holder, vmInput)
+		require.Nil(t, err)
+		require.Equal(t, 0, len(holder.GetUserName()))
+
+		returnInput := createReceiveInput(userName, newHolderAddress, 1000)
+		returnInput.CallerAddr = newHolderAddress
+		returnInput.RecipientAddr = holderAddress
+		returnInput.ReturnCallAfterError = true
+		_, err = transferFunc.ProcessBuiltinFunction(nil, holder, returnInput)
+		require.Nil(t, err)
+		require.Equal(t, userName, holder.GetUserName())
+		expiryEpoch, _ := getUserNameExpiryEpoch(holder)
+		require.Equal(t, uint32(200), expiryEpoch)
+
+		_, err = transferFunc.ProcessBuiltinFunction(nil, holder, returnInput)
+		require.Equal(t, ErrUserNameMismatch, err)
+	})
+	t.Run("return after error without a pending transfer should error", func(t *testing.T) {
+		t.Parallel()
+
+		transferFunc := createTransferFunc(100)
+		holder := mock.NewUserAccount(holderAddress)
+
+		returnInput := createReceiveInput(userName, newHolderAddress, 200)
+		returnInput.CallerAddr = newHolderAddress
+		returnInput.RecipientAddr = holderAddress
+		returnInput.ReturnCallAfterError = true
+		_, err := transferFunc.ProcessBuiltinFunction(nil, holder, returnInput)
+		require.Equal(t, ErrUserNameMismatch, err)
+		require.Equal(t, 0, len(holder.GetUserName()))
+	})
+}
+
+func createReceiveInput(userName []byte, newHolder []byte, expiryEpoch uint32) *vmcommon.ContractCallInput {
+	vmInput := createUserNameLifecycleInput(vmcommon.BuiltInFunctionTransferUserName, newHolder, userName, newHolder, userNameExpiryEpochToBytes(expiryEpoch))
+	vmInput.CallerAddr = []byte("holder01")
+	vmInput.CallType = vm.AsynchronousCallBack
+	return vmInput
+}
@@ -19,7 +19,8 @@ func NewUnGuardAccountFunc(args GuardAccountArgs) (*unGuardAccountFunc, error) {
 }
 
 // ProcessBuiltinFunction will unset the frozen bit in
-// user's code metadata, if it has at least one enabled guardian
+// user's code metadata, if it has at least one enabled guardian. An account protected by a guardian set
+// needs the threshold of co-signers
 func (ua *unGuardAccountFunc) ProcessBuiltinFunction(
 	acntSnd, _ vmcommon.UserAccountHandler,
 	vmInput *vmcommon.ContractCallInput,
@@ -27,10 +28,16 @@ func (ua *unGuardAccountFunc) ProcessBuiltinFunction(
 	ua.mutExecution.Lock()
 	defer ua.mutExecution.Unlock()
 
-	err := ua.checkGuardAccountArgs(acntSnd, vmInput)
+	guardianSet, err := ua.checkGuardAccountArgs(acntSnd, vmInput)
 	if err != nil {
 		return nil, err
 	}
+	if guardianSet != nil {
+		err = ua.multiGuardedAccountHandler.VerifyCoSigners(acntSnd, vmInput.TxCoSigners)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	err = unGuardAccount(acntSnd)
 	if err != nil {
@@ -41,6 +48,9 @@ func (ua *unGuardAccountFunc) ProcessBuiltinFunction(
 		Address:    acntSnd.AddressBytes(),
 		Identifier: []byte(core.BuiltInFunctionUnGuardAccount),
 	}
+	if guardianSet != nil {
+		entry.Topics = guardianSetToTopics(guardianSet)
+	}
 
 	return &vmcommon.VMOutput{
 		ReturnCode:   vmcommon.Ok,
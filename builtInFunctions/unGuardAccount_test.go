@@ -89,4 +89,57 @@ func TestUnGuardAccountFunc_ProcessBuiltinFunction(t *testing.T) {
 		requireVMOutputOk(t, output, vmInput.GasProvided, args.FuncGasCost, entry)
 		requireAccountFrozen(t, account, false)
 	})
+
+	t.Run("un-guard account with guardian set", func(t *testing.T) {
+		guardianSet := &vmcommon.GuardianSet{
+			Guardians:  [][]byte{generateRandomByteArray(pubKeyLen), generateRandomByteArray(pubKeyLen)},
+			Threshold:  2,
+			ServiceUID: []byte("uid"),
+		}
+		expectedErr := errors.New("not enough co-signers")
+		var verifiedCoSigners [][]byte
+		args.MultiGuardedAccountHandler = &mockvm.GuardedAccountHandlerStub{
+			GetActiveGuardianSetCalled: func(uah vmcommon.UserAccountHandler) (*vmcommon.GuardianSet, error) {
+				return guardianSet, nil
+			},
+			VerifyCoSignersCalled: func(uah vmcommon.UserAccountHandler, txCoSigners [][]byte) error {
+				verifiedCoSigners = txCoSigners
+				if len(txCoSigners) < int(guardianSet.Threshold) {
+					return expectedErr
+				}
+				return nil
+			},
+		}
+		args.EnableEpochsHandler = &mockvm.EnableEpochsHandlerStub{
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == SetGuardianFlag || flag == MultiGuardianFlag
+			},
+		}
+		unGuardAccountFunc, _ := NewUnGuardAccountFunc(args)
+
+		address := generateRandomByteArray(pubKeyLen)
+		account := mockvm.NewUserAccount(address)
+		code := vmcommon.CodeMetadata{Guarded: true}
+		account.SetCodeMetadata(code.ToBytes())
+		vmInput.CallerAddr = address
+		vmInput.RecipientAddr = address
+		vmInput.TxCoSigners = guardianSet.Guardians[:1]
+
+		output, err := unGuardAccountFunc.ProcessBuiltinFunction(account, account, vmInput)
+		require.Nil(t, output)
+		require.Equal(t, expectedErr, err)
+		require.Equal(t, guardianSet.Guardians[:1], verifiedCoSigners)
+		requireAccountFrozen(t, account, true)
+
+		vmInput.TxCoSigners = guardianSet.Guardians
+		output, err = unGuardAccountFunc.ProcessBuiltinFunction(account, account, vmInput)
+		require.Nil(t, err)
+		entry := &vmcommon.LogEntry{
+			Address:    address,
+			Identifier: []byte(core.BuiltInFunctionUnGuardAccount),
+			Topics:     append([][]byte{{2}, []byte("uid")}, guardianSet.Guardians...),
+		}
+		requireVMOutputOk(t, output, vmInput.GasProvided, args.FuncGasCost, entry)
+		requireAccountFrozen(t, account, false)
+	})
 }
@@ -0,0 +1,785 @@
+package builtInLogs
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+)
+
+const (
+	identifierESDTNFTCreateRoleTransfer = core.BuiltInFunctionESDTNFTCreateRoleTransfer
+	identifierESDTNFTUpdateAttributes   = core.BuiltInFunctionESDTNFTUpdateAttributes
+	identifierESDTModifyRoyalties       = core.ESDTModifyRoyalties
+	identifierESDTModifyCreator         = core.ESDTModifyCreator
+	identifierChangeOwnerAddress        = core.BuiltInFunctionChangeOwnerAddress
+	identifierClaimDeveloperRewards     = core.BuiltInFunctionClaimDeveloperRewards
+	identifierSetGuardian               = core.BuiltInFunctionSetGuardian
+	identifierDeleteUserName            = "DeleteUserName"
+)
+
+type decodeFunc func(entry *vmcommon.LogEntry) (Event, error)
+
+type logsCodec struct {
+	marshaller vmcommon.Marshalizer
+	decoders   map[string]decodeFunc
+}
+
+// NewLogsCodec creates a new codec able to translate the log entries emitted by the built-in functions
+// into typed events and back
+func NewLogsCodec(marshaller vmcommon.Marshalizer) (*logsCodec, error) {
+	if check.IfNil(marshaller) {
+		return nil, ErrNilMarshalizer
+	}
+
+	codec := &logsCodec{
+		marshaller: marshaller,
+	}
+	codec.decoders = codec.createDecoders()
+
+	return codec, nil
+}
+
+func (codec *logsCodec) createDecoders() map[string]decodeFunc {
+	decoders := make(map[string]decodeFunc)
+
+	for _, identifier := range []string{
+		core.BuiltInFunctionESDTTransfer,
+		core.BuiltInFunctionESDTNFTTransfer,
+		core.BuiltInFunctionMultiESDTNFTTransfer,
+	} {
+		decoders[identifier] = decodeTransferEvent
+	}
+	for _, identifier := range []string{
+		core.BuiltInFunctionESDTLocalMint,
+		core.BuiltInFunctionESDTLocalBurn,
+		core.BuiltInFunctionESDTBurn,
+		core.BuiltInFunctionESDTNFTAddQuantity,
+		core.BuiltInFunctionESDTNFTBurn,
+		vmcommon.BuiltInFunctionESDTSetMaxSupply,
+	} {
+		decoders[identifier] = decodeTokenSupplyEvent
+	}
+	for _, identifier := range []string{
+		core.BuiltInFunctionESDTNFTCreate,
+		core.ESDTMetaDataUpdate,
+		core.ESDTMetaDataRecreate,
+	} {
+		decoders[identifier] = codec.decodeMetaDataEvent
+	}
+	for _, identifier := range []string{
+		core.BuiltInFunctionESDTFreeze,
+		core.BuiltInFunctionESDTUnFreeze,
+		core.BuiltInFunctionESDTWipe,
+	} {
+		decoders[identifier] = decodeFreezeWipeEvent
+	}
+	decoders[core.BuiltInFunctionSetESDTRole] = decodeRolesEvent
+	decoders[core.BuiltInFunctionUnSetESDTRole] = decodeRolesEvent
+	decoders[vmcommon.BuiltInFunctionESDTTransferRoleAddAddress] = decodeTransferRoleAddressesEvent
+	decoders[vmcommon.BuiltInFunctionESDTTransferRoleDeleteAddress] = decodeTransferRoleAddressesEvent
+	decoders[identifierESDTNFTCreateRoleTransfer] = decodeCreateRoleTransferEvent
+	decoders[identifierESDTNFTUpdateAttributes] = decodeUpdateAttributesEvent
+	decoders[core.BuiltInFunctionESDTNFTAddURI] = decodeURIsEvent
+	decoders[core.ESDTSetNewURIs] = decodeURIsEvent
+	decoders[identifierESDTModifyRoyalties] = decodeModifyRoyaltiesEvent
+	decoders[identifierESDTModifyCreator] = decodeModifyCreatorEvent
+	decoders[identifierChangeOwnerAddress] = decodeChangeOwnerEvent
+	decoders[vmcommon.BuiltInFunctionProposeOwnerAddress] = decodeOwnerAddressTransferEvent
+	decoders[vmcommon.BuiltInFunctionAcceptOwnerAddress] = decodeOwnerAddressTransferEvent
+	decoders[vmcommon.BuiltInFunctionCancelOwnerAddressProposal] = decodeOwnerAddressTransferEvent
+	decoders[identifierClaimDeveloperRewards] = decodeClaimDeveloperRewardsEvent
+	decoders[vmcommon.BuiltInFunctionSetDeveloperRewardsSplit] = decodeSetDeveloperRewardsSplitEvent
+	decoders[core.BuiltInFunctionSetUserName] = decodeUserNameChangeEvent
+	decoders[identifierDeleteUserName] = decodeUserNameChangeEvent
+	decoders[vmcommon.BuiltInFunctionRenewUserName] = decodeRenewUserNameEvent
+	decoders[vmcommon.BuiltInFunctionTransferUserName] = decodeTransferUserNameEvent
+	decoders[vmcommon.BuiltInFunctionApproveUserNameTransfer] = decodeApproveUserNameTransferEvent
+	decoders[identifierSetGuardian] = decodeSetGuardianEvent
+	decoders[core.BuiltInFunctionGuardAccount] = decodeGuardEvent
+	decoders[core.BuiltInFunctionUnGuardAccount] = decodeGuardEvent
+	decoders[vmcommon.BuiltInFunctionSetGuardianSet] = decodeSetGuardianSetEvent
+	decoders[vmcommon.BuiltInFunctionSetGuardedSpendingPolicy] = decodeSetGuardedSpendingPolicyEvent
+	decoders[vmcommon.BuiltInFunctionAddCrossChainWhiteListedAddress] = decodeCrossChainWhiteListEvent
+	decoders[vmcommon.BuiltInFunctionRemoveCrossChainWhiteListedAddress] = decodeCrossChainWhiteListEvent
+	decoders[vmcommon.BuiltInFunctionESDTBridgeDeposit] = codec.decodeBridgeDepositEvent
+	decoders[vmcommon.BuiltInFunctionESDTBridgeWithdraw] = decodeBridgeWithdrawEvent
+	decoders[vmcommon.BuiltInFunctionESDTNativeIssue] = decodeNativeIssueEvent
+	decoders[vmcommon.BuiltInFunctionESDTNativeIssueCollection] = decodeNativeIssueEvent
+	decoders[vmcommon.BuiltInFunctionESDTNativeSetTokenProperties] = decodeNativeTokenPropertiesEvent
+	decoders[vmcommon.BuiltInFunctionESDTNativeTransferOwnership] = decodeNativeTransferOwnershipEvent
+	decoders[vmcommon.BuiltInFunctionESDTVestingTransfer] = decodeVestingTransferEvent
+	decoders[vmcommon.BuiltInFunctionESDTReleaseVesting] = decodeVestingReleaseEvent
+	decoders[vmcommon.ESDTTransferFeeIdentifier] = decodeTransferFeeEvent
+	decoders[vmcommon.BuiltInFunctionESDTSetTransferFee] = decodeSetTransferFeeEvent
+	decoders[vmcommon.BuiltInFunctionESDTAllowListAddAddress] = decodeAccessListEvent
+	decoders[vmcommon.BuiltInFunctionESDTAllowListDeleteAddress] = decodeAccessListEvent
+	decoders[vmcommon.BuiltInFunctionESDTDenyListAddAddress] = decodeAccessListEvent
+	decoders[vmcommon.BuiltInFunctionESDTDenyListDeleteAddress] = decodeAccessListEvent
+	decoders[vmcommon.BuiltInFunctionFreezeAccount] = decodeAccountFreezeEvent
+	decoders[vmcommon.BuiltInFunctionUnFreezeAccount] = decodeAccountFreezeEvent
+
+	return decoders
+}
+
+// Decode translates a log entry emitted by a built-in function into a typed event
+func (codec *logsCodec) Decode(entry *vmcommon.LogEntry) (Event, error) {
+	if entry == nil {
+		return nil, ErrNilLogEntry
+	}
+
+	decode, found := codec.decoders[string(entry.Identifier)]
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownIdentifier, entry.Identifier)
+	}
+
+	return decode(entry)
+}
+
+// DecodeAll translates all the log entries which belong to built-in functions. Unknown entries are skipped
+func (codec *logsCodec) DecodeAll(entries []*vmcommon.LogEntry) ([]Event, error) {
+	events := make([]Event, 0, len(entries))
+	for _, entry := range entries {
+		if entry == nil || !codec.IsBuiltInFunctionEvent(entry.Identifier) {
+			continue
+		}
+
+		event, err := codec.Decode(entry)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// IsBuiltInFunctionEvent returns true if the identifier belongs to a log entry emitted by a built-in function
+func (codec *logsCodec) IsBuiltInFunctionEvent(identifier []byte) bool {
+	_, found := codec.decoders[string(identifier)]
+	return found
+}
+
+// Encode translates a typed event into the log entry emitted by the built-in function
+func (codec *logsCodec) Encode(event Event) (*vmcommon.LogEntry, error) {
+	if check.IfNilReflect(event) {
+		return nil, ErrNilEvent
+	}
+
+	return event.encode(codec.marshaller)
+}
+
+func decodeTransferEvent(entry *vmcommon.LogEntry) (Event, error) {
+	numTopics := len(entry.Topics)
+	if numTopics < numTopicsPerToken+1 || (numTopics-1)%numTopicsPerToken != 0 {
+		return nil, fmt.Errorf("%w for %s: %d", ErrInvalidNumberOfTopics, entry.Identifier, numTopics)
+	}
+
+	event := &TransferEvent{
+		Identifier: string(entry.Identifier),
+		Layout:     TokenListLayout,
+		Sender:     entry.Address,
+		Receiver:   entry.Topics[numTopics-1],
+		Tokens:     make([]*builtInFunctions.TopicTokenData, 0, numTopics/numTopicsPerToken),
+	}
+	for i := 0; i+numTopicsPerToken < numTopics; i += numTopicsPerToken {
+		event.Tokens = append(event.Tokens, decodeTokenTopics(entry.Topics[i:]))
+	}
+
+	if len(entry.Data) == 0 {
+		event.Layout = SingleTokenLayout
+		if len(event.Tokens) != 1 {
+			event.Layout = TokenListLayout
+		}
+		return event, nil
+	}
+
+	event.CallData = &CallData{
+		CallType: string(entry.Data[0]),
+	}
+	if len(entry.Data) > 1 {
+		event.CallData.Function = string(entry.Data[1])
+		event.CallData.Arguments = entry.Data[2:]
+	}
+
+	return event, nil
+}
+
+func decodeTokenSupplyEvent(entry *vmcommon.LogEntry) (Event, error) {
+	token, _, err := decodeESDTTopics(entry, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TokenSupplyEvent{
+		Identifier: string(entry.Identifier),
+		Caller:     entry.Address,
+		Token:      token,
+	}, nil
+}
+
+func (codec *logsCodec) decodeMetaDataEvent(entry *vmcommon.LogEntry) (Event, error) {
+	token, extraTopics, err := decodeESDTTopics(entry, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	esdtData := &esdt.ESDigitalToken{}
+	err = codec.marshaller.Unmarshal(esdtData, extraTopics[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return &MetaDataEvent{
+		Identifier: string(entry.Identifier),
+		Caller:     entry.Address,
+		Token:      token,
+		ESDTData:   esdtData,
+	}, nil
+}
+
+func decodeFreezeWipeEvent(entry *vmcommon.LogEntry) (Event, error) {
+	token, extraTopics, err := decodeESDTTopics(entry, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(extraTopics) != 1 && len(extraTopics) != 2 {
+		return nil, fmt.Errorf("%w for %s: %d", ErrInvalidNumberOfTopics, entry.Identifier, len(entry.Topics))
+	}
+
+	event := &FreezeWipeEvent{
+		Identifier: string(entry.Identifier),
+		Caller:     entry.Address,
+		Account:    extraTopics[0],
+		Token:      token,
+	}
+	if len(extraTopics) == 2 {
+		event.FrozenAmount = big.NewInt(0).SetBytes(extraTopics[1])
+	}
+
+	return event, nil
+}
+
+func decodeRolesEvent(entry *vmcommon.LogEntry) (Event, error) {
+	token, extraTopics, err := decodeESDTTopics(entry, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RolesEvent{
+		Identifier: string(entry.Identifier),
+		Account:    entry.Address,
+		TokenID:    token.TokenID,
+		Roles:      extraTopics,
+	}, nil
+}
+
+func decodeTransferRoleAddressesEvent(entry *vmcommon.LogEntry) (Event, error) {
+	token, extraTopics, err := decodeESDTTopics(entry, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransferRoleAddressesEvent{
+		Identifier:    string(entry.Identifier),
+		SystemAccount: entry.Address,
+		TokenID:       token.TokenID,
+		Addresses:     extraTopics,
+	}, nil
+}
+
+func decodeCreateRoleTransferEvent(entry *vmcommon.LogEntry) (Event, error) {
+	token, extraTopics, err := decodeESDTTopics(entry, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	hasRole, err := strconv.ParseBool(string(extraTopics[0]))
+	if err != nil {
+		return nil, err
+	}
+
+	return &CreateRoleTransferEvent{
+		Account: entry.Address,
+		TokenID: token.TokenID,
+		HasRole: hasRole,
+	}, nil
+}
+
+func decodeUpdateAttributesEvent(entry *vmcommon.LogEntry) (Event, error) {
+	token, extraTopics, err := decodeESDTTopics(entry, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UpdateAttributesEvent{
+		Caller:     entry.Address,
+		Token:      token,
+		Attributes: extraTopics[0],
+	}, nil
+}
+
+func decodeURIsEvent(entry *vmcommon.LogEntry) (Event, error) {
+	token, extraTopics, err := decodeESDTTopics(entry, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &URIsEvent{
+		Identifier: string(entry.Identifier),
+		Caller:     entry.Address,
+		Token:      token,
+		URIs:       extraTopics,
+	}, nil
+}
+
+func decodeModifyRoyaltiesEvent(entry *vmcommon.LogEntry) (Event, error) {
+	token, extraTopics, err := decodeESDTTopics(entry, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ModifyRoyaltiesEvent{
+		Caller:    entry.Address,
+		Token:     token,
+		Royalties: uint32(big.NewInt(0).SetBytes(extraTopics[0]).Uint64()),
+	}, nil
+}
+
+func decodeModifyCreatorEvent(entry *vmcommon.LogEntry) (Event, error) {
+	token, _, err := decodeESDTTopics(entry, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ModifyCreatorEvent{
+		Caller: entry.Address,
+		Token:  token,
+	}, nil
+}
+
+func decodeChangeOwnerEvent(entry *vmcommon.LogEntry) (Event, error) {
+	err := checkNumTopics(entry, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ChangeOwnerEvent{
+		Contract: entry.Address,
+		NewOwner: entry.Topics[0],
+	}, nil
+}
+
+func decodeOwnerAddressTransferEvent(entry *vmcommon.LogEntry) (Event, error) {
+	err := checkNumTopics(entry, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OwnerAddressTransferEvent{
+		Identifier: string(entry.Identifier),
+		Contract:   entry.Address,
+		Owner:      entry.Topics[0],
+	}, nil
+}
+
+func decodeClaimDeveloperRewardsEvent(entry *vmcommon.LogEntry) (Event, error) {
+	numTopics := len(entry.Topics)
+	if numTopics < 2 || numTopics%2 != 0 {
+		return nil, fmt.Errorf("%w for %s: %d", ErrInvalidNumberOfTopics, entry.Identifier, numTopics)
+	}
+
+	event := &ClaimDeveloperRewardsEvent{
+		Contract:  entry.Address,
+		Value:     big.NewInt(0).SetBytes(entry.Topics[0]),
+		Developer: entry.Topics[1],
+	}
+	for i := 2; i < numTopics; i += 2 {
+		event.Payouts = append(event.Payouts, &DeveloperRewardsPayout{
+			Beneficiary: entry.Topics[i],
+			Value:       big.NewInt(0).SetBytes(entry.Topics[i+1]),
+		})
+	}
+
+	return event, nil
+}
+
+func decodeSetDeveloperRewardsSplitEvent(entry *vmcommon.LogEntry) (Event, error) {
+	numTopics := len(entry.Topics)
+	if numTopics%2 != 0 {
+		return nil, fmt.Errorf("%w for %s: %d", ErrInvalidNumberOfTopics, entry.Identifier, numTopics)
+	}
+
+	event := &SetDeveloperRewardsSplitEvent{Contract: entry.Address}
+	for i := 0; i < numTopics; i += 2 {
+		event.Shares = append(event.Shares, &DeveloperRewardsShare{
+			Beneficiary: entry.Topics[i],
+			BasisPoints: uint32(big.NewInt(0).SetBytes(entry.Topics[i+1]).Uint64()),
+		})
+	}
+
+	return event, nil
+}
+
+func decodeUserNameChangeEvent(entry *vmcommon.LogEntry) (Event, error) {
+	err := checkNumTopics(entry, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &UserNameChangeEvent{
+		Identifier:  string(entry.Identifier),
+		Account:     entry.Address,
+		OldUserName: entry.Topics[0],
+	}, nil
+}
+
+func decodeRenewUserNameEvent(entry *vmcommon.LogEntry) (Event, error) {
+	err := checkNumTopics(entry, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RenewUserNameEvent{
+		Account:     entry.Address,
+		UserName:    entry.Topics[0],
+		ExpiryEpoch: uint32(big.NewInt(0).SetBytes(entry.Topics[1]).Uint64()),
+	}, nil
+}
+
+func decodeTransferUserNameEvent(entry *vmcommon.LogEntry) (Event, error) {
+	err := checkNumTopics(entry, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransferUserNameEvent{
+		Account:   entry.Address,
+		UserName:  entry.Topics[0],
+		NewHolder: entry.Topics[1],
+	}, nil
+}
+
+func decodeApproveUserNameTransferEvent(entry *vmcommon.LogEntry) (Event, error) {
+	err := checkNumTopics(entry, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ApproveUserNameTransferEvent{
+		Account:       entry.Address,
+		UserName:      entry.Topics[0],
+		CurrentHolder: entry.Topics[1],
+	}, nil
+}
+
+func decodeSetGuardianEvent(entry *vmcommon.LogEntry) (Event, error) {
+	err := checkNumTopics(entry, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetGuardianEvent{
+		Account:    entry.Address,
+		Guardian:   entry.Topics[0],
+		ServiceUID: entry.Topics[1],
+	}, nil
+}
+
+func decodeGuardEvent(entry *vmcommon.LogEntry) (Event, error) {
+	event := &GuardEvent{
+		Identifier: string(entry.Identifier),
+		Account:    entry.Address,
+	}
+	if len(entry.Topics) == 0 {
+		return event, nil
+	}
+
+	guardianSet, err := decodeGuardianSet(entry)
+	if err != nil {
+		return nil, err
+	}
+	event.GuardianSet = guardianSet
+
+	return event, nil
+}
+
+func decodeSetGuardianSetEvent(entry *vmcommon.LogEntry) (Event, error) {
+	guardianSet, err := decodeGuardianSet(entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SetGuardianSetEvent{
+		Account:     entry.Address,
+		GuardianSet: guardianSet,
+	}, nil
+}
+
+func decodeSetGuardedSpendingPolicyEvent(entry *vmcommon.LogEntry) (Event, error) {
+	event := &SetGuardedSpendingPolicyEvent{Account: entry.Address}
+	if len(entry.Topics) == 0 {
+		return event, nil
+	}
+
+	err := checkNumTopics(entry, 1)
+	if err != nil {
+		return nil, err
+	}
+	event.Policy, err = builtInFunctions.GuardedSpendingPolicyFromBytes(entry.Topics[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+// decodeGuardianSet decodes the topics written as threshold, service UID and the guardian addresses
+func decodeGuardianSet(entry *vmcommon.LogEntry) (*vmcommon.GuardianSet, error) {
+	if len(entry.Topics) < numTopicsGuardianSet {
+		return nil, fmt.Errorf("%w for %s: %d", ErrInvalidNumberOfTopics, entry.Identifier, len(entry.Topics))
+	}
+
+	return &vmcommon.GuardianSet{
+		Guardians:  entry.Topics[2:],
+		Threshold:  uint32(big.NewInt(0).SetBytes(entry.Topics[0]).Uint64()),
+		ServiceUID: entry.Topics[1],
+	}, nil
+}
+
+func decodeCrossChainWhiteListEvent(entry *vmcommon.LogEntry) (Event, error) {
+	err := checkNumTopics(entry, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CrossChainWhiteListEvent{
+		Identifier: string(entry.Identifier),
+		Caller:     entry.Address,
+		Address:    entry.Topics[0],
+	}, nil
+}
+
+func (codec *logsCodec) decodeBridgeDepositEvent(entry *vmcommon.LogEntry) (Event, error) {
+	token, extraTopics, err := decodeESDTTopics(entry, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	var esdtData *esdt.ESDigitalToken
+	if len(extraTopics[1]) > 0 {
+		esdtData = &esdt.ESDigitalToken{}
+		err = codec.marshaller.Unmarshal(esdtData, extraTopics[1])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &BridgeDepositEvent{
+		Depositor:   entry.Address,
+		Destination: extraTopics[0],
+		Token:       token,
+		ESDTData:    esdtData,
+	}, nil
+}
+
+func decodeBridgeWithdrawEvent(entry *vmcommon.LogEntry) (Event, error) {
+	token, extraTopics, err := decodeESDTTopics(entry, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BridgeWithdrawEvent{
+		Bridge:        entry.Address,
+		Receiver:      extraTopics[0],
+		Token:         token,
+		OperationHash: extraTopics[1],
+	}, nil
+}
+
+func decodeNativeIssueEvent(entry *vmcommon.LogEntry) (Event, error) {
+	token, extraTopics, err := decodeESDTTopics(entry, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NativeIssueEvent{
+		Identifier: string(entry.Identifier),
+		Owner:      entry.Address,
+		Token:      token,
+		Name:       extraTopics[0],
+		Ticker:     extraTopics[1],
+		TokenType:  string(extraTopics[2]),
+	}, nil
+}
+
+func decodeNativeTokenPropertiesEvent(entry *vmcommon.LogEntry) (Event, error) {
+	token, extraTopics, err := decodeESDTTopics(entry, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NativeTokenPropertiesEvent{
+		Owner:      entry.Address,
+		TokenID:    token.TokenID,
+		Properties: extraTopics,
+	}, nil
+}
+
+func decodeNativeTransferOwnershipEvent(entry *vmcommon.LogEntry) (Event, error) {
+	token, extraTopics, err := decodeESDTTopics(entry, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &NativeTransferOwnershipEvent{
+		PreviousOwner: entry.Address,
+		NewOwner:      extraTopics[0],
+		TokenID:       token.TokenID,
+	}, nil
+}
+
+func decodeVestingTransferEvent(entry *vmcommon.LogEntry) (Event, error) {
+	token, extraTopics, err := decodeESDTTopics(entry, 0)
+	if err != nil {
+		return nil, err
+	}
+	if len(extraTopics) == 0 || (len(extraTopics)-1)%2 != 0 {
+		return nil, fmt.Errorf("%w for %s: %d", ErrInvalidNumberOfTopics, entry.Identifier, len(entry.Topics))
+	}
+
+	schedules := make([]*builtInFunctions.VestingSchedule, 0, len(extraTopics)/2)
+	for i := 1; i < len(extraTopics); i += 2 {
+		schedules = append(schedules, &builtInFunctions.VestingSchedule{
+			UnlockRound: big.NewInt(0).SetBytes(extraTopics[i]).Uint64(),
+			Amount:      big.NewInt(0).SetBytes(extraTopics[i+1]),
+		})
+	}
+
+	return &VestingTransferEvent{
+		Sender:    entry.Address,
+		Receiver:  extraTopics[0],
+		Token:     token,
+		Schedules: schedules,
+	}, nil
+}
+
+func decodeVestingReleaseEvent(entry *vmcommon.LogEntry) (Event, error) {
+	token, extraTopics, err := decodeESDTTopics(entry, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VestingReleaseEvent{
+		Account:     entry.Address,
+		Token:       token,
+		UnlockRound: big.NewInt(0).SetBytes(extraTopics[0]).Uint64(),
+	}, nil
+}
+
+func decodeTransferFeeEvent(entry *vmcommon.LogEntry) (Event, error) {
+	token, extraTopics, err := decodeESDTTopics(entry, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransferFeeEvent{
+		Sender:      entry.Address,
+		FeeReceiver: extraTopics[0],
+		Destination: extraTopics[1],
+		Token:       token,
+	}, nil
+}
+
+func decodeSetTransferFeeEvent(entry *vmcommon.LogEntry) (Event, error) {
+	token, extraTopics, err := decodeESDTTopics(entry, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	event := &SetTransferFeeEvent{
+		Caller:  entry.Address,
+		TokenID: token.TokenID,
+	}
+	if len(extraTopics) == 0 {
+		return event, nil
+	}
+	if len(extraTopics) < 3 {
+		return nil, fmt.Errorf("%w for %s: %d", ErrInvalidNumberOfTopics, entry.Identifier, len(entry.Topics))
+	}
+
+	event.Policy = &builtInFunctions.ESDTTransferFeePolicy{
+		BasisPoints:     uint32(big.NewInt(0).SetBytes(extraTopics[0]).Uint64()),
+		FixedAmount:     big.NewInt(0).SetBytes(extraTopics[1]),
+		FeeReceiver:     extraTopics[2],
+		ExemptAddresses: extraTopics[3:],
+	}
+
+	return event, nil
+}
+
+func decodeAccessListEvent(entry *vmcommon.LogEntry) (Event, error) {
+	token, extraTopics, err := decodeESDTTopics(entry, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccessListEvent{
+		Identifier:    string(entry.Identifier),
+		SystemAccount: entry.Address,
+		TokenID:       token.TokenID,
+		Addresses:     extraTopics,
+	}, nil
+}
+
+func decodeAccountFreezeEvent(entry *vmcommon.LogEntry) (Event, error) {
+	err := checkNumTopics(entry, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccountFreezeEvent{
+		Identifier: string(entry.Identifier),
+		Authority:  entry.Address,
+		Account:    entry.Topics[0],
+	}, nil
+}
+
+// decodeESDTTopics decodes the token topics and returns the extra topics. A positive number of expected extra
+// topics makes the decoding strict, otherwise any number of extra topics is accepted
+func decodeESDTTopics(entry *vmcommon.LogEntry, numExpectedExtraTopics int) (*builtInFunctions.TopicTokenData, [][]byte, error) {
+	numTopics := len(entry.Topics)
+	isStrict := numExpectedExtraTopics > 0
+	if numTopics < numTopicsPerToken || (isStrict && numTopics != numTopicsPerToken+numExpectedExtraTopics) {
+		return nil, nil, fmt.Errorf("%w for %s: %d", ErrInvalidNumberOfTopics, entry.Identifier, numTopics)
+	}
+
+	return decodeTokenTopics(entry.Topics), entry.Topics[numTopicsPerToken:], nil
+}
+
+func decodeTokenTopics(topics [][]byte) *builtInFunctions.TopicTokenData {
+	return &builtInFunctions.TopicTokenData{
+		TokenID: topics[0],
+		Nonce:   big.NewInt(0).SetBytes(topics[1]).Uint64(),
+		Value:   big.NewInt(0).SetBytes(topics[2]),
+	}
+}
+
+func checkNumTopics(entry *vmcommon.LogEntry, numExpectedTopics int) error {
+	if len(entry.Topics) != numExpectedTopics {
+		return fmt.Errorf("%w for %s: %d", ErrInvalidNumberOfTopics, entry.Identifier, len(entry.Topics))
+	}
+
+	return nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (codec *logsCodec) IsInterfaceNil() bool {
+	return codec == nil
+}
@@ -0,0 +1,306 @@
+package builtInLogs
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	"github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/multiversx/mx-chain-vm-common-go/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	callerAddress   = []byte("12345678901234567890123456789012")
+	receiverAddress = []byte("12345678901234567890123456789022")
+	tokenID         = []byte("TKN-abcdef")
+)
+
+func createTokenData(nonce uint64, value int64) *builtInFunctions.TopicTokenData {
+	return &builtInFunctions.TopicTokenData{
+		TokenID: tokenID,
+		Nonce:   nonce,
+		Value:   big.NewInt(value),
+	}
+}
+
+func TestNewLogsCodec(t *testing.T) {
+	t.Parallel()
+
+	codec, err := NewLogsCodec(nil)
+	assert.Nil(t, codec)
+	assert.Equal(t, ErrNilMarshalizer, err)
+
+	codec, err = NewLogsCodec(&mock.MarshalizerMock{})
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(codec))
+}
+
+func TestLogsCodec_Decode(t *testing.T) {
+	t.Parallel()
+
+	codec, _ := NewLogsCodec(&mock.MarshalizerMock{})
+
+	event, err := codec.Decode(nil)
+	assert.Nil(t, event)
+	assert.Equal(t, ErrNilLogEntry, err)
+
+	event, err = codec.Decode(&vmcommon.LogEntry{Identifier: []byte("unknown")})
+	assert.Nil(t, event)
+	assert.True(t, errors.Is(err, ErrUnknownIdentifier))
+
+	event, err = codec.Decode(&vmcommon.LogEntry{
+		Identifier: []byte(core.BuiltInFunctionESDTTransfer),
+		Topics:     [][]byte{tokenID, {}, {1}},
+	})
+	assert.Nil(t, event)
+	assert.True(t, errors.Is(err, ErrInvalidNumberOfTopics))
+
+	event, err = codec.Decode(&vmcommon.LogEntry{
+		Identifier: []byte(core.BuiltInFunctionESDTFreeze),
+		Topics:     [][]byte{tokenID, {}, {1}},
+	})
+	assert.Nil(t, event)
+	assert.True(t, errors.Is(err, ErrInvalidNumberOfTopics))
+
+	event, err = codec.Decode(&vmcommon.LogEntry{
+		Identifier: []byte(core.BuiltInFunctionSetGuardian),
+		Topics:     [][]byte{receiverAddress},
+	})
+	assert.Nil(t, event)
+	assert.True(t, errors.Is(err, ErrInvalidNumberOfTopics))
+
+	event, err = codec.Decode(&vmcommon.LogEntry{
+		Identifier: []byte(core.BuiltInFunctionGuardAccount),
+		Topics:     [][]byte{{1}, []byte("uid"), receiverAddress},
+	})
+	assert.Nil(t, event)
+	assert.True(t, errors.Is(err, ErrInvalidNumberOfTopics))
+}
+
+func TestLogsCodec_Encode(t *testing.T) {
+	t.Parallel()
+
+	codec, _ := NewLogsCodec(&mock.MarshalizerMock{})
+
+	entry, err := codec.Encode(nil)
+	assert.Nil(t, entry)
+	assert.Equal(t, ErrNilEvent, err)
+
+	var nilEvent *GuardEvent
+	entry, err = codec.Encode(nilEvent)
+	assert.Nil(t, entry)
+	assert.Equal(t, ErrNilEvent, err)
+
+	entry, err = codec.Encode(&TransferEvent{Identifier: core.BuiltInFunctionESDTTransfer})
+	assert.Nil(t, entry)
+	assert.Equal(t, ErrUnknownTopicsLayout, err)
+
+	entry, err = codec.Encode(&TransferEvent{Identifier: core.BuiltInFunctionESDTTransfer, Layout: SingleTokenLayout})
+	assert.Nil(t, entry)
+	assert.Equal(t, ErrInvalidNumberOfTopics, err)
+}
+
+func TestLogsCodec_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	codec, _ := NewLogsCodec(marshaller)
+
+	esdtData := &esdt.ESDigitalToken{
+		Value: big.NewInt(10),
+		TokenMetaData: &esdt.MetaData{
+			Nonce: 2,
+			Name:  []byte("name"),
+		},
+	}
+	esdtDataBytes, _ := marshaller.Marshal(esdtData)
+	guardianSet := &vmcommon.GuardianSet{
+		Guardians:  [][]byte{receiverAddress, []byte("guardian")},
+		Threshold:  2,
+		ServiceUID: []byte("uid"),
+	}
+
+	events := []Event{
+		&TransferEvent{
+			Identifier: core.BuiltInFunctionMultiESDTNFTTransfer,
+			Layout:     TokenListLayout,
+			Sender:     callerAddress,
+			Receiver:   receiverAddress,
+			Tokens:     []*builtInFunctions.TopicTokenData{createTokenData(0, 100), createTokenData(5, 1)},
+			CallData: &CallData{
+				Function:  core.BuiltInFunctionMultiESDTNFTTransfer,
+				Arguments: [][]byte{receiverAddress, {2}},
+			},
+		},
+		&TransferEvent{
+			Identifier: core.BuiltInFunctionMultiESDTNFTTransfer,
+			Layout:     SingleTokenLayout,
+			Sender:     callerAddress,
+			Receiver:   receiverAddress,
+			Tokens:     []*builtInFunctions.TopicTokenData{createTokenData(5, 1)},
+		},
+		&TokenSupplyEvent{Identifier: core.BuiltInFunctionESDTLocalMint, Caller: callerAddress, Token: createTokenData(0, 100)},
+		&MetaDataEvent{Identifier: core.BuiltInFunctionESDTNFTCreate, Caller: callerAddress, Token: createTokenData(2, 10), ESDTData: esdtData},
+		&FreezeWipeEvent{Identifier: core.BuiltInFunctionESDTWipe, Caller: core.ESDTSCAddress, Account: receiverAddress, Token: createTokenData(0, 7)},
+		&RolesEvent{Identifier: core.BuiltInFunctionSetESDTRole, Account: receiverAddress, TokenID: tokenID, Roles: [][]byte{[]byte(core.ESDTRoleLocalMint)}},
+		&TransferRoleAddressesEvent{Identifier: vmcommon.BuiltInFunctionESDTTransferRoleAddAddress, SystemAccount: vmcommon.SystemAccountAddress, TokenID: tokenID, Addresses: [][]byte{receiverAddress}},
+		&CreateRoleTransferEvent{Account: receiverAddress, TokenID: tokenID, HasRole: true},
+		&UpdateAttributesEvent{Caller: callerAddress, Token: createTokenData(2, 0), Attributes: []byte("attributes")},
+		&URIsEvent{Identifier: core.ESDTSetNewURIs, Caller: callerAddress, Token: createTokenData(2, 0), URIs: [][]byte{[]byte("uri1"), []byte("uri2")}},
+		&ModifyRoyaltiesEvent{Caller: callerAddress, Token: createTokenData(2, 0), Royalties: 500},
+		&ModifyCreatorEvent{Caller: callerAddress, Token: createTokenData(2, 0)},
+		&ChangeOwnerEvent{Contract: receiverAddress, NewOwner: callerAddress},
+		&OwnerAddressTransferEvent{Identifier: vmcommon.BuiltInFunctionProposeOwnerAddress, Contract: receiverAddress, Owner: callerAddress},
+		&OwnerAddressTransferEvent{Identifier: vmcommon.BuiltInFunctionAcceptOwnerAddress, Contract: receiverAddress, Owner: callerAddress},
+		&ClaimDeveloperRewardsEvent{Contract: receiverAddress, Value: big.NewInt(1000), Developer: callerAddress},
+		&ClaimDeveloperRewardsEvent{
+			Contract:  receiverAddress,
+			Value:     big.NewInt(1000),
+			Developer: callerAddress,
+			Payouts: []*DeveloperRewardsPayout{
+				{Beneficiary: receiverAddress, Value: big.NewInt(300)},
+				{Beneficiary: callerAddress, Value: big.NewInt(700)},
+			},
+		},
+		&SetDeveloperRewardsSplitEvent{Contract: receiverAddress},
+		&SetDeveloperRewardsSplitEvent{
+			Contract: receiverAddress,
+			Shares:   []*DeveloperRewardsShare{{Beneficiary: callerAddress, BasisPoints: 3000}},
+		},
+		&UserNameChangeEvent{Identifier: identifierDeleteUserName, Account: callerAddress, OldUserName: []byte("name.elrond")},
+		&RenewUserNameEvent{Account: callerAddress, UserName: []byte("name.elrond"), ExpiryEpoch: 1500},
+		&TransferUserNameEvent{Account: callerAddress, UserName: []byte("name.elrond"), NewHolder: receiverAddress},
+		&ApproveUserNameTransferEvent{Account: receiverAddress, UserName: []byte("name.elrond"), CurrentHolder: callerAddress},
+		&SetGuardianEvent{Account: callerAddress, Guardian: receiverAddress, ServiceUID: []byte("uid")},
+		&GuardEvent{Identifier: core.BuiltInFunctionGuardAccount, Account: callerAddress},
+		&CrossChainWhiteListEvent{Identifier: vmcommon.BuiltInFunctionAddCrossChainWhiteListedAddress, Caller: callerAddress, Address: receiverAddress},
+		&BridgeDepositEvent{Depositor: callerAddress, Destination: []byte("destination"), Token: createTokenData(0, 100)},
+		&BridgeDepositEvent{Depositor: callerAddress, Destination: []byte("destination"), Token: createTokenData(2, 10), ESDTData: esdtData},
+		&BridgeWithdrawEvent{Bridge: callerAddress, Receiver: receiverAddress, Token: createTokenData(2, 10), OperationHash: []byte("hash")},
+		&NativeIssueEvent{Identifier: vmcommon.BuiltInFunctionESDTNativeIssue, Owner: callerAddress, Token: createTokenData(0, 1000), Name: []byte("Token"), Ticker: []byte("TKN"), TokenType: core.FungibleESDT},
+		&NativeTokenPropertiesEvent{Owner: callerAddress, TokenID: tokenID, Properties: [][]byte{[]byte("canFreeze"), []byte("true")}},
+		&NativeTransferOwnershipEvent{PreviousOwner: callerAddress, NewOwner: receiverAddress, TokenID: tokenID},
+		&TokenSupplyEvent{Identifier: vmcommon.BuiltInFunctionESDTSetMaxSupply, Caller: callerAddress, Token: createTokenData(0, 1000)},
+		&VestingTransferEvent{
+			Sender:    callerAddress,
+			Receiver:  receiverAddress,
+			Token:     createTokenData(0, 100),
+			Schedules: []*builtInFunctions.VestingSchedule{{UnlockRound: 10, Amount: big.NewInt(40)}, {UnlockRound: 20, Amount: big.NewInt(60)}},
+		},
+		&VestingReleaseEvent{Account: receiverAddress, Token: createTokenData(0, 40), UnlockRound: 10},
+		&TransferFeeEvent{Sender: callerAddress, FeeReceiver: []byte("feeReceiver"), Destination: receiverAddress, Token: createTokenData(0, 5)},
+		&SetTransferFeeEvent{Caller: callerAddress, TokenID: tokenID},
+		&SetTransferFeeEvent{
+			Caller:  callerAddress,
+			TokenID: tokenID,
+			Policy: &builtInFunctions.ESDTTransferFeePolicy{
+				BasisPoints:     150,
+				FixedAmount:     big.NewInt(10),
+				FeeReceiver:     []byte("feeReceiver"),
+				ExemptAddresses: [][]byte{receiverAddress},
+			},
+		},
+		&AccessListEvent{Identifier: vmcommon.BuiltInFunctionESDTDenyListAddAddress, SystemAccount: vmcommon.SystemAccountAddress, TokenID: tokenID, Addresses: [][]byte{receiverAddress}},
+		&FreezeWipeEvent{Identifier: core.BuiltInFunctionESDTFreeze, Caller: core.ESDTSCAddress, Account: receiverAddress, Token: createTokenData(0, 5), FrozenAmount: big.NewInt(12)},
+		&AccountFreezeEvent{Identifier: vmcommon.BuiltInFunctionFreezeAccount, Authority: callerAddress, Account: receiverAddress},
+		&SetGuardianSetEvent{Account: callerAddress, GuardianSet: guardianSet},
+		&GuardEvent{Identifier: core.BuiltInFunctionUnGuardAccount, Account: callerAddress, GuardianSet: guardianSet},
+		&SetGuardedSpendingPolicyEvent{Account: callerAddress},
+		&SetGuardedSpendingPolicyEvent{
+			Account: callerAddress,
+			Policy: &builtInFunctions.GuardedSpendingPolicy{
+				PeriodInRounds:       14400,
+				Limits:               []*builtInFunctions.GuardedSpendingLimit{{TokenID: []byte(vmcommon.EGLDIdentifier), Amount: big.NewInt(1000)}},
+				WhitelistedReceivers: [][]byte{receiverAddress},
+			},
+		},
+	}
+
+	for _, event := range events {
+		entry, err := codec.Encode(event)
+		require.Nil(t, err, event.GetIdentifier())
+		assert.Equal(t, event.GetIdentifier(), string(entry.Identifier))
+
+		decoded, err := codec.Decode(entry)
+		require.Nil(t, err, event.GetIdentifier())
+		assert.Equal(t, event, decoded, event.GetIdentifier())
+
+		encodedAgain, err := codec.Encode(decoded)
+		require.Nil(t, err, event.GetIdentifier())
+		assert.Equal(t, entry, encodedAgain, event.GetIdentifier())
+	}
+
+	metaDataEntry, _ := codec.Encode(events[3])
+	assert.Equal(t, esdtDataBytes, metaDataEntry.Topics[3])
+}
+
+func TestLogsCodec_DecodeBuiltInFunctionOutput(t *testing.T) {
+	t.Parallel()
+
+	marshaller := &mock.MarshalizerMock{}
+	codec, _ := NewLogsCodec(marshaller)
+
+	accounts, _ := state.NewAccountsAdapter(state.ArgsNewAccountsAdapter{EnableEpochsHandler: &mock.EnableEpochsHandlerStub{}})
+	sender, _ := accounts.LoadAccount(callerAddress)
+	receiver, _ := accounts.LoadAccount(receiverAddress)
+	tokenKey := append([]byte(core.ProtectedKeyPrefix+core.ESDTKeyIdentifier), tokenID...)
+	marshalledData, _ := marshaller.Marshal(&esdt.ESDigitalToken{Value: big.NewInt(100)})
+	_ = sender.(vmcommon.UserAccountHandler).AccountDataHandler().SaveKeyValue(tokenKey, marshalledData)
+
+	esdtTransfer, _ := builtInFunctions.NewESDTTransferFunc(builtInFunctions.ESDTTransferFuncArgs{
+		FuncGasCost:           1,
+		Marshaller:            marshaller,
+		ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+		GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+		ShardCoordinator:      mock.NewMultiShardsCoordinatorMock(1),
+		RolesHandler:          &mock.ESDTRoleHandlerStub{},
+		EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+		LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+		TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+		SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+		AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
+	})
+	_ = esdtTransfer.SetPayableChecker(&mock.PayableHandlerStub{})
+
+	vmOutput, err := esdtTransfer.ProcessBuiltinFunction(
+		sender.(vmcommon.UserAccountHandler),
+		receiver.(vmcommon.UserAccountHandler),
+		&vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  callerAddress,
+				CallValue:   big.NewInt(0),
+				GasProvided: 10,
+				Arguments:   [][]byte{tokenID, big.NewInt(40).Bytes()},
+			},
+			RecipientAddr: receiverAddress,
+			Function:      core.BuiltInFunctionESDTTransfer,
+		})
+	require.Nil(t, err)
+
+	events, err := codec.DecodeAll(append(vmOutput.Logs, &vmcommon.LogEntry{Identifier: []byte("writeLog")}))
+	require.Nil(t, err)
+	require.Equal(t, 1, len(events))
+
+	expectedEvent := &TransferEvent{
+		Identifier: core.BuiltInFunctionESDTTransfer,
+		Layout:     TokenListLayout,
+		Sender:     callerAddress,
+		Receiver:   receiverAddress,
+		Tokens:     []*builtInFunctions.TopicTokenData{createTokenData(0, 40)},
+		CallData: &CallData{
+			Function:  core.BuiltInFunctionESDTTransfer,
+			Arguments: [][]byte{tokenID, big.NewInt(40).Bytes()},
+		},
+	}
+	assert.Equal(t, expectedEvent, events[0])
+
+	entry, _ := codec.Encode(events[0])
+	assert.Equal(t, vmOutput.Logs[0], entry)
+}
@@ -0,0 +1,23 @@
+package builtInLogs
+
+import (
+	"errors"
+)
+
+// ErrNilMarshalizer signals that a nil marshalizer has been provided
+var ErrNilMarshalizer = errors.New("nil marshalizer")
+
+// ErrNilLogEntry signals that a nil log entry has been provided
+var ErrNilLogEntry = errors.New("nil log entry")
+
+// ErrNilEvent signals that a nil event has been provided
+var ErrNilEvent = errors.New("nil event")
+
+// ErrUnknownIdentifier signals that the log entry identifier does not belong to a known built-in function
+var ErrUnknownIdentifier = errors.New("unknown log entry identifier")
+
+// ErrInvalidNumberOfTopics signals that the log entry has an unexpected number of topics
+var ErrInvalidNumberOfTopics = errors.New("invalid number of topics")
+
+// ErrUnknownTopicsLayout signals that an unknown topics layout has been provided
+var ErrUnknownTopicsLayout = errors.New("unknown topics layout")
@@ -0,0 +1,824 @@
+package builtInLogs
+
+import (
+	"encoding/binary"
+	"math/big"
+	"strconv"
+
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+)
+
+// TopicsLayout defines how the token data is placed in the topics of a transfer log entry
+type TopicsLayout uint8
+
+const (
+	// SingleTokenLayout is the layout of the per-token multi transfer log entries emitted before the sc to sc log
+	// event activation: token identifier, nonce, value and receiver, without any call data
+	SingleTokenLayout TopicsLayout = 1
+	// TokenListLayout is the layout built from TopicTokenData: (token identifier, nonce, value) triplets followed
+	// by the receiver, the call data being formatted by vmcommon.FormatLogDataForCall
+	TokenListLayout TopicsLayout = 2
+)
+
+const numTopicsPerToken = 3
+
+// numTopicsGuardianSet is the minimum number of topics of a guardian set: threshold, service UID and two guardians
+const numTopicsGuardianSet = 4
+
+// Event defines a typed log event emitted by a built-in function
+type Event interface {
+	GetIdentifier() string
+	encode(marshaller vmcommon.Marshalizer) (*vmcommon.LogEntry, error)
+}
+
+// CallData holds the decoded data of a transfer log entry
+type CallData struct {
+	CallType  string
+	Function  string
+	Arguments [][]byte
+}
+
+// TransferEvent is emitted by ESDTTransfer, ESDTNFTTransfer and MultiESDTNFTTransfer
+type TransferEvent struct {
+	Identifier string
+	Layout     TopicsLayout
+	Sender     []byte
+	Receiver   []byte
+	Tokens     []*builtInFunctions.TopicTokenData
+	CallData   *CallData
+}
+
+// TokenSupplyEvent is emitted by ESDTLocalMint, ESDTLocalBurn, ESDTBurn, ESDTNFTAddQuantity, ESDTNFTBurn and
+// ESDTSetMaxSupply, the value of the latter being the new max supply
+type TokenSupplyEvent struct {
+	Identifier string
+	Caller     []byte
+	Token      *builtInFunctions.TopicTokenData
+}
+
+// MetaDataEvent is emitted by ESDTNFTCreate, ESDTMetaDataUpdate and ESDTMetaDataRecreate
+type MetaDataEvent struct {
+	Identifier string
+	Caller     []byte
+	Token      *builtInFunctions.TopicTokenData
+	ESDTData   *esdt.ESDigitalToken
+}
+
+// FreezeWipeEvent is emitted by ESDTFreeze, ESDTUnFreeze and ESDTWipe. FrozenAmount is set only when a partial amount
+// was frozen, unfrozen or wiped and holds the amount which remains frozen on the account
+type FreezeWipeEvent struct {
+	Identifier   string
+	Caller       []byte
+	Account      []byte
+	Token        *builtInFunctions.TopicTokenData
+	FrozenAmount *big.Int
+}
+
+// RolesEvent is emitted by SetESDTRole and UnSetESDTRole
+type RolesEvent struct {
+	Identifier string
+	Account    []byte
+	TokenID    []byte
+	Roles      [][]byte
+}
+
+// TransferRoleAddressesEvent is emitted by ESDTTransferRoleAddAddress and ESDTTransferRoleDeleteAddress
+type TransferRoleAddressesEvent struct {
+	Identifier    string
+	SystemAccount []byte
+	TokenID       []byte
+	Addresses     [][]byte
+}
+
+// CreateRoleTransferEvent is emitted by ESDTNFTCreateRoleTransfer
+type CreateRoleTransferEvent struct {
+	Account []byte
+	TokenID []byte
+	HasRole bool
+}
+
+// UpdateAttributesEvent is emitted by ESDTNFTUpdateAttributes
+type UpdateAttributesEvent struct {
+	Caller     []byte
+	Token      *builtInFunctions.TopicTokenData
+	Attributes []byte
+}
+
+// URIsEvent is emitted by ESDTNFTAddURI and ESDTSetNewURIs
+type URIsEvent struct {
+	Identifier string
+	Caller     []byte
+	Token      *builtInFunctions.TopicTokenData
+	URIs       [][]byte
+}
+
+// ModifyRoyaltiesEvent is emitted by ESDTModifyRoyalties
+type ModifyRoyaltiesEvent struct {
+	Caller    []byte
+	Token     *builtInFunctions.TopicTokenData
+	Royalties uint32
+}
+
+// ModifyCreatorEvent is emitted by ESDTModifyCreator
+type ModifyCreatorEvent struct {
+	Caller []byte
+	Token  *builtInFunctions.TopicTokenData
+}
+
+// ChangeOwnerEvent is emitted by ChangeOwnerAddress
+type ChangeOwnerEvent struct {
+	Contract []byte
+	NewOwner []byte
+}
+
+// OwnerAddressTransferEvent is emitted by ProposeOwnerAddress, AcceptOwnerAddress and CancelOwnerAddressProposal. The
+// owner is the proposed owner, the new owner or the owner whose proposal was cancelled
+type OwnerAddressTransferEvent struct {
+	Identifier string
+	Contract   []byte
+	Owner      []byte
+}
+
+// ClaimDeveloperRewardsEvent is emitted by ClaimDeveloperRewards. The payouts are set only when the rewards were split
+// among several beneficiaries, the last payout being the one of the developer
+type ClaimDeveloperRewardsEvent struct {
+	Contract  []byte
+	Value     *big.Int
+	Developer []byte
+	Payouts   []*DeveloperRewardsPayout
+}
+
+// DeveloperRewardsPayout holds the value received by a beneficiary of the developer rewards
+type DeveloperRewardsPayout struct {
+	Beneficiary []byte
+	Value       *big.Int
+}
+
+// SetDeveloperRewardsSplitEvent is emitted by SetDeveloperRewardsSplit, no shares meaning the split was removed
+type SetDeveloperRewardsSplitEvent struct {
+	Contract []byte
+	Shares   []*DeveloperRewardsShare
+}
+
+// DeveloperRewardsShare holds the share of the developer rewards, in basis points, of a beneficiary
+type DeveloperRewardsShare struct {
+	Beneficiary []byte
+	BasisPoints uint32
+}
+
+// UserNameChangeEvent is emitted by SetUserName and DeleteUserName
+type UserNameChangeEvent struct {
+	Identifier  string
+	Account     []byte
+	OldUserName []byte
+}
+
+// RenewUserNameEvent is emitted by RenewUserName
+type RenewUserNameEvent struct {
+	Account     []byte
+	UserName    []byte
+	ExpiryEpoch uint32
+}
+
+// TransferUserNameEvent is emitted by TransferUserName on the shard of the previous holder of the username
+type TransferUserNameEvent struct {
+	Account   []byte
+	UserName  []byte
+	NewHolder []byte
+}
+
+// ApproveUserNameTransferEvent is emitted by ApproveUserNameTransfer on the shard of the account accepting the username
+type ApproveUserNameTransferEvent struct {
+	Account       []byte
+	UserName      []byte
+	CurrentHolder []byte
+}
+
+// SetGuardianEvent is emitted by SetGuardian
+type SetGuardianEvent struct {
+	Account    []byte
+	Guardian   []byte
+	ServiceUID []byte
+}
+
+// GuardEvent is emitted by GuardAccount and UnGuardAccount. GuardianSet is nil for accounts protected by a single
+// guardian
+type GuardEvent struct {
+	Identifier  string
+	Account     []byte
+	GuardianSet *vmcommon.GuardianSet
+}
+
+// SetGuardianSetEvent is emitted by SetGuardianSet
+type SetGuardianSetEvent struct {
+	Account     []byte
+	GuardianSet *vmcommon.GuardianSet
+}
+
+// SetGuardedSpendingPolicyEvent is emitted by SetGuardedSpendingPolicy, a nil policy meaning the policy was removed
+type SetGuardedSpendingPolicyEvent struct {
+	Account []byte
+	Policy  *builtInFunctions.GuardedSpendingPolicy
+}
+
+// CrossChainWhiteListEvent is emitted for every address by AddCrossChainWhiteListedAddress and
+// RemoveCrossChainWhiteListedAddress
+type CrossChainWhiteListEvent struct {
+	Identifier string
+	Caller     []byte
+	Address    []byte
+}
+
+// BridgeDepositEvent is emitted by ESDTBridgeDeposit. The token data holds the deposited quantity together with the
+// token type and metadata, being nil for fungible tokens
+type BridgeDepositEvent struct {
+	Depositor   []byte
+	Destination []byte
+	Token       *builtInFunctions.TopicTokenData
+	ESDTData    *esdt.ESDigitalToken
+}
+
+// BridgeWithdrawEvent is emitted by ESDTBridgeWithdraw
+type BridgeWithdrawEvent struct {
+	Bridge        []byte
+	Receiver      []byte
+	Token         *builtInFunctions.TopicTokenData
+	OperationHash []byte
+}
+
+// NativeIssueEvent is emitted by ESDTNativeIssue and ESDTNativeIssueCollection. The token data holds the issued
+// token identifier and the initial supply
+type NativeIssueEvent struct {
+	Identifier string
+	Owner      []byte
+	Token      *builtInFunctions.TopicTokenData
+	Name       []byte
+	Ticker     []byte
+	TokenType  string
+}
+
+// NativeTokenPropertiesEvent is emitted by ESDTNativeSetTokenProperties, the properties being (name, value) pairs
+type NativeTokenPropertiesEvent struct {
+	Owner      []byte
+	TokenID    []byte
+	Properties [][]byte
+}
+
+// NativeTransferOwnershipEvent is emitted by ESDTNativeTransferOwnership
+type NativeTransferOwnershipEvent struct {
+	PreviousOwner []byte
+	NewOwner      []byte
+	TokenID       []byte
+}
+
+// VestingTransferEvent is emitted by ESDTVestingTransfer
+type VestingTransferEvent struct {
+	Sender    []byte
+	Receiver  []byte
+	Token     *builtInFunctions.TopicTokenData
+	Schedules []*builtInFunctions.VestingSchedule
+}
+
+// VestingReleaseEvent is emitted by ESDTReleaseVesting for every released schedule, the token value being the
+// unlocked quantity
+type VestingReleaseEvent struct {
+	Account     []byte
+	Token       *builtInFunctions.TopicTokenData
+	UnlockRound uint64
+}
+
+// TransferFeeEvent is emitted when a transfer fee is charged to the sender of an ESDT transfer, the token value
+// being the collected fee
+type TransferFeeEvent struct {
+	Sender      []byte
+	FeeReceiver []byte
+	Destination []byte
+	Token       *builtInFunctions.TopicTokenData
+}
+
+// SetTransferFeeEvent is emitted by ESDTSetTransferFee, a nil policy meaning the policy was removed
+type SetTransferFeeEvent struct {
+	Caller  []byte
+	TokenID []byte
+	Policy  *builtInFunctions.ESDTTransferFeePolicy
+}
+
+// AccessListEvent is emitted by ESDTAllowListAddAddress, ESDTAllowListDeleteAddress, ESDTDenyListAddAddress and
+// ESDTDenyListDeleteAddress
+type AccessListEvent struct {
+	Identifier    string
+	SystemAccount []byte
+	TokenID       []byte
+	Addresses     [][]byte
+}
+
+// AccountFreezeEvent is emitted by FreezeAccount and UnFreezeAccount
+type AccountFreezeEvent struct {
+	Identifier string
+	Authority  []byte
+	Account    []byte
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *TransferEvent) GetIdentifier() string {
+	return event.Identifier
+}
+
+func (event *TransferEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	switch event.Layout {
+	case SingleTokenLayout:
+		if len(event.Tokens) != 1 {
+			return nil, ErrInvalidNumberOfTopics
+		}
+		return newESDTLogEntry(event.Identifier, event.Tokens[0], event.Sender, event.Receiver), nil
+	case TokenListLayout:
+		topics := make([][]byte, 0, len(event.Tokens)*numTopicsPerToken+1)
+		for _, token := range event.Tokens {
+			topics = append(topics, tokenTopics(token)...)
+		}
+		topics = append(topics, event.Receiver)
+
+		entry := &vmcommon.LogEntry{
+			Identifier: []byte(event.Identifier),
+			Address:    event.Sender,
+			Topics:     topics,
+		}
+		if event.CallData != nil {
+			entry.Data = vmcommon.FormatLogDataForCall(event.CallData.CallType, event.CallData.Function, event.CallData.Arguments)
+		}
+		return entry, nil
+	default:
+		return nil, ErrUnknownTopicsLayout
+	}
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *TokenSupplyEvent) GetIdentifier() string {
+	return event.Identifier
+}
+
+func (event *TokenSupplyEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	return newESDTLogEntry(event.Identifier, event.Token, event.Caller), nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *MetaDataEvent) GetIdentifier() string {
+	return event.Identifier
+}
+
+func (event *MetaDataEvent) encode(marshaller vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	esdtDataBytes, err := marshaller.Marshal(event.ESDTData)
+	if err != nil {
+		return nil, err
+	}
+
+	return newESDTLogEntry(event.Identifier, event.Token, event.Caller, esdtDataBytes), nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *FreezeWipeEvent) GetIdentifier() string {
+	return event.Identifier
+}
+
+func (event *FreezeWipeEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	if event.FrozenAmount == nil {
+		return newESDTLogEntry(event.Identifier, event.Token, event.Caller, event.Account), nil
+	}
+
+	return newESDTLogEntry(event.Identifier, event.Token, event.Caller, event.Account, event.FrozenAmount.Bytes()), nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *RolesEvent) GetIdentifier() string {
+	return event.Identifier
+}
+
+func (event *RolesEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	args := append([][]byte{event.Account}, event.Roles...)
+	return newESDTLogEntry(event.Identifier, tokenIDOnly(event.TokenID), args...), nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *TransferRoleAddressesEvent) GetIdentifier() string {
+	return event.Identifier
+}
+
+func (event *TransferRoleAddressesEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	args := append([][]byte{event.SystemAccount}, event.Addresses...)
+	return newESDTLogEntry(event.Identifier, tokenIDOnly(event.TokenID), args...), nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *CreateRoleTransferEvent) GetIdentifier() string {
+	return identifierESDTNFTCreateRoleTransfer
+}
+
+func (event *CreateRoleTransferEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	hasRole := []byte(strconv.FormatBool(event.HasRole))
+	return newESDTLogEntry(identifierESDTNFTCreateRoleTransfer, tokenIDOnly(event.TokenID), event.Account, hasRole), nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *UpdateAttributesEvent) GetIdentifier() string {
+	return identifierESDTNFTUpdateAttributes
+}
+
+func (event *UpdateAttributesEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	return newESDTLogEntry(identifierESDTNFTUpdateAttributes, event.Token, event.Caller, event.Attributes), nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *URIsEvent) GetIdentifier() string {
+	return event.Identifier
+}
+
+func (event *URIsEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	args := append([][]byte{event.Caller}, event.URIs...)
+	return newESDTLogEntry(event.Identifier, event.Token, args...), nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *ModifyRoyaltiesEvent) GetIdentifier() string {
+	return identifierESDTModifyRoyalties
+}
+
+func (event *ModifyRoyaltiesEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	royalties := big.NewInt(0).SetUint64(uint64(event.Royalties)).Bytes()
+	return newESDTLogEntry(identifierESDTModifyRoyalties, event.Token, event.Caller, royalties), nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *ModifyCreatorEvent) GetIdentifier() string {
+	return identifierESDTModifyCreator
+}
+
+func (event *ModifyCreatorEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	return newESDTLogEntry(identifierESDTModifyCreator, event.Token, event.Caller), nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *ChangeOwnerEvent) GetIdentifier() string {
+	return identifierChangeOwnerAddress
+}
+
+func (event *ChangeOwnerEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(identifierChangeOwnerAddress),
+		Address:    event.Contract,
+		Topics:     [][]byte{event.NewOwner},
+	}, nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *OwnerAddressTransferEvent) GetIdentifier() string {
+	return event.Identifier
+}
+
+func (event *OwnerAddressTransferEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(event.Identifier),
+		Address:    event.Contract,
+		Topics:     [][]byte{event.Owner},
+	}, nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *ClaimDeveloperRewardsEvent) GetIdentifier() string {
+	return identifierClaimDeveloperRewards
+}
+
+func (event *ClaimDeveloperRewardsEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	topics := [][]byte{valueBytes(event.Value), event.Developer}
+	for _, payout := range event.Payouts {
+		topics = append(topics, payout.Beneficiary, valueBytes(payout.Value))
+	}
+
+	return &vmcommon.LogEntry{
+		Identifier: []byte(identifierClaimDeveloperRewards),
+		Address:    event.Contract,
+		Topics:     topics,
+	}, nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *SetDeveloperRewardsSplitEvent) GetIdentifier() string {
+	return vmcommon.BuiltInFunctionSetDeveloperRewardsSplit
+}
+
+func (event *SetDeveloperRewardsSplitEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	topics := make([][]byte, 0, 2*len(event.Shares))
+	for _, share := range event.Shares {
+		topics = append(topics, share.Beneficiary, big.NewInt(int64(share.BasisPoints)).Bytes())
+	}
+
+	return &vmcommon.LogEntry{
+		Identifier: []byte(vmcommon.BuiltInFunctionSetDeveloperRewardsSplit),
+		Address:    event.Contract,
+		Topics:     topics,
+	}, nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *UserNameChangeEvent) GetIdentifier() string {
+	return event.Identifier
+}
+
+func (event *UserNameChangeEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(event.Identifier),
+		Address:    event.Account,
+		Topics:     [][]byte{event.OldUserName},
+	}, nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *RenewUserNameEvent) GetIdentifier() string {
+	return vmcommon.BuiltInFunctionRenewUserName
+}
+
+func (event *RenewUserNameEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(vmcommon.BuiltInFunctionRenewUserName),
+		Address:    event.Account,
+		Topics:     [][]byte{event.UserName, binary.BigEndian.AppendUint32(nil, event.ExpiryEpoch)},
+	}, nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *TransferUserNameEvent) GetIdentifier() string {
+	return vmcommon.BuiltInFunctionTransferUserName
+}
+
+func (event *TransferUserNameEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(vmcommon.BuiltInFunctionTransferUserName),
+		Address:    event.Account,
+		Topics:     [][]byte{event.UserName, event.NewHolder},
+	}, nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *ApproveUserNameTransferEvent) GetIdentifier() string {
+	return vmcommon.BuiltInFunctionApproveUserNameTransfer
+}
+
+func (event *ApproveUserNameTransferEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(vmcommon.BuiltInFunctionApproveUserNameTransfer),
+		Address:    event.Account,
+		Topics:     [][]byte{event.UserName, event.CurrentHolder},
+	}, nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *SetGuardianEvent) GetIdentifier() string {
+	return identifierSetGuardian
+}
+
+func (event *SetGuardianEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(identifierSetGuardian),
+		Address:    event.Account,
+		Topics:     [][]byte{event.Guardian, event.ServiceUID},
+	}, nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *GuardEvent) GetIdentifier() string {
+	return event.Identifier
+}
+
+func (event *GuardEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	entry := &vmcommon.LogEntry{
+		Identifier: []byte(event.Identifier),
+		Address:    event.Account,
+	}
+	if event.GuardianSet != nil {
+		entry.Topics = guardianSetToTopics(event.GuardianSet)
+	}
+
+	return entry, nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *SetGuardianSetEvent) GetIdentifier() string {
+	return vmcommon.BuiltInFunctionSetGuardianSet
+}
+
+func (event *SetGuardianSetEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	if event.GuardianSet == nil {
+		return nil, ErrNilEvent
+	}
+
+	return &vmcommon.LogEntry{
+		Identifier: []byte(vmcommon.BuiltInFunctionSetGuardianSet),
+		Address:    event.Account,
+		Topics:     guardianSetToTopics(event.GuardianSet),
+	}, nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *SetGuardedSpendingPolicyEvent) GetIdentifier() string {
+	return vmcommon.BuiltInFunctionSetGuardedSpendingPolicy
+}
+
+func (event *SetGuardedSpendingPolicyEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	entry := &vmcommon.LogEntry{
+		Identifier: []byte(vmcommon.BuiltInFunctionSetGuardedSpendingPolicy),
+		Address:    event.Account,
+	}
+	if event.Policy != nil {
+		entry.Topics = [][]byte{event.Policy.ToBytes()}
+	}
+
+	return entry, nil
+}
+
+func guardianSetToTopics(guardianSet *vmcommon.GuardianSet) [][]byte {
+	topics := [][]byte{big.NewInt(int64(guardianSet.Threshold)).Bytes(), guardianSet.ServiceUID}
+	return append(topics, guardianSet.Guardians...)
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *CrossChainWhiteListEvent) GetIdentifier() string {
+	return event.Identifier
+}
+
+func (event *CrossChainWhiteListEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(event.Identifier),
+		Address:    event.Caller,
+		Topics:     [][]byte{event.Address},
+	}, nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *BridgeDepositEvent) GetIdentifier() string {
+	return vmcommon.BuiltInFunctionESDTBridgeDeposit
+}
+
+func (event *BridgeDepositEvent) encode(marshaller vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	var esdtDataBytes []byte
+	if event.ESDTData != nil {
+		var err error
+		esdtDataBytes, err = marshaller.Marshal(event.ESDTData)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return newESDTLogEntry(vmcommon.BuiltInFunctionESDTBridgeDeposit, event.Token, event.Depositor, event.Destination, esdtDataBytes), nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *BridgeWithdrawEvent) GetIdentifier() string {
+	return vmcommon.BuiltInFunctionESDTBridgeWithdraw
+}
+
+func (event *BridgeWithdrawEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	return newESDTLogEntry(vmcommon.BuiltInFunctionESDTBridgeWithdraw, event.Token, event.Bridge, event.Receiver, event.OperationHash), nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *NativeIssueEvent) GetIdentifier() string {
+	return event.Identifier
+}
+
+func (event *NativeIssueEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	return newESDTLogEntry(event.Identifier, event.Token, event.Owner, event.Name, event.Ticker, []byte(event.TokenType)), nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *NativeTokenPropertiesEvent) GetIdentifier() string {
+	return vmcommon.BuiltInFunctionESDTNativeSetTokenProperties
+}
+
+func (event *NativeTokenPropertiesEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	args := append([][]byte{event.Owner}, event.Properties...)
+	return newESDTLogEntry(vmcommon.BuiltInFunctionESDTNativeSetTokenProperties, tokenIDOnly(event.TokenID), args...), nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *NativeTransferOwnershipEvent) GetIdentifier() string {
+	return vmcommon.BuiltInFunctionESDTNativeTransferOwnership
+}
+
+func (event *NativeTransferOwnershipEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	return newESDTLogEntry(vmcommon.BuiltInFunctionESDTNativeTransferOwnership, tokenIDOnly(event.TokenID), event.PreviousOwner, event.NewOwner), nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *VestingTransferEvent) GetIdentifier() string {
+	return vmcommon.BuiltInFunctionESDTVestingTransfer
+}
+
+func (event *VestingTransferEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	args := [][]byte{event.Sender, event.Receiver}
+	for _, schedule := range event.Schedules {
+		args = append(args, big.NewInt(0).SetUint64(schedule.UnlockRound).Bytes(), valueBytes(schedule.Amount))
+	}
+
+	return newESDTLogEntry(vmcommon.BuiltInFunctionESDTVestingTransfer, event.Token, args...), nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *VestingReleaseEvent) GetIdentifier() string {
+	return vmcommon.BuiltInFunctionESDTReleaseVesting
+}
+
+func (event *VestingReleaseEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	unlockRound := big.NewInt(0).SetUint64(event.UnlockRound).Bytes()
+	return newESDTLogEntry(vmcommon.BuiltInFunctionESDTReleaseVesting, event.Token, event.Account, unlockRound), nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *TransferFeeEvent) GetIdentifier() string {
+	return vmcommon.ESDTTransferFeeIdentifier
+}
+
+func (event *TransferFeeEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	return newESDTLogEntry(vmcommon.ESDTTransferFeeIdentifier, event.Token, event.Sender, event.FeeReceiver, event.Destination), nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *SetTransferFeeEvent) GetIdentifier() string {
+	return vmcommon.BuiltInFunctionESDTSetTransferFee
+}
+
+func (event *SetTransferFeeEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	args := [][]byte{event.Caller}
+	if event.Policy != nil {
+		basisPoints := big.NewInt(0).SetUint64(uint64(event.Policy.BasisPoints)).Bytes()
+		args = append(args, basisPoints, valueBytes(event.Policy.FixedAmount), event.Policy.FeeReceiver)
+		args = append(args, event.Policy.ExemptAddresses...)
+	}
+
+	return newESDTLogEntry(vmcommon.BuiltInFunctionESDTSetTransferFee, tokenIDOnly(event.TokenID), args...), nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *AccessListEvent) GetIdentifier() string {
+	return event.Identifier
+}
+
+func (event *AccessListEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	args := append([][]byte{event.SystemAccount}, event.Addresses...)
+	return newESDTLogEntry(event.Identifier, tokenIDOnly(event.TokenID), args...), nil
+}
+
+// GetIdentifier returns the identifier of the log entry
+func (event *AccountFreezeEvent) GetIdentifier() string {
+	return event.Identifier
+}
+
+func (event *AccountFreezeEvent) encode(_ vmcommon.Marshalizer) (*vmcommon.LogEntry, error) {
+	return &vmcommon.LogEntry{
+		Identifier: []byte(event.Identifier),
+		Address:    event.Authority,
+		Topics:     [][]byte{event.Account},
+	}, nil
+}
+
+// newESDTLogEntry mirrors the log entry layout used by the built-in functions for ESDT operations: the first
+// argument is the address of the entry, the others are appended to the token topics
+func newESDTLogEntry(identifier string, token *builtInFunctions.TopicTokenData, args ...[]byte) *vmcommon.LogEntry {
+	entry := &vmcommon.LogEntry{
+		Identifier: []byte(identifier),
+		Topics:     tokenTopics(token),
+	}
+	if len(args) > 0 {
+		entry.Address = args[0]
+	}
+	if len(args) > 1 {
+		entry.Topics = append(entry.Topics, args[1:]...)
+	}
+
+	return entry
+}
+
+func tokenTopics(token *builtInFunctions.TopicTokenData) [][]byte {
+	if token == nil {
+		return [][]byte{nil, {}, {}}
+	}
+
+	nonce := big.NewInt(0).SetUint64(token.Nonce)
+	return [][]byte{token.TokenID, nonce.Bytes(), valueBytes(token.Value)}
+}
+
+func tokenIDOnly(tokenID []byte) *builtInFunctions.TopicTokenData {
+	return &builtInFunctions.TopicTokenData{
+		TokenID: tokenID,
+		Value:   big.NewInt(0),
+	}
+}
+
+func valueBytes(value *big.Int) []byte {
+	if value == nil {
+		return big.NewInt(0).Bytes()
+	}
+
+	return value.Bytes()
+}
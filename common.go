@@ -1,9 +1,11 @@
 package vmcommon
 
 import (
+	"bytes"
 	"math/big"
 
 	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
 )
 
 const tickerMinLength = 3
@@ -11,6 +13,7 @@ const tickerMaxLength = 10
 const additionalRandomCharsLength = 6
 const identifierMinLength = tickerMinLength + additionalRandomCharsLength + 1
 const identifierMaxLength = tickerMaxLength + additionalRandomCharsLength + 1
+const tokenPrefixSeparator = "-"
 
 // ESDTDeleteMetadata represents the defined built in function name for esdt delete metadata
 const ESDTDeleteMetadata = "ESDTDeleteMetadata"
@@ -30,12 +33,119 @@ const BuiltInFunctionESDTTransferRoleAddAddress = "ESDTTransferRoleAddAddress"
 // BuiltInFunctionESDTTransferRoleDeleteAddress represents the defined built in function name for transfer role delete address
 const BuiltInFunctionESDTTransferRoleDeleteAddress = "ESDTTransferRoleDeleteAddress"
 
+// BuiltInFunctionAddCrossChainWhiteListedAddress represents the defined built in function name for adding cross chain whitelisted addresses
+const BuiltInFunctionAddCrossChainWhiteListedAddress = "AddCrossChainWhiteListedAddress"
+
+// BuiltInFunctionRemoveCrossChainWhiteListedAddress represents the defined built in function name for removing cross chain whitelisted addresses
+const BuiltInFunctionRemoveCrossChainWhiteListedAddress = "RemoveCrossChainWhiteListedAddress"
+
+// BuiltInFunctionESDTBridgeDeposit represents the defined built in function name for esdt bridge deposit
+const BuiltInFunctionESDTBridgeDeposit = "ESDTBridgeDeposit"
+
+// BuiltInFunctionESDTBridgeWithdraw represents the defined built in function name for esdt bridge withdraw
+const BuiltInFunctionESDTBridgeWithdraw = "ESDTBridgeWithdraw"
+
+// BuiltInFunctionESDTNativeIssue represents the defined built in function name for esdt native fungible token issue
+const BuiltInFunctionESDTNativeIssue = "ESDTNativeIssue"
+
+// BuiltInFunctionESDTNativeIssueCollection represents the defined built in function name for esdt native collection issue
+const BuiltInFunctionESDTNativeIssueCollection = "ESDTNativeIssueCollection"
+
+// BuiltInFunctionESDTNativeSetTokenProperties represents the defined built in function name for esdt native set token properties
+const BuiltInFunctionESDTNativeSetTokenProperties = "ESDTNativeSetTokenProperties"
+
+// BuiltInFunctionESDTNativeTransferOwnership represents the defined built in function name for esdt native transfer ownership
+const BuiltInFunctionESDTNativeTransferOwnership = "ESDTNativeTransferOwnership"
+
+// BuiltInFunctionESDTSetMaxSupply represents the defined built in function name for esdt set max supply
+const BuiltInFunctionESDTSetMaxSupply = "ESDTSetMaxSupply"
+
+// BuiltInFunctionESDTVestingTransfer represents the defined built in function name for esdt vesting transfer
+const BuiltInFunctionESDTVestingTransfer = "ESDTVestingTransfer"
+
+// BuiltInFunctionESDTReleaseVesting represents the defined built in function name for esdt release vesting
+const BuiltInFunctionESDTReleaseVesting = "ESDTReleaseVesting"
+
+// BuiltInFunctionESDTSetTransferFee represents the defined built in function name for esdt set transfer fee
+const BuiltInFunctionESDTSetTransferFee = "ESDTSetTransferFee"
+
+// ESDTTransferFeeIdentifier represents the identifier of the log entry emitted when a transfer fee is collected
+const ESDTTransferFeeIdentifier = "ESDTTransferFee"
+
+// BuiltInFunctionESDTSetAllowListRequired represents the defined built in function name for esdt set allow list required
+const BuiltInFunctionESDTSetAllowListRequired = "ESDTSetAllowListRequired"
+
+// BuiltInFunctionESDTUnSetAllowListRequired represents the defined built in function name for esdt unset allow list required
+const BuiltInFunctionESDTUnSetAllowListRequired = "ESDTUnSetAllowListRequired"
+
+// BuiltInFunctionESDTAllowListAddAddress represents the defined built in function name for esdt allow list add address
+const BuiltInFunctionESDTAllowListAddAddress = "ESDTAllowListAddAddress"
+
+// BuiltInFunctionESDTAllowListDeleteAddress represents the defined built in function name for esdt allow list delete address
+const BuiltInFunctionESDTAllowListDeleteAddress = "ESDTAllowListDeleteAddress"
+
+// BuiltInFunctionESDTDenyListAddAddress represents the defined built in function name for esdt deny list add address
+const BuiltInFunctionESDTDenyListAddAddress = "ESDTDenyListAddAddress"
+
+// BuiltInFunctionESDTDenyListDeleteAddress represents the defined built in function name for esdt deny list delete address
+const BuiltInFunctionESDTDenyListDeleteAddress = "ESDTDenyListDeleteAddress"
+
+// BuiltInFunctionFreezeAccount represents the defined built in function name for freezing a whole account
+const BuiltInFunctionFreezeAccount = "FreezeAccount"
+
+// BuiltInFunctionUnFreezeAccount represents the defined built in function name for unfreezing a whole account
+const BuiltInFunctionUnFreezeAccount = "UnFreezeAccount"
+
+// BuiltInFunctionSetGuardianSet represents the defined built in function name for setting a guardian set
+const BuiltInFunctionSetGuardianSet = "SetGuardianSet"
+
+// BuiltInFunctionSetGuardedSpendingPolicy represents the defined built in function name for setting the spending
+// policy of a guarded account
+const BuiltInFunctionSetGuardedSpendingPolicy = "SetGuardedSpendingPolicy"
+
+// BuiltInFunctionProposeOwnerAddress represents the defined built in function name for proposing a new owner of a
+// smart contract
+const BuiltInFunctionProposeOwnerAddress = "ProposeOwnerAddress"
+
+// BuiltInFunctionAcceptOwnerAddress represents the defined built in function name for accepting the ownership of a
+// smart contract
+const BuiltInFunctionAcceptOwnerAddress = "AcceptOwnerAddress"
+
+// BuiltInFunctionCancelOwnerAddressProposal represents the defined built in function name for cancelling a proposed
+// owner of a smart contract
+const BuiltInFunctionCancelOwnerAddressProposal = "CancelOwnerAddressProposal"
+
+// BuiltInFunctionSetDeveloperRewardsSplit represents the defined built in function name for setting the beneficiaries
+// of the developer rewards of a smart contract
+const BuiltInFunctionSetDeveloperRewardsSplit = "SetDeveloperRewardsSplit"
+
+// BuiltInFunctionRenewUserName represents the defined built in function name for extending the expiry epoch of a username
+const BuiltInFunctionRenewUserName = "RenewUserName"
+
+// BuiltInFunctionTransferUserName represents the defined built in function name for moving a username to another account
+const BuiltInFunctionTransferUserName = "TransferUserName"
+
+// BuiltInFunctionApproveUserNameTransfer represents the defined built in function name for accepting a username which
+// is about to be moved from another account
+const BuiltInFunctionApproveUserNameTransfer = "ApproveUserNameTransfer"
+
 // ESDTRoleBurnForAll represents the role for burn for all
 const ESDTRoleBurnForAll = "ESDTRoleBurnForAll"
 
 // EGLDIdentifier represents the identifier for the EGLD in case of a transfer with MultIESDTNFTTransfer built-in function
 const EGLDIdentifier = "EGLD-000000"
 
+// ValidatePrefixedToken - validates the token ID issued through the native issuance built-in functions, which can also
+// be prefixed by a valid token prefix (prefix-TICKER-abcdef)
+func ValidatePrefixedToken(tokenID []byte) bool {
+	prefix, tokenIDWithoutPrefix, found := bytes.Cut(tokenID, []byte(tokenPrefixSeparator))
+	if found && esdt.IsValidTokenPrefix(string(prefix)) && ValidateToken(tokenIDWithoutPrefix) {
+		return true
+	}
+
+	return ValidateToken(tokenID)
+}
+
 // ValidateToken - validates the token ID
 func ValidateToken(tokenID []byte) bool {
 	tokenIDLen := len(tokenID)
@@ -104,6 +214,14 @@ func ZeroValueIfNil(value *big.Int) *big.Int {
 	return value
 }
 
+// GuardianSet defines the guardians protecting an account out of which at least Threshold must co-sign the
+// guarded transactions
+type GuardianSet struct {
+	Guardians  [][]byte
+	Threshold  uint32
+	ServiceUID []byte
+}
+
 // ArgsMigrateDataTrieLeaves is the argument structure for the MigrateDataTrieLeaves function
 type ArgsMigrateDataTrieLeaves struct {
 	OldVersion   core.TrieNodeVersion
@@ -51,6 +51,31 @@ func TestEI_validateToken(t *testing.T) {
 	assert.True(t, result)
 }
 
+func TestEI_validatePrefixedToken(t *testing.T) {
+	var result bool
+	result = ValidatePrefixedToken([]byte("sov1-ALC-6258d2"))
+	assert.True(t, result)
+	result = ValidatePrefixedToken([]byte("a-EGLDRIDEFL-08d8ef"))
+	assert.True(t, result)
+	result = ValidatePrefixedToken([]byte("ALC-6258d2"))
+	assert.True(t, result)
+	result = ValidateToken([]byte("sov1-ALC-6258d2"))
+	assert.False(t, result)
+
+	result = ValidatePrefixedToken([]byte("sov12-ALC-6258d2"))
+	assert.False(t, result)
+	result = ValidatePrefixedToken([]byte("SOV-ALC-6258d2"))
+	assert.False(t, result)
+	result = ValidatePrefixedToken([]byte("-ALC-6258d2"))
+	assert.False(t, result)
+	result = ValidatePrefixedToken([]byte("sov1-alc-6258d2"))
+	assert.False(t, result)
+	result = ValidatePrefixedToken([]byte("sov1-ALC-6258d2-abc"))
+	assert.False(t, result)
+	result = ValidatePrefixedToken([]byte("sov1-ALC"))
+	assert.False(t, result)
+}
+
 func TestZeroValueIfNil(t *testing.T) {
 	assert.Equal(t, big.NewInt(0), ZeroValueIfNil(nil))
 	assert.Equal(t, big.NewInt(42), ZeroValueIfNil(big.NewInt(42)))
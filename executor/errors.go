@@ -0,0 +1,32 @@
+package executor
+
+import (
+	"errors"
+)
+
+// ErrNilAccountsAdapter signals that a nil accounts adapter has been provided
+var ErrNilAccountsAdapter = errors.New("nil accounts adapter")
+
+// ErrNilBuiltInFunctionsContainer signals that a nil built-in functions container has been provided
+var ErrNilBuiltInFunctionsContainer = errors.New("nil built-in functions container")
+
+// ErrNilShardCoordinator signals that a nil shard coordinator has been provided
+var ErrNilShardCoordinator = errors.New("nil shard coordinator")
+
+// ErrNilArgsParser signals that a nil arguments parser has been provided
+var ErrNilArgsParser = errors.New("nil arguments parser")
+
+// ErrNilContractCallInput signals that a nil contract call input has been provided
+var ErrNilContractCallInput = errors.New("nil contract call input")
+
+// ErrBuiltInFunctionNotActive signals that the called built-in function is not active
+var ErrBuiltInFunctionNotActive = errors.New("built-in function is not active")
+
+// ErrExecutionFailed signals that the built-in function returned a VM output with a return code different from ok
+var ErrExecutionFailed = errors.New("built-in function execution failed")
+
+// ErrMaxCallDepthReached signals that the follow-up transfers exceeded the maximum call depth
+var ErrMaxCallDepthReached = errors.New("max call depth reached")
+
+// ErrWrongTypeAssertion signals that a type assertion failed
+var ErrWrongTypeAssertion = errors.New("wrong type assertion")
@@ -0,0 +1,356 @@
+package executor
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const defaultMaxCallDepth = 10
+
+var zero = big.NewInt(0)
+
+// ArgsNewBuiltInFunctionsExecutor defines the arguments needed to create a new built-in functions executor
+type ArgsNewBuiltInFunctionsExecutor struct {
+	Accounts         vmcommon.AccountsAdapter
+	BuiltInFunctions vmcommon.BuiltInFunctionContainer
+	ShardCoordinator vmcommon.Coordinator
+	ArgsParser       vmcommon.CallArgsParser
+	MaxCallDepth     uint32
+}
+
+// ExecutedStep holds the input and the output of one executed built-in function call
+type ExecutedStep struct {
+	Depth    uint32
+	Input    *vmcommon.ContractCallInput
+	VMOutput *vmcommon.VMOutput
+}
+
+// PendingTransfer holds an output transfer which was not executed, either because its destination
+// is in another shard or because it does not call a built-in function
+type PendingTransfer struct {
+	Destination []byte
+	Transfer    vmcommon.OutputTransfer
+}
+
+// ExecutionResult holds all the executed steps, in execution order, and the transfers left for other components
+type ExecutionResult struct {
+	Steps            []*ExecutedStep
+	PendingTransfers []*PendingTransfer
+}
+
+type builtInFunctionsExecutor struct {
+	mutExecution     sync.Mutex
+	accounts         vmcommon.AccountsAdapter
+	builtInFunctions vmcommon.BuiltInFunctionContainer
+	shardCoordinator vmcommon.Coordinator
+	argsParser       vmcommon.CallArgsParser
+	maxCallDepth     uint32
+}
+
+// NewBuiltInFunctionsExecutor creates a new executor which runs built-in functions against the provided accounts
+func NewBuiltInFunctionsExecutor(args ArgsNewBuiltInFunctionsExecutor) (*builtInFunctionsExecutor, error) {
+	if check.IfNil(args.Accounts) {
+		return nil, ErrNilAccountsAdapter
+	}
+	if check.IfNil(args.BuiltInFunctions) {
+		return nil, ErrNilBuiltInFunctionsContainer
+	}
+	if check.IfNil(args.ShardCoordinator) {
+		return nil, ErrNilShardCoordinator
+	}
+	if check.IfNil(args.ArgsParser) {
+		return nil, ErrNilArgsParser
+	}
+
+	maxCallDepth := args.MaxCallDepth
+	if maxCallDepth == 0 {
+		maxCallDepth = defaultMaxCallDepth
+	}
+
+	return &builtInFunctionsExecutor{
+		accounts:         args.Accounts,
+		builtInFunctions: args.BuiltInFunctions,
+		shardCoordinator: args.ShardCoordinator,
+		argsParser:       args.ArgsParser,
+		maxCallDepth:     maxCallDepth,
+	}, nil
+}
+
+// Execute runs the built-in function from the input, applies the resulting VM output on the accounts and
+// follows the output transfers which call built-in functions on destinations from the self shard.
+// If any step fails, all the changes done by the execution are reverted
+func (bfe *builtInFunctionsExecutor) Execute(input *vmcommon.ContractCallInput) (*ExecutionResult, error) {
+	if input == nil {
+		return nil, ErrNilContractCallInput
+	}
+
+	bfe.mutExecution.Lock()
+	defer bfe.mutExecution.Unlock()
+
+	snapshot := bfe.accounts.JournalLen()
+	result := &ExecutionResult{
+		Steps:            make([]*ExecutedStep, 0),
+		PendingTransfers: make([]*PendingTransfer, 0),
+	}
+
+	err := bfe.executeStep(input, 0, false, result)
+	if err != nil {
+		errRevert := bfe.accounts.RevertToSnapshot(snapshot)
+		if errRevert != nil {
+			return nil, fmt.Errorf("%w, revert failed: %s", err, errRevert.Error())
+		}
+
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (bfe *builtInFunctionsExecutor) executeStep(
+	input *vmcommon.ContractCallInput,
+	depth uint32,
+	isFollowUp bool,
+	result *ExecutionResult,
+) error {
+	if depth > bfe.maxCallDepth {
+		return fmt.Errorf("%w: %d", ErrMaxCallDepthReached, bfe.maxCallDepth)
+	}
+
+	function, err := bfe.builtInFunctions.Get(input.Function)
+	if err != nil {
+		return fmt.Errorf("%w for function %s", err, input.Function)
+	}
+	if !function.IsActive() {
+		return fmt.Errorf("%w: %s", ErrBuiltInFunctionNotActive, input.Function)
+	}
+
+	loadedAccounts := make(map[string]vmcommon.UserAccountHandler)
+
+	// follow-up transfers are executed as on the destination shard, the sender side being already processed
+	var acntSnd vmcommon.UserAccountHandler
+	if !isFollowUp {
+		acntSnd, err = bfe.loadAccountIfInSelfShard(input.CallerAddr, loadedAccounts)
+		if err != nil {
+			return err
+		}
+	}
+	acntDst, err := bfe.loadAccountIfInSelfShard(input.RecipientAddr, loadedAccounts)
+	if err != nil {
+		return err
+	}
+
+	vmOutput, err := function.ProcessBuiltinFunction(acntSnd, acntDst, input)
+	if err != nil {
+		return err
+	}
+	if vmOutput == nil {
+		return fmt.Errorf("%w: nil VM output", ErrExecutionFailed)
+	}
+	if vmOutput.ReturnCode != vmcommon.Ok {
+		return fmt.Errorf("%w: %s, %s", ErrExecutionFailed, vmOutput.ReturnCode.String(), vmOutput.ReturnMessage)
+	}
+
+	err = bfe.applyOutputAccounts(vmOutput, loadedAccounts)
+	if err != nil {
+		return err
+	}
+
+	err = bfe.saveAccounts(loadedAccounts)
+	if err != nil {
+		return err
+	}
+
+	result.Steps = append(result.Steps, &ExecutedStep{
+		Depth:    depth,
+		Input:    input,
+		VMOutput: vmOutput,
+	})
+
+	return bfe.followOutputTransfers(input, vmOutput, depth, result)
+}
+
+func (bfe *builtInFunctionsExecutor) applyOutputAccounts(
+	vmOutput *vmcommon.VMOutput,
+	loadedAccounts map[string]vmcommon.UserAccountHandler,
+) error {
+	for _, outAcc := range sortedOutputAccounts(vmOutput) {
+		if !hasStateChanges(outAcc) || !bfe.isInSelfShard(outAcc.Address) {
+			continue
+		}
+
+		account, err := bfe.loadAccountIfInSelfShard(outAcc.Address, loadedAccounts)
+		if err != nil {
+			return err
+		}
+
+		if outAcc.BalanceDelta != nil && outAcc.BalanceDelta.Cmp(zero) != 0 {
+			err = account.AddToBalance(outAcc.BalanceDelta)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, storageUpdate := range sortedStorageUpdates(outAcc) {
+			err = account.AccountDataHandler().SaveKeyValue(storageUpdate.Offset, storageUpdate.Data)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (bfe *builtInFunctionsExecutor) followOutputTransfers(
+	input *vmcommon.ContractCallInput,
+	vmOutput *vmcommon.VMOutput,
+	depth uint32,
+	result *ExecutionResult,
+) error {
+	for _, outAcc := range sortedOutputAccounts(vmOutput) {
+		for _, transfer := range outAcc.OutputTransfers {
+			followUpInput, isBuiltInCall := bfe.createFollowUpInput(input, outAcc.Address, transfer)
+			if !isBuiltInCall || !bfe.isInSelfShard(outAcc.Address) {
+				result.PendingTransfers = append(result.PendingTransfers, &PendingTransfer{
+					Destination: outAcc.Address,
+					Transfer:    transfer,
+				})
+				continue
+			}
+
+			err := bfe.executeStep(followUpInput, depth+1, true, result)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func (bfe *builtInFunctionsExecutor) createFollowUpInput(
+	input *vmcommon.ContractCallInput,
+	destination []byte,
+	transfer vmcommon.OutputTransfer,
+) (*vmcommon.ContractCallInput, bool) {
+	function, arguments, err := bfe.argsParser.ParseData(string(transfer.Data))
+	if err != nil {
+		return nil, false
+	}
+	_, err = bfe.builtInFunctions.Get(function)
+	if err != nil {
+		return nil, false
+	}
+
+	callValue := big.NewInt(0)
+	if transfer.Value != nil {
+		callValue.Set(transfer.Value)
+	}
+
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:         transfer.SenderAddress,
+			Arguments:          arguments,
+			CallValue:          callValue,
+			CallType:           transfer.CallType,
+			GasPrice:           input.GasPrice,
+			GasProvided:        transfer.GasLimit,
+			GasLocked:          transfer.GasLocked,
+			OriginalTxHash:     input.OriginalTxHash,
+			CurrentTxHash:      input.CurrentTxHash,
+			PrevTxHash:         input.PrevTxHash,
+			OriginalCallerAddr: input.OriginalCallerAddr,
+		},
+		RecipientAddr: destination,
+		Function:      function,
+	}, true
+}
+
+func (bfe *builtInFunctionsExecutor) loadAccountIfInSelfShard(
+	address []byte,
+	loadedAccounts map[string]vmcommon.UserAccountHandler,
+) (vmcommon.UserAccountHandler, error) {
+	if len(address) == 0 || !bfe.isInSelfShard(address) {
+		return nil, nil
+	}
+
+	account, found := loadedAccounts[string(address)]
+	if found {
+		return account, nil
+	}
+
+	accountHandler, err := bfe.accounts.LoadAccount(address)
+	if err != nil {
+		return nil, err
+	}
+	account, ok := accountHandler.(vmcommon.UserAccountHandler)
+	if !ok {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	loadedAccounts[string(address)] = account
+
+	return account, nil
+}
+
+func (bfe *builtInFunctionsExecutor) saveAccounts(loadedAccounts map[string]vmcommon.UserAccountHandler) error {
+	addresses := make([]string, 0, len(loadedAccounts))
+	for address := range loadedAccounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	for _, address := range addresses {
+		err := bfe.accounts.SaveAccount(loadedAccounts[address])
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (bfe *builtInFunctionsExecutor) isInSelfShard(address []byte) bool {
+	return bfe.shardCoordinator.ComputeId(address) == bfe.shardCoordinator.SelfId()
+}
+
+func hasStateChanges(outAcc *vmcommon.OutputAccount) bool {
+	hasBalanceChange := outAcc.BalanceDelta != nil && outAcc.BalanceDelta.Cmp(zero) != 0
+	return hasBalanceChange || len(outAcc.StorageUpdates) > 0
+}
+
+func sortedOutputAccounts(vmOutput *vmcommon.VMOutput) []*vmcommon.OutputAccount {
+	outputAccounts := make([]*vmcommon.OutputAccount, 0, len(vmOutput.OutputAccounts))
+	for _, outAcc := range vmOutput.OutputAccounts {
+		outputAccounts = append(outputAccounts, outAcc)
+	}
+	sort.Slice(outputAccounts, func(i, j int) bool {
+		return bytes.Compare(outputAccounts[i].Address, outputAccounts[j].Address) < 0
+	})
+
+	return outputAccounts
+}
+
+func sortedStorageUpdates(outAcc *vmcommon.OutputAccount) []*vmcommon.StorageUpdate {
+	storageUpdates := make([]*vmcommon.StorageUpdate, 0, len(outAcc.StorageUpdates))
+	for _, storageUpdate := range outAcc.StorageUpdates {
+		storageUpdates = append(storageUpdates, storageUpdate)
+	}
+	sort.Slice(storageUpdates, func(i, j int) bool {
+		return bytes.Compare(storageUpdates[i].Offset, storageUpdates[j].Offset) < 0
+	})
+
+	return storageUpdates
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (bfe *builtInFunctionsExecutor) IsInterfaceNil() bool {
+	return bfe == nil
+}
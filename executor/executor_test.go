@@ -0,0 +1,395 @@
+package executor
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	"github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/multiversx/mx-chain-vm-common-go/parsers"
+	"github.com/multiversx/mx-chain-vm-common-go/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	senderAddress   = []byte("12345678901234567890123456789012")
+	receiverAddress = []byte("12345678901234567890123456789022")
+	otherAddress    = []byte("12345678901234567890123456789032")
+)
+
+func createMockArgs(t *testing.T) ArgsNewBuiltInFunctionsExecutor {
+	accounts, err := state.NewAccountsAdapter(state.ArgsNewAccountsAdapter{
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+	})
+	require.Nil(t, err)
+
+	return ArgsNewBuiltInFunctionsExecutor{
+		Accounts:         accounts,
+		BuiltInFunctions: builtInFunctions.NewBuiltInFunctionContainer(),
+		ShardCoordinator: mock.NewMultiShardsCoordinatorMock(1),
+		ArgsParser:       parsers.NewCallArgsParser(),
+	}
+}
+
+func createCallInput(function string, arguments ...[]byte) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  senderAddress,
+			CallValue:   big.NewInt(0),
+			GasProvided: 1000,
+			Arguments:   arguments,
+		},
+		RecipientAddr: receiverAddress,
+		Function:      function,
+	}
+}
+
+func getBalance(t *testing.T, accounts vmcommon.AccountsAdapter, address []byte) *big.Int {
+	account, err := accounts.LoadAccount(address)
+	require.Nil(t, err)
+
+	return account.(vmcommon.UserAccountHandler).GetBalance()
+}
+
+func getValue(t *testing.T, accounts vmcommon.AccountsAdapter, address []byte, key []byte) []byte {
+	account, err := accounts.LoadAccount(address)
+	require.Nil(t, err)
+
+	value, _, err := account.(vmcommon.UserAccountHandler).AccountDataHandler().RetrieveValue(key)
+	require.Nil(t, err)
+
+	return value
+}
+
+func TestNewBuiltInFunctionsExecutor(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil accounts adapter should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgs(t)
+		args.Accounts = nil
+		bfe, err := NewBuiltInFunctionsExecutor(args)
+		assert.Nil(t, bfe)
+		assert.Equal(t, ErrNilAccountsAdapter, err)
+	})
+	t.Run("nil built-in functions container should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgs(t)
+		args.BuiltInFunctions = nil
+		bfe, err := NewBuiltInFunctionsExecutor(args)
+		assert.Nil(t, bfe)
+		assert.Equal(t, ErrNilBuiltInFunctionsContainer, err)
+	})
+	t.Run("nil shard coordinator should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgs(t)
+		args.ShardCoordinator = nil
+		bfe, err := NewBuiltInFunctionsExecutor(args)
+		assert.Nil(t, bfe)
+		assert.Equal(t, ErrNilShardCoordinator, err)
+	})
+	t.Run("nil args parser should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgs(t)
+		args.ArgsParser = nil
+		bfe, err := NewBuiltInFunctionsExecutor(args)
+		assert.Nil(t, bfe)
+		assert.Equal(t, ErrNilArgsParser, err)
+	})
+	t.Run("should work", func(t *testing.T) {
+		t.Parallel()
+
+		bfe, err := NewBuiltInFunctionsExecutor(createMockArgs(t))
+		assert.Nil(t, err)
+		assert.False(t, check.IfNil(bfe))
+		assert.Equal(t, uint32(defaultMaxCallDepth), bfe.maxCallDepth)
+	})
+}
+
+func TestBuiltInFunctionsExecutor_Execute(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil input should error", func(t *testing.T) {
+		t.Parallel()
+
+		bfe, _ := NewBuiltInFunctionsExecutor(createMockArgs(t))
+		result, err := bfe.Execute(nil)
+		assert.Nil(t, result)
+		assert.Equal(t, ErrNilContractCallInput, err)
+	})
+	t.Run("unknown function should error", func(t *testing.T) {
+		t.Parallel()
+
+		bfe, _ := NewBuiltInFunctionsExecutor(createMockArgs(t))
+		result, err := bfe.Execute(createCallInput("unknown"))
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, builtInFunctions.ErrInvalidContainerKey))
+	})
+	t.Run("inactive function should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgs(t)
+		_ = args.BuiltInFunctions.Add("func", &mock.BuiltInFunctionStub{
+			IsActiveCalled: func() bool {
+				return false
+			},
+		})
+		bfe, _ := NewBuiltInFunctionsExecutor(args)
+		result, err := bfe.Execute(createCallInput("func"))
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, ErrBuiltInFunctionNotActive))
+	})
+	t.Run("function outside its schedule should error", func(t *testing.T) {
+		t.Parallel()
+
+		isRetired := false
+		container, _ := builtInFunctions.NewEpochAwareBuiltInFunctionContainer(&mock.EnableEpochsHandlerStub{
+			IsFlagDefinedCalled: func(flag core.EnableEpochFlag) bool {
+				return true
+			},
+			IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+				return flag == "activation" || (flag == "deactivation" && isRetired)
+			},
+		})
+		_ = container.Add("func", &mock.BuiltInFunctionStub{})
+		_ = container.SetActivation("func", "activation", "deactivation")
+
+		args := createMockArgs(t)
+		args.BuiltInFunctions = container
+		bfe, _ := NewBuiltInFunctionsExecutor(args)
+		_, err := bfe.Execute(createCallInput("func"))
+		assert.Nil(t, err)
+
+		isRetired = true
+		result, err := bfe.Execute(createCallInput("func"))
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, builtInFunctions.ErrBuiltInFunctionIsNotActive))
+	})
+	t.Run("return code not ok should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgs(t)
+		_ = args.BuiltInFunctions.Add("func", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(_, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				return &vmcommon.VMOutput{ReturnCode: vmcommon.UserError, ReturnMessage: "message"}, nil
+			},
+		})
+		bfe, _ := NewBuiltInFunctionsExecutor(args)
+		result, err := bfe.Execute(createCallInput("func"))
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, ErrExecutionFailed))
+	})
+	t.Run("should apply output accounts and follow built-in transfers", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgs(t)
+		_ = args.BuiltInFunctions.Add("first", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				assert.Equal(t, senderAddress, acntSnd.AddressBytes())
+				assert.Equal(t, receiverAddress, acntDst.AddressBytes())
+				_ = acntSnd.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("sender"))
+
+				return &vmcommon.VMOutput{
+					ReturnCode:   vmcommon.Ok,
+					GasRemaining: 900,
+					OutputAccounts: map[string]*vmcommon.OutputAccount{
+						string(receiverAddress): {
+							Address:      receiverAddress,
+							BalanceDelta: big.NewInt(10),
+							StorageUpdates: map[string]*vmcommon.StorageUpdate{
+								"key": {Offset: []byte("key"), Data: []byte("receiver")},
+							},
+							OutputTransfers: []vmcommon.OutputTransfer{
+								{
+									Value:         big.NewInt(0),
+									GasLimit:      500,
+									Data:          []byte("second@01"),
+									SenderAddress: senderAddress,
+								},
+							},
+						},
+					},
+				}, nil
+			},
+		})
+		_ = args.BuiltInFunctions.Add("second", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, acntDst vmcommon.UserAccountHandler, vmInput *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				assert.True(t, check.IfNil(acntSnd))
+				assert.Equal(t, receiverAddress, acntDst.AddressBytes())
+				assert.Equal(t, [][]byte{{1}}, vmInput.Arguments)
+				assert.Equal(t, uint64(500), vmInput.GasProvided)
+				_ = acntDst.AddToBalance(big.NewInt(5))
+
+				return &vmcommon.VMOutput{ReturnCode: vmcommon.Ok}, nil
+			},
+		})
+		bfe, _ := NewBuiltInFunctionsExecutor(args)
+
+		result, err := bfe.Execute(createCallInput("first"))
+		require.Nil(t, err)
+		require.Equal(t, 2, len(result.Steps))
+		assert.Equal(t, "first", result.Steps[0].Input.Function)
+		assert.Equal(t, uint32(0), result.Steps[0].Depth)
+		assert.Equal(t, "second", result.Steps[1].Input.Function)
+		assert.Equal(t, uint32(1), result.Steps[1].Depth)
+		assert.Equal(t, 0, len(result.PendingTransfers))
+
+		assert.Equal(t, big.NewInt(15), getBalance(t, args.Accounts, receiverAddress))
+		assert.Equal(t, []byte("receiver"), getValue(t, args.Accounts, receiverAddress, []byte("key")))
+		assert.Equal(t, []byte("sender"), getValue(t, args.Accounts, senderAddress, []byte("key")))
+	})
+	t.Run("transfers to other shards and non built-in calls should be pending", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgs(t)
+		shardCoordinator := mock.NewMultiShardsCoordinatorMock(2)
+		shardCoordinator.ComputeIdCalled = func(address []byte) uint32 {
+			if string(address) == string(otherAddress) {
+				return 1
+			}
+			return 0
+		}
+		args.ShardCoordinator = shardCoordinator
+		_ = args.BuiltInFunctions.Add("func", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(_, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				return &vmcommon.VMOutput{
+					ReturnCode: vmcommon.Ok,
+					OutputAccounts: map[string]*vmcommon.OutputAccount{
+						string(receiverAddress): {
+							Address:         receiverAddress,
+							OutputTransfers: []vmcommon.OutputTransfer{{Data: []byte("scCall@01")}},
+						},
+						string(otherAddress): {
+							Address:         otherAddress,
+							BalanceDelta:    big.NewInt(10),
+							OutputTransfers: []vmcommon.OutputTransfer{{Data: []byte("func@01")}},
+						},
+					},
+				}, nil
+			},
+		})
+		bfe, _ := NewBuiltInFunctionsExecutor(args)
+
+		result, err := bfe.Execute(createCallInput("func"))
+		require.Nil(t, err)
+		assert.Equal(t, 1, len(result.Steps))
+		require.Equal(t, 2, len(result.PendingTransfers))
+		assert.Equal(t, receiverAddress, result.PendingTransfers[0].Destination)
+		assert.Equal(t, otherAddress, result.PendingTransfers[1].Destination)
+
+		_, err = args.Accounts.GetExistingAccount(otherAddress)
+		assert.NotNil(t, err)
+	})
+	t.Run("failing follow-up should revert all changes", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgs(t)
+		expectedErr := errors.New("expected error")
+		_ = args.BuiltInFunctions.Add("first", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(acntSnd, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				_ = acntSnd.AddToBalance(big.NewInt(10))
+
+				return &vmcommon.VMOutput{
+					ReturnCode: vmcommon.Ok,
+					OutputAccounts: map[string]*vmcommon.OutputAccount{
+						string(receiverAddress): {
+							Address:         receiverAddress,
+							OutputTransfers: []vmcommon.OutputTransfer{{Data: []byte("second")}},
+						},
+					},
+				}, nil
+			},
+		})
+		_ = args.BuiltInFunctions.Add("second", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(_, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				return nil, expectedErr
+			},
+		})
+		bfe, _ := NewBuiltInFunctionsExecutor(args)
+		rootHash, _ := args.Accounts.RootHash()
+
+		result, err := bfe.Execute(createCallInput("first"))
+		assert.Nil(t, result)
+		assert.Equal(t, expectedErr, err)
+
+		currentRootHash, _ := args.Accounts.RootHash()
+		assert.Equal(t, rootHash, currentRootHash)
+		assert.Equal(t, big.NewInt(0), getBalance(t, args.Accounts, senderAddress))
+	})
+	t.Run("max call depth reached should error", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgs(t)
+		args.MaxCallDepth = 3
+		numCalls := 0
+		_ = args.BuiltInFunctions.Add("func", &mock.BuiltInFunctionStub{
+			ProcessBuiltinFunctionCalled: func(_, _ vmcommon.UserAccountHandler, _ *vmcommon.ContractCallInput) (*vmcommon.VMOutput, error) {
+				numCalls++
+				return &vmcommon.VMOutput{
+					ReturnCode: vmcommon.Ok,
+					OutputAccounts: map[string]*vmcommon.OutputAccount{
+						string(receiverAddress): {
+							Address:         receiverAddress,
+							OutputTransfers: []vmcommon.OutputTransfer{{Data: []byte("func")}},
+						},
+					},
+				}, nil
+			},
+		})
+		bfe, _ := NewBuiltInFunctionsExecutor(args)
+
+		result, err := bfe.Execute(createCallInput("func"))
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, ErrMaxCallDepthReached))
+		assert.Equal(t, 4, numCalls)
+	})
+	t.Run("should execute ESDT transfer", func(t *testing.T) {
+		t.Parallel()
+
+		args := createMockArgs(t)
+		marshaller := &mock.MarshalizerMock{}
+		esdtTransfer, _ := builtInFunctions.NewESDTTransferFunc(builtInFunctions.ESDTTransferFuncArgs{
+			FuncGasCost:           1,
+			Marshaller:            marshaller,
+			ESDTSupplyHandler:     &mock.ESDTNFTStorageHandlerStub{},
+			GlobalSettingsHandler: &mock.GlobalSettingsHandlerStub{},
+			ShardCoordinator:      args.ShardCoordinator,
+			RolesHandler:          &mock.ESDTRoleHandlerStub{},
+			EnableEpochsHandler:   &mock.EnableEpochsHandlerStub{},
+			LockedBalanceHandler:  &mock.ESDTLockedBalanceHandlerStub{},
+			TransferFeeHandler:    &mock.ESDTTransferFeeHandlerStub{},
+			SpendingPolicyHandler: &mock.GuardedSpendingPolicyHandlerStub{},
+			AccessListChecker:     &mock.ESDTTransferAccessListCheckerStub{},
+		})
+		_ = esdtTransfer.SetPayableChecker(&mock.PayableHandlerStub{})
+		_ = args.BuiltInFunctions.Add(core.BuiltInFunctionESDTTransfer, esdtTransfer)
+
+		tokenKey := []byte(core.ProtectedKeyPrefix + core.ESDTKeyIdentifier + "TKN-abcdef")
+		sender, _ := args.Accounts.LoadAccount(senderAddress)
+		marshalledData, _ := marshaller.Marshal(&esdt.ESDigitalToken{Value: big.NewInt(100)})
+		_ = sender.(vmcommon.UserAccountHandler).AccountDataHandler().SaveKeyValue(tokenKey, marshalledData)
+		_ = args.Accounts.SaveAccount(sender)
+		bfe, _ := NewBuiltInFunctionsExecutor(args)
+
+		result, err := bfe.Execute(createCallInput(core.BuiltInFunctionESDTTransfer, []byte("TKN-abcdef"), big.NewInt(40).Bytes()))
+		require.Nil(t, err)
+		require.Equal(t, 1, len(result.Steps))
+		assert.Equal(t, uint64(999), result.Steps[0].VMOutput.GasRemaining)
+
+		esdtData := &esdt.ESDigitalToken{}
+		_ = marshaller.Unmarshal(esdtData, getValue(t, args.Accounts, senderAddress, tokenKey))
+		assert.Equal(t, big.NewInt(60), esdtData.Value)
+		_ = marshaller.Unmarshal(esdtData, getValue(t, args.Accounts, receiverAddress, tokenKey))
+		assert.Equal(t, big.NewInt(40), esdtData.Value)
+	})
+}
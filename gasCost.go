@@ -12,31 +12,48 @@ type BaseOperationCost struct {
 
 // BuiltInCost defines cost for built-in methods
 type BuiltInCost struct {
-	ChangeOwnerAddress       uint64
-	ClaimDeveloperRewards    uint64
-	SaveUserName             uint64
-	SaveKeyValue             uint64
-	ESDTTransfer             uint64
-	ESDTBurn                 uint64
-	ESDTLocalMint            uint64
-	ESDTLocalBurn            uint64
-	ESDTModifyRoyalties      uint64
-	ESDTModifyCreator        uint64
-	ESDTNFTCreate            uint64
-	ESDTNFTRecreate          uint64
-	ESDTNFTUpdate            uint64
-	ESDTNFTAddQuantity       uint64
-	ESDTNFTBurn              uint64
-	ESDTNFTTransfer          uint64
-	ESDTNFTChangeCreateOwner uint64
-	ESDTNFTMultiTransfer     uint64
-	ESDTNFTAddURI            uint64
-	ESDTNFTSetNewURIs        uint64
-	ESDTNFTUpdateAttributes  uint64
-	SetGuardian              uint64
-	GuardAccount             uint64
-	TrieLoadPerNode          uint64
-	TrieStorePerNode         uint64
+	ChangeOwnerAddress           uint64
+	ClaimDeveloperRewards        uint64
+	SaveUserName                 uint64
+	SaveKeyValue                 uint64
+	ESDTTransfer                 uint64
+	ESDTBurn                     uint64
+	ESDTLocalMint                uint64
+	ESDTLocalBurn                uint64
+	ESDTModifyRoyalties          uint64
+	ESDTModifyCreator            uint64
+	ESDTNFTCreate                uint64
+	ESDTNFTRecreate              uint64
+	ESDTNFTUpdate                uint64
+	ESDTNFTAddQuantity           uint64
+	ESDTNFTBurn                  uint64
+	ESDTNFTTransfer              uint64
+	ESDTNFTChangeCreateOwner     uint64
+	ESDTNFTMultiTransfer         uint64
+	ESDTNFTAddURI                uint64
+	ESDTNFTSetNewURIs            uint64
+	ESDTNFTUpdateAttributes      uint64
+	CrossChainWhiteList          uint64
+	ESDTBridgeDeposit            uint64
+	ESDTBridgeWithdraw           uint64
+	ESDTNativeIssue              uint64
+	ESDTNativeSetTokenProperties uint64
+	ESDTNativeTransferOwnership  uint64
+	ESDTSetMaxSupply             uint64
+	ESDTVestingTransfer          uint64
+	ESDTReleaseVesting           uint64
+	ESDTSetTransferFee           uint64
+	FreezeAccount                uint64
+	SetGuardianSet               uint64
+	SetGuardedSpendingPolicy     uint64
+	OwnerAddressTransfer         uint64
+	SetDeveloperRewardsSplit     uint64
+	RenewUserName                uint64
+	TransferUserName             uint64
+	SetGuardian                  uint64
+	GuardAccount                 uint64
+	TrieLoadPerNode              uint64
+	TrieStorePerNode             uint64
 }
 
 // GasCost holds all the needed gas costs for system smart contracts
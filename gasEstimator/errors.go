@@ -0,0 +1,23 @@
+package gasEstimator
+
+import (
+	"errors"
+)
+
+// ErrNilGasCost signals that a nil gas cost has been provided
+var ErrNilGasCost = errors.New("nil gas cost")
+
+// ErrNilContractCallInput signals that a nil contract call input has been provided
+var ErrNilContractCallInput = errors.New("nil contract call input")
+
+// ErrNilFunctionGasEstimator signals that a nil function gas estimator has been provided
+var ErrNilFunctionGasEstimator = errors.New("nil function gas estimator")
+
+// ErrEmptyFunctionName signals that an empty function name has been provided
+var ErrEmptyFunctionName = errors.New("empty function name")
+
+// ErrUnknownBuiltInFunction signals that there is no gas estimator for the called function
+var ErrUnknownBuiltInFunction = errors.New("unknown built-in function")
+
+// ErrInvalidNumberOfArguments signals that the call does not have enough arguments to estimate its gas
+var ErrInvalidNumberOfArguments = errors.New("invalid number of arguments")
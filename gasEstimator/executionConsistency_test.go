@@ -0,0 +1,319 @@
+package gasEstimator
+
+import (
+	"bytes"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/multiversx/mx-chain-vm-common-go/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	numRandomCalls = 50
+	gasProvided    = uint64(1_000_000_000)
+)
+
+var (
+	senderAddress   = []byte("12345678901234567890123456789012")
+	receiverAddress = []byte("12345678901234567890123456789022")
+)
+
+type executionContext struct {
+	adb       vmcommon.AccountsAdapter
+	container vmcommon.BuiltInFunctionContainer
+	estimator *gasEstimator
+	gasCost   *vmcommon.GasCost
+	rnd       *rand.Rand
+}
+
+// createGasMap assigns a distinct cost to every gas schedule entry, so that a wrong entry used by the estimator
+// can not go unnoticed
+func createGasMap() map[string]map[string]uint64 {
+	return map[string]map[string]uint64{
+		core.BaseOperationCostString: createGasMapFromStruct(vmcommon.BaseOperationCost{}, 1),
+		core.BuiltInCostString:       createGasMapFromStruct(vmcommon.BuiltInCost{}, 1000),
+	}
+}
+
+func createGasMapFromStruct(costs interface{}, step uint64) map[string]uint64 {
+	gasMap := make(map[string]uint64)
+	costsType := reflect.TypeOf(costs)
+	for i := 0; i < costsType.NumField(); i++ {
+		gasMap[costsType.Field(i).Name] = uint64(i+1) * step
+	}
+
+	return gasMap
+}
+
+func createGasCost() *vmcommon.GasCost {
+	gasCost := &vmcommon.GasCost{}
+	fillStructFromGasMap(&gasCost.BaseOperationCost, createGasMapFromStruct(vmcommon.BaseOperationCost{}, 1))
+	fillStructFromGasMap(&gasCost.BuiltInCost, createGasMapFromStruct(vmcommon.BuiltInCost{}, 1000))
+
+	return gasCost
+}
+
+func fillStructFromGasMap(costs interface{}, gasMap map[string]uint64) {
+	costsValue := reflect.ValueOf(costs).Elem()
+	for name, value := range gasMap {
+		costsValue.FieldByName(name).SetUint(value)
+	}
+}
+
+func createExecutionContext(t *testing.T) *executionContext {
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return true
+		},
+	}
+	adb, err := state.NewAccountsAdapter(state.ArgsNewAccountsAdapter{
+		EnableEpochsHandler: enableEpochsHandler,
+	})
+	require.Nil(t, err)
+
+	creator, err := builtInFunctions.NewBuiltInFunctionsCreator(builtInFunctions.ArgsCreateBuiltInFunctionContainer{
+		GasMap:                            createGasMap(),
+		MapDNSAddresses:                   make(map[string]struct{}),
+		MapDNSV2Addresses:                 make(map[string]struct{}),
+		MapWhiteListedCrossChainAddresses: map[string]struct{}{"whiteListedAddress": {}},
+		Marshalizer:                       &mock.MarshalizerMock{},
+		Accounts:                          adb,
+		ShardCoordinator:                  mock.NewMultiShardsCoordinatorMock(1),
+		EnableEpochsHandler:               enableEpochsHandler,
+		GuardedAccountHandler:             &mock.GuardedAccountHandlerStub{},
+		MaxNumOfAddressesForTransferRole:  100,
+	})
+	require.Nil(t, err)
+	require.Nil(t, creator.CreateBuiltInFunctionContainer())
+	require.Nil(t, creator.SetBlockchainHook(&mock.BlockDataHandlerStub{}))
+	require.Nil(t, creator.SetPayableHandler(&mock.PayableHandlerStub{}))
+
+	gasCost := createGasCost()
+	estimator, err := NewGasEstimator(ArgsNewGasEstimator{GasCost: gasCost})
+	require.Nil(t, err)
+
+	return &executionContext{
+		adb:       adb,
+		container: creator.BuiltInFunctionContainer(),
+		estimator: estimator,
+		gasCost:   gasCost,
+		rnd:       rand.New(rand.NewSource(1)),
+	}
+}
+
+func (ec *executionContext) loadUserAccount(t *testing.T, address []byte) vmcommon.UserAccountHandler {
+	account, err := ec.adb.LoadAccount(address)
+	require.Nil(t, err)
+
+	return account.(vmcommon.UserAccountHandler)
+}
+
+// execute runs the built-in function and returns the gas it consumed, without the gas forwarded to the receivers
+func (ec *executionContext) execute(t *testing.T, input *vmcommon.ContractCallInput) uint64 {
+	function, err := ec.container.Get(input.Function)
+	require.Nil(t, err)
+
+	sender := ec.loadUserAccount(t, input.CallerAddr)
+	receiver := sender
+	if !bytes.Equal(input.CallerAddr, input.RecipientAddr) {
+		receiver = ec.loadUserAccount(t, input.RecipientAddr)
+	}
+
+	vmOutput, err := function.ProcessBuiltinFunction(sender, receiver, input)
+	require.Nil(t, err, input.Function)
+	require.Nil(t, ec.adb.SaveAccount(sender))
+	require.Nil(t, ec.adb.SaveAccount(receiver))
+
+	consumed := input.GasProvided - vmOutput.GasRemaining
+	for _, outputAccount := range vmOutput.OutputAccounts {
+		for _, outputTransfer := range outputAccount.OutputTransfers {
+			consumed -= outputTransfer.GasLimit
+		}
+	}
+
+	return consumed
+}
+
+func (ec *executionContext) estimate(t *testing.T, input *vmcommon.ContractCallInput) *GasBreakdown {
+	breakdown, err := ec.estimator.EstimateGas(input)
+	require.Nil(t, err, input.Function)
+
+	return breakdown
+}
+
+func (ec *executionContext) setRoles(t *testing.T, token []byte, roles ...string) {
+	args := [][]byte{token}
+	for _, role := range roles {
+		args = append(args, []byte(role))
+	}
+
+	ec.execute(t, createInput(core.ESDTSCAddress, senderAddress, core.BuiltInFunctionSetESDTRole, args...))
+}
+
+func (ec *executionContext) randomBytes(minLength int, maxLength int) []byte {
+	buff := make([]byte, minLength+ec.rnd.Intn(maxLength-minLength+1))
+	_, _ = ec.rnd.Read(buff)
+
+	return buff
+}
+
+func (ec *executionContext) randomNFTCreateArgs(token []byte) [][]byte {
+	args := [][]byte{
+		token,
+		big.NewInt(int64(2 + ec.rnd.Intn(100))).Bytes(),
+		ec.randomBytes(1, 32),
+		big.NewInt(int64(ec.rnd.Intn(int(core.MaxRoyalty)))).Bytes(),
+		ec.randomBytes(0, 32),
+		ec.randomBytes(0, 64),
+	}
+	numURIs := 1 + ec.rnd.Intn(3)
+	for i := 0; i < numURIs; i++ {
+		args = append(args, ec.randomBytes(1, 64))
+	}
+
+	return args
+}
+
+func createInput(caller []byte, recipient []byte, function string, args ...[]byte) *vmcommon.ContractCallInput {
+	return &vmcommon.ContractCallInput{
+		VMInput: vmcommon.VMInput{
+			CallerAddr:  caller,
+			CallValue:   big.NewInt(0),
+			GasProvided: gasProvided,
+			Arguments:   args,
+		},
+		RecipientAddr: recipient,
+		Function:      function,
+	}
+}
+
+func TestGasEstimator_ShouldCoverAllBuiltInFunctions(t *testing.T) {
+	t.Parallel()
+
+	ec := createExecutionContext(t)
+	for function := range ec.container.Keys() {
+		_, found := ec.estimator.estimators[function]
+		assert.True(t, found, "missing gas estimator for %s", function)
+	}
+}
+
+func TestGasEstimator_ConsistentWithExecution(t *testing.T) {
+	t.Parallel()
+
+	ec := createExecutionContext(t)
+	marshaller := &mock.MarshalizerMock{}
+
+	fungibleToken := []byte("FNG-abcdef")
+	fungibleTokenKey := append([]byte(core.ProtectedKeyPrefix+core.ESDTKeyIdentifier), fungibleToken...)
+	sender := ec.loadUserAccount(t, senderAddress)
+	marshalledData, _ := marshaller.Marshal(&esdt.ESDigitalToken{Value: big.NewInt(1_000_000)})
+	require.Nil(t, sender.AccountDataHandler().SaveKeyValue(fungibleTokenKey, marshalledData))
+	require.Nil(t, ec.adb.SaveAccount(sender))
+
+	nftToken := []byte("NFT-abcdef")
+	ec.setRoles(t, nftToken, core.ESDTRoleNFTCreate, core.ESDTRoleNFTAddQuantity, core.ESDTRoleNFTUpdateAttributes, core.ESDTRoleNFTAddURI,
+		core.ESDTRoleSetNewURI, core.ESDTRoleModifyCreator)
+	ec.setRoles(t, fungibleToken, core.ESDTRoleLocalMint, core.ESDTRoleLocalBurn)
+
+	assertExact := func(t *testing.T, input *vmcommon.ContractCallInput) {
+		breakdown := ec.estimate(t, input)
+		require.True(t, breakdown.IsExact, input.Function)
+		assert.Equal(t, breakdown.Total(), ec.execute(t, input), input.Function)
+	}
+	assertUpperBound := func(t *testing.T, input *vmcommon.ContractCallInput) {
+		breakdown := ec.estimate(t, input)
+		require.False(t, breakdown.IsExact, input.Function)
+		assert.LessOrEqual(t, ec.execute(t, input), breakdown.Total(), input.Function)
+	}
+	assertWithoutDataCopy := func(t *testing.T, input *vmcommon.ContractCallInput) {
+		breakdown := ec.estimate(t, input)
+		require.False(t, breakdown.IsExact, input.Function)
+		consumed := ec.execute(t, input)
+		require.GreaterOrEqual(t, consumed, breakdown.Total(), input.Function)
+		assert.Zero(t, (consumed-breakdown.Total())%ec.gasCost.BaseOperationCost.DataCopyPerByte, input.Function)
+	}
+
+	t.Run("fungible transfers", func(t *testing.T) {
+		for i := 0; i < numRandomCalls; i++ {
+			value := big.NewInt(int64(1 + ec.rnd.Intn(100))).Bytes()
+			assertExact(t, createInput(senderAddress, receiverAddress, core.BuiltInFunctionESDTTransfer, fungibleToken, value))
+
+			numTransfers := 1 + ec.rnd.Intn(5)
+			args := [][]byte{receiverAddress, big.NewInt(int64(numTransfers)).Bytes()}
+			for j := 0; j < numTransfers; j++ {
+				args = append(args, fungibleToken, big.NewInt(0).Bytes(), big.NewInt(int64(1+ec.rnd.Intn(100))).Bytes())
+			}
+			assertExact(t, createInput(senderAddress, senderAddress, core.BuiltInFunctionMultiESDTNFTTransfer, args...))
+		}
+	})
+	t.Run("local mint and burn", func(t *testing.T) {
+		for i := 0; i < numRandomCalls; i++ {
+			value := big.NewInt(int64(1 + ec.rnd.Intn(100))).Bytes()
+			assertExact(t, createInput(senderAddress, senderAddress, core.BuiltInFunctionESDTLocalMint, fungibleToken, value))
+			assertExact(t, createInput(senderAddress, senderAddress, core.BuiltInFunctionESDTLocalBurn, fungibleToken, value))
+		}
+	})
+	t.Run("save key value", func(t *testing.T) {
+		keys := make([][]byte, 0)
+		for i := 0; i < numRandomCalls; i++ {
+			key := append([]byte("key"), big.NewInt(int64(i)).Bytes()...)
+			keys = append(keys, key)
+			assertUpperBound(t, createInput(senderAddress, senderAddress, core.BuiltInFunctionSaveKeyValue, key, ec.randomBytes(0, 64)))
+		}
+
+		for i := 0; i < numRandomCalls; i++ {
+			args := make([][]byte, 0)
+			numPairs := 1 + ec.rnd.Intn(4)
+			for j := 0; j < numPairs; j++ {
+				args = append(args, keys[ec.rnd.Intn(len(keys))], ec.randomBytes(0, 64))
+			}
+			assertUpperBound(t, createInput(senderAddress, senderAddress, core.BuiltInFunctionSaveKeyValue, args...))
+		}
+	})
+	t.Run("developer rewards", func(t *testing.T) {
+		contractAddress := make([]byte, 32)
+		contractAddress[31] = 1
+		contract := ec.loadUserAccount(t, contractAddress)
+		contract.SetOwnerAddress(senderAddress)
+		require.Nil(t, ec.adb.SaveAccount(contract))
+
+		for i := 0; i < numRandomCalls; i++ {
+			args := make([][]byte, 0)
+			numBeneficiaries := ec.rnd.Intn(5)
+			for j := 0; j < numBeneficiaries; j++ {
+				args = append(args, bytes.Repeat([]byte{byte(j + 2)}, 32), big.NewInt(int64(1+ec.rnd.Intn(2000))).Bytes())
+			}
+			assertExact(t, createInput(senderAddress, contractAddress, vmcommon.BuiltInFunctionSetDeveloperRewardsSplit, args...))
+
+			contract = ec.loadUserAccount(t, contractAddress)
+			contract.(interface{ AddToDeveloperReward(*big.Int) }).AddToDeveloperReward(big.NewInt(int64(1 + ec.rnd.Intn(1000))))
+			require.Nil(t, ec.adb.SaveAccount(contract))
+			assertUpperBound(t, createInput(senderAddress, contractAddress, core.BuiltInFunctionClaimDeveloperRewards))
+		}
+	})
+	t.Run("non fungible tokens", func(t *testing.T) {
+		for nonce := int64(1); nonce <= numRandomCalls; nonce++ {
+			nonceBytes := big.NewInt(nonce).Bytes()
+			assertExact(t, createInput(senderAddress, senderAddress, core.BuiltInFunctionESDTNFTCreate, ec.randomNFTCreateArgs(nftToken)...))
+			assertExact(t, createInput(senderAddress, senderAddress, core.BuiltInFunctionESDTNFTUpdateAttributes, nftToken, nonceBytes, ec.randomBytes(0, 64)))
+			assertExact(t, createInput(senderAddress, senderAddress, core.BuiltInFunctionESDTNFTAddURI, nftToken, nonceBytes, ec.randomBytes(1, 64), ec.randomBytes(1, 64)))
+			assertUpperBound(t, createInput(senderAddress, senderAddress, core.ESDTSetNewURIs, nftToken, nonceBytes, ec.randomBytes(1, 64), ec.randomBytes(1, 64)))
+			assertExact(t, createInput(senderAddress, senderAddress, core.ESDTModifyCreator, nftToken, nonceBytes))
+
+			transferArgs := [][]byte{nftToken, nonceBytes, big.NewInt(1).Bytes(), receiverAddress}
+			assertWithoutDataCopy(t, createInput(senderAddress, senderAddress, core.BuiltInFunctionESDTNFTTransfer, transferArgs...))
+
+			multiTransferArgs := [][]byte{receiverAddress, big.NewInt(2).Bytes(), nftToken, nonceBytes, big.NewInt(1).Bytes(), fungibleToken, big.NewInt(0).Bytes(), big.NewInt(1).Bytes()}
+			assertWithoutDataCopy(t, createInput(senderAddress, senderAddress, core.BuiltInFunctionMultiESDTNFTTransfer, multiTransferArgs...))
+		}
+	})
+}
@@ -0,0 +1,329 @@
+package gasEstimator
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const (
+	deleteUserNameFuncName = "DeleteUserName"
+
+	argumentsPerTransfer           = 3
+	minNumArgsMultiTransfer        = 2
+	minNumArgsNFTTransfer          = 4
+	minNumArgsNFTCreate            = 7
+	minNumArgsMetaDataRecreate     = 7
+	minNumArgsNFTURIs              = 3
+	numArgsUpdateAttributes        = 3
+	numArgsPairSaveKeyValue        = 2
+	indexAttributesUpdate          = 2
+	indexURIsStart                 = 2
+	indexNumTransfersMultiTransfer = 1
+
+	maxNumDeveloperRewardsBeneficiaries   = 20
+	numArgsPerDeveloperRewardsBeneficiary = 2
+	developerRewardsShareLength           = 2
+)
+
+func createBuiltInEstimators() map[string]FunctionGasEstimator {
+	return map[string]FunctionGasEstimator{
+		core.BuiltInFunctionClaimDeveloperRewards:             estimateClaimDeveloperRewards,
+		core.BuiltInFunctionChangeOwnerAddress:                fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.ChangeOwnerAddress }),
+		core.BuiltInFunctionSetUserName:                       fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.SaveUserName }),
+		deleteUserNameFuncName:                                fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.SaveUserName }),
+		core.BuiltInFunctionSaveKeyValue:                      estimateSaveKeyValue,
+		core.BuiltInFunctionESDTTransfer:                      fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.ESDTTransfer }),
+		core.BuiltInFunctionESDTBurn:                          fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.ESDTBurn }),
+		core.BuiltInFunctionESDTLocalMint:                     fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.ESDTLocalMint }),
+		core.BuiltInFunctionESDTLocalBurn:                     fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.ESDTLocalBurn }),
+		core.BuiltInFunctionESDTNFTAddQuantity:                fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.ESDTNFTAddQuantity }),
+		core.BuiltInFunctionESDTNFTBurn:                       fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.ESDTNFTBurn }),
+		core.BuiltInFunctionESDTNFTCreate:                     estimateNFTCreate,
+		core.BuiltInFunctionESDTNFTTransfer:                   estimateNFTTransfer,
+		core.BuiltInFunctionMultiESDTNFTTransfer:              estimateMultiTransfer,
+		core.BuiltInFunctionESDTNFTUpdateAttributes:           estimateUpdateAttributes,
+		core.BuiltInFunctionESDTNFTAddURI:                     estimateAddURIs,
+		core.ESDTMetaDataRecreate:                             estimateMetaDataRecreate,
+		core.ESDTMetaDataUpdate:                               estimateMetaDataUpdate,
+		core.ESDTSetNewURIs:                                   estimateSetNewURIs,
+		core.ESDTModifyRoyalties:                              fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.ESDTModifyRoyalties }),
+		core.ESDTModifyCreator:                                fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.ESDTModifyRoyalties }), // created with the ESDTModifyRoyalties cost
+		core.BuiltInFunctionSetGuardian:                       fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.SetGuardian }),
+		core.BuiltInFunctionGuardAccount:                      fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.GuardAccount }),
+		core.BuiltInFunctionUnGuardAccount:                    fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.GuardAccount }),
+		core.BuiltInFunctionMigrateDataTrie:                   estimateMigrateDataTrie,
+		core.BuiltInFunctionESDTFreeze:                        estimateFree,
+		core.BuiltInFunctionESDTUnFreeze:                      estimateFree,
+		core.BuiltInFunctionESDTWipe:                          estimateFree,
+		core.BuiltInFunctionESDTPause:                         estimateFree,
+		core.BuiltInFunctionESDTUnPause:                       estimateFree,
+		core.BuiltInFunctionSetESDTRole:                       estimateFree,
+		core.BuiltInFunctionUnSetESDTRole:                     estimateFree,
+		core.BuiltInFunctionESDTSetLimitedTransfer:            estimateFree,
+		core.BuiltInFunctionESDTUnSetLimitedTransfer:          estimateFree,
+		core.BuiltInFunctionESDTNFTCreateRoleTransfer:         estimateFree,
+		core.ESDTSetTokenType:                                 estimateFree,
+		vmcommon.ESDTDeleteMetadata:                           estimateFree,
+		vmcommon.ESDTAddMetadata:                              estimateFree,
+		vmcommon.BuiltInFunctionESDTSetBurnRoleForAll:         estimateFree,
+		vmcommon.BuiltInFunctionESDTUnSetBurnRoleForAll:       estimateFree,
+		vmcommon.BuiltInFunctionESDTTransferRoleAddAddress:    estimateFree,
+		vmcommon.BuiltInFunctionESDTTransferRoleDeleteAddress: estimateFree,
+		vmcommon.BuiltInFunctionESDTSetAllowListRequired:      estimateFree,
+		vmcommon.BuiltInFunctionESDTUnSetAllowListRequired:    estimateFree,
+		vmcommon.BuiltInFunctionESDTAllowListAddAddress:       estimateFree,
+		vmcommon.BuiltInFunctionESDTAllowListDeleteAddress:    estimateFree,
+		vmcommon.BuiltInFunctionESDTDenyListAddAddress:        estimateFree,
+		vmcommon.BuiltInFunctionESDTDenyListDeleteAddress:     estimateFree,
+
+		vmcommon.BuiltInFunctionAddCrossChainWhiteListedAddress:    estimateCrossChainWhiteList,
+		vmcommon.BuiltInFunctionRemoveCrossChainWhiteListedAddress: estimateCrossChainWhiteList,
+		vmcommon.BuiltInFunctionESDTBridgeDeposit:                  fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.ESDTBridgeDeposit }),
+		vmcommon.BuiltInFunctionESDTBridgeWithdraw:                 fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.ESDTBridgeWithdraw }),
+		vmcommon.BuiltInFunctionESDTNativeIssue:                    fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.ESDTNativeIssue }),
+		vmcommon.BuiltInFunctionESDTNativeIssueCollection:          fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.ESDTNativeIssue }),
+		vmcommon.BuiltInFunctionESDTNativeSetTokenProperties:       fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.ESDTNativeSetTokenProperties }),
+		vmcommon.BuiltInFunctionESDTNativeTransferOwnership:        fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.ESDTNativeTransferOwnership }),
+		vmcommon.BuiltInFunctionESDTSetMaxSupply:                   fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.ESDTSetMaxSupply }),
+		vmcommon.BuiltInFunctionESDTVestingTransfer:                fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.ESDTVestingTransfer }),
+		vmcommon.BuiltInFunctionESDTReleaseVesting:                 fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.ESDTReleaseVesting }),
+		vmcommon.BuiltInFunctionESDTSetTransferFee:                 fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.ESDTSetTransferFee }),
+		vmcommon.BuiltInFunctionFreezeAccount:                      fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.FreezeAccount }),
+		vmcommon.BuiltInFunctionUnFreezeAccount:                    fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.FreezeAccount }),
+		vmcommon.BuiltInFunctionSetGuardianSet:                     fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.SetGuardianSet }),
+		vmcommon.BuiltInFunctionSetGuardedSpendingPolicy:           fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.SetGuardedSpendingPolicy }),
+		vmcommon.BuiltInFunctionProposeOwnerAddress:                fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.OwnerAddressTransfer }),
+		vmcommon.BuiltInFunctionAcceptOwnerAddress:                 fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.OwnerAddressTransfer }),
+		vmcommon.BuiltInFunctionCancelOwnerAddressProposal:         fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.OwnerAddressTransfer }),
+		vmcommon.BuiltInFunctionSetDeveloperRewardsSplit:           estimateSetDeveloperRewardsSplit,
+		vmcommon.BuiltInFunctionRenewUserName:                      fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.RenewUserName }),
+		vmcommon.BuiltInFunctionTransferUserName:                   fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return 2 * c.TransferUserName }),
+		vmcommon.BuiltInFunctionApproveUserNameTransfer:            fixedCostEstimator(func(c *vmcommon.BuiltInCost) uint64 { return c.TransferUserName }),
+	}
+}
+
+// fixedCostEstimator creates an estimator for the functions which only charge their own cost from the built-in schedule
+func fixedCostEstimator(getCost func(builtInCost *vmcommon.BuiltInCost) uint64) FunctionGasEstimator {
+	return func(_ *vmcommon.ContractCallInput, gasCost *vmcommon.GasCost) (*GasBreakdown, error) {
+		return &GasBreakdown{
+			BaseCost: getCost(&gasCost.BuiltInCost),
+			IsExact:  true,
+		}, nil
+	}
+}
+
+// estimateFree is used for the functions called by the system smart contracts, which do not charge gas
+func estimateFree(_ *vmcommon.ContractCallInput, _ *vmcommon.GasCost) (*GasBreakdown, error) {
+	return &GasBreakdown{IsExact: true}, nil
+}
+
+// estimateSaveKeyValue charges the persist cost for every pair and the store cost for the stored values. The
+// execution only charges the store cost for the bytes added over the previously stored values
+func estimateSaveKeyValue(input *vmcommon.ContractCallInput, gasCost *vmcommon.GasCost) (*GasBreakdown, error) {
+	if len(input.Arguments) < numArgsPairSaveKeyValue || len(input.Arguments)%numArgsPairSaveKeyValue != 0 {
+		return nil, fmt.Errorf("%w, expected key value pairs, got %d arguments", ErrInvalidNumberOfArguments, len(input.Arguments))
+	}
+
+	persistLength := uint64(0)
+	storeLength := uint64(0)
+	for i := 0; i < len(input.Arguments); i += numArgsPairSaveKeyValue {
+		persistLength += uint64(len(input.Arguments[i]) + len(input.Arguments[i+1]))
+		storeLength += uint64(len(input.Arguments[i+1]))
+	}
+
+	return &GasBreakdown{
+		BaseCost:    gasCost.BuiltInCost.SaveKeyValue,
+		PersistCost: persistLength * gasCost.BaseOperationCost.PersistPerByte,
+		StoreCost:   storeLength * gasCost.BaseOperationCost.StorePerByte,
+	}, nil
+}
+
+// estimateClaimDeveloperRewards charges the loading and saving of an account for the maximum number of beneficiaries
+// of the developer rewards split. The execution only charges them for the beneficiaries of the split of the contract
+func estimateClaimDeveloperRewards(_ *vmcommon.ContractCallInput, gasCost *vmcommon.GasCost) (*GasBreakdown, error) {
+	return &GasBreakdown{
+		BaseCost:      gasCost.BuiltInCost.ClaimDeveloperRewards,
+		TrieLoadCost:  maxNumDeveloperRewardsBeneficiaries * gasCost.BuiltInCost.TrieLoadPerNode,
+		TrieStoreCost: maxNumDeveloperRewardsBeneficiaries * gasCost.BuiltInCost.TrieStorePerNode,
+	}, nil
+}
+
+// estimateSetDeveloperRewardsSplit charges the persist cost for the stored split, which holds every beneficiary
+// address prefixed by its length and followed by its share
+func estimateSetDeveloperRewardsSplit(input *vmcommon.ContractCallInput, gasCost *vmcommon.GasCost) (*GasBreakdown, error) {
+	if len(input.Arguments)%numArgsPerDeveloperRewardsBeneficiary != 0 {
+		return nil, fmt.Errorf("%w, expected pairs of beneficiary and share, got %d arguments", ErrInvalidNumberOfArguments, len(input.Arguments))
+	}
+
+	persistLength := uint64(0)
+	for i := 0; i < len(input.Arguments); i += numArgsPerDeveloperRewardsBeneficiary {
+		persistLength += uint64(1 + len(input.Arguments[i]) + developerRewardsShareLength)
+	}
+
+	return &GasBreakdown{
+		BaseCost:    gasCost.BuiltInCost.SetDeveloperRewardsSplit,
+		PersistCost: persistLength * gasCost.BaseOperationCost.PersistPerByte,
+		IsExact:     true,
+	}, nil
+}
+
+// estimateCrossChainWhiteList charges the persist cost for every address
+func estimateCrossChainWhiteList(input *vmcommon.ContractCallInput, gasCost *vmcommon.GasCost) (*GasBreakdown, error) {
+	return &GasBreakdown{
+		BaseCost:    gasCost.BuiltInCost.CrossChainWhiteList,
+		PersistCost: lenArgs(input.Arguments) * gasCost.BaseOperationCost.PersistPerByte,
+		IsExact:     true,
+	}, nil
+}
+
+func estimateNFTCreate(input *vmcommon.ContractCallInput, gasCost *vmcommon.GasCost) (*GasBreakdown, error) {
+	err := checkMinNumArguments(input, minNumArgsNFTCreate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GasBreakdown{
+		BaseCost:  gasCost.BuiltInCost.ESDTNFTCreate,
+		StoreCost: lenArgs(input.Arguments) * gasCost.BaseOperationCost.StorePerByte,
+		IsExact:   true,
+	}, nil
+}
+
+// estimateNFTTransfer does not include the data copy cost of the token, as the transferred token data is read
+// from the sender account
+func estimateNFTTransfer(input *vmcommon.ContractCallInput, gasCost *vmcommon.GasCost) (*GasBreakdown, error) {
+	err := checkMinNumArguments(input, minNumArgsNFTTransfer)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GasBreakdown{
+		BaseCost: gasCost.BuiltInCost.ESDTNFTTransfer,
+	}, nil
+}
+
+// estimateMultiTransfer charges the transfer cost once for every transferred token. The data copy cost of the
+// transferred NFTs is not included
+func estimateMultiTransfer(input *vmcommon.ContractCallInput, gasCost *vmcommon.GasCost) (*GasBreakdown, error) {
+	err := checkMinNumArguments(input, minNumArgsMultiTransfer)
+	if err != nil {
+		return nil, err
+	}
+
+	numTransfers := big.NewInt(0).SetBytes(input.Arguments[indexNumTransfersMultiTransfer]).Uint64()
+	maxNumTransfers := uint64(len(input.Arguments)-minNumArgsMultiTransfer) / argumentsPerTransfer
+	if numTransfers == 0 || numTransfers > maxNumTransfers {
+		return nil, fmt.Errorf("%w for %d transfers", ErrInvalidNumberOfArguments, numTransfers)
+	}
+
+	hasNFTTransfers := false
+	for i := uint64(0); i < numTransfers; i++ {
+		nonceIndex := minNumArgsMultiTransfer + i*argumentsPerTransfer + 1
+		if big.NewInt(0).SetBytes(input.Arguments[nonceIndex]).Uint64() > 0 {
+			hasNFTTransfers = true
+		}
+	}
+
+	return &GasBreakdown{
+		TransferCost: numTransfers * gasCost.BuiltInCost.ESDTNFTMultiTransfer,
+		IsExact:      !hasNFTTransfers,
+	}, nil
+}
+
+func estimateUpdateAttributes(input *vmcommon.ContractCallInput, gasCost *vmcommon.GasCost) (*GasBreakdown, error) {
+	err := checkMinNumArguments(input, numArgsUpdateAttributes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GasBreakdown{
+		BaseCost:  gasCost.BuiltInCost.ESDTNFTUpdateAttributes,
+		StoreCost: uint64(len(input.Arguments[indexAttributesUpdate])) * gasCost.BaseOperationCost.StorePerByte,
+		IsExact:   true,
+	}, nil
+}
+
+func estimateAddURIs(input *vmcommon.ContractCallInput, gasCost *vmcommon.GasCost) (*GasBreakdown, error) {
+	err := checkMinNumArguments(input, minNumArgsNFTURIs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GasBreakdown{
+		BaseCost:  gasCost.BuiltInCost.ESDTNFTAddURI,
+		StoreCost: lenArgs(input.Arguments[indexURIsStart:]) * gasCost.BaseOperationCost.StorePerByte,
+		IsExact:   true,
+	}, nil
+}
+
+// estimateMetaDataRecreate charges the store cost for all the arguments. The execution only charges the store
+// cost for the bytes added over the size of the current metadata
+func estimateMetaDataRecreate(input *vmcommon.ContractCallInput, gasCost *vmcommon.GasCost) (*GasBreakdown, error) {
+	err := checkMinNumArguments(input, minNumArgsMetaDataRecreate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GasBreakdown{
+		BaseCost:  gasCost.BuiltInCost.ESDTNFTRecreate,
+		StoreCost: lenArgs(input.Arguments) * gasCost.BaseOperationCost.StorePerByte,
+	}, nil
+}
+
+// estimateMetaDataUpdate charges the store cost for all the arguments. The execution only charges the store
+// cost for the bytes added over the updated fields of the current metadata
+func estimateMetaDataUpdate(input *vmcommon.ContractCallInput, gasCost *vmcommon.GasCost) (*GasBreakdown, error) {
+	err := checkMinNumArguments(input, minNumArgsMetaDataRecreate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GasBreakdown{
+		BaseCost:  gasCost.BuiltInCost.ESDTNFTUpdate,
+		StoreCost: lenArgs(input.Arguments) * gasCost.BaseOperationCost.StorePerByte,
+	}, nil
+}
+
+// estimateSetNewURIs charges the store cost for all the new URIs. The execution only charges the store cost for
+// the bytes added over the current URIs. The base cost is the ESDTNFTRecreate cost the function is created with
+func estimateSetNewURIs(input *vmcommon.ContractCallInput, gasCost *vmcommon.GasCost) (*GasBreakdown, error) {
+	err := checkMinNumArguments(input, minNumArgsNFTURIs)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GasBreakdown{
+		BaseCost:  gasCost.BuiltInCost.ESDTNFTRecreate,
+		StoreCost: lenArgs(input.Arguments[indexURIsStart:]) * gasCost.BaseOperationCost.StorePerByte,
+	}, nil
+}
+
+// estimateMigrateDataTrie returns the cost of migrating one data trie node. The execution migrates nodes until
+// the data trie is migrated or the provided gas is consumed
+func estimateMigrateDataTrie(_ *vmcommon.ContractCallInput, gasCost *vmcommon.GasCost) (*GasBreakdown, error) {
+	return &GasBreakdown{
+		TrieLoadCost:  gasCost.BuiltInCost.TrieLoadPerNode,
+		TrieStoreCost: gasCost.BuiltInCost.TrieStorePerNode,
+	}, nil
+}
+
+func checkMinNumArguments(input *vmcommon.ContractCallInput, minimum int) error {
+	if len(input.Arguments) < minimum {
+		return fmt.Errorf("%w, expected at least %d, got %d", ErrInvalidNumberOfArguments, minimum, len(input.Arguments))
+	}
+
+	return nil
+}
+
+func lenArgs(args [][]byte) uint64 {
+	totalLength := uint64(0)
+	for _, arg := range args {
+		totalLength += uint64(len(arg))
+	}
+
+	return totalLength
+}
@@ -0,0 +1,105 @@
+package gasEstimator
+
+import (
+	"fmt"
+	"sync"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// GasBreakdown details the gas charged by a built-in function call, split by the gas schedule entries it uses
+type GasBreakdown struct {
+	BaseCost      uint64
+	TransferCost  uint64
+	PersistCost   uint64
+	StoreCost     uint64
+	DataCopyCost  uint64
+	TrieLoadCost  uint64
+	TrieStoreCost uint64
+	// IsExact is false when part of the charge depends on the state of the accounts: the store costs are then
+	// computed as if nothing was previously stored, the data copy of the token metadata sent along with the NFT
+	// transfers is not included, the trie costs are given for a single migrated node and the developer rewards claims
+	// are charged for the maximum number of beneficiaries
+	IsExact bool
+}
+
+// Total returns the sum of all the costs in the breakdown
+func (gb *GasBreakdown) Total() uint64 {
+	return gb.BaseCost + gb.TransferCost + gb.PersistCost + gb.StoreCost + gb.DataCopyCost + gb.TrieLoadCost + gb.TrieStoreCost
+}
+
+// FunctionGasEstimator estimates the gas charged by one built-in function for the provided input
+type FunctionGasEstimator func(input *vmcommon.ContractCallInput, gasCost *vmcommon.GasCost) (*GasBreakdown, error)
+
+// ArgsNewGasEstimator defines the arguments needed to create a new built-in functions gas estimator
+type ArgsNewGasEstimator struct {
+	GasCost *vmcommon.GasCost
+}
+
+type gasEstimator struct {
+	mutEstimator sync.RWMutex
+	gasCost      *vmcommon.GasCost
+	estimators   map[string]FunctionGasEstimator
+}
+
+// NewGasEstimator creates a new gas estimator which computes, from the call input alone, the gas that the
+// built-in functions charge on execution
+func NewGasEstimator(args ArgsNewGasEstimator) (*gasEstimator, error) {
+	if args.GasCost == nil {
+		return nil, ErrNilGasCost
+	}
+
+	return &gasEstimator{
+		gasCost:    args.GasCost,
+		estimators: createBuiltInEstimators(),
+	}, nil
+}
+
+// EstimateGas returns the gas breakdown for the provided built-in function call
+func (ge *gasEstimator) EstimateGas(input *vmcommon.ContractCallInput) (*GasBreakdown, error) {
+	if input == nil {
+		return nil, ErrNilContractCallInput
+	}
+
+	ge.mutEstimator.RLock()
+	estimator, found := ge.estimators[input.Function]
+	gasCost := ge.gasCost
+	ge.mutEstimator.RUnlock()
+	if !found {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownBuiltInFunction, input.Function)
+	}
+
+	return estimator(input, gasCost)
+}
+
+// RegisterEstimator adds or replaces the gas estimator of the provided function
+func (ge *gasEstimator) RegisterEstimator(function string, estimator FunctionGasEstimator) error {
+	if len(function) == 0 {
+		return ErrEmptyFunctionName
+	}
+	if estimator == nil {
+		return ErrNilFunctionGasEstimator
+	}
+
+	ge.mutEstimator.Lock()
+	ge.estimators[function] = estimator
+	ge.mutEstimator.Unlock()
+
+	return nil
+}
+
+// SetNewGasConfig is called whenever gas cost is changed
+func (ge *gasEstimator) SetNewGasConfig(gasCost *vmcommon.GasCost) {
+	if gasCost == nil {
+		return
+	}
+
+	ge.mutEstimator.Lock()
+	ge.gasCost = gasCost
+	ge.mutEstimator.Unlock()
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (ge *gasEstimator) IsInterfaceNil() bool {
+	return ge == nil
+}
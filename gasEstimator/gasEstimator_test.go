@@ -0,0 +1,107 @@
+package gasEstimator
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGasEstimator(t *testing.T) {
+	t.Parallel()
+
+	estimator, err := NewGasEstimator(ArgsNewGasEstimator{})
+	assert.Nil(t, estimator)
+	assert.Equal(t, ErrNilGasCost, err)
+
+	estimator, err = NewGasEstimator(ArgsNewGasEstimator{GasCost: &vmcommon.GasCost{}})
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(estimator))
+}
+
+func TestGasEstimator_EstimateGas(t *testing.T) {
+	t.Parallel()
+
+	gasCost := &vmcommon.GasCost{
+		BaseOperationCost: vmcommon.BaseOperationCost{StorePerByte: 2, PersistPerByte: 3},
+		BuiltInCost:       vmcommon.BuiltInCost{SaveKeyValue: 100, ESDTNFTMultiTransfer: 50, ESDTNFTCreate: 70},
+	}
+	estimator, _ := NewGasEstimator(ArgsNewGasEstimator{GasCost: gasCost})
+
+	breakdown, err := estimator.EstimateGas(nil)
+	assert.Nil(t, breakdown)
+	assert.Equal(t, ErrNilContractCallInput, err)
+
+	breakdown, err = estimator.EstimateGas(&vmcommon.ContractCallInput{Function: "unknown"})
+	assert.Nil(t, breakdown)
+	assert.True(t, errors.Is(err, ErrUnknownBuiltInFunction))
+
+	breakdown, err = estimator.EstimateGas(&vmcommon.ContractCallInput{
+		VMInput:  vmcommon.VMInput{Arguments: [][]byte{[]byte("key"), []byte("value"), []byte("k")}},
+		Function: core.BuiltInFunctionSaveKeyValue,
+	})
+	assert.Nil(t, breakdown)
+	assert.True(t, errors.Is(err, ErrInvalidNumberOfArguments))
+
+	breakdown, err = estimator.EstimateGas(&vmcommon.ContractCallInput{
+		VMInput:  vmcommon.VMInput{Arguments: [][]byte{[]byte("key"), []byte("value")}},
+		Function: core.BuiltInFunctionSaveKeyValue,
+	})
+	require.Nil(t, err)
+	assert.Equal(t, &GasBreakdown{BaseCost: 100, PersistCost: 24, StoreCost: 10}, breakdown)
+	assert.Equal(t, uint64(134), breakdown.Total())
+
+	multiTransferArgs := [][]byte{[]byte("receiver"), {2}, []byte("TKN-abcdef"), {}, {1}, []byte("NFT-abcdef"), {1}, {1}}
+	breakdown, err = estimator.EstimateGas(&vmcommon.ContractCallInput{
+		VMInput:  vmcommon.VMInput{Arguments: multiTransferArgs},
+		Function: core.BuiltInFunctionMultiESDTNFTTransfer,
+	})
+	require.Nil(t, err)
+	assert.Equal(t, &GasBreakdown{TransferCost: 100, IsExact: false}, breakdown)
+
+	breakdown, err = estimator.EstimateGas(&vmcommon.ContractCallInput{
+		VMInput:  vmcommon.VMInput{Arguments: multiTransferArgs[:5]},
+		Function: core.BuiltInFunctionMultiESDTNFTTransfer,
+	})
+	assert.Nil(t, breakdown)
+	assert.True(t, errors.Is(err, ErrInvalidNumberOfArguments))
+
+	breakdown, err = estimator.EstimateGas(&vmcommon.ContractCallInput{
+		VMInput:  vmcommon.VMInput{Arguments: [][]byte{[]byte("TKN-abcdef"), {1}, []byte("name"), {10}, []byte("hash"), []byte("attr"), []byte("uri")}},
+		Function: core.BuiltInFunctionESDTNFTCreate,
+	})
+	require.Nil(t, err)
+	assert.Equal(t, &GasBreakdown{BaseCost: 70, StoreCost: 54, IsExact: true}, breakdown)
+
+	estimator.SetNewGasConfig(nil)
+	estimator.SetNewGasConfig(&vmcommon.GasCost{BuiltInCost: vmcommon.BuiltInCost{ESDTNFTCreate: 7}})
+	breakdown, _ = estimator.EstimateGas(&vmcommon.ContractCallInput{
+		VMInput:  vmcommon.VMInput{Arguments: [][]byte{[]byte("TKN-abcdef"), {1}, []byte("name"), {10}, []byte("hash"), []byte("attr"), []byte("uri")}},
+		Function: core.BuiltInFunctionESDTNFTCreate,
+	})
+	assert.Equal(t, &GasBreakdown{BaseCost: 7, IsExact: true}, breakdown)
+}
+
+func TestGasEstimator_RegisterEstimator(t *testing.T) {
+	t.Parallel()
+
+	estimator, _ := NewGasEstimator(ArgsNewGasEstimator{GasCost: &vmcommon.GasCost{}})
+	customEstimator := func(input *vmcommon.ContractCallInput, _ *vmcommon.GasCost) (*GasBreakdown, error) {
+		return &GasBreakdown{BaseCost: uint64(len(input.Arguments)), IsExact: true}, nil
+	}
+
+	assert.Equal(t, ErrEmptyFunctionName, estimator.RegisterEstimator("", customEstimator))
+	assert.Equal(t, ErrNilFunctionGasEstimator, estimator.RegisterEstimator("custom", nil))
+	require.Nil(t, estimator.RegisterEstimator("custom", customEstimator))
+
+	breakdown, err := estimator.EstimateGas(&vmcommon.ContractCallInput{
+		VMInput:  vmcommon.VMInput{Arguments: [][]byte{{1}, {2}}},
+		Function: "custom",
+	})
+	require.Nil(t, err)
+	assert.Equal(t, uint64(2), breakdown.Total())
+}
@@ -0,0 +1,77 @@
+package gasSchedule
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GasCostChange describes how one gas cost differs between two gas schedules
+type GasCostChange struct {
+	Section   string
+	Name      string
+	OldValue  uint64
+	NewValue  uint64
+	IsAdded   bool
+	IsRemoved bool
+}
+
+// String returns a human-readable description of the change
+func (gcc *GasCostChange) String() string {
+	key := gcc.Section + "." + gcc.Name
+	switch {
+	case gcc.IsAdded:
+		return fmt.Sprintf("+ %s = %d", key, gcc.NewValue)
+	case gcc.IsRemoved:
+		return fmt.Sprintf("- %s = %d", key, gcc.OldValue)
+	default:
+		return fmt.Sprintf("~ %s: %d -> %d", key, gcc.OldValue, gcc.NewValue)
+	}
+}
+
+// Diff returns the gas costs which were added, removed or changed from the old gas schedule to the new one, sorted
+// by section and name
+func Diff(oldGasSchedule map[string]map[string]uint64, newGasSchedule map[string]map[string]uint64) []*GasCostChange {
+	changes := make([]*GasCostChange, 0)
+	for section, oldCosts := range oldGasSchedule {
+		newCosts := newGasSchedule[section]
+		for name, oldValue := range oldCosts {
+			newValue, found := newCosts[name]
+			if !found {
+				changes = append(changes, &GasCostChange{Section: section, Name: name, OldValue: oldValue, IsRemoved: true})
+				continue
+			}
+			if oldValue != newValue {
+				changes = append(changes, &GasCostChange{Section: section, Name: name, OldValue: oldValue, NewValue: newValue})
+			}
+		}
+	}
+
+	for section, newCosts := range newGasSchedule {
+		oldCosts := oldGasSchedule[section]
+		for name, newValue := range newCosts {
+			if _, found := oldCosts[name]; !found {
+				changes = append(changes, &GasCostChange{Section: section, Name: name, NewValue: newValue, IsAdded: true})
+			}
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Section != changes[j].Section {
+			return changes[i].Section < changes[j].Section
+		}
+		return changes[i].Name < changes[j].Name
+	})
+
+	return changes
+}
+
+// FormatDiff returns the changes one per line
+func FormatDiff(changes []*GasCostChange) string {
+	lines := make([]string, 0, len(changes))
+	for _, change := range changes {
+		lines = append(lines, change.String())
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,40 @@
+package gasSchedule
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	oldGasSchedule := map[string]map[string]uint64{
+		"BaseOperationCost": {"StorePerByte": 10, "GetCode": 100},
+		"BuiltInCost":       {"ESDTTransfer": 200, "ESDTBurn": 300},
+		"RemovedSection":    {"Cost": 1},
+	}
+	newGasSchedule := map[string]map[string]uint64{
+		"BaseOperationCost": {"StorePerByte": 10, "GetCode": 150},
+		"BuiltInCost":       {"ESDTTransfer": 100, "SetGuardian": 400},
+	}
+
+	changes := Diff(oldGasSchedule, newGasSchedule)
+	assert.Equal(t, []*GasCostChange{
+		{Section: "BaseOperationCost", Name: "GetCode", OldValue: 100, NewValue: 150},
+		{Section: "BuiltInCost", Name: "ESDTBurn", OldValue: 300, IsRemoved: true},
+		{Section: "BuiltInCost", Name: "ESDTTransfer", OldValue: 200, NewValue: 100},
+		{Section: "BuiltInCost", Name: "SetGuardian", NewValue: 400, IsAdded: true},
+		{Section: "RemovedSection", Name: "Cost", OldValue: 1, IsRemoved: true},
+	}, changes)
+
+	expectedDiff := "~ BaseOperationCost.GetCode: 100 -> 150\n" +
+		"- BuiltInCost.ESDTBurn = 300\n" +
+		"~ BuiltInCost.ESDTTransfer: 200 -> 100\n" +
+		"+ BuiltInCost.SetGuardian = 400\n" +
+		"- RemovedSection.Cost = 1"
+	assert.Equal(t, expectedDiff, FormatDiff(changes))
+
+	assert.Empty(t, Diff(oldGasSchedule, oldGasSchedule))
+	assert.Empty(t, FormatDiff(nil))
+}
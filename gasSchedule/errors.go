@@ -0,0 +1,21 @@
+package gasSchedule
+
+import "errors"
+
+// ErrNilGasSchedule signals that a nil gas schedule was provided
+var ErrNilGasSchedule = errors.New("nil gas schedule")
+
+// ErrUnsupportedFileFormat signals that the gas schedule file is neither a toml nor a json file
+var ErrUnsupportedFileFormat = errors.New("unsupported gas schedule file format")
+
+// ErrInvalidGasScheduleSection signals that a section of the gas schedule is not a table of gas costs
+var ErrInvalidGasScheduleSection = errors.New("invalid gas schedule section")
+
+// ErrInvalidGasCostValue signals that a gas cost is not a positive integer
+var ErrInvalidGasCostValue = errors.New("invalid gas cost value")
+
+// ErrMissingGasCosts signals that required gas costs are missing from the gas schedule
+var ErrMissingGasCosts = errors.New("missing gas costs")
+
+// ErrZeroGasCosts signals that required gas costs are set to zero in the gas schedule
+var ErrZeroGasCosts = errors.New("zero gas costs")
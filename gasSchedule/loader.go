@@ -0,0 +1,63 @@
+package gasSchedule
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+)
+
+const (
+	tomlExtension = ".toml"
+	jsonExtension = ".json"
+)
+
+// LoadGasScheduleFile loads a gas schedule from a toml or a json file, chosen by the file extension
+func LoadGasScheduleFile(path string) (map[string]map[string]uint64, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case tomlExtension:
+		return loadTomlGasSchedule(path)
+	case jsonExtension:
+		return loadJsonGasSchedule(path)
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedFileFormat, path)
+	}
+}
+
+func loadJsonGasSchedule(path string) (map[string]map[string]uint64, error) {
+	gasSchedule := make(map[string]map[string]uint64)
+	err := core.LoadJsonFile(&gasSchedule, path)
+	if err != nil {
+		return nil, err
+	}
+
+	return gasSchedule, nil
+}
+
+func loadTomlGasSchedule(path string) (map[string]map[string]uint64, error) {
+	loadedMap, err := core.LoadTomlFileToMap(path)
+	if err != nil {
+		return nil, err
+	}
+
+	gasSchedule := make(map[string]map[string]uint64, len(loadedMap))
+	for section, costs := range loadedMap {
+		costsMap, ok := costs.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidGasScheduleSection, section)
+		}
+
+		gasSchedule[section] = make(map[string]uint64, len(costsMap))
+		for name, cost := range costsMap {
+			value, isInt := cost.(int64)
+			if !isInt || value < 0 {
+				return nil, fmt.Errorf("%w for %s.%s: %v", ErrInvalidGasCostValue, section, name, cost)
+			}
+
+			gasSchedule[section][name] = uint64(value)
+		}
+	}
+
+	return gasSchedule, nil
+}
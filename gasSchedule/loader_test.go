@@ -0,0 +1,93 @@
+package gasSchedule
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFile(t *testing.T, name string, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.Nil(t, os.WriteFile(path, []byte(content), 0600))
+
+	return path
+}
+
+func TestLoadGasScheduleFile(t *testing.T) {
+	t.Parallel()
+
+	expectedGasSchedule := map[string]map[string]uint64{
+		"BaseOperationCost": {"StorePerByte": 10, "GetCode": 100},
+		"BuiltInCost":       {"ESDTTransfer": 200000},
+	}
+
+	t.Run("toml file", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeFile(t, "gasSchedule.toml", `
+[BaseOperationCost]
+    StorePerByte = 10
+    GetCode = 100
+
+[BuiltInCost]
+    ESDTTransfer = 200000
+`)
+		gasSchedule, err := LoadGasScheduleFile(path)
+		require.Nil(t, err)
+		assert.Equal(t, expectedGasSchedule, gasSchedule)
+	})
+	t.Run("json file", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeFile(t, "gasSchedule.JSON", `{
+	"BaseOperationCost": {"StorePerByte": 10, "GetCode": 100},
+	"BuiltInCost": {"ESDTTransfer": 200000}
+}`)
+		gasSchedule, err := LoadGasScheduleFile(path)
+		require.Nil(t, err)
+		assert.Equal(t, expectedGasSchedule, gasSchedule)
+	})
+	t.Run("unsupported file format", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeFile(t, "gasSchedule.yaml", "")
+		gasSchedule, err := LoadGasScheduleFile(path)
+		assert.Nil(t, gasSchedule)
+		assert.True(t, errors.Is(err, ErrUnsupportedFileFormat))
+	})
+	t.Run("missing file", func(t *testing.T) {
+		t.Parallel()
+
+		gasSchedule, err := LoadGasScheduleFile(filepath.Join(t.TempDir(), "missing.toml"))
+		assert.Nil(t, gasSchedule)
+		assert.NotNil(t, err)
+	})
+	t.Run("toml value outside of a section", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeFile(t, "gasSchedule.toml", "StorePerByte = 10\n")
+		gasSchedule, err := LoadGasScheduleFile(path)
+		assert.Nil(t, gasSchedule)
+		assert.True(t, errors.Is(err, ErrInvalidGasScheduleSection))
+	})
+	t.Run("negative toml value", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeFile(t, "gasSchedule.toml", "[BuiltInCost]\nESDTTransfer = -1\n")
+		gasSchedule, err := LoadGasScheduleFile(path)
+		assert.Nil(t, gasSchedule)
+		assert.True(t, errors.Is(err, ErrInvalidGasCostValue))
+	})
+	t.Run("string toml value", func(t *testing.T) {
+		t.Parallel()
+
+		path := writeFile(t, "gasSchedule.toml", "[BuiltInCost]\nESDTTransfer = \"1\"\n")
+		gasSchedule, err := LoadGasScheduleFile(path)
+		assert.Nil(t, gasSchedule)
+		assert.True(t, errors.Is(err, ErrInvalidGasCostValue))
+	})
+}
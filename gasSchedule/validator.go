@@ -0,0 +1,110 @@
+package gasSchedule
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// ValidationReport holds the problems found in the built-in functions sections of a gas schedule. The keys are
+// given as section.name
+type ValidationReport struct {
+	MissingKeys []string
+	ZeroKeys    []string
+	UnknownKeys []string
+}
+
+// IsValid returns true if all the required gas costs are present and not zero. Unknown keys do not invalidate
+// the gas schedule, as it is shared with the virtual machines which define their own costs
+func (vr *ValidationReport) IsValid() bool {
+	return len(vr.MissingKeys) == 0 && len(vr.ZeroKeys) == 0
+}
+
+// Err returns the error describing why the gas schedule is not valid, or nil if it is valid
+func (vr *ValidationReport) Err() error {
+	if len(vr.MissingKeys) > 0 {
+		return fmt.Errorf("%w: %s", ErrMissingGasCosts, strings.Join(vr.MissingKeys, ", "))
+	}
+	if len(vr.ZeroKeys) > 0 {
+		return fmt.Errorf("%w: %s", ErrZeroGasCosts, strings.Join(vr.ZeroKeys, ", "))
+	}
+
+	return nil
+}
+
+// Validate checks that the gas schedule defines all the costs of the BaseOperationCost and BuiltInCost sections
+func Validate(gasSchedule map[string]map[string]uint64) *ValidationReport {
+	report := &ValidationReport{
+		MissingKeys: make([]string, 0),
+		ZeroKeys:    make([]string, 0),
+		UnknownKeys: make([]string, 0),
+	}
+
+	validateSection(report, gasSchedule[core.BaseOperationCostString], core.BaseOperationCostString, vmcommon.BaseOperationCost{})
+	validateSection(report, gasSchedule[core.BuiltInCostString], core.BuiltInCostString, vmcommon.BuiltInCost{})
+
+	return report
+}
+
+// validateSection matches the keys ignoring the case, same as the decoding of the gas schedule does
+func validateSection(report *ValidationReport, costs map[string]uint64, section string, costsStruct interface{}) {
+	costsByName := make(map[string]uint64, len(costs))
+	for name, value := range costs {
+		costsByName[strings.ToLower(name)] = value
+	}
+
+	knownNames := make(map[string]struct{})
+	costsType := reflect.TypeOf(costsStruct)
+	for i := 0; i < costsType.NumField(); i++ {
+		name := costsType.Field(i).Name
+		knownNames[strings.ToLower(name)] = struct{}{}
+
+		value, found := costsByName[strings.ToLower(name)]
+		if !found {
+			report.MissingKeys = append(report.MissingKeys, section+"."+name)
+			continue
+		}
+		if value == 0 {
+			report.ZeroKeys = append(report.ZeroKeys, section+"."+name)
+		}
+	}
+
+	unknownKeys := make([]string, 0)
+	for name := range costs {
+		if _, found := knownNames[strings.ToLower(name)]; !found {
+			unknownKeys = append(unknownKeys, section+"."+name)
+		}
+	}
+	sort.Strings(unknownKeys)
+	report.UnknownKeys = append(report.UnknownKeys, unknownKeys...)
+}
+
+// CreateGasCost validates the gas schedule and creates the gas costs used by the built-in functions
+func CreateGasCost(gasSchedule map[string]map[string]uint64) (*vmcommon.GasCost, error) {
+	if gasSchedule == nil {
+		return nil, ErrNilGasSchedule
+	}
+
+	err := Validate(gasSchedule).Err()
+	if err != nil {
+		return nil, err
+	}
+
+	gasCost := &vmcommon.GasCost{}
+	err = mapstructure.Decode(gasSchedule[core.BaseOperationCostString], &gasCost.BaseOperationCost)
+	if err != nil {
+		return nil, err
+	}
+
+	err = mapstructure.Decode(gasSchedule[core.BuiltInCostString], &gasCost.BuiltInCost)
+	if err != nil {
+		return nil, err
+	}
+
+	return gasCost, nil
+}
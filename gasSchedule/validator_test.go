@@ -0,0 +1,108 @@
+package gasSchedule
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createGasSchedule(value uint64) map[string]map[string]uint64 {
+	return map[string]map[string]uint64{
+		core.BaseOperationCostString: createCosts(vmcommon.BaseOperationCost{}, value),
+		core.BuiltInCostString:       createCosts(vmcommon.BuiltInCost{}, value),
+		"WASMOpcodeCost":             {"Unreachable": value},
+	}
+}
+
+func createCosts(costs interface{}, value uint64) map[string]uint64 {
+	gasMap := make(map[string]uint64)
+	costsType := reflect.TypeOf(costs)
+	for i := 0; i < costsType.NumField(); i++ {
+		gasMap[costsType.Field(i).Name] = value
+	}
+
+	return gasMap
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid gas schedule", func(t *testing.T) {
+		t.Parallel()
+
+		report := Validate(createGasSchedule(1))
+		assert.True(t, report.IsValid())
+		assert.Nil(t, report.Err())
+		assert.Empty(t, report.UnknownKeys)
+	})
+	t.Run("keys are matched ignoring the case", func(t *testing.T) {
+		t.Parallel()
+
+		gasSchedule := createGasSchedule(1)
+		delete(gasSchedule[core.BuiltInCostString], "ESDTNFTAddURI")
+		gasSchedule[core.BuiltInCostString]["ESDTNFTAddUri"] = 1
+
+		report := Validate(gasSchedule)
+		assert.True(t, report.IsValid())
+		assert.Empty(t, report.UnknownKeys)
+	})
+	t.Run("missing, zero and unknown keys", func(t *testing.T) {
+		t.Parallel()
+
+		gasSchedule := createGasSchedule(1)
+		delete(gasSchedule, core.BaseOperationCostString)
+		gasSchedule[core.BuiltInCostString]["ESDTTransfer"] = 0
+		gasSchedule[core.BuiltInCostString]["UnGuardAccount"] = 1
+		gasSchedule[core.BuiltInCostString]["DeleteUserName"] = 1
+
+		report := Validate(gasSchedule)
+		assert.False(t, report.IsValid())
+		assert.Equal(t, []string{
+			"BaseOperationCost.StorePerByte",
+			"BaseOperationCost.ReleasePerByte",
+			"BaseOperationCost.DataCopyPerByte",
+			"BaseOperationCost.PersistPerByte",
+			"BaseOperationCost.CompilePerByte",
+			"BaseOperationCost.AoTPreparePerByte",
+		}, report.MissingKeys)
+		assert.Equal(t, []string{"BuiltInCost.ESDTTransfer"}, report.ZeroKeys)
+		assert.Equal(t, []string{"BuiltInCost.DeleteUserName", "BuiltInCost.UnGuardAccount"}, report.UnknownKeys)
+		assert.True(t, errors.Is(report.Err(), ErrMissingGasCosts))
+	})
+	t.Run("zero keys", func(t *testing.T) {
+		t.Parallel()
+
+		gasSchedule := createGasSchedule(1)
+		gasSchedule[core.BaseOperationCostString]["StorePerByte"] = 0
+
+		report := Validate(gasSchedule)
+		assert.False(t, report.IsValid())
+		assert.Equal(t, "zero gas costs: BaseOperationCost.StorePerByte", report.Err().Error())
+	})
+}
+
+func TestCreateGasCost(t *testing.T) {
+	t.Parallel()
+
+	gasCost, err := CreateGasCost(nil)
+	assert.Nil(t, gasCost)
+	assert.Equal(t, ErrNilGasSchedule, err)
+
+	gasSchedule := createGasSchedule(1)
+	gasSchedule[core.BuiltInCostString]["ESDTTransfer"] = 0
+	gasCost, err = CreateGasCost(gasSchedule)
+	assert.Nil(t, gasCost)
+	assert.True(t, errors.Is(err, ErrZeroGasCosts))
+
+	gasSchedule = createGasSchedule(3)
+	gasSchedule[core.BuiltInCostString]["ESDTTransfer"] = 7
+	gasCost, err = CreateGasCost(gasSchedule)
+	require.Nil(t, err)
+	assert.Equal(t, uint64(7), gasCost.BuiltInCost.ESDTTransfer)
+	assert.Equal(t, uint64(3), gasCost.BaseOperationCost.StorePerByte)
+}
@@ -72,6 +72,9 @@ type VMInput struct {
 	// GuardianSigned specifies whether the transaction was signed by the guardian
 	TxGuardian []byte
 
+	// TxCoSigners holds the guardians of a guardian set which co-signed the transaction
+	TxCoSigners [][]byte
+
 	// OriginalCallerAddr is the public key of the wallet originally initiating the transaction
 	OriginalCallerAddr []byte
 
@@ -400,6 +400,16 @@ type BuiltInFunctionContainer interface {
 	IsInterfaceNil() bool
 }
 
+// EpochAwareBuiltInFunctionContainer defines a built-in functions container which schedules by epoch the
+// introduction, the retirement and the implementation versions of the built-in functions
+type EpochAwareBuiltInFunctionContainer interface {
+	BuiltInFunctionContainer
+	SetActivation(key string, activationFlag core.EnableEpochFlag, deactivationFlag core.EnableEpochFlag) error
+	AddVersion(key string, flag core.EnableEpochFlag, function BuiltinFunction) error
+	GetInEpoch(key string, epoch uint32) (BuiltinFunction, error)
+	GetAllVersions(key string) ([]BuiltinFunction, error)
+}
+
 // EpochSubscriberHandler defines the behavior of a component that can be notified if a new epoch was confirmed
 type EpochSubscriberHandler interface {
 	EpochConfirmed(epoch uint32, timestamp uint64)
@@ -437,6 +447,16 @@ type NftSaveArgs struct {
 	KeepMetaDataOnZeroLiquidity bool
 }
 
+// ESDTSupply holds the supply counters of an esdt token, as tracked on the system account of the shard. The minted and
+// burned counters start from the supply last confirmed when setting the max supply. A zero max supply means the supply
+// of the token is not capped
+type ESDTSupply struct {
+	Supply    *big.Int
+	Minted    *big.Int
+	Burned    *big.Int
+	MaxSupply *big.Int
+}
+
 // ESDTNFTStorageHandler will handle the storage for the nft metadata
 type ESDTNFTStorageHandler interface {
 	SaveESDTNFTToken(senderAddress []byte, acnt UserAccountHandler, esdtTokenKey []byte, nonce uint64, esdtData *esdt.ESDigitalToken, saveArgs NftSaveArgs) ([]byte, error)
@@ -451,6 +471,13 @@ type ESDTNFTStorageHandler interface {
 	IsInterfaceNil() bool
 }
 
+// ESDTSupplyHandler will handle the supply counters of the esdt tokens saved on the system account
+type ESDTSupplyHandler interface {
+	AddToSupplySystemAcc(tokenID []byte, value *big.Int) error
+	GetESDTSupply(tokenID []byte) (*ESDTSupply, error)
+	IsInterfaceNil() bool
+}
+
 // SimpleESDTNFTStorageHandler will handle get of ESDT data and save metadata to system acc
 type SimpleESDTNFTStorageHandler interface {
 	GetESDTNFTTokenOnDestination(accnt UserAccountHandler, esdtTokenKey []byte, nonce uint64) (*esdt.ESDigitalToken, bool, error)
@@ -506,6 +533,16 @@ type GuardedAccountHandler interface {
 	IsInterfaceNil() bool
 }
 
+// MultiGuardedAccountHandler extends the guarded account handler with guardian sets, which protect an account with
+// several guardians out of which a threshold must co-sign. GetActiveGuardianSet returns nil if the account has no
+// active guardian set
+type MultiGuardedAccountHandler interface {
+	GuardedAccountHandler
+	GetActiveGuardianSet(uah UserAccountHandler) (*GuardianSet, error)
+	SetGuardianSet(uah UserAccountHandler, guardianSet *GuardianSet, txCoSigners [][]byte) error
+	VerifyCoSigners(uah UserAccountHandler, txCoSigners [][]byte) error
+}
+
 // DataTrieMigrator is the interface that defines the methods needed for migrating data trie leaves
 type DataTrieMigrator interface {
 	ConsumeStorageLoadGas() bool
@@ -3,6 +3,7 @@ package mock
 // BlockDataHandlerStub -
 type BlockDataHandlerStub struct {
 	CurrentRoundCalled func() uint64
+	CurrentEpochCalled func() uint32
 }
 
 // CurrentRound -
@@ -13,6 +14,14 @@ func (b *BlockDataHandlerStub) CurrentRound() uint64 {
 	return 0
 }
 
+// CurrentEpoch -
+func (b *BlockDataHandlerStub) CurrentEpoch() uint32 {
+	if b.CurrentEpochCalled != nil {
+		return b.CurrentEpochCalled()
+	}
+	return 0
+}
+
 // IsInterfaceNil returns true if there is no value under the interface
 func (b *BlockDataHandlerStub) IsInterfaceNil() bool {
 	return b == nil
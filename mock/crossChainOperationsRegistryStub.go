@@ -0,0 +1,40 @@
+package mock
+
+// CrossChainOperationsRegistryStub -
+type CrossChainOperationsRegistryStub struct {
+	RegisterOperationCalled    func(operationID []byte) error
+	IsOperationProcessedCalled func(operationID []byte) (bool, error)
+	PruneOperationsCalled      func(epoch uint32) error
+}
+
+// RegisterOperation -
+func (stub *CrossChainOperationsRegistryStub) RegisterOperation(operationID []byte) error {
+	if stub.RegisterOperationCalled != nil {
+		return stub.RegisterOperationCalled(operationID)
+	}
+
+	return nil
+}
+
+// IsOperationProcessed -
+func (stub *CrossChainOperationsRegistryStub) IsOperationProcessed(operationID []byte) (bool, error) {
+	if stub.IsOperationProcessedCalled != nil {
+		return stub.IsOperationProcessedCalled(operationID)
+	}
+
+	return false, nil
+}
+
+// PruneOperations -
+func (stub *CrossChainOperationsRegistryStub) PruneOperations(epoch uint32) error {
+	if stub.PruneOperationsCalled != nil {
+		return stub.PruneOperationsCalled(epoch)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *CrossChainOperationsRegistryStub) IsInterfaceNil() bool {
+	return stub == nil
+}
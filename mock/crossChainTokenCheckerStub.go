@@ -4,6 +4,8 @@ package mock
 type CrossChainTokenCheckerMock struct {
 	IsCrossChainOperationCalled        func(tokenID []byte) bool
 	IsCrossChainOperationAllowedCalled func(address []byte, tokenID []byte) bool
+	IsCrossChainActionAllowedCalled    func(address []byte, tokenID []byte, action []byte) bool
+	IsWhiteListedCalled                func(address []byte) bool
 }
 
 // IsCrossChainOperation -
@@ -24,6 +26,24 @@ func (stub *CrossChainTokenCheckerMock) IsCrossChainOperationAllowed(address []b
 	return false
 }
 
+// IsCrossChainActionAllowed -
+func (stub *CrossChainTokenCheckerMock) IsCrossChainActionAllowed(address []byte, tokenID []byte, action []byte) bool {
+	if stub.IsCrossChainActionAllowedCalled != nil {
+		return stub.IsCrossChainActionAllowedCalled(address, tokenID, action)
+	}
+
+	return stub.IsCrossChainOperationAllowed(address, tokenID)
+}
+
+// IsWhiteListed -
+func (stub *CrossChainTokenCheckerMock) IsWhiteListed(address []byte) bool {
+	if stub.IsWhiteListedCalled != nil {
+		return stub.IsWhiteListedCalled(address)
+	}
+
+	return false
+}
+
 // IsInterfaceNil -
 func (stub *CrossChainTokenCheckerMock) IsInterfaceNil() bool {
 	return stub == nil
@@ -0,0 +1,26 @@
+package mock
+
+import (
+	"math/big"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// ESDTLockedBalanceHandlerStub -
+type ESDTLockedBalanceHandlerStub struct {
+	GetLockedBalanceCalled func(account vmcommon.UserAccountHandler, tokenID []byte) (*big.Int, error)
+}
+
+// GetLockedBalance -
+func (stub *ESDTLockedBalanceHandlerStub) GetLockedBalance(account vmcommon.UserAccountHandler, tokenID []byte) (*big.Int, error) {
+	if stub.GetLockedBalanceCalled != nil {
+		return stub.GetLockedBalanceCalled(account, tokenID)
+	}
+
+	return big.NewInt(0), nil
+}
+
+// IsInterfaceNil -
+func (stub *ESDTLockedBalanceHandlerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
@@ -19,6 +19,8 @@ type ESDTNFTStorageHandlerStub struct {
 	AddToLiquiditySystemAccCalled                             func(esdtTokenKey []byte, tokenType uint32, nonce uint64, transferValue *big.Int, keepMetadataOnZeroLiquidity bool) error
 	GetMetaDataFromSystemAccountCalled                        func([]byte, uint64) (*esdt.ESDigitalToken, error)
 	SaveMetaDataToSystemAccountCalled                         func(tokenKey []byte, nonce uint64, esdtData *esdt.ESDigitalToken) error
+	AddToSupplySystemAccCalled                                func(tokenID []byte, value *big.Int) error
+	GetESDTSupplyCalled                                       func(tokenID []byte) (*vmcommon.ESDTSupply, error)
 }
 
 // SaveESDTNFTToken -
@@ -93,6 +95,22 @@ func (stub *ESDTNFTStorageHandlerStub) AddToLiquiditySystemAcc(esdtTokenKey []by
 	return nil
 }
 
+// AddToSupplySystemAcc -
+func (stub *ESDTNFTStorageHandlerStub) AddToSupplySystemAcc(tokenID []byte, value *big.Int) error {
+	if stub.AddToSupplySystemAccCalled != nil {
+		return stub.AddToSupplySystemAccCalled(tokenID, value)
+	}
+	return nil
+}
+
+// GetESDTSupply -
+func (stub *ESDTNFTStorageHandlerStub) GetESDTSupply(tokenID []byte) (*vmcommon.ESDTSupply, error) {
+	if stub.GetESDTSupplyCalled != nil {
+		return stub.GetESDTSupplyCalled(tokenID)
+	}
+	return nil, nil
+}
+
 // IsInterfaceNil -
 func (stub *ESDTNFTStorageHandlerStub) IsInterfaceNil() bool {
 	return stub == nil
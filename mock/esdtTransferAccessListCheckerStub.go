@@ -0,0 +1,20 @@
+package mock
+
+// ESDTTransferAccessListCheckerStub -
+type ESDTTransferAccessListCheckerStub struct {
+	CheckTransferAccessListsCalled func(esdtTokenKey []byte, sender, destination []byte) error
+}
+
+// CheckTransferAccessLists -
+func (stub *ESDTTransferAccessListCheckerStub) CheckTransferAccessLists(esdtTokenKey []byte, sender, destination []byte) error {
+	if stub.CheckTransferAccessListsCalled != nil {
+		return stub.CheckTransferAccessListsCalled(esdtTokenKey, sender, destination)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *ESDTTransferAccessListCheckerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
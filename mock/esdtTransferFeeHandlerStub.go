@@ -0,0 +1,36 @@
+package mock
+
+import (
+	"math/big"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// ESDTTransferFeeHandlerStub -
+type ESDTTransferFeeHandlerStub struct {
+	ComputeTransferFeeCalled func(tokenID []byte, sender []byte, destination []byte, value *big.Int) (*big.Int, []byte, error)
+	CollectTransferFeeCalled func(acntSnd vmcommon.UserAccountHandler, destination []byte, tokenID []byte, fee *big.Int, feeReceiver []byte, vmOutput *vmcommon.VMOutput) error
+}
+
+// ComputeTransferFee -
+func (stub *ESDTTransferFeeHandlerStub) ComputeTransferFee(tokenID []byte, sender []byte, destination []byte, value *big.Int) (*big.Int, []byte, error) {
+	if stub.ComputeTransferFeeCalled != nil {
+		return stub.ComputeTransferFeeCalled(tokenID, sender, destination, value)
+	}
+
+	return big.NewInt(0), nil, nil
+}
+
+// CollectTransferFee -
+func (stub *ESDTTransferFeeHandlerStub) CollectTransferFee(acntSnd vmcommon.UserAccountHandler, destination []byte, tokenID []byte, fee *big.Int, feeReceiver []byte, vmOutput *vmcommon.VMOutput) error {
+	if stub.CollectTransferFeeCalled != nil {
+		return stub.CollectTransferFeeCalled(acntSnd, destination, tokenID, fee, feeReceiver, vmOutput)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *ESDTTransferFeeHandlerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
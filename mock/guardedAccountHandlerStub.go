@@ -7,6 +7,9 @@ type GuardedAccountHandlerStub struct {
 	GetActiveGuardianCalled    func(handler vmcommon.UserAccountHandler) ([]byte, error)
 	SetGuardianCalled          func(uah vmcommon.UserAccountHandler, guardianAddress []byte, txGuardianAddress []byte, guardianServiceUID []byte) error
 	CleanOtherThanActiveCalled func(uah vmcommon.UserAccountHandler)
+	GetActiveGuardianSetCalled func(uah vmcommon.UserAccountHandler) (*vmcommon.GuardianSet, error)
+	SetGuardianSetCalled       func(uah vmcommon.UserAccountHandler, guardianSet *vmcommon.GuardianSet, txCoSigners [][]byte) error
+	VerifyCoSignersCalled      func(uah vmcommon.UserAccountHandler, txCoSigners [][]byte) error
 }
 
 // GetActiveGuardian -
@@ -32,6 +35,30 @@ func (gahs *GuardedAccountHandlerStub) CleanOtherThanActive(uah vmcommon.UserAcc
 	}
 }
 
+// GetActiveGuardianSet -
+func (gahs *GuardedAccountHandlerStub) GetActiveGuardianSet(uah vmcommon.UserAccountHandler) (*vmcommon.GuardianSet, error) {
+	if gahs.GetActiveGuardianSetCalled != nil {
+		return gahs.GetActiveGuardianSetCalled(uah)
+	}
+	return nil, nil
+}
+
+// SetGuardianSet -
+func (gahs *GuardedAccountHandlerStub) SetGuardianSet(uah vmcommon.UserAccountHandler, guardianSet *vmcommon.GuardianSet, txCoSigners [][]byte) error {
+	if gahs.SetGuardianSetCalled != nil {
+		return gahs.SetGuardianSetCalled(uah, guardianSet, txCoSigners)
+	}
+	return nil
+}
+
+// VerifyCoSigners -
+func (gahs *GuardedAccountHandlerStub) VerifyCoSigners(uah vmcommon.UserAccountHandler, txCoSigners [][]byte) error {
+	if gahs.VerifyCoSignersCalled != nil {
+		return gahs.VerifyCoSignersCalled(uah, txCoSigners)
+	}
+	return nil
+}
+
 // IsInterfaceNil -
 func (gahs *GuardedAccountHandlerStub) IsInterfaceNil() bool {
 	return gahs == nil
@@ -0,0 +1,32 @@
+package mock
+
+import (
+	"math/big"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+// GuardedSpendingPolicyHandlerStub -
+type GuardedSpendingPolicyHandlerStub struct {
+	CheckUnguardedTransferCalled func(acntSnd vmcommon.UserAccountHandler, vmInput *vmcommon.VMInput, destination []byte, tokenID []byte, value *big.Int) error
+}
+
+// CheckUnguardedTransfer -
+func (stub *GuardedSpendingPolicyHandlerStub) CheckUnguardedTransfer(
+	acntSnd vmcommon.UserAccountHandler,
+	vmInput *vmcommon.VMInput,
+	destination []byte,
+	tokenID []byte,
+	value *big.Int,
+) error {
+	if stub.CheckUnguardedTransferCalled != nil {
+		return stub.CheckUnguardedTransferCalled(acntSnd, vmInput, destination, tokenID, value)
+	}
+
+	return nil
+}
+
+// IsInterfaceNil -
+func (stub *GuardedSpendingPolicyHandlerStub) IsInterfaceNil() bool {
+	return stub == nil
+}
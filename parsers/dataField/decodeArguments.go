@@ -0,0 +1,1148 @@
+package datafield
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+const (
+	deleteUserNameFuncName = "DeleteUserName"
+
+	numArgsPerAddMetadata          = 3
+	minNumArgsDeleteMetadata       = 4
+	minNumArgsMetaDataRecreate     = 7
+	argsURIsStartPositionMetaData  = 6
+	argsURIsStartPositionSetURIs   = 2
+	argsURIsStartPositionNFTCreate = 6
+	numArgsBridgeDeposit           = 4
+	minNumArgsBridgeWithdraw       = 4
+	maxNumArgsBridgeWithdraw       = 5
+	minNumArgsNativeIssue          = 4
+	minNumArgsVestingTransfer      = 4
+	minNumArgsSetTransferFee       = 4
+	maxNumArgsFreezeWipe           = 3
+	minNumArgsSetGuardianSet       = 4
+	minNumArgsSetSpendingPolicy    = 2
+)
+
+type argumentsDecoder func(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error)
+
+func (odp *operationDataFieldParser) createArgumentsDecoders() map[string]argumentsDecoder {
+	return map[string]argumentsDecoder{
+		core.BuiltInFunctionClaimDeveloperRewards:             decodeClaimDeveloperRewards,
+		core.BuiltInFunctionChangeOwnerAddress:                odp.decodeChangeOwnerAddress,
+		core.BuiltInFunctionSetUserName:                       decodeSetUserName,
+		deleteUserNameFuncName:                                decodeNoArguments,
+		core.BuiltInFunctionSaveKeyValue:                      decodeSaveKeyValue,
+		core.BuiltInFunctionESDTPause:                         decodeGlobalSettings,
+		core.BuiltInFunctionESDTUnPause:                       decodeGlobalSettings,
+		core.BuiltInFunctionESDTSetLimitedTransfer:            decodeGlobalSettings,
+		core.BuiltInFunctionESDTUnSetLimitedTransfer:          decodeGlobalSettings,
+		vmcommon.BuiltInFunctionESDTSetBurnRoleForAll:         decodeGlobalSettings,
+		vmcommon.BuiltInFunctionESDTUnSetBurnRoleForAll:       decodeGlobalSettings,
+		core.BuiltInFunctionSetESDTRole:                       decodeRoles,
+		core.BuiltInFunctionUnSetESDTRole:                     decodeRoles,
+		vmcommon.BuiltInFunctionESDTTransferRoleAddAddress:    odp.decodeTransferRoleAddresses,
+		vmcommon.BuiltInFunctionESDTTransferRoleDeleteAddress: odp.decodeTransferRoleAddresses,
+		vmcommon.BuiltInFunctionESDTSetAllowListRequired:      decodeGlobalSettings,
+		vmcommon.BuiltInFunctionESDTUnSetAllowListRequired:    decodeGlobalSettings,
+		vmcommon.BuiltInFunctionESDTAllowListAddAddress:       odp.decodeTransferRoleAddresses,
+		vmcommon.BuiltInFunctionESDTAllowListDeleteAddress:    odp.decodeTransferRoleAddresses,
+		vmcommon.BuiltInFunctionESDTDenyListAddAddress:        odp.decodeTransferRoleAddresses,
+		vmcommon.BuiltInFunctionESDTDenyListDeleteAddress:     odp.decodeTransferRoleAddresses,
+		core.BuiltInFunctionESDTBurn:                          decodeESDTBurn,
+		core.BuiltInFunctionESDTLocalBurn:                     decodeLocalQuantity,
+		core.BuiltInFunctionESDTLocalMint:                     decodeLocalQuantity,
+		core.BuiltInFunctionESDTNFTAddQuantity:                decodeNFTQuantity,
+		core.BuiltInFunctionESDTNFTBurn:                       decodeNFTQuantity,
+		core.BuiltInFunctionESDTNFTCreate:                     decodeNFTCreate,
+		core.BuiltInFunctionESDTFreeze:                        decodeFreezeWipe,
+		core.BuiltInFunctionESDTUnFreeze:                      decodeFreezeWipe,
+		core.BuiltInFunctionESDTWipe:                          decodeFreezeWipe,
+		core.BuiltInFunctionESDTNFTCreateRoleTransfer:         odp.decodeNFTCreateRoleTransfer,
+		core.BuiltInFunctionESDTNFTUpdateAttributes:           decodeNFTUpdateAttributes,
+		core.BuiltInFunctionESDTNFTAddURI:                     decodeNFTAddURIs,
+		vmcommon.ESDTDeleteMetadata:                           decodeDeleteMetadata,
+		vmcommon.ESDTAddMetadata:                              decodeAddMetadata,
+		core.BuiltInFunctionSetGuardian:                       odp.decodeSetGuardian,
+		core.BuiltInFunctionGuardAccount:                      decodeGuardAccount,
+		core.BuiltInFunctionUnGuardAccount:                    decodeGuardAccount,
+		core.BuiltInFunctionMigrateDataTrie:                   decodeNoArguments,
+		core.ESDTSetTokenType:                                 decodeSetTokenType,
+		core.ESDTMetaDataRecreate:                             decodeMetaDataRecreate,
+		core.ESDTMetaDataUpdate:                               decodeMetaDataUpdate,
+		core.ESDTSetNewURIs:                                   decodeNFTAddURIs,
+		core.ESDTModifyRoyalties:                              decodeModifyRoyalties,
+		core.ESDTModifyCreator:                                decodeModifyCreator,
+
+		vmcommon.BuiltInFunctionAddCrossChainWhiteListedAddress:    odp.decodeCrossChainWhiteList,
+		vmcommon.BuiltInFunctionRemoveCrossChainWhiteListedAddress: odp.decodeCrossChainWhiteList,
+		vmcommon.BuiltInFunctionESDTBridgeDeposit:                  decodeBridgeDeposit,
+		vmcommon.BuiltInFunctionESDTBridgeWithdraw:                 decodeBridgeWithdraw,
+		vmcommon.BuiltInFunctionESDTNativeIssue:                    decodeNativeIssue,
+		vmcommon.BuiltInFunctionESDTNativeIssueCollection:          decodeNativeIssueCollection,
+		vmcommon.BuiltInFunctionESDTNativeSetTokenProperties:       decodeNativeSetTokenProperties,
+		vmcommon.BuiltInFunctionESDTNativeTransferOwnership:        odp.decodeNativeTransferOwnership,
+		vmcommon.BuiltInFunctionESDTSetMaxSupply:                   decodeSetMaxSupply,
+		vmcommon.BuiltInFunctionESDTVestingTransfer:                decodeVestingTransfer,
+		vmcommon.BuiltInFunctionESDTReleaseVesting:                 decodeReleaseVesting,
+		vmcommon.BuiltInFunctionESDTSetTransferFee:                 odp.decodeSetTransferFee,
+		vmcommon.BuiltInFunctionFreezeAccount:                      decodeNoArguments,
+		vmcommon.BuiltInFunctionUnFreezeAccount:                    decodeNoArguments,
+		vmcommon.BuiltInFunctionSetGuardianSet:                     odp.decodeSetGuardianSet,
+		vmcommon.BuiltInFunctionSetGuardedSpendingPolicy:           odp.decodeSetGuardedSpendingPolicy,
+		vmcommon.BuiltInFunctionProposeOwnerAddress:                odp.decodeProposeOwnerAddress,
+		vmcommon.BuiltInFunctionAcceptOwnerAddress:                 decodeNoArguments,
+		vmcommon.BuiltInFunctionCancelOwnerAddressProposal:         decodeNoArguments,
+		vmcommon.BuiltInFunctionSetDeveloperRewardsSplit:           odp.decodeSetDeveloperRewardsSplit,
+		vmcommon.BuiltInFunctionRenewUserName:                      decodeRenewUserName,
+		vmcommon.BuiltInFunctionTransferUserName:                   odp.decodeTransferUserName,
+		vmcommon.BuiltInFunctionApproveUserNameTransfer:            odp.decodeApproveUserNameTransfer,
+	}
+}
+
+// decodeESDTTransfers fills the decoded data field with the transfers of an ESDT transfer built-in function call
+func (odp *operationDataFieldParser) decodeESDTTransfers(decoded *DecodedDataField, function string, args [][]byte, sender, receiver []byte) error {
+	parsedESDTTransfers, err := odp.esdtTransferParser.ParseESDTTransfers(sender, receiver, function, args)
+	if err != nil {
+		return err
+	}
+
+	decoded.Transfers = make([]*DecodedESDTTransfer, 0, len(parsedESDTTransfers.ESDTTransfers))
+	for _, transfer := range parsedESDTTransfers.ESDTTransfers {
+		if !isValidTokenIdentifier(transfer.ESDTTokenName) {
+			return ErrInvalidTokenIdentifier
+		}
+
+		decoded.Transfers = append(decoded.Transfers, &DecodedESDTTransfer{
+			Token: string(transfer.ESDTTokenName),
+			Nonce: transfer.ESDTTokenNonce,
+			Value: transfer.ESDTValue,
+		})
+	}
+	decoded.Destination = parsedESDTTransfers.RcvAddr
+	decoded.CallFunction = parsedESDTTransfers.CallFunction
+	decoded.CallArguments = parsedESDTTransfers.CallArgs
+
+	return nil
+}
+
+func decodeNoArguments(args [][]byte, _, _ []byte) ([]*DecodedArgument, error) {
+	return nil, checkNumArguments(args, 0)
+}
+
+func decodeClaimDeveloperRewards(_ [][]byte, _, _ []byte) ([]*DecodedArgument, error) {
+	return nil, nil
+}
+
+func (odp *operationDataFieldParser) decodeChangeOwnerAddress(args [][]byte, _, _ []byte) ([]*DecodedArgument, error) {
+	err := checkMinNumArguments(args, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	newOwner, err := odp.addressArgument("newOwner", args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return []*DecodedArgument{newOwner}, nil
+}
+
+func (odp *operationDataFieldParser) decodeProposeOwnerAddress(args [][]byte, _, _ []byte) ([]*DecodedArgument, error) {
+	err := checkNumArguments(args, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	pendingOwner, err := odp.addressArgument("pendingOwner", args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return []*DecodedArgument{pendingOwner}, nil
+}
+
+func (odp *operationDataFieldParser) decodeSetDeveloperRewardsSplit(args [][]byte, _, _ []byte) ([]*DecodedArgument, error) {
+	if len(args)%2 != 0 {
+		return nil, fmt.Errorf("%w, expected pairs of beneficiary and share", ErrInvalidNumberOfArguments)
+	}
+
+	decodedArgs := make([]*DecodedArgument, 0, len(args))
+	for i := 0; i < len(args); i += 2 {
+		beneficiary, err := odp.addressArgument("beneficiary", args[i])
+		if err != nil {
+			return nil, err
+		}
+
+		decodedArgs = append(decodedArgs, beneficiary, &DecodedArgument{
+			Name:  "basisPoints",
+			Type:  ArgumentTypeUint32,
+			Value: uint32(big.NewInt(0).SetBytes(args[i+1]).Uint64()),
+		})
+	}
+
+	return decodedArgs, nil
+}
+
+func decodeSetUserName(args [][]byte, _, _ []byte) ([]*DecodedArgument, error) {
+	if len(args) == 2 {
+		return []*DecodedArgument{stringArgument("userName", args[0]), expiryEpochArgument(args[1])}, nil
+	}
+
+	err := checkNumArguments(args, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*DecodedArgument{stringArgument("userName", args[0])}, nil
+}
+
+func decodeRenewUserName(args [][]byte, _, _ []byte) ([]*DecodedArgument, error) {
+	err := checkNumArguments(args, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	return []*DecodedArgument{expiryEpochArgument(args[0])}, nil
+}
+
+func (odp *operationDataFieldParser) decodeTransferUserName(args [][]byte, _, _ []byte) ([]*DecodedArgument, error) {
+	if len(args) != 3 {
+		err := checkNumArguments(args, 2)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	newHolder, err := odp.addressArgument("newHolder", args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := []*DecodedArgument{stringArgument("userName", args[0]), newHolder}
+	if len(args) == 3 {
+		decoded = append(decoded, expiryEpochArgument(args[2]))
+	}
+
+	return decoded, nil
+}
+
+func (odp *operationDataFieldParser) decodeApproveUserNameTransfer(args [][]byte, _, _ []byte) ([]*DecodedArgument, error) {
+	err := checkNumArguments(args, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	currentHolder, err := odp.addressArgument("currentHolder", args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return []*DecodedArgument{stringArgument("userName", args[0]), currentHolder}, nil
+}
+
+func decodeSaveKeyValue(args [][]byte, _, _ []byte) ([]*DecodedArgument, error) {
+	err := checkMinNumArguments(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	if len(args)%2 != 0 {
+		return nil, fmt.Errorf("%w, expected key value pairs, got %d arguments", ErrInvalidNumberOfArguments, len(args))
+	}
+
+	decodedArgs := make([]*DecodedArgument, 0, len(args))
+	for i := 0; i < len(args); i += 2 {
+		decodedArgs = append(decodedArgs, bytesArgument("key", args[i]), bytesArgument("value", args[i+1]))
+	}
+
+	return decodedArgs, nil
+}
+
+func decodeGlobalSettings(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	err := checkNumArguments(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSystemAccountCall(sender, receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := tokenArgument(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return []*DecodedArgument{token}, nil
+}
+
+func decodeRoles(args [][]byte, sender, _ []byte) ([]*DecodedArgument, error) {
+	err := checkMinNumArguments(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	err = checkCalledByESDTSystemSC(sender)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := tokenArgument(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make([]string, 0, len(args)-1)
+	for _, role := range args[1:] {
+		roles = append(roles, string(role))
+	}
+
+	return []*DecodedArgument{
+		token,
+		{Name: "roles", Type: ArgumentTypeStringList, Value: roles},
+	}, nil
+}
+
+func (odp *operationDataFieldParser) decodeTransferRoleAddresses(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	err := checkMinNumArguments(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSystemAccountCall(sender, receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := tokenArgument(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	for _, address := range args[1:] {
+		if len(address) != odp.addressLength {
+			return nil, fmt.Errorf("%w for addresses", ErrInvalidAddressArgument)
+		}
+	}
+
+	return []*DecodedArgument{
+		token,
+		{Name: "addresses", Type: ArgumentTypeAddressList, Value: args[1:]},
+	}, nil
+}
+
+func (odp *operationDataFieldParser) decodeCrossChainWhiteList(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	err := checkMinNumArguments(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSenderIsReceiver(sender, receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, address := range args {
+		if len(address) != odp.addressLength {
+			return nil, fmt.Errorf("%w for addresses", ErrInvalidAddressArgument)
+		}
+	}
+
+	return []*DecodedArgument{
+		{Name: "addresses", Type: ArgumentTypeAddressList, Value: args},
+	}, nil
+}
+
+func decodeBridgeDeposit(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	err := checkNumArguments(args, numArgsBridgeDeposit)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSenderIsReceiver(sender, receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := tokenArgument(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return []*DecodedArgument{
+		bytesArgument("destination", args[0]),
+		token,
+		nonceArgument(args[2]),
+		bigIntArgument("value", args[3]),
+	}, nil
+}
+
+func decodeBridgeWithdraw(args [][]byte, _, _ []byte) ([]*DecodedArgument, error) {
+	err := checkMinNumArguments(args, minNumArgsBridgeWithdraw)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) > maxNumArgsBridgeWithdraw {
+		return nil, fmt.Errorf("%w, expected at most %d, got %d", ErrInvalidNumberOfArguments, maxNumArgsBridgeWithdraw, len(args))
+	}
+
+	token, err := tokenArgument(args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	decodedArgs := []*DecodedArgument{
+		bytesArgument("operationHash", args[0]),
+		token,
+		nonceArgument(args[2]),
+		bigIntArgument("value", args[3]),
+	}
+	if len(args) == maxNumArgsBridgeWithdraw {
+		decodedArgs = append(decodedArgs, bytesArgument("tokenData", args[4]))
+	}
+
+	return decodedArgs, nil
+}
+
+func decodeNativeIssue(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	err := checkMinNumArguments(args, minNumArgsNativeIssue)
+	if err != nil {
+		return nil, err
+	}
+
+	return decodeNativeIssueArguments(args, sender, receiver, bigIntArgument("initialSupply", args[2]))
+}
+
+func decodeNativeIssueCollection(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	err := checkMinNumArguments(args, minNumArgsNativeIssue)
+	if err != nil {
+		return nil, err
+	}
+	_, err = core.ConvertESDTTypeToUint32(string(args[2]))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTokenType, args[2])
+	}
+
+	return decodeNativeIssueArguments(args, sender, receiver, stringArgument("tokenType", args[2]))
+}
+
+func decodeNativeIssueArguments(args [][]byte, sender, receiver []byte, supplyOrType *DecodedArgument) ([]*DecodedArgument, error) {
+	err := checkSenderIsReceiver(sender, receiver)
+	if err != nil {
+		return nil, err
+	}
+	err = checkTokenPropertiesArguments(args[minNumArgsNativeIssue:])
+	if err != nil {
+		return nil, err
+	}
+
+	return []*DecodedArgument{
+		stringArgument("name", args[0]),
+		stringArgument("ticker", args[1]),
+		supplyOrType,
+		{Name: "numDecimals", Type: ArgumentTypeUint32, Value: uint32(big.NewInt(0).SetBytes(args[3]).Uint64())},
+		tokenPropertiesArgument(args[minNumArgsNativeIssue:]),
+	}, nil
+}
+
+func decodeNativeSetTokenProperties(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	err := checkMinNumArguments(args, 3)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSenderIsReceiver(sender, receiver)
+	if err != nil {
+		return nil, err
+	}
+	err = checkTokenPropertiesArguments(args[1:])
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := tokenArgument(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return []*DecodedArgument{token, tokenPropertiesArgument(args[1:])}, nil
+}
+
+func (odp *operationDataFieldParser) decodeNativeTransferOwnership(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	err := checkNumArguments(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSenderIsReceiver(sender, receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := tokenArgument(args[0])
+	if err != nil {
+		return nil, err
+	}
+	newOwner, err := odp.addressArgument("newOwner", args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return []*DecodedArgument{token, newOwner}, nil
+}
+
+func decodeSetMaxSupply(args [][]byte, _, _ []byte) ([]*DecodedArgument, error) {
+	err := checkNumArguments(args, 3)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := tokenArgument(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return []*DecodedArgument{token, bigIntArgument("maxSupply", args[1]), bigIntArgument("currentSupply", args[2])}, nil
+}
+
+func decodeVestingTransfer(args [][]byte, _, _ []byte) ([]*DecodedArgument, error) {
+	err := checkMinNumArguments(args, minNumArgsVestingTransfer)
+	if err != nil {
+		return nil, err
+	}
+	if len(args)%2 != 0 {
+		return nil, fmt.Errorf("%w, vesting schedules should be provided as unlock round and amount pairs", ErrInvalidNumberOfArguments)
+	}
+
+	token, err := tokenArgument(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	decodedArgs := []*DecodedArgument{token, bigIntArgument("value", args[1])}
+	for i := 2; i < len(args); i += 2 {
+		decodedArgs = append(decodedArgs,
+			&DecodedArgument{Name: "unlockRound", Type: ArgumentTypeUint64, Value: big.NewInt(0).SetBytes(args[i]).Uint64()},
+			bigIntArgument("amount", args[i+1]),
+		)
+	}
+
+	return decodedArgs, nil
+}
+
+func decodeReleaseVesting(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	err := checkNumArguments(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSenderIsReceiver(sender, receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := tokenArgument(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return []*DecodedArgument{token}, nil
+}
+
+func (odp *operationDataFieldParser) decodeSetTransferFee(args [][]byte, _, _ []byte) ([]*DecodedArgument, error) {
+	err := checkMinNumArguments(args, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := tokenArgument(args[0])
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 1 {
+		return []*DecodedArgument{token}, nil
+	}
+
+	err = checkMinNumArguments(args, minNumArgsSetTransferFee)
+	if err != nil {
+		return nil, err
+	}
+	feeReceiver, err := odp.addressArgument("feeReceiver", args[3])
+	if err != nil {
+		return nil, err
+	}
+	for _, address := range args[4:] {
+		if len(address) != odp.addressLength {
+			return nil, fmt.Errorf("%w for exemptAddresses", ErrInvalidAddressArgument)
+		}
+	}
+
+	return []*DecodedArgument{
+		token,
+		{Name: "basisPoints", Type: ArgumentTypeUint32, Value: uint32(big.NewInt(0).SetBytes(args[1]).Uint64())},
+		bigIntArgument("fixedAmount", args[2]),
+		feeReceiver,
+		{Name: "exemptAddresses", Type: ArgumentTypeAddressList, Value: args[4:]},
+	}, nil
+}
+
+func decodeESDTBurn(args [][]byte, _, receiver []byte) ([]*DecodedArgument, error) {
+	err := checkNumArguments(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(receiver, core.ESDTSCAddress) {
+		return nil, ErrReceiverIsNotESDTSystemSC
+	}
+
+	token, err := tokenArgument(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return []*DecodedArgument{token, bigIntArgument("value", args[1])}, nil
+}
+
+func decodeLocalQuantity(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	err := checkMinNumArguments(args, minArgumentsQuantityOperationESDT)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSenderIsReceiver(sender, receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := tokenArgument(args[argsTokenPosition])
+	if err != nil {
+		return nil, err
+	}
+
+	return []*DecodedArgument{token, bigIntArgument("value", args[argsValuePositionFungible])}, nil
+}
+
+func decodeNFTQuantity(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	err := checkMinNumArguments(args, minArgumentsQuantityOperationNFT)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSenderIsReceiver(sender, receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenAndNonce, err := tokenAndNonceArguments(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(tokenAndNonce, bigIntArgument("value", args[argsValuePositionNonAndSemiFungible])), nil
+}
+
+func decodeNFTCreate(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	err := checkMinNumArguments(args, argsURIsStartPositionNFTCreate+1)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSenderIsReceiver(sender, receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := tokenArgument(args[0])
+	if err != nil {
+		return nil, err
+	}
+	royalties, err := royaltiesArgument(args[3])
+	if err != nil {
+		return nil, err
+	}
+
+	return []*DecodedArgument{
+		token,
+		bigIntArgument("initialQuantity", args[1]),
+		stringArgument("name", args[2]),
+		royalties,
+		bytesArgument("hash", args[4]),
+		bytesArgument("attributes", args[5]),
+		urisArgument(args[argsURIsStartPositionNFTCreate:]),
+	}, nil
+}
+
+// decodeFreezeWipe decodes both the call which names the token key and the call with separate token identifier, nonce
+// and optional partial amount
+func decodeFreezeWipe(args [][]byte, sender, _ []byte) ([]*DecodedArgument, error) {
+	if len(args) > maxNumArgsFreezeWipe {
+		return nil, fmt.Errorf("%w, expected at most %d, got %d", ErrInvalidNumberOfArguments, maxNumArgsFreezeWipe, len(args))
+	}
+	err := checkMinNumArguments(args, 1)
+	if err != nil {
+		return nil, err
+	}
+	err = checkCalledByESDTSystemSC(sender)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) > 1 {
+		decodedArgs, errDecode := tokenAndNonceArguments(args)
+		if errDecode != nil {
+			return nil, errDecode
+		}
+		if len(args) == maxNumArgsFreezeWipe {
+			decodedArgs = append(decodedArgs, bigIntArgument("amount", args[2]))
+		}
+
+		return decodedArgs, nil
+	}
+
+	token, nonce := extractTokenAndNonce(args[argsTokenPosition])
+	if !isValidTokenIdentifier([]byte(token)) {
+		return nil, ErrInvalidTokenIdentifier
+	}
+
+	return []*DecodedArgument{
+		{Name: "token", Type: ArgumentTypeString, Value: token},
+		{Name: "nonce", Type: ArgumentTypeUint64, Value: nonce},
+	}, nil
+}
+
+// decodeNFTCreateRoleTransfer decodes both the call issued by the ESDT system smart contract, which names the new
+// holder of the role, and the cross shard call which carries the last created nonce
+func (odp *operationDataFieldParser) decodeNFTCreateRoleTransfer(args [][]byte, sender, _ []byte) ([]*DecodedArgument, error) {
+	err := checkNumArguments(args, 2)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := tokenArgument(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	if !bytes.Equal(sender, core.ESDTSCAddress) {
+		return []*DecodedArgument{token, nonceArgument(args[1])}, nil
+	}
+
+	destination, err := odp.addressArgument("destination", args[1])
+	if err != nil {
+		return nil, err
+	}
+
+	return []*DecodedArgument{token, destination}, nil
+}
+
+func decodeNFTUpdateAttributes(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	err := checkNumArguments(args, 3)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSenderIsReceiver(sender, receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenAndNonce, err := tokenAndNonceArguments(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(tokenAndNonce, bytesArgument("attributes", args[2])), nil
+}
+
+func decodeNFTAddURIs(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	err := checkMinNumArguments(args, argsURIsStartPositionSetURIs+1)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSenderIsReceiver(sender, receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenAndNonce, err := tokenAndNonceArguments(args)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(tokenAndNonce, urisArgument(args[argsURIsStartPositionSetURIs:])), nil
+}
+
+func decodeDeleteMetadata(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	err := checkMinNumArguments(args, minNumArgsDeleteMetadata)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSenderIsReceiver(sender, receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	decodedArgs := make([]*DecodedArgument, 0, len(args))
+	for i := 0; i < len(args); {
+		token, errToken := tokenArgument(args[i])
+		if errToken != nil {
+			return nil, errToken
+		}
+		if i+1 >= len(args) {
+			return nil, ErrInvalidNumberOfArguments
+		}
+
+		numIntervals := big.NewInt(0).SetBytes(args[i+1]).Uint64()
+		i += 2
+		if numIntervals > uint64(len(args)-i)/2 {
+			return nil, ErrInvalidNumberOfArguments
+		}
+
+		decodedArgs = append(decodedArgs, token)
+		for j := uint64(0); j < numIntervals; j++ {
+			start := big.NewInt(0).SetBytes(args[i]).Uint64()
+			end := big.NewInt(0).SetBytes(args[i+1]).Uint64()
+			if start == 0 || end < start {
+				return nil, fmt.Errorf("%w, interval %d-%d", ErrInvalidNonce, start, end)
+			}
+
+			decodedArgs = append(decodedArgs,
+				&DecodedArgument{Name: "startNonce", Type: ArgumentTypeUint64, Value: start},
+				&DecodedArgument{Name: "endNonce", Type: ArgumentTypeUint64, Value: end},
+			)
+			i += 2
+		}
+	}
+
+	return decodedArgs, nil
+}
+
+func decodeAddMetadata(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	if len(args) < numArgsPerAddMetadata || len(args)%numArgsPerAddMetadata != 0 {
+		return nil, fmt.Errorf("%w, expected groups of %d arguments, got %d arguments", ErrInvalidNumberOfArguments, numArgsPerAddMetadata, len(args))
+	}
+	err := checkSenderIsReceiver(sender, receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	decodedArgs := make([]*DecodedArgument, 0, len(args))
+	for i := 0; i < len(args); i += numArgsPerAddMetadata {
+		if big.NewInt(0).SetBytes(args[i+1]).Uint64() == 0 {
+			return nil, ErrInvalidNonce
+		}
+		tokenAndNonce, errDecode := tokenAndNonceArguments(args[i:])
+		if errDecode != nil {
+			return nil, errDecode
+		}
+
+		decodedArgs = append(decodedArgs, tokenAndNonce...)
+		decodedArgs = append(decodedArgs, bytesArgument("metadata", args[i+2]))
+	}
+
+	return decodedArgs, nil
+}
+
+func (odp *operationDataFieldParser) decodeSetGuardian(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	err := checkNumArguments(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSenderIsReceiver(sender, receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	guardian, err := odp.addressArgument("guardian", args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	return []*DecodedArgument{guardian, bytesArgument("serviceUID", args[1])}, nil
+}
+
+func (odp *operationDataFieldParser) decodeSetGuardianSet(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	err := checkMinNumArguments(args, minNumArgsSetGuardianSet)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSenderIsReceiver(sender, receiver)
+	if err != nil {
+		return nil, err
+	}
+	for _, guardian := range args[2:] {
+		if len(guardian) != odp.addressLength {
+			return nil, fmt.Errorf("%w for guardians", ErrInvalidAddressArgument)
+		}
+	}
+
+	return []*DecodedArgument{
+		{Name: "threshold", Type: ArgumentTypeUint32, Value: uint32(big.NewInt(0).SetBytes(args[0]).Uint64())},
+		bytesArgument("serviceUID", args[1]),
+		{Name: "guardians", Type: ArgumentTypeAddressList, Value: args[2:]},
+	}, nil
+}
+
+func (odp *operationDataFieldParser) decodeSetGuardedSpendingPolicy(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	err := checkSenderIsReceiver(sender, receiver)
+	if err != nil {
+		return nil, err
+	}
+	if len(args) == 0 {
+		return nil, nil
+	}
+	err = checkMinNumArguments(args, minNumArgsSetSpendingPolicy)
+	if err != nil {
+		return nil, err
+	}
+
+	numLimits := big.NewInt(0).SetBytes(args[1]).Uint64()
+	receiversStartIndex := 2 + 2*numLimits
+	if numLimits > uint64(len(args)) || uint64(len(args)) < receiversStartIndex {
+		return nil, fmt.Errorf("%w, spending limits should be provided as token and amount pairs", ErrInvalidNumberOfArguments)
+	}
+
+	decodedArgs := []*DecodedArgument{
+		{Name: "periodInRounds", Type: ArgumentTypeUint64, Value: big.NewInt(0).SetBytes(args[0]).Uint64()},
+	}
+	for i := uint64(2); i < receiversStartIndex; i += 2 {
+		token, errToken := tokenArgument(args[i])
+		if errToken != nil {
+			return nil, errToken
+		}
+		decodedArgs = append(decodedArgs, token, bigIntArgument("limit", args[i+1]))
+	}
+
+	receivers := args[receiversStartIndex:]
+	for _, address := range receivers {
+		if len(address) != odp.addressLength {
+			return nil, fmt.Errorf("%w for whitelistedReceivers", ErrInvalidAddressArgument)
+		}
+	}
+
+	return append(decodedArgs, &DecodedArgument{Name: "whitelistedReceivers", Type: ArgumentTypeAddressList, Value: receivers}), nil
+}
+
+func decodeGuardAccount(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	err := checkNumArguments(args, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, checkSenderIsReceiver(sender, receiver)
+}
+
+func decodeSetTokenType(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	err := checkNumArguments(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSystemAccountCall(sender, receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := tokenArgument(args[0])
+	if err != nil {
+		return nil, err
+	}
+	_, err = core.ConvertESDTTypeToUint32(string(args[1]))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidTokenType, args[1])
+	}
+
+	return []*DecodedArgument{token, stringArgument("tokenType", args[1])}, nil
+}
+
+func decodeMetaDataRecreate(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	return decodeMetaDataArguments(args, sender, receiver, false)
+}
+
+func decodeMetaDataUpdate(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	return decodeMetaDataArguments(args, sender, receiver, true)
+}
+
+// decodeMetaDataArguments decodes the arguments of the metadata recreate and update functions. On update, empty
+// royalties mean that the current value is kept
+func decodeMetaDataArguments(args [][]byte, sender, receiver []byte, allowEmptyRoyalties bool) ([]*DecodedArgument, error) {
+	err := checkMinNumArguments(args, minNumArgsMetaDataRecreate)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSenderIsReceiver(sender, receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenAndNonce, err := tokenAndNonceArguments(args)
+	if err != nil {
+		return nil, err
+	}
+
+	royalties := &DecodedArgument{Name: "royalties", Type: ArgumentTypeUint32, Value: uint32(0)}
+	if !allowEmptyRoyalties || len(args[3]) > 0 {
+		royalties, err = royaltiesArgument(args[3])
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return append(tokenAndNonce,
+		stringArgument("name", args[2]),
+		royalties,
+		bytesArgument("hash", args[4]),
+		bytesArgument("attributes", args[5]),
+		urisArgument(args[argsURIsStartPositionMetaData:]),
+	), nil
+}
+
+func decodeModifyRoyalties(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	err := checkMinNumArguments(args, 3)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSenderIsReceiver(sender, receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	tokenAndNonce, err := tokenAndNonceArguments(args)
+	if err != nil {
+		return nil, err
+	}
+	royalties, err := royaltiesArgument(args[2])
+	if err != nil {
+		return nil, err
+	}
+
+	return append(tokenAndNonce, royalties), nil
+}
+
+func decodeModifyCreator(args [][]byte, sender, receiver []byte) ([]*DecodedArgument, error) {
+	err := checkMinNumArguments(args, 2)
+	if err != nil {
+		return nil, err
+	}
+	err = checkSenderIsReceiver(sender, receiver)
+	if err != nil {
+		return nil, err
+	}
+
+	return tokenAndNonceArguments(args)
+}
+
+func checkNumArguments(args [][]byte, expected int) error {
+	if len(args) != expected {
+		return fmt.Errorf("%w, expected %d, got %d", ErrInvalidNumberOfArguments, expected, len(args))
+	}
+
+	return nil
+}
+
+func checkMinNumArguments(args [][]byte, minimum int) error {
+	if len(args) < minimum {
+		return fmt.Errorf("%w, expected at least %d, got %d", ErrInvalidNumberOfArguments, minimum, len(args))
+	}
+
+	return nil
+}
+
+func checkTokenPropertiesArguments(args [][]byte) error {
+	if len(args)%2 != 0 {
+		return fmt.Errorf("%w, token properties should be name and value pairs, got %d arguments", ErrInvalidNumberOfArguments, len(args))
+	}
+
+	return nil
+}
+
+func checkCalledByESDTSystemSC(sender []byte) error {
+	if !bytes.Equal(sender, core.ESDTSCAddress) {
+		return ErrCallerIsNotESDTSystemSC
+	}
+
+	return nil
+}
+
+func checkSystemAccountCall(sender, receiver []byte) error {
+	err := checkCalledByESDTSystemSC(sender)
+	if err != nil {
+		return err
+	}
+	if !vmcommon.IsSystemAccountAddress(receiver) {
+		return ErrReceiverIsNotSystemAccount
+	}
+
+	return nil
+}
+
+func checkSenderIsReceiver(sender, receiver []byte) error {
+	if !bytes.Equal(sender, receiver) {
+		return ErrSenderIsNotReceiver
+	}
+
+	return nil
+}
+
+func isValidTokenIdentifier(token []byte) bool {
+	return len(token) > 0 && isASCIIString(string(token))
+}
+
+func tokenArgument(arg []byte) (*DecodedArgument, error) {
+	if !isValidTokenIdentifier(arg) {
+		return nil, ErrInvalidTokenIdentifier
+	}
+
+	return stringArgument("token", arg), nil
+}
+
+func tokenAndNonceArguments(args [][]byte) ([]*DecodedArgument, error) {
+	token, err := tokenArgument(args[argsTokenPosition])
+	if err != nil {
+		return nil, err
+	}
+
+	return []*DecodedArgument{token, nonceArgument(args[argsNoncePosition])}, nil
+}
+
+func nonceArgument(arg []byte) *DecodedArgument {
+	return &DecodedArgument{Name: "nonce", Type: ArgumentTypeUint64, Value: big.NewInt(0).SetBytes(arg).Uint64()}
+}
+
+func royaltiesArgument(arg []byte) (*DecodedArgument, error) {
+	royalties := big.NewInt(0).SetBytes(arg)
+	if royalties.Cmp(big.NewInt(int64(core.MaxRoyalty))) > 0 {
+		return nil, fmt.Errorf("%w, maximum is %d, got %s", ErrInvalidRoyalties, core.MaxRoyalty, royalties.String())
+	}
+
+	return &DecodedArgument{Name: "royalties", Type: ArgumentTypeUint32, Value: uint32(royalties.Uint64())}, nil
+}
+
+func (odp *operationDataFieldParser) addressArgument(name string, arg []byte) (*DecodedArgument, error) {
+	if len(arg) != odp.addressLength {
+		return nil, fmt.Errorf("%w for %s", ErrInvalidAddressArgument, name)
+	}
+
+	return &DecodedArgument{Name: name, Type: ArgumentTypeAddress, Value: arg}, nil
+}
+
+func urisArgument(args [][]byte) *DecodedArgument {
+	return &DecodedArgument{Name: "uris", Type: ArgumentTypeBytesList, Value: args}
+}
+
+func tokenPropertiesArgument(args [][]byte) *DecodedArgument {
+	properties := make([]string, 0, len(args))
+	for _, arg := range args {
+		properties = append(properties, string(arg))
+	}
+
+	return &DecodedArgument{Name: "properties", Type: ArgumentTypeStringList, Value: properties}
+}
+
+func bigIntArgument(name string, arg []byte) *DecodedArgument {
+	return &DecodedArgument{Name: name, Type: ArgumentTypeBigInt, Value: big.NewInt(0).SetBytes(arg)}
+}
+
+func expiryEpochArgument(arg []byte) *DecodedArgument {
+	return &DecodedArgument{Name: "expiryEpoch", Type: ArgumentTypeUint32, Value: uint32(big.NewInt(0).SetBytes(arg).Uint64())}
+}
+
+func stringArgument(name string, arg []byte) *DecodedArgument {
+	return &DecodedArgument{Name: name, Type: ArgumentTypeString, Value: string(arg)}
+}
+
+func bytesArgument(name string, arg []byte) *DecodedArgument {
+	return &DecodedArgument{Name: name, Type: ArgumentTypeBytes, Value: arg}
+}
@@ -0,0 +1,383 @@
+package datafield
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createDataField(function string, args ...[]byte) []byte {
+	dataField := function
+	for _, arg := range args {
+		dataField += "@" + hex.EncodeToString(arg)
+	}
+
+	return []byte(dataField)
+}
+
+func fillGasMapFromStruct(costs interface{}) map[string]uint64 {
+	gasMap := make(map[string]uint64)
+	costsType := reflect.TypeOf(costs)
+	for i := 0; i < costsType.NumField(); i++ {
+		gasMap[costsType.Field(i).Name] = 1
+	}
+
+	return gasMap
+}
+
+func TestOperationDataFieldParser_ParseDecodedCoversAllBuiltInFunctions(t *testing.T) {
+	t.Parallel()
+
+	creator, err := builtInFunctions.NewBuiltInFunctionsCreator(builtInFunctions.ArgsCreateBuiltInFunctionContainer{
+		GasMap: map[string]map[string]uint64{
+			core.BaseOperationCostString: fillGasMapFromStruct(vmcommon.BaseOperationCost{}),
+			core.BuiltInCostString:       fillGasMapFromStruct(vmcommon.BuiltInCost{}),
+		},
+		MapDNSAddresses:                   make(map[string]struct{}),
+		MapDNSV2Addresses:                 make(map[string]struct{}),
+		MapWhiteListedCrossChainAddresses: map[string]struct{}{"whiteListedAddress": {}},
+		Marshalizer:                       &mock.MarshalizerMock{},
+		Accounts:                          &mock.AccountsStub{},
+		ShardCoordinator:                  mock.NewMultiShardsCoordinatorMock(1),
+		EnableEpochsHandler:               &mock.EnableEpochsHandlerStub{},
+		GuardedAccountHandler:             &mock.GuardedAccountHandlerStub{},
+		MaxNumOfAddressesForTransferRole:  100,
+	})
+	require.Nil(t, err)
+	require.Nil(t, creator.CreateBuiltInFunctionContainer())
+
+	parser, _ := NewOperationDataFieldParser(createMockArgumentsOperationParser())
+	for function := range creator.BuiltInFunctionContainer().Keys() {
+		res := parser.ParseDecoded([]byte(function), sender, sender, 3)
+		assert.True(t, res.IsBuiltInFunction, function)
+	}
+}
+
+func TestOperationDataFieldParser_ParseDecoded(t *testing.T) {
+	t.Parallel()
+
+	parser, _ := NewOperationDataFieldParser(createMockArgumentsOperationParser())
+	token := []byte("NFT-1f0ff8")
+
+	t.Run("not a built-in function should be valid", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.ParseDecoded([]byte("callMe@01"), sender, receiverSC, 3)
+		assert.False(t, res.IsBuiltInFunction)
+		assert.True(t, res.IsValid)
+		assert.Equal(t, "callMe", res.Function)
+		assert.Nil(t, res.Arguments)
+	})
+	t.Run("non hex arguments should be rejected", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.ParseDecoded([]byte("ESDTNFTCreate@zz"), sender, sender, 3)
+		assert.True(t, res.IsBuiltInFunction)
+		assert.False(t, res.IsValid)
+		assert.NotEmpty(t, res.InvalidReason)
+	})
+	t.Run("ESDTNFTCreate", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := createDataField(core.BuiltInFunctionESDTNFTCreate, token, []byte{1}, []byte("name"), []byte{0x03, 0xe8}, []byte("hash"), []byte("attributes"), []byte("uri1"), []byte("uri2"))
+		res := parser.ParseDecoded(dataField, sender, sender, 3)
+		require.True(t, res.IsValid, res.InvalidReason)
+		assert.Equal(t, []string{"1"}, res.ESDTValues)
+		assert.Equal(t, []*DecodedArgument{
+			{Name: "token", Type: ArgumentTypeString, Value: string(token)},
+			{Name: "initialQuantity", Type: ArgumentTypeBigInt, Value: big.NewInt(1)},
+			{Name: "name", Type: ArgumentTypeString, Value: "name"},
+			{Name: "royalties", Type: ArgumentTypeUint32, Value: uint32(1000)},
+			{Name: "hash", Type: ArgumentTypeBytes, Value: []byte("hash")},
+			{Name: "attributes", Type: ArgumentTypeBytes, Value: []byte("attributes")},
+			{Name: "uris", Type: ArgumentTypeBytesList, Value: [][]byte{[]byte("uri1"), []byte("uri2")}},
+		}, res.Arguments)
+
+		dataField = createDataField(core.BuiltInFunctionESDTNFTCreate, token, []byte{1}, []byte("name"), big.NewInt(10001).Bytes(), []byte("hash"), []byte("attributes"), []byte("uri"))
+		res = parser.ParseDecoded(dataField, sender, sender, 3)
+		assert.False(t, res.IsValid)
+		assert.Nil(t, res.Arguments)
+		assert.True(t, strings.Contains(res.InvalidReason, ErrInvalidRoyalties.Error()))
+
+		res = parser.ParseDecoded(dataField, sender, receiver, 3)
+		assert.Equal(t, ErrSenderIsNotReceiver.Error(), res.InvalidReason)
+	})
+	t.Run("ESDTModifyRoyalties", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.ParseDecoded(createDataField(core.ESDTModifyRoyalties, token, []byte{5}, []byte{0x01, 0xf4}), sender, sender, 3)
+		require.True(t, res.IsValid, res.InvalidReason)
+		assert.Equal(t, []string{"NFT-1f0ff8-05"}, res.Tokens)
+		assert.Equal(t, []*DecodedArgument{
+			{Name: "token", Type: ArgumentTypeString, Value: string(token)},
+			{Name: "nonce", Type: ArgumentTypeUint64, Value: uint64(5)},
+			{Name: "royalties", Type: ArgumentTypeUint32, Value: uint32(500)},
+		}, res.Arguments)
+
+		res = parser.ParseDecoded(createDataField(core.ESDTModifyRoyalties, token, []byte{5}), sender, sender, 3)
+		assert.False(t, res.IsValid)
+		assert.Equal(t, fmt.Sprintf("%s, expected at least 3, got 2", ErrInvalidNumberOfArguments), res.InvalidReason)
+	})
+	t.Run("ESDTMetaDataUpdate with empty royalties should keep them", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.ParseDecoded(createDataField(core.ESDTMetaDataUpdate, token, []byte{5}, []byte("name"), nil, nil, nil, nil), sender, sender, 3)
+		require.True(t, res.IsValid, res.InvalidReason)
+		assert.Equal(t, &DecodedArgument{Name: "royalties", Type: ArgumentTypeUint32, Value: uint32(0)}, res.Arguments[3])
+
+		res = parser.ParseDecoded(createDataField(core.ESDTMetaDataRecreate, token, []byte{5}, []byte("name"), big.NewInt(10001).Bytes(), nil, nil, nil), sender, sender, 3)
+		assert.False(t, res.IsValid)
+	})
+	t.Run("SetGuardian", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.ParseDecoded(createDataField(core.BuiltInFunctionSetGuardian, receiver, []byte("uid")), sender, sender, 3)
+		require.True(t, res.IsValid, res.InvalidReason)
+		assert.Equal(t, []*DecodedArgument{
+			{Name: "guardian", Type: ArgumentTypeAddress, Value: receiver},
+			{Name: "serviceUID", Type: ArgumentTypeBytes, Value: []byte("uid")},
+		}, res.Arguments)
+
+		res = parser.ParseDecoded(createDataField(core.BuiltInFunctionSetGuardian, []byte("short"), []byte("uid")), sender, sender, 3)
+		assert.Equal(t, ErrInvalidAddressArgument.Error()+" for guardian", res.InvalidReason)
+	})
+	t.Run("SetGuardianSet", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.ParseDecoded(createDataField(vmcommon.BuiltInFunctionSetGuardianSet, []byte{2}, []byte("uid"), receiver, sender), sender, sender, 3)
+		require.True(t, res.IsValid, res.InvalidReason)
+		assert.Equal(t, []*DecodedArgument{
+			{Name: "threshold", Type: ArgumentTypeUint32, Value: uint32(2)},
+			{Name: "serviceUID", Type: ArgumentTypeBytes, Value: []byte("uid")},
+			{Name: "guardians", Type: ArgumentTypeAddressList, Value: [][]byte{receiver, sender}},
+		}, res.Arguments)
+
+		res = parser.ParseDecoded(createDataField(vmcommon.BuiltInFunctionSetGuardianSet, []byte{2}, []byte("uid"), receiver, []byte("short")), sender, sender, 3)
+		assert.Equal(t, ErrInvalidAddressArgument.Error()+" for guardians", res.InvalidReason)
+
+		res = parser.ParseDecoded(createDataField(vmcommon.BuiltInFunctionSetGuardianSet, []byte{2}, []byte("uid"), receiver, sender), sender, receiver, 3)
+		assert.False(t, res.IsValid)
+	})
+	t.Run("SetGuardedSpendingPolicy", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.ParseDecoded(createDataField(vmcommon.BuiltInFunctionSetGuardedSpendingPolicy, []byte{100}, []byte{1}, token, []byte{10}, receiver), sender, sender, 3)
+		require.True(t, res.IsValid, res.InvalidReason)
+		assert.Equal(t, []*DecodedArgument{
+			{Name: "periodInRounds", Type: ArgumentTypeUint64, Value: uint64(100)},
+			{Name: "token", Type: ArgumentTypeString, Value: string(token)},
+			{Name: "limit", Type: ArgumentTypeBigInt, Value: big.NewInt(10)},
+			{Name: "whitelistedReceivers", Type: ArgumentTypeAddressList, Value: [][]byte{receiver}},
+		}, res.Arguments)
+
+		res = parser.ParseDecoded(createDataField(vmcommon.BuiltInFunctionSetGuardedSpendingPolicy, []byte{100}, []byte{2}, token, []byte{10}), sender, sender, 3)
+		assert.False(t, res.IsValid)
+
+		res = parser.ParseDecoded(createDataField(vmcommon.BuiltInFunctionSetGuardedSpendingPolicy), sender, sender, 3)
+		assert.True(t, res.IsValid, res.InvalidReason)
+	})
+	t.Run("SetESDTRole", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := createDataField(core.BuiltInFunctionSetESDTRole, token, []byte(core.ESDTRoleNFTCreate), []byte(core.ESDTRoleNFTBurn))
+		res := parser.ParseDecoded(dataField, core.ESDTSCAddress, receiver, 3)
+		require.True(t, res.IsValid, res.InvalidReason)
+		assert.Equal(t, []*DecodedArgument{
+			{Name: "token", Type: ArgumentTypeString, Value: string(token)},
+			{Name: "roles", Type: ArgumentTypeStringList, Value: []string{core.ESDTRoleNFTCreate, core.ESDTRoleNFTBurn}},
+		}, res.Arguments)
+
+		res = parser.ParseDecoded(dataField, sender, receiver, 3)
+		assert.Equal(t, ErrCallerIsNotESDTSystemSC.Error(), res.InvalidReason)
+	})
+	t.Run("ESDTPause should be sent to the system account", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := createDataField(core.BuiltInFunctionESDTPause, token)
+		res := parser.ParseDecoded(dataField, core.ESDTSCAddress, vmcommon.SystemAccountAddress, 3)
+		assert.True(t, res.IsValid, res.InvalidReason)
+
+		res = parser.ParseDecoded(dataField, core.ESDTSCAddress, receiver, 3)
+		assert.Equal(t, ErrReceiverIsNotSystemAccount.Error(), res.InvalidReason)
+	})
+	t.Run("ESDTSetTokenType", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.ParseDecoded(createDataField(core.ESDTSetTokenType, token, []byte(core.NonFungibleESDTv2)), core.ESDTSCAddress, vmcommon.SystemAccountAddress, 3)
+		assert.True(t, res.IsValid, res.InvalidReason)
+
+		res = parser.ParseDecoded(createDataField(core.ESDTSetTokenType, token, []byte("unknown")), core.ESDTSCAddress, vmcommon.SystemAccountAddress, 3)
+		assert.Equal(t, ErrInvalidTokenType.Error()+": unknown", res.InvalidReason)
+	})
+	t.Run("ESDTNativeIssueCollection", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := createDataField(vmcommon.BuiltInFunctionESDTNativeIssueCollection, []byte("Collection"), []byte("COL"), []byte(core.SemiFungibleESDT), []byte{}, []byte("canFreeze"), []byte("true"))
+		res := parser.ParseDecoded(dataField, sender, sender, 3)
+		require.True(t, res.IsValid, res.InvalidReason)
+		assert.Equal(t, []*DecodedArgument{
+			{Name: "name", Type: ArgumentTypeString, Value: "Collection"},
+			{Name: "ticker", Type: ArgumentTypeString, Value: "COL"},
+			{Name: "tokenType", Type: ArgumentTypeString, Value: core.SemiFungibleESDT},
+			{Name: "numDecimals", Type: ArgumentTypeUint32, Value: uint32(0)},
+			{Name: "properties", Type: ArgumentTypeStringList, Value: []string{"canFreeze", "true"}},
+		}, res.Arguments)
+
+		res = parser.ParseDecoded(createDataField(vmcommon.BuiltInFunctionESDTNativeIssueCollection, []byte("Collection"), []byte("COL"), []byte("unknown"), []byte{}), sender, sender, 3)
+		assert.Equal(t, ErrInvalidTokenType.Error()+": unknown", res.InvalidReason)
+
+		res = parser.ParseDecoded(append(dataField, []byte("@00")...), sender, sender, 3)
+		assert.False(t, res.IsValid)
+	})
+	t.Run("ESDTFreeze with nonce", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.ParseDecoded(createDataField(core.BuiltInFunctionESDTFreeze, append([]byte("NFT-1f0ff8"), 0x0a)), core.ESDTSCAddress, receiver, 3)
+		require.True(t, res.IsValid, res.InvalidReason)
+		assert.Equal(t, []*DecodedArgument{
+			{Name: "token", Type: ArgumentTypeString, Value: "NFT-1f0ff8"},
+			{Name: "nonce", Type: ArgumentTypeUint64, Value: uint64(10)},
+		}, res.Arguments)
+	})
+	t.Run("ESDTWipe partial amount", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.ParseDecoded(createDataField(core.BuiltInFunctionESDTWipe, []byte("TKN-1f0ff8"), []byte{}, big.NewInt(25).Bytes()), core.ESDTSCAddress, receiver, 3)
+		require.True(t, res.IsValid, res.InvalidReason)
+		assert.Equal(t, []*DecodedArgument{
+			{Name: "token", Type: ArgumentTypeString, Value: "TKN-1f0ff8"},
+			{Name: "nonce", Type: ArgumentTypeUint64, Value: uint64(0)},
+			{Name: "amount", Type: ArgumentTypeBigInt, Value: big.NewInt(25)},
+		}, res.Arguments)
+		assert.Equal(t, []string{"TKN-1f0ff8"}, res.Tokens)
+
+		res = parser.ParseDecoded(createDataField(core.BuiltInFunctionESDTWipe, []byte("TKN-1f0ff8"), []byte{}, []byte{1}, []byte{1}), core.ESDTSCAddress, receiver, 3)
+		assert.False(t, res.IsValid)
+	})
+	t.Run("ChangeOwnerAddress", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.ParseDecoded(createDataField(core.BuiltInFunctionChangeOwnerAddress, receiver), sender, receiverSC, 3)
+		require.True(t, res.IsValid, res.InvalidReason)
+		assert.Equal(t, []*DecodedArgument{{Name: "newOwner", Type: ArgumentTypeAddress, Value: receiver}}, res.Arguments)
+	})
+	t.Run("ProposeOwnerAddress", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.ParseDecoded(createDataField(vmcommon.BuiltInFunctionProposeOwnerAddress, receiver), sender, receiverSC, 3)
+		require.True(t, res.IsValid, res.InvalidReason)
+		assert.Equal(t, []*DecodedArgument{{Name: "pendingOwner", Type: ArgumentTypeAddress, Value: receiver}}, res.Arguments)
+
+		res = parser.ParseDecoded(createDataField(vmcommon.BuiltInFunctionProposeOwnerAddress, receiver, sender), sender, receiverSC, 3)
+		assert.False(t, res.IsValid)
+	})
+	t.Run("RenewUserName", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.ParseDecoded(createDataField(vmcommon.BuiltInFunctionRenewUserName, big.NewInt(1500).Bytes()), sender, receiver, 3)
+		require.True(t, res.IsValid, res.InvalidReason)
+		assert.Equal(t, []*DecodedArgument{{Name: "expiryEpoch", Type: ArgumentTypeUint32, Value: uint32(1500)}}, res.Arguments)
+	})
+	t.Run("TransferUserName", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.ParseDecoded(createDataField(vmcommon.BuiltInFunctionTransferUserName, []byte("name.elrond"), receiver), sender, receiver, 3)
+		require.True(t, res.IsValid, res.InvalidReason)
+		assert.Equal(t, []*DecodedArgument{
+			{Name: "userName", Type: ArgumentTypeString, Value: "name.elrond"},
+			{Name: "newHolder", Type: ArgumentTypeAddress, Value: receiver},
+		}, res.Arguments)
+
+		res = parser.ParseDecoded(createDataField(vmcommon.BuiltInFunctionTransferUserName, []byte("name.elrond"), receiver, big.NewInt(1500).Bytes()), sender, receiver, 3)
+		require.True(t, res.IsValid, res.InvalidReason)
+		assert.Equal(t, []*DecodedArgument{
+			{Name: "userName", Type: ArgumentTypeString, Value: "name.elrond"},
+			{Name: "newHolder", Type: ArgumentTypeAddress, Value: receiver},
+			{Name: "expiryEpoch", Type: ArgumentTypeUint32, Value: uint32(1500)},
+		}, res.Arguments)
+
+		res = parser.ParseDecoded(createDataField(vmcommon.BuiltInFunctionTransferUserName, []byte("name.elrond")), sender, receiver, 3)
+		assert.False(t, res.IsValid)
+	})
+	t.Run("ApproveUserNameTransfer", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.ParseDecoded(createDataField(vmcommon.BuiltInFunctionApproveUserNameTransfer, []byte("name.elrond"), sender), sender, receiver, 3)
+		require.True(t, res.IsValid, res.InvalidReason)
+		assert.Equal(t, []*DecodedArgument{
+			{Name: "userName", Type: ArgumentTypeString, Value: "name.elrond"},
+			{Name: "currentHolder", Type: ArgumentTypeAddress, Value: sender},
+		}, res.Arguments)
+
+		res = parser.ParseDecoded(createDataField(vmcommon.BuiltInFunctionApproveUserNameTransfer, []byte("name.elrond")), sender, receiver, 3)
+		assert.False(t, res.IsValid)
+	})
+	t.Run("SetDeveloperRewardsSplit", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.ParseDecoded(createDataField(vmcommon.BuiltInFunctionSetDeveloperRewardsSplit, receiver, big.NewInt(2500).Bytes()), sender, receiverSC, 3)
+		require.True(t, res.IsValid, res.InvalidReason)
+		assert.Equal(t, []*DecodedArgument{
+			{Name: "beneficiary", Type: ArgumentTypeAddress, Value: receiver},
+			{Name: "basisPoints", Type: ArgumentTypeUint32, Value: uint32(2500)},
+		}, res.Arguments)
+
+		res = parser.ParseDecoded(createDataField(vmcommon.BuiltInFunctionSetDeveloperRewardsSplit, receiver), sender, receiverSC, 3)
+		assert.False(t, res.IsValid)
+	})
+	t.Run("SaveKeyValue", func(t *testing.T) {
+		t.Parallel()
+
+		res := parser.ParseDecoded(createDataField(core.BuiltInFunctionSaveKeyValue, []byte("key"), []byte("value")), sender, sender, 3)
+		require.True(t, res.IsValid, res.InvalidReason)
+		assert.Equal(t, []*DecodedArgument{
+			{Name: "key", Type: ArgumentTypeBytes, Value: []byte("key")},
+			{Name: "value", Type: ArgumentTypeBytes, Value: []byte("value")},
+		}, res.Arguments)
+
+		res = parser.ParseDecoded(createDataField(core.BuiltInFunctionSaveKeyValue, []byte("key"), []byte("value"), []byte("key2")), sender, sender, 3)
+		assert.False(t, res.IsValid)
+	})
+	t.Run("ESDTDeleteMetadata", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := createDataField(vmcommon.ESDTDeleteMetadata, token, []byte{1}, []byte{1}, []byte{5})
+		res := parser.ParseDecoded(dataField, sender, sender, 3)
+		require.True(t, res.IsValid, res.InvalidReason)
+		assert.Equal(t, 3, len(res.Arguments))
+
+		dataField = createDataField(vmcommon.ESDTDeleteMetadata, token, []byte{2}, []byte{1}, []byte{5})
+		res = parser.ParseDecoded(dataField, sender, sender, 3)
+		assert.Equal(t, ErrInvalidNumberOfArguments.Error(), res.InvalidReason)
+	})
+	t.Run("MultiESDTNFTTransfer", func(t *testing.T) {
+		t.Parallel()
+
+		dataField := createDataField(core.BuiltInFunctionMultiESDTNFTTransfer, receiverSC, []byte{2}, []byte("TKN-abcdef"), nil, []byte{100}, token, []byte{3}, []byte{1}, []byte("callMe"), []byte{7})
+		res := parser.ParseDecoded(dataField, sender, sender, 3)
+		require.True(t, res.IsValid, res.InvalidReason)
+		assert.Nil(t, res.Arguments)
+		assert.Equal(t, []*DecodedESDTTransfer{
+			{Token: "TKN-abcdef", Nonce: 0, Value: big.NewInt(100)},
+			{Token: string(token), Nonce: 3, Value: big.NewInt(1)},
+		}, res.Transfers)
+		assert.Equal(t, receiverSC, res.Destination)
+		assert.Equal(t, "callMe", res.CallFunction)
+		assert.Equal(t, [][]byte{{7}}, res.CallArguments)
+
+		dataField = createDataField(core.BuiltInFunctionMultiESDTNFTTransfer, receiverSC, []byte{2}, []byte("TKN-abcdef"), nil, []byte{100})
+		res = parser.ParseDecoded(dataField, sender, sender, 3)
+		assert.False(t, res.IsValid)
+		assert.Nil(t, res.Transfers)
+	})
+}
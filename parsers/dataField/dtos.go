@@ -1,5 +1,7 @@
 package datafield
 
+import "math/big"
+
 // ResponseParseData is the response with results after the data field was parsed
 type ResponseParseData struct {
 	// Operation field is used to store the name of the operation that the transaction will try to do
@@ -19,3 +21,55 @@ func NewResponseParseDataAsRelayed() *ResponseParseData {
 		IsRelayed: true,
 	}
 }
+
+// ArgumentType specifies the go type of the value held by a decoded argument
+type ArgumentType string
+
+const (
+	// ArgumentTypeString is used for arguments holding a string value
+	ArgumentTypeString ArgumentType = "string"
+	// ArgumentTypeBytes is used for arguments holding a []byte value
+	ArgumentTypeBytes ArgumentType = "bytes"
+	// ArgumentTypeAddress is used for arguments holding an address as a []byte value
+	ArgumentTypeAddress ArgumentType = "address"
+	// ArgumentTypeBigInt is used for arguments holding a *big.Int value
+	ArgumentTypeBigInt ArgumentType = "bigInt"
+	// ArgumentTypeUint64 is used for arguments holding an uint64 value
+	ArgumentTypeUint64 ArgumentType = "uint64"
+	// ArgumentTypeUint32 is used for arguments holding an uint32 value
+	ArgumentTypeUint32 ArgumentType = "uint32"
+	// ArgumentTypeStringList is used for arguments holding a []string value
+	ArgumentTypeStringList ArgumentType = "stringList"
+	// ArgumentTypeBytesList is used for arguments holding a [][]byte value
+	ArgumentTypeBytesList ArgumentType = "bytesList"
+	// ArgumentTypeAddressList is used for arguments holding a list of addresses as a [][]byte value
+	ArgumentTypeAddressList ArgumentType = "addressList"
+)
+
+// DecodedArgument is one named and typed argument of a built-in function call
+type DecodedArgument struct {
+	Name  string
+	Type  ArgumentType
+	Value interface{}
+}
+
+// DecodedESDTTransfer is one token transfer decoded from an ESDT transfer built-in function call
+type DecodedESDTTransfer struct {
+	Token string
+	Nonce uint64
+	Value *big.Int
+}
+
+// DecodedDataField is the response of the decoding mode of the data field parser. Besides the summary returned by
+// Parse, it holds the decoded arguments of the called built-in function and whether the call would be rejected
+type DecodedDataField struct {
+	*ResponseParseData
+	IsBuiltInFunction bool
+	Arguments         []*DecodedArgument
+	Transfers         []*DecodedESDTTransfer
+	Destination       []byte
+	CallFunction      string
+	CallArguments     [][]byte
+	IsValid           bool
+	InvalidReason     string
+}
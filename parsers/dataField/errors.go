@@ -0,0 +1,33 @@
+package datafield
+
+import "errors"
+
+// ErrInvalidNumberOfArguments signals that the data field holds a wrong number of arguments for the called function
+var ErrInvalidNumberOfArguments = errors.New("invalid number of arguments")
+
+// ErrInvalidTokenIdentifier signals that a token identifier argument is empty or not readable
+var ErrInvalidTokenIdentifier = errors.New("invalid token identifier")
+
+// ErrInvalidAddressArgument signals that an address argument does not have the expected length
+var ErrInvalidAddressArgument = errors.New("invalid address argument")
+
+// ErrInvalidRoyalties signals that the provided royalties exceed the maximum allowed value
+var ErrInvalidRoyalties = errors.New("invalid royalties")
+
+// ErrInvalidTokenType signals that the provided token type is not known
+var ErrInvalidTokenType = errors.New("invalid token type")
+
+// ErrInvalidNonce signals that a nonce argument is not valid for the called function
+var ErrInvalidNonce = errors.New("invalid nonce")
+
+// ErrCallerIsNotESDTSystemSC signals that a function reserved to the ESDT system smart contract was called by another address
+var ErrCallerIsNotESDTSystemSC = errors.New("caller is not the ESDT system smart contract")
+
+// ErrReceiverIsNotSystemAccount signals that a function which must be sent to the system account has another receiver
+var ErrReceiverIsNotSystemAccount = errors.New("receiver is not the system account")
+
+// ErrReceiverIsNotESDTSystemSC signals that a function which must be sent to the ESDT system smart contract has another receiver
+var ErrReceiverIsNotESDTSystemSC = errors.New("receiver is not the ESDT system smart contract")
+
+// ErrSenderIsNotReceiver signals that a function which must be sent to self has a different receiver
+var ErrSenderIsNotReceiver = errors.New("sender is not the receiver")
@@ -1,6 +1,7 @@
 package datafield
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"math/big"
@@ -28,6 +29,8 @@ const (
 	argsNoncePosition                   = 1
 	argsValuePositionNonAndSemiFungible = 2
 	argsValuePositionFungible           = 1
+
+	atSeparator = "@"
 )
 
 var errInvalidAddressLength = errors.New("invalid address length")
@@ -38,6 +41,7 @@ type operationDataFieldParser struct {
 	addressLength      int
 	argsParser         vmcommon.CallArgsParser
 	esdtTransferParser vmcommon.ESDTTransferParser
+	argumentsDecoders  map[string]argumentsDecoder
 }
 
 // NewOperationDataFieldParser will return a new instance of operationDataFieldParser
@@ -55,12 +59,15 @@ func NewOperationDataFieldParser(args *ArgsOperationDataFieldParser) (*operation
 		return nil, err
 	}
 
-	return &operationDataFieldParser{
+	odp := &operationDataFieldParser{
 		argsParser:           argsParser,
 		esdtTransferParser:   esdtTransferParser,
 		addressLength:        args.AddressLength,
 		builtInFunctionsList: getAllBuiltInFunctions(),
-	}, nil
+	}
+	odp.argumentsDecoders = odp.createArgumentsDecoders()
+
+	return odp, nil
 }
 
 // Parse will parse the provided data field
@@ -68,6 +75,48 @@ func (odp *operationDataFieldParser) Parse(dataField []byte, sender, receiver []
 	return odp.parse(dataField, sender, receiver, false, numOfShards)
 }
 
+// ParseDecoded will parse the provided data field and will also decode the arguments of the called built-in function,
+// reporting the reason for which the built-in function would reject the data field, if any
+func (odp *operationDataFieldParser) ParseDecoded(dataField []byte, sender, receiver []byte, numOfShards uint32) *DecodedDataField {
+	decoded := &DecodedDataField{
+		ResponseParseData: odp.parse(dataField, sender, receiver, false, numOfShards),
+		IsValid:           true,
+	}
+	if decoded.IsRelayed || decoded.Operation == operationDeploy {
+		return decoded
+	}
+
+	function, args, err := odp.argsParser.ParseData(string(dataField))
+	if err != nil {
+		function = string(bytes.Split(dataField, []byte(atSeparator))[0])
+	}
+
+	isDecodedTransfer := function == core.BuiltInFunctionESDTTransfer ||
+		function == core.BuiltInFunctionESDTNFTTransfer ||
+		function == core.BuiltInFunctionMultiESDTNFTTransfer
+	decoder, isDecodedFunction := odp.argumentsDecoders[function]
+	if !isDecodedTransfer && !isDecodedFunction {
+		return decoded
+	}
+
+	decoded.IsBuiltInFunction = true
+	if err == nil {
+		if isDecodedTransfer {
+			err = odp.decodeESDTTransfers(decoded, function, args, sender, receiver)
+		} else {
+			decoded.Arguments, err = decoder(args, sender, receiver)
+		}
+	}
+	if err != nil {
+		decoded.Arguments = nil
+		decoded.Transfers = nil
+		decoded.IsValid = false
+		decoded.InvalidReason = err.Error()
+	}
+
+	return decoded
+}
+
 func (odp *operationDataFieldParser) parse(dataField []byte, sender, receiver []byte, ignoreRelayed bool, numOfShards uint32) *ResponseParseData {
 	responseParse := &ResponseParseData{
 		Operation: OperationTransfer,
@@ -188,6 +237,10 @@ func parseBlockingOperationESDT(args [][]byte, funcName string) *ResponseParseDa
 	}
 
 	token, nonce := extractTokenAndNonce(args[argsTokenPosition])
+	if len(args) > argsNoncePosition {
+		token = string(args[argsTokenPosition])
+		nonce = big.NewInt(0).SetBytes(args[argsNoncePosition]).Uint64()
+	}
 	if !isASCIIString(token) {
 		return responseData
 	}
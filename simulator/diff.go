@@ -0,0 +1,206 @@
+package simulator
+
+import (
+	"bytes"
+	"math/big"
+	"sort"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+)
+
+const (
+	esdtKeyPrefix            = core.ProtectedKeyPrefix + core.ESDTKeyIdentifier
+	esdtIdentifierSeparator  = "-"
+	esdtRandomSequenceLength = 6
+)
+
+// AccountChange holds all the changes done on one account
+type AccountChange struct {
+	Address               []byte
+	BalanceBefore         *big.Int
+	BalanceAfter          *big.Int
+	ESDTChanges           []*ESDTChange
+	GlobalMetadataChanges []*GlobalMetadataChange
+	StorageChanges        []*StorageChange
+}
+
+// BalanceDelta returns the difference between the balance after and the balance before the execution
+func (ac *AccountChange) BalanceDelta() *big.Int {
+	return big.NewInt(0).Sub(ac.BalanceAfter, ac.BalanceBefore)
+}
+
+// ESDTChange holds the change of one ESDT token saved on an account. A nil before or after value means
+// that the token did not exist
+type ESDTChange struct {
+	TokenIdentifier []byte
+	Nonce           uint64
+	Before          *esdt.ESDigitalToken
+	After           *esdt.ESDigitalToken
+}
+
+// ValueDelta returns the difference between the token value after and the token value before the execution
+func (ec *ESDTChange) ValueDelta() *big.Int {
+	return big.NewInt(0).Sub(esdtValue(ec.After), esdtValue(ec.Before))
+}
+
+// GlobalMetadataChange holds the change of the global settings of one token, saved on the system account
+type GlobalMetadataChange struct {
+	TokenIdentifier []byte
+	Before          builtInFunctions.ESDTGlobalMetadata
+	After           builtInFunctions.ESDTGlobalMetadata
+}
+
+// StorageChange holds the change of a storage key which could not be decoded as ESDT data.
+// A nil before or after value means that the key did not exist
+type StorageChange struct {
+	Key    []byte
+	Before []byte
+	After  []byte
+}
+
+type accountState struct {
+	balance *big.Int
+	data    map[string][]byte
+}
+
+func (s *simulator) computeAccountChange(address []byte, before *accountState, after *accountState) *AccountChange {
+	change := &AccountChange{
+		Address:               address,
+		BalanceBefore:         before.balance,
+		BalanceAfter:          after.balance,
+		ESDTChanges:           make([]*ESDTChange, 0),
+		GlobalMetadataChanges: make([]*GlobalMetadataChange, 0),
+		StorageChanges:        make([]*StorageChange, 0),
+	}
+
+	isSystemAccount := bytes.Equal(address, vmcommon.SystemAccountAddress)
+	for _, key := range unionOfKeys(before.data, after.data) {
+		valueBefore := before.data[key]
+		valueAfter := after.data[key]
+		if bytes.Equal(valueBefore, valueAfter) {
+			continue
+		}
+
+		s.addKeyChange(change, []byte(key), valueBefore, valueAfter, isSystemAccount)
+	}
+
+	return change
+}
+
+func (s *simulator) addKeyChange(change *AccountChange, key []byte, valueBefore []byte, valueAfter []byte, isSystemAccount bool) {
+	tokenIdentifier, nonce, isESDTKey := extractTokenIdentifierAndNonce(key)
+	if !isESDTKey {
+		change.StorageChanges = append(change.StorageChanges, &StorageChange{Key: key, Before: valueBefore, After: valueAfter})
+		return
+	}
+
+	if isSystemAccount && nonce == 0 {
+		change.GlobalMetadataChanges = append(change.GlobalMetadataChanges, &GlobalMetadataChange{
+			TokenIdentifier: tokenIdentifier,
+			Before:          builtInFunctions.ESDTGlobalMetadataFromBytes(valueBefore),
+			After:           builtInFunctions.ESDTGlobalMetadataFromBytes(valueAfter),
+		})
+		return
+	}
+
+	esdtBefore, errBefore := s.unmarshalESDTData(valueBefore)
+	esdtAfter, errAfter := s.unmarshalESDTData(valueAfter)
+	if errBefore != nil || errAfter != nil {
+		change.StorageChanges = append(change.StorageChanges, &StorageChange{Key: key, Before: valueBefore, After: valueAfter})
+		return
+	}
+
+	change.ESDTChanges = append(change.ESDTChanges, &ESDTChange{
+		TokenIdentifier: tokenIdentifier,
+		Nonce:           nonce,
+		Before:          esdtBefore,
+		After:           esdtAfter,
+	})
+}
+
+func (s *simulator) unmarshalESDTData(value []byte) (*esdt.ESDigitalToken, error) {
+	if len(value) == 0 {
+		return nil, nil
+	}
+
+	esdtData := &esdt.ESDigitalToken{}
+	err := s.marshaller.Unmarshal(esdtData, value)
+	if err != nil {
+		return nil, err
+	}
+
+	return esdtData, nil
+}
+
+// extractTokenIdentifierAndNonce splits an ESDT storage key into the token identifier and the nonce.
+// The random sequence of the identifier is expected right after the separator following the ticker,
+// the remaining bytes being the nonce
+func extractTokenIdentifierAndNonce(key []byte) ([]byte, uint64, bool) {
+	if !bytes.HasPrefix(key, []byte(esdtKeyPrefix)) {
+		return nil, 0, false
+	}
+
+	tokenKey := key[len(esdtKeyPrefix):]
+	for index := 0; index < len(tokenKey); index++ {
+		if tokenKey[index] != esdtIdentifierSeparator[0] {
+			continue
+		}
+
+		randomSequenceEnd := index + 1 + esdtRandomSequenceLength
+		if randomSequenceEnd > len(tokenKey) || !isRandomSequence(tokenKey[index+1:randomSequenceEnd]) {
+			continue
+		}
+
+		nonce := big.NewInt(0).SetBytes(tokenKey[randomSequenceEnd:])
+		return tokenKey[:randomSequenceEnd], nonce.Uint64(), true
+	}
+
+	return nil, 0, false
+}
+
+func isRandomSequence(sequence []byte) bool {
+	for _, c := range sequence {
+		isDigit := c >= '0' && c <= '9'
+		isLowerHex := c >= 'a' && c <= 'f'
+		if !isDigit && !isLowerHex {
+			return false
+		}
+	}
+
+	return true
+}
+
+func unionOfKeys(first map[string][]byte, second map[string][]byte) []string {
+	keys := make([]string, 0, len(first)+len(second))
+	for key := range first {
+		keys = append(keys, key)
+	}
+	for key := range second {
+		_, found := first[key]
+		if !found {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func esdtValue(esdtData *esdt.ESDigitalToken) *big.Int {
+	if esdtData == nil || esdtData.Value == nil {
+		return big.NewInt(0)
+	}
+
+	return esdtData.Value
+}
+
+func hasChanges(change *AccountChange) bool {
+	return change.BalanceBefore.Cmp(change.BalanceAfter) != 0 ||
+		len(change.ESDTChanges) > 0 ||
+		len(change.GlobalMetadataChanges) > 0 ||
+		len(change.StorageChanges) > 0
+}
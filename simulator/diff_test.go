@@ -0,0 +1,46 @@
+package simulator
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtractTokenIdentifierAndNonce(t *testing.T) {
+	t.Parallel()
+
+	_, _, isESDTKey := extractTokenIdentifierAndNonce([]byte("key"))
+	assert.False(t, isESDTKey)
+
+	_, _, isESDTKey = extractTokenIdentifierAndNonce([]byte(esdtKeyPrefix + "TKN"))
+	assert.False(t, isESDTKey)
+
+	identifier, nonce, isESDTKey := extractTokenIdentifierAndNonce([]byte(esdtKeyPrefix + "TKN-abcdef"))
+	assert.True(t, isESDTKey)
+	assert.Equal(t, []byte("TKN-abcdef"), identifier)
+	assert.Equal(t, uint64(0), nonce)
+
+	key := append([]byte(esdtKeyPrefix+"TKN-abcdef"), big.NewInt(0x2d2d).Bytes()...)
+	identifier, nonce, isESDTKey = extractTokenIdentifierAndNonce(key)
+	assert.True(t, isESDTKey)
+	assert.Equal(t, []byte("TKN-abcdef"), identifier)
+	assert.Equal(t, uint64(0x2d2d), nonce)
+
+	key = append([]byte(esdtKeyPrefix+"sov-TKN-abcdef"), big.NewInt(5).Bytes()...)
+	identifier, nonce, isESDTKey = extractTokenIdentifierAndNonce(key)
+	assert.True(t, isESDTKey)
+	assert.Equal(t, []byte("sov-TKN-abcdef"), identifier)
+	assert.Equal(t, uint64(5), nonce)
+}
+
+func TestESDTChange_ValueDelta(t *testing.T) {
+	t.Parallel()
+
+	change := &ESDTChange{After: &esdt.ESDigitalToken{Value: big.NewInt(10)}}
+	assert.Equal(t, big.NewInt(10), change.ValueDelta())
+
+	change = &ESDTChange{Before: &esdt.ESDigitalToken{Value: big.NewInt(10)}, After: &esdt.ESDigitalToken{Value: big.NewInt(4)}}
+	assert.Equal(t, big.NewInt(-6), change.ValueDelta())
+}
@@ -0,0 +1,17 @@
+package simulator
+
+import (
+	"errors"
+)
+
+// ErrNilAccountsAdapter signals that a nil accounts adapter has been provided
+var ErrNilAccountsAdapter = errors.New("nil accounts adapter")
+
+// ErrNilExecutor signals that a nil built-in functions executor has been provided
+var ErrNilExecutor = errors.New("nil built-in functions executor")
+
+// ErrNilMarshalizer signals that a nil marshalizer has been provided
+var ErrNilMarshalizer = errors.New("nil marshalizer")
+
+// ErrWrongTypeAssertion signals that a type assertion failed
+var ErrWrongTypeAssertion = errors.New("wrong type assertion")
@@ -0,0 +1,17 @@
+package simulator
+
+import (
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/executor"
+)
+
+// BuiltInFunctionsExecutor defines the component able to execute built-in functions against the accounts state
+type BuiltInFunctionsExecutor interface {
+	Execute(input *vmcommon.ContractCallInput) (*executor.ExecutionResult, error)
+	IsInterfaceNil() bool
+}
+
+// accountDataLister is implemented by the accounts which can list all their key-value pairs
+type accountDataLister interface {
+	DirtyData() map[string][]byte
+}
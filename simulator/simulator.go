@@ -0,0 +1,238 @@
+package simulator
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/executor"
+)
+
+// ArgsNewSimulator defines the arguments needed to create a new built-in functions simulator
+type ArgsNewSimulator struct {
+	Accounts   vmcommon.AccountsAdapter
+	Executor   BuiltInFunctionsExecutor
+	Marshaller vmcommon.Marshalizer
+}
+
+// StepResult holds the outcome of one executed built-in function call
+type StepResult struct {
+	Depth         uint32
+	Function      string
+	CallerAddr    []byte
+	RecipientAddr []byte
+	GasProvided   uint64
+	GasRemaining  uint64
+	GasConsumed   uint64
+	ReturnData    [][]byte
+}
+
+// SimulationResult holds the outcome of a simulated execution. The state is reverted after the simulation,
+// so the result describes the changes that the execution would have done
+type SimulationResult struct {
+	Steps                []*StepResult
+	AccountChanges       []*AccountChange
+	SystemAccountChanges *AccountChange
+	Logs                 []*vmcommon.LogEntry
+	PendingTransfers     []*executor.PendingTransfer
+	TotalGasConsumed     uint64
+}
+
+type simulator struct {
+	mutSimulation sync.Mutex
+	accounts      vmcommon.AccountsAdapter
+	executor      BuiltInFunctionsExecutor
+	marshaller    vmcommon.Marshalizer
+}
+
+// NewSimulator creates a new simulator which executes built-in functions without persisting their changes
+func NewSimulator(args ArgsNewSimulator) (*simulator, error) {
+	if check.IfNil(args.Accounts) {
+		return nil, ErrNilAccountsAdapter
+	}
+	if check.IfNil(args.Executor) {
+		return nil, ErrNilExecutor
+	}
+	if check.IfNil(args.Marshaller) {
+		return nil, ErrNilMarshalizer
+	}
+
+	return &simulator{
+		accounts:   args.Accounts,
+		executor:   args.Executor,
+		marshaller: args.Marshaller,
+	}, nil
+}
+
+// Simulate executes the provided call, computes the changes done on all the touched accounts and
+// reverts the state to the one before the call
+func (s *simulator) Simulate(input *vmcommon.ContractCallInput) (*SimulationResult, error) {
+	s.mutSimulation.Lock()
+	defer s.mutSimulation.Unlock()
+
+	snapshot := s.accounts.JournalLen()
+	executionResult, err := s.executor.Execute(input)
+	if err != nil {
+		return nil, err
+	}
+
+	addresses, keysToCheck := collectTouchedData(executionResult)
+	statesAfter, errCapture := s.captureStates(addresses, keysToCheck)
+
+	err = s.accounts.RevertToSnapshot(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	if errCapture != nil {
+		return nil, errCapture
+	}
+
+	statesBefore, err := s.captureStates(addresses, keysToCheck)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SimulationResult{
+		Steps:            make([]*StepResult, 0, len(executionResult.Steps)),
+		AccountChanges:   make([]*AccountChange, 0),
+		Logs:             make([]*vmcommon.LogEntry, 0),
+		PendingTransfers: executionResult.PendingTransfers,
+	}
+	for _, step := range executionResult.Steps {
+		stepResult := createStepResult(step)
+		result.TotalGasConsumed += stepResult.GasConsumed
+		result.Steps = append(result.Steps, stepResult)
+		result.Logs = append(result.Logs, step.VMOutput.Logs...)
+	}
+
+	for _, address := range addresses {
+		change := s.computeAccountChange([]byte(address), statesBefore[address], statesAfter[address])
+		if !hasChanges(change) {
+			continue
+		}
+
+		if bytes.Equal(change.Address, vmcommon.SystemAccountAddress) {
+			result.SystemAccountChanges = change
+			continue
+		}
+		result.AccountChanges = append(result.AccountChanges, change)
+	}
+
+	return result, nil
+}
+
+func createStepResult(step *executor.ExecutedStep) *StepResult {
+	gasConsumed, err := vmcommon.SafeSubUint64(step.Input.GasProvided, step.VMOutput.GasRemaining)
+	if err != nil {
+		gasConsumed = 0
+	}
+
+	return &StepResult{
+		Depth:         step.Depth,
+		Function:      step.Input.Function,
+		CallerAddr:    step.Input.CallerAddr,
+		RecipientAddr: step.Input.RecipientAddr,
+		GasProvided:   step.Input.GasProvided,
+		GasRemaining:  step.VMOutput.GasRemaining,
+		GasConsumed:   gasConsumed,
+		ReturnData:    step.VMOutput.ReturnData,
+	}
+}
+
+// collectTouchedData returns, sorted, the addresses of all the accounts involved in the execution and, for
+// each address, the storage keys which were explicitly updated through the VM output
+func collectTouchedData(executionResult *executor.ExecutionResult) ([]string, map[string][][]byte) {
+	keysToCheck := make(map[string][][]byte)
+	keysToCheck[string(vmcommon.SystemAccountAddress)] = make([][]byte, 0)
+
+	addAddress := func(address []byte) {
+		if len(address) == 0 {
+			return
+		}
+		_, found := keysToCheck[string(address)]
+		if !found {
+			keysToCheck[string(address)] = make([][]byte, 0)
+		}
+	}
+
+	for _, step := range executionResult.Steps {
+		addAddress(step.Input.CallerAddr)
+		addAddress(step.Input.RecipientAddr)
+		for _, outAcc := range step.VMOutput.OutputAccounts {
+			addAddress(outAcc.Address)
+			for _, storageUpdate := range outAcc.StorageUpdates {
+				keysToCheck[string(outAcc.Address)] = append(keysToCheck[string(outAcc.Address)], storageUpdate.Offset)
+			}
+		}
+	}
+
+	addresses := make([]string, 0, len(keysToCheck))
+	for address := range keysToCheck {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	return addresses, keysToCheck
+}
+
+func (s *simulator) captureStates(addresses []string, keysToCheck map[string][][]byte) (map[string]*accountState, error) {
+	states := make(map[string]*accountState, len(addresses))
+	for _, address := range addresses {
+		state, err := s.captureState([]byte(address), keysToCheck[address])
+		if err != nil {
+			return nil, err
+		}
+
+		states[address] = state
+	}
+
+	return states, nil
+}
+
+func (s *simulator) captureState(address []byte, keysToCheck [][]byte) (*accountState, error) {
+	account, err := s.accounts.LoadAccount(address)
+	if err != nil {
+		return nil, err
+	}
+	userAccount, ok := account.(vmcommon.UserAccountHandler)
+	if !ok {
+		return nil, fmt.Errorf("%w for address %x", ErrWrongTypeAssertion, address)
+	}
+
+	state := &accountState{
+		balance: big.NewInt(0),
+		data:    make(map[string][]byte),
+	}
+	if userAccount.GetBalance() != nil {
+		state.balance.Set(userAccount.GetBalance())
+	}
+
+	lister, ok := account.(accountDataLister)
+	if ok {
+		for key, value := range lister.DirtyData() {
+			state.data[key] = value
+		}
+	}
+
+	for _, key := range keysToCheck {
+		value, _, errRetrieve := userAccount.AccountDataHandler().RetrieveValue(key)
+		if errRetrieve != nil {
+			return nil, errRetrieve
+		}
+		if len(value) > 0 {
+			state.data[string(key)] = value
+		}
+	}
+
+	return state, nil
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (s *simulator) IsInterfaceNil() bool {
+	return s == nil
+}
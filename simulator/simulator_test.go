@@ -0,0 +1,268 @@
+package simulator
+
+import (
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	"github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+	"github.com/multiversx/mx-chain-vm-common-go/executor"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/multiversx/mx-chain-vm-common-go/parsers"
+	"github.com/multiversx/mx-chain-vm-common-go/state"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	senderAddress   = []byte("12345678901234567890123456789012")
+	receiverAddress = []byte("12345678901234567890123456789022")
+	tokenIdentifier = []byte("TKN-abcdef")
+	tokenKey        = append([]byte(esdtKeyPrefix), tokenIdentifier...)
+)
+
+type executorStub struct {
+	ExecuteCalled func(input *vmcommon.ContractCallInput) (*executor.ExecutionResult, error)
+}
+
+func (stub *executorStub) Execute(input *vmcommon.ContractCallInput) (*executor.ExecutionResult, error) {
+	if stub.ExecuteCalled != nil {
+		return stub.ExecuteCalled(input)
+	}
+	return &executor.ExecutionResult{}, nil
+}
+
+func (stub *executorStub) IsInterfaceNil() bool {
+	return stub == nil
+}
+
+type simulatorComponents struct {
+	accounts  vmcommon.AccountsAdapter
+	simulator *simulator
+}
+
+func fillGasMapFromStruct(costs interface{}, value uint64) map[string]uint64 {
+	gasMap := make(map[string]uint64)
+	costsType := reflect.TypeOf(costs)
+	for i := 0; i < costsType.NumField(); i++ {
+		gasMap[costsType.Field(i).Name] = value
+	}
+
+	return gasMap
+}
+
+func createSimulatorComponents(t *testing.T) *simulatorComponents {
+	enableEpochsHandler := &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			return true
+		},
+	}
+	accounts, err := state.NewAccountsAdapter(state.ArgsNewAccountsAdapter{
+		EnableEpochsHandler: enableEpochsHandler,
+	})
+	require.Nil(t, err)
+
+	creator, err := builtInFunctions.NewBuiltInFunctionsCreator(builtInFunctions.ArgsCreateBuiltInFunctionContainer{
+		GasMap: map[string]map[string]uint64{
+			core.BaseOperationCostString: fillGasMapFromStruct(vmcommon.BaseOperationCost{}, 1),
+			core.BuiltInCostString:       fillGasMapFromStruct(vmcommon.BuiltInCost{}, 10),
+		},
+		MapDNSAddresses:                   make(map[string]struct{}),
+		MapDNSV2Addresses:                 make(map[string]struct{}),
+		MapWhiteListedCrossChainAddresses: map[string]struct{}{"whiteListedAddress": {}},
+		Marshalizer:                       &mock.MarshalizerMock{},
+		Accounts:                          accounts,
+		ShardCoordinator:                  mock.NewMultiShardsCoordinatorMock(1),
+		EnableEpochsHandler:               enableEpochsHandler,
+		GuardedAccountHandler:             &mock.GuardedAccountHandlerStub{},
+		MaxNumOfAddressesForTransferRole:  100,
+	})
+	require.Nil(t, err)
+	require.Nil(t, creator.CreateBuiltInFunctionContainer())
+	require.Nil(t, creator.SetPayableHandler(&mock.PayableHandlerStub{}))
+
+	bfe, err := executor.NewBuiltInFunctionsExecutor(executor.ArgsNewBuiltInFunctionsExecutor{
+		Accounts:         accounts,
+		BuiltInFunctions: creator.BuiltInFunctionContainer(),
+		ShardCoordinator: mock.NewMultiShardsCoordinatorMock(1),
+		ArgsParser:       parsers.NewCallArgsParser(),
+	})
+	require.Nil(t, err)
+
+	sim, err := NewSimulator(ArgsNewSimulator{
+		Accounts:   accounts,
+		Executor:   bfe,
+		Marshaller: &mock.MarshalizerMock{},
+	})
+	require.Nil(t, err)
+
+	return &simulatorComponents{
+		accounts:  accounts,
+		simulator: sim,
+	}
+}
+
+func saveESDTBalance(t *testing.T, accounts vmcommon.AccountsAdapter, address []byte, value int64) {
+	account, err := accounts.LoadAccount(address)
+	require.Nil(t, err)
+
+	marshalledData, _ := (&mock.MarshalizerMock{}).Marshal(&esdt.ESDigitalToken{Value: big.NewInt(value)})
+	require.Nil(t, account.(vmcommon.UserAccountHandler).AccountDataHandler().SaveKeyValue(tokenKey, marshalledData))
+	require.Nil(t, accounts.SaveAccount(account))
+}
+
+func TestNewSimulator(t *testing.T) {
+	t.Parallel()
+
+	args := ArgsNewSimulator{
+		Accounts:   &mock.AccountsStub{},
+		Executor:   &executorStub{},
+		Marshaller: &mock.MarshalizerMock{},
+	}
+
+	argsCopy := args
+	argsCopy.Accounts = nil
+	sim, err := NewSimulator(argsCopy)
+	assert.Nil(t, sim)
+	assert.Equal(t, ErrNilAccountsAdapter, err)
+
+	argsCopy = args
+	argsCopy.Executor = nil
+	sim, err = NewSimulator(argsCopy)
+	assert.Nil(t, sim)
+	assert.Equal(t, ErrNilExecutor, err)
+
+	argsCopy = args
+	argsCopy.Marshaller = nil
+	sim, err = NewSimulator(argsCopy)
+	assert.Nil(t, sim)
+	assert.Equal(t, ErrNilMarshalizer, err)
+
+	sim, err = NewSimulator(args)
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(sim))
+}
+
+func TestSimulator_Simulate(t *testing.T) {
+	t.Parallel()
+
+	t.Run("execution error should return error", func(t *testing.T) {
+		t.Parallel()
+
+		expectedErr := errors.New("expected error")
+		sim, _ := NewSimulator(ArgsNewSimulator{
+			Accounts: &mock.AccountsStub{},
+			Executor: &executorStub{
+				ExecuteCalled: func(_ *vmcommon.ContractCallInput) (*executor.ExecutionResult, error) {
+					return nil, expectedErr
+				},
+			},
+			Marshaller: &mock.MarshalizerMock{},
+		})
+
+		result, err := sim.Simulate(&vmcommon.ContractCallInput{})
+		assert.Nil(t, result)
+		assert.Equal(t, expectedErr, err)
+	})
+	t.Run("ESDT transfer should report changes and revert state", func(t *testing.T) {
+		t.Parallel()
+
+		components := createSimulatorComponents(t)
+		saveESDTBalance(t, components.accounts, senderAddress, 100)
+		rootHash, _ := components.accounts.RootHash()
+
+		result, err := components.simulator.Simulate(&vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  senderAddress,
+				CallValue:   big.NewInt(0),
+				GasProvided: 100,
+				Arguments:   [][]byte{tokenIdentifier, big.NewInt(40).Bytes()},
+			},
+			RecipientAddr: receiverAddress,
+			Function:      core.BuiltInFunctionESDTTransfer,
+		})
+		require.Nil(t, err)
+
+		currentRootHash, _ := components.accounts.RootHash()
+		assert.Equal(t, rootHash, currentRootHash)
+
+		require.Equal(t, 1, len(result.Steps))
+		assert.Equal(t, uint64(10), result.Steps[0].GasConsumed)
+		assert.Equal(t, uint64(10), result.TotalGasConsumed)
+		require.Equal(t, 1, len(result.Logs))
+		assert.Equal(t, []byte(core.BuiltInFunctionESDTTransfer), result.Logs[0].Identifier)
+		assert.Nil(t, result.SystemAccountChanges)
+
+		require.Equal(t, 2, len(result.AccountChanges))
+		senderChange := result.AccountChanges[0]
+		assert.Equal(t, senderAddress, senderChange.Address)
+		require.Equal(t, 1, len(senderChange.ESDTChanges))
+		assert.Equal(t, tokenIdentifier, senderChange.ESDTChanges[0].TokenIdentifier)
+		assert.Equal(t, big.NewInt(-40), senderChange.ESDTChanges[0].ValueDelta())
+		assert.Equal(t, big.NewInt(100), senderChange.ESDTChanges[0].Before.Value)
+
+		receiverChange := result.AccountChanges[1]
+		assert.Equal(t, receiverAddress, receiverChange.Address)
+		require.Equal(t, 1, len(receiverChange.ESDTChanges))
+		assert.Nil(t, receiverChange.ESDTChanges[0].Before)
+		assert.Equal(t, big.NewInt(40), receiverChange.ESDTChanges[0].ValueDelta())
+		assert.Equal(t, big.NewInt(0), receiverChange.BalanceDelta())
+	})
+	t.Run("ESDT pause should report system account changes", func(t *testing.T) {
+		t.Parallel()
+
+		components := createSimulatorComponents(t)
+
+		result, err := components.simulator.Simulate(&vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr: core.ESDTSCAddress,
+				CallValue:  big.NewInt(0),
+				Arguments:  [][]byte{tokenIdentifier},
+			},
+			RecipientAddr: vmcommon.SystemAccountAddress,
+			Function:      core.BuiltInFunctionESDTPause,
+		})
+		require.Nil(t, err)
+
+		assert.Equal(t, 0, len(result.AccountChanges))
+		require.NotNil(t, result.SystemAccountChanges)
+		require.Equal(t, 1, len(result.SystemAccountChanges.GlobalMetadataChanges))
+		change := result.SystemAccountChanges.GlobalMetadataChanges[0]
+		assert.Equal(t, tokenIdentifier, change.TokenIdentifier)
+		assert.False(t, change.Before.Paused)
+		assert.True(t, change.After.Paused)
+
+		systemAccount, _ := components.accounts.LoadAccount(vmcommon.SystemAccountAddress)
+		value, _, _ := systemAccount.(vmcommon.UserAccountHandler).AccountDataHandler().RetrieveValue(tokenKey)
+		assert.Nil(t, value)
+	})
+	t.Run("key value storage should report storage changes", func(t *testing.T) {
+		t.Parallel()
+
+		components := createSimulatorComponents(t)
+
+		result, err := components.simulator.Simulate(&vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  senderAddress,
+				CallValue:   big.NewInt(0),
+				GasProvided: 1000,
+				Arguments:   [][]byte{[]byte("key"), []byte("value")},
+			},
+			RecipientAddr: senderAddress,
+			Function:      core.BuiltInFunctionSaveKeyValue,
+		})
+		require.Nil(t, err)
+
+		require.Equal(t, 1, len(result.AccountChanges))
+		require.Equal(t, 1, len(result.AccountChanges[0].StorageChanges))
+		storageChange := result.AccountChanges[0].StorageChanges[0]
+		assert.Equal(t, []byte("key"), storageChange.Key)
+		assert.Nil(t, storageChange.Before)
+		assert.Equal(t, []byte("value"), storageChange.After)
+	})
+}
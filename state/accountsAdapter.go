@@ -0,0 +1,241 @@
+package state
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+var _ vmcommon.AccountsAdapter = (*accountsAdapter)(nil)
+
+// journalEntry holds the state of an account before it was saved or removed.
+// A nil previous account means that the account did not exist
+type journalEntry struct {
+	address  string
+	previous *userAccount
+}
+
+// ArgsNewAccountsAdapter defines the arguments needed to create a new in-memory accounts adapter
+type ArgsNewAccountsAdapter struct {
+	EnableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+// accountsAdapter is an in-memory, journaled, accounts adapter. Loaded accounts are copies of the saved ones,
+// so changes are only visible to the other components after SaveAccount is called
+type accountsAdapter struct {
+	mut                 sync.RWMutex
+	accounts            map[string]*userAccount
+	codes               map[string][]byte
+	journal             []*journalEntry
+	enableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+// NewAccountsAdapter creates a new in-memory accounts adapter which already holds the system account
+func NewAccountsAdapter(args ArgsNewAccountsAdapter) (*accountsAdapter, error) {
+	if check.IfNil(args.EnableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+
+	adb := &accountsAdapter{
+		accounts:            make(map[string]*userAccount),
+		codes:               make(map[string][]byte),
+		journal:             make([]*journalEntry, 0),
+		enableEpochsHandler: args.EnableEpochsHandler,
+	}
+
+	systemAccount, err := NewUserAccount(vmcommon.SystemAccountAddress, args.EnableEpochsHandler)
+	if err != nil {
+		return nil, err
+	}
+	adb.accounts[string(vmcommon.SystemAccountAddress)] = systemAccount
+
+	return adb, nil
+}
+
+// GetExistingAccount returns a copy of an existing account. Errors if the account does not exist
+func (adb *accountsAdapter) GetExistingAccount(address []byte) (vmcommon.AccountHandler, error) {
+	if len(address) == 0 {
+		return nil, ErrNilAddress
+	}
+
+	adb.mut.RLock()
+	defer adb.mut.RUnlock()
+
+	account, found := adb.accounts[string(address)]
+	if !found {
+		return nil, fmt.Errorf("%w for address %x", ErrAccNotFound, address)
+	}
+
+	return account.clone(), nil
+}
+
+// LoadAccount returns a copy of an existing account or a new account if it does not exist
+func (adb *accountsAdapter) LoadAccount(address []byte) (vmcommon.AccountHandler, error) {
+	if len(address) == 0 {
+		return nil, ErrNilAddress
+	}
+
+	adb.mut.RLock()
+	defer adb.mut.RUnlock()
+
+	account, found := adb.accounts[string(address)]
+	if !found {
+		return NewUserAccount(address, adb.enableEpochsHandler)
+	}
+
+	return account.clone(), nil
+}
+
+// SystemAccount returns a copy of the system account
+func (adb *accountsAdapter) SystemAccount() (vmcommon.UserAccountHandler, error) {
+	account, err := adb.LoadAccount(vmcommon.SystemAccountAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	userAcc, ok := account.(vmcommon.UserAccountHandler)
+	if !ok {
+		return nil, ErrWrongTypeAssertion
+	}
+
+	return userAcc, nil
+}
+
+// SaveAccount saves a copy of the provided account, journaling the previous state
+func (adb *accountsAdapter) SaveAccount(account vmcommon.AccountHandler) error {
+	if check.IfNil(account) {
+		return ErrNilAccountHandler
+	}
+
+	userAcc, ok := account.(*userAccount)
+	if !ok {
+		return ErrWrongTypeAssertion
+	}
+
+	adb.mut.Lock()
+	defer adb.mut.Unlock()
+
+	adb.addJournalEntry(userAcc.address)
+	adb.accounts[string(userAcc.address)] = userAcc.clone()
+	if len(userAcc.code) > 0 {
+		adb.codes[string(userAcc.codeHash)] = cloneBytes(userAcc.code)
+	}
+
+	return nil
+}
+
+// RemoveAccount removes the account, journaling the previous state
+func (adb *accountsAdapter) RemoveAccount(address []byte) error {
+	if len(address) == 0 {
+		return ErrNilAddress
+	}
+
+	adb.mut.Lock()
+	defer adb.mut.Unlock()
+
+	_, found := adb.accounts[string(address)]
+	if !found {
+		return nil
+	}
+
+	adb.addJournalEntry(address)
+	delete(adb.accounts, string(address))
+
+	return nil
+}
+
+func (adb *accountsAdapter) addJournalEntry(address []byte) {
+	entry := &journalEntry{
+		address: string(address),
+	}
+
+	previous, found := adb.accounts[string(address)]
+	if found {
+		entry.previous = previous
+	}
+
+	adb.journal = append(adb.journal, entry)
+}
+
+// JournalLen returns the number of entries in the journal, which can be used as a snapshot identifier
+func (adb *accountsAdapter) JournalLen() int {
+	adb.mut.RLock()
+	defer adb.mut.RUnlock()
+
+	return len(adb.journal)
+}
+
+// RevertToSnapshot reverts all the changes done after the provided snapshot was taken
+func (adb *accountsAdapter) RevertToSnapshot(snapshot int) error {
+	adb.mut.Lock()
+	defer adb.mut.Unlock()
+
+	if snapshot < 0 || snapshot > len(adb.journal) {
+		return fmt.Errorf("%w: %d, journal length: %d", ErrInvalidSnapshot, snapshot, len(adb.journal))
+	}
+
+	for i := len(adb.journal) - 1; i >= snapshot; i-- {
+		entry := adb.journal[i]
+		if entry.previous == nil {
+			delete(adb.accounts, entry.address)
+			continue
+		}
+
+		adb.accounts[entry.address] = entry.previous
+	}
+
+	adb.journal = adb.journal[:snapshot]
+
+	return nil
+}
+
+// Commit clears the journal and returns the current root hash
+func (adb *accountsAdapter) Commit() ([]byte, error) {
+	adb.mut.Lock()
+	defer adb.mut.Unlock()
+
+	adb.journal = make([]*journalEntry, 0)
+
+	return adb.computeRootHash(), nil
+}
+
+// RootHash returns the hash computed over all the saved accounts
+func (adb *accountsAdapter) RootHash() ([]byte, error) {
+	adb.mut.RLock()
+	defer adb.mut.RUnlock()
+
+	return adb.computeRootHash(), nil
+}
+
+func (adb *accountsAdapter) computeRootHash() []byte {
+	addresses := make([]string, 0, len(adb.accounts))
+	for address := range adb.accounts {
+		addresses = append(addresses, address)
+	}
+	sort.Strings(addresses)
+
+	hasher := sha256.New()
+	for _, address := range addresses {
+		writeWithLength(hasher, adb.accounts[address].hash())
+	}
+
+	return hasher.Sum(nil)
+}
+
+// GetCode returns the code saved for the provided code hash
+func (adb *accountsAdapter) GetCode(codeHash []byte) []byte {
+	adb.mut.RLock()
+	defer adb.mut.RUnlock()
+
+	return cloneBytes(adb.codes[string(codeHash)])
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (adb *accountsAdapter) IsInterfaceNil() bool {
+	return adb == nil
+}
@@ -0,0 +1,302 @@
+package state
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-core-go/data/esdt"
+	"github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	senderAddress   = []byte("12345678901234567890123456789012")
+	receiverAddress = []byte("12345678901234567890123456789022")
+)
+
+func createAccountsAdapter(t *testing.T) *accountsAdapter {
+	adb, err := NewAccountsAdapter(ArgsNewAccountsAdapter{
+		EnableEpochsHandler: &mock.EnableEpochsHandlerStub{},
+	})
+	require.Nil(t, err)
+
+	return adb
+}
+
+func loadUserAccount(t *testing.T, adb vmcommon.AccountsAdapter, address []byte) vmcommon.UserAccountHandler {
+	account, err := adb.LoadAccount(address)
+	require.Nil(t, err)
+
+	return account.(vmcommon.UserAccountHandler)
+}
+
+func fillGasMap(value uint64) map[string]map[string]uint64 {
+	return map[string]map[string]uint64{
+		core.BaseOperationCostString: fillGasMapFromStruct(vmcommon.BaseOperationCost{}, value),
+		core.BuiltInCostString:       fillGasMapFromStruct(vmcommon.BuiltInCost{}, value),
+	}
+}
+
+func fillGasMapFromStruct(costs interface{}, value uint64) map[string]uint64 {
+	gasMap := make(map[string]uint64)
+	costsType := reflect.TypeOf(costs)
+	for i := 0; i < costsType.NumField(); i++ {
+		gasMap[costsType.Field(i).Name] = value
+	}
+
+	return gasMap
+}
+
+func createBuiltInFunctionsContainer(t *testing.T, adb vmcommon.AccountsAdapter) vmcommon.BuiltInFunctionContainer {
+	creator, err := builtInFunctions.NewBuiltInFunctionsCreator(builtInFunctions.ArgsCreateBuiltInFunctionContainer{
+		GasMap:                            fillGasMap(1),
+		MapDNSAddresses:                   make(map[string]struct{}),
+		MapDNSV2Addresses:                 make(map[string]struct{}),
+		MapWhiteListedCrossChainAddresses: map[string]struct{}{"whiteListedAddress": {}},
+		Marshalizer:                       &mock.MarshalizerMock{},
+		Accounts:                          adb,
+		ShardCoordinator:                  mock.NewMultiShardsCoordinatorMock(1),
+		EnableEpochsHandler:               &mock.EnableEpochsHandlerStub{},
+		GuardedAccountHandler:             &mock.GuardedAccountHandlerStub{},
+		MaxNumOfAddressesForTransferRole:  100,
+	})
+	require.Nil(t, err)
+	require.Nil(t, creator.CreateBuiltInFunctionContainer())
+	require.Nil(t, creator.SetBlockchainHook(&mock.BlockDataHandlerStub{}))
+	require.Nil(t, creator.SetPayableHandler(&mock.PayableHandlerStub{}))
+
+	return creator.BuiltInFunctionContainer()
+}
+
+func executeBuiltInFunction(
+	t *testing.T,
+	adb vmcommon.AccountsAdapter,
+	container vmcommon.BuiltInFunctionContainer,
+	input *vmcommon.ContractCallInput,
+) *vmcommon.VMOutput {
+	function, err := container.Get(input.Function)
+	require.Nil(t, err)
+
+	sender := loadUserAccount(t, adb, input.CallerAddr)
+	receiver := sender
+	if !bytes.Equal(input.CallerAddr, input.RecipientAddr) {
+		receiver = loadUserAccount(t, adb, input.RecipientAddr)
+	}
+
+	vmOutput, err := function.ProcessBuiltinFunction(sender, receiver, input)
+	require.Nil(t, err)
+	require.Nil(t, adb.SaveAccount(sender))
+	require.Nil(t, adb.SaveAccount(receiver))
+
+	return vmOutput
+}
+
+func getESDTData(t *testing.T, adb vmcommon.AccountsAdapter, address []byte, tokenKey []byte) *esdt.ESDigitalToken {
+	account := loadUserAccount(t, adb, address)
+	value, _, err := account.AccountDataHandler().RetrieveValue(tokenKey)
+	require.Nil(t, err)
+
+	esdtData := &esdt.ESDigitalToken{Value: big.NewInt(0)}
+	if len(value) == 0 {
+		return esdtData
+	}
+
+	require.Nil(t, (&mock.MarshalizerMock{}).Unmarshal(esdtData, value))
+	return esdtData
+}
+
+func TestNewAccountsAdapter(t *testing.T) {
+	t.Parallel()
+
+	adb, err := NewAccountsAdapter(ArgsNewAccountsAdapter{})
+	assert.Nil(t, adb)
+	assert.Equal(t, ErrNilEnableEpochsHandler, err)
+
+	adb = createAccountsAdapter(t)
+	assert.False(t, check.IfNil(adb))
+
+	systemAccount, err := adb.GetExistingAccount(vmcommon.SystemAccountAddress)
+	assert.Nil(t, err)
+	assert.Equal(t, vmcommon.SystemAccountAddress, systemAccount.AddressBytes())
+}
+
+func TestAccountsAdapter_LoadAndSaveAccount(t *testing.T) {
+	t.Parallel()
+
+	adb := createAccountsAdapter(t)
+
+	_, err := adb.LoadAccount(nil)
+	assert.Equal(t, ErrNilAddress, err)
+	_, err = adb.GetExistingAccount(senderAddress)
+	assert.True(t, errors.Is(err, ErrAccNotFound))
+	assert.Equal(t, ErrNilAccountHandler, adb.SaveAccount(nil))
+	assert.Equal(t, ErrWrongTypeAssertion, adb.SaveAccount(&mock.AccountWrapMock{}))
+
+	account := loadUserAccount(t, adb, senderAddress)
+	_ = account.AddToBalance(big.NewInt(100))
+
+	_, err = adb.GetExistingAccount(senderAddress)
+	assert.True(t, errors.Is(err, ErrAccNotFound))
+
+	require.Nil(t, adb.SaveAccount(account))
+	_ = account.AddToBalance(big.NewInt(100))
+
+	existing, err := adb.GetExistingAccount(senderAddress)
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(100), existing.(vmcommon.UserAccountHandler).GetBalance())
+}
+
+func TestAccountsAdapter_RevertToSnapshot(t *testing.T) {
+	t.Parallel()
+
+	adb := createAccountsAdapter(t)
+
+	account := loadUserAccount(t, adb, senderAddress)
+	_ = account.AddToBalance(big.NewInt(100))
+	require.Nil(t, adb.SaveAccount(account))
+
+	rootHash, err := adb.Commit()
+	require.Nil(t, err)
+	assert.Equal(t, 0, adb.JournalLen())
+
+	snapshot := adb.JournalLen()
+	_ = account.AddToBalance(big.NewInt(50))
+	require.Nil(t, adb.SaveAccount(account))
+	require.Nil(t, adb.SaveAccount(loadUserAccount(t, adb, receiverAddress)))
+	require.Nil(t, adb.RemoveAccount(vmcommon.SystemAccountAddress))
+	assert.Equal(t, 3, adb.JournalLen())
+
+	currentRootHash, _ := adb.RootHash()
+	assert.NotEqual(t, rootHash, currentRootHash)
+
+	err = adb.RevertToSnapshot(4)
+	assert.True(t, errors.Is(err, ErrInvalidSnapshot))
+
+	require.Nil(t, adb.RevertToSnapshot(snapshot))
+	assert.Equal(t, 0, adb.JournalLen())
+
+	currentRootHash, _ = adb.RootHash()
+	assert.Equal(t, rootHash, currentRootHash)
+	assert.Equal(t, big.NewInt(100), loadUserAccount(t, adb, senderAddress).GetBalance())
+	_, err = adb.GetExistingAccount(receiverAddress)
+	assert.True(t, errors.Is(err, ErrAccNotFound))
+	_, err = adb.GetExistingAccount(vmcommon.SystemAccountAddress)
+	assert.Nil(t, err)
+}
+
+func TestAccountsAdapter_GetCode(t *testing.T) {
+	t.Parallel()
+
+	adb := createAccountsAdapter(t)
+
+	account, _ := adb.LoadAccount(senderAddress)
+	account.(*userAccount).SetCode([]byte("code"))
+	require.Nil(t, adb.SaveAccount(account))
+
+	assert.Equal(t, []byte("code"), adb.GetCode(account.(*userAccount).GetCodeHash()))
+	assert.Nil(t, adb.GetCode([]byte("missing")))
+}
+
+func TestAccountsAdapter_ExecuteBuiltInFunctions(t *testing.T) {
+	t.Parallel()
+
+	adb := createAccountsAdapter(t)
+	container := createBuiltInFunctionsContainer(t, adb)
+	marshaller := &mock.MarshalizerMock{}
+
+	fungibleToken := []byte("FNG-abcdef")
+	fungibleTokenKey := append([]byte(core.ProtectedKeyPrefix+core.ESDTKeyIdentifier), fungibleToken...)
+	sender := loadUserAccount(t, adb, senderAddress)
+	marshalledData, _ := marshaller.Marshal(&esdt.ESDigitalToken{Value: big.NewInt(1000)})
+	require.Nil(t, sender.AccountDataHandler().SaveKeyValue(fungibleTokenKey, marshalledData))
+	require.Nil(t, adb.SaveAccount(sender))
+
+	t.Run("ESDTTransfer", func(t *testing.T) {
+		executeBuiltInFunction(t, adb, container, &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  senderAddress,
+				CallValue:   big.NewInt(0),
+				GasProvided: 1000,
+				Arguments:   [][]byte{fungibleToken, big.NewInt(300).Bytes()},
+			},
+			RecipientAddr: receiverAddress,
+			Function:      core.BuiltInFunctionESDTTransfer,
+		})
+
+		assert.Equal(t, big.NewInt(700), getESDTData(t, adb, senderAddress, fungibleTokenKey).Value)
+		assert.Equal(t, big.NewInt(300), getESDTData(t, adb, receiverAddress, fungibleTokenKey).Value)
+	})
+
+	nftToken := []byte("NFT-abcdef")
+	nftTokenKey := append(append([]byte(core.ProtectedKeyPrefix+core.ESDTKeyIdentifier), nftToken...), big.NewInt(1).Bytes()...)
+	t.Run("SetESDTRole and ESDTNFTCreate", func(t *testing.T) {
+		executeBuiltInFunction(t, adb, container, &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr: core.ESDTSCAddress,
+				CallValue:  big.NewInt(0),
+				Arguments:  [][]byte{nftToken, []byte(core.ESDTRoleNFTCreate), []byte(core.ESDTRoleNFTAddQuantity)},
+			},
+			RecipientAddr: senderAddress,
+			Function:      core.BuiltInFunctionSetESDTRole,
+		})
+
+		vmOutput := executeBuiltInFunction(t, adb, container, &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  senderAddress,
+				CallValue:   big.NewInt(0),
+				GasProvided: 1000,
+				Arguments: [][]byte{
+					nftToken,
+					big.NewInt(10).Bytes(),
+					[]byte("name"),
+					big.NewInt(100).Bytes(),
+					[]byte("hash"),
+					[]byte("attributes"),
+					[]byte("uri"),
+				},
+			},
+			RecipientAddr: senderAddress,
+			Function:      core.BuiltInFunctionESDTNFTCreate,
+		})
+
+		assert.Equal(t, [][]byte{big.NewInt(1).Bytes()}, vmOutput.ReturnData)
+		esdtData := getESDTData(t, adb, senderAddress, nftTokenKey)
+		assert.Equal(t, big.NewInt(10), esdtData.Value)
+		assert.Equal(t, []byte("name"), esdtData.TokenMetaData.Name)
+	})
+
+	t.Run("MultiESDTNFTTransfer", func(t *testing.T) {
+		executeBuiltInFunction(t, adb, container, &vmcommon.ContractCallInput{
+			VMInput: vmcommon.VMInput{
+				CallerAddr:  senderAddress,
+				CallValue:   big.NewInt(0),
+				GasProvided: 1000,
+				Arguments: [][]byte{
+					receiverAddress,
+					big.NewInt(2).Bytes(),
+					fungibleToken,
+					big.NewInt(0).Bytes(),
+					big.NewInt(200).Bytes(),
+					nftToken,
+					big.NewInt(1).Bytes(),
+					big.NewInt(4).Bytes(),
+				},
+			},
+			RecipientAddr: senderAddress,
+			Function:      core.BuiltInFunctionMultiESDTNFTTransfer,
+		})
+
+		assert.Equal(t, big.NewInt(500), getESDTData(t, adb, senderAddress, fungibleTokenKey).Value)
+		assert.Equal(t, big.NewInt(500), getESDTData(t, adb, receiverAddress, fungibleTokenKey).Value)
+		assert.Equal(t, big.NewInt(6), getESDTData(t, adb, senderAddress, nftTokenKey).Value)
+		assert.Equal(t, big.NewInt(4), getESDTData(t, adb, receiverAddress, nftTokenKey).Value)
+	})
+}
@@ -0,0 +1,38 @@
+package state
+
+import (
+	"errors"
+)
+
+// ErrNilEnableEpochsHandler signals that a nil enable epochs handler was provided
+var ErrNilEnableEpochsHandler = errors.New("nil enable epochs handler")
+
+// ErrNilAddress signals that an operation has been attempted with a nil address
+var ErrNilAddress = errors.New("nil address")
+
+// ErrNilAccountHandler signals that a nil account handler has been provided
+var ErrNilAccountHandler = errors.New("nil account handler")
+
+// ErrWrongTypeAssertion signals that a type assertion failed
+var ErrWrongTypeAssertion = errors.New("wrong type assertion")
+
+// ErrAccNotFound signals that the requested account does not exist
+var ErrAccNotFound = errors.New("account was not found")
+
+// ErrInvalidSnapshot signals that an invalid journal snapshot was provided
+var ErrInvalidSnapshot = errors.New("invalid snapshot")
+
+// ErrInsufficientFunds signals the funds are insufficient for the balance operation
+var ErrInsufficientFunds = errors.New("insufficient funds")
+
+// ErrNilValue signals that a nil value has been provided
+var ErrNilValue = errors.New("nil value")
+
+// ErrOperationNotPermitted signals that the operation is not permitted on the account
+var ErrOperationNotPermitted = errors.New("operation in account not permitted")
+
+// ErrInvalidAddressLength signals that the address length is invalid
+var ErrInvalidAddressLength = errors.New("invalid address length")
+
+// ErrNilTrieMigrator signals that a nil trie migrator has been provided
+var ErrNilTrieMigrator = errors.New("nil trie migrator")
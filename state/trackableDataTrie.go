@@ -0,0 +1,186 @@
+package state
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+	"sort"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-core-go/core/check"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+)
+
+var _ vmcommon.AccountDataHandler = (*trackableDataTrie)(nil)
+
+type dataTrieEntry struct {
+	value   []byte
+	version core.TrieNodeVersion
+}
+
+// trackableDataTrie is an in-memory replacement for the node's data trie. Every saved value remembers the
+// trie node version it was written with, so data trie migrations can be executed on top of it
+type trackableDataTrie struct {
+	entries             map[string]*dataTrieEntry
+	enableEpochsHandler vmcommon.EnableEpochsHandler
+}
+
+func newTrackableDataTrie(enableEpochsHandler vmcommon.EnableEpochsHandler) *trackableDataTrie {
+	return &trackableDataTrie{
+		entries:             make(map[string]*dataTrieEntry),
+		enableEpochsHandler: enableEpochsHandler,
+	}
+}
+
+// RetrieveValue returns the value saved under the provided key and the trie node version it was saved with.
+// A missing key will return an empty value and no error
+func (tdt *trackableDataTrie) RetrieveValue(key []byte) ([]byte, uint32, error) {
+	entry, found := tdt.entries[string(key)]
+	if !found {
+		return nil, 0, nil
+	}
+
+	return cloneBytes(entry.value), uint32(entry.version), nil
+}
+
+// SaveKeyValue saves the value under the provided key. An empty value removes the key
+func (tdt *trackableDataTrie) SaveKeyValue(key []byte, value []byte) error {
+	if len(value) == 0 {
+		delete(tdt.entries, string(key))
+		return nil
+	}
+
+	tdt.entries[string(key)] = &dataTrieEntry{
+		value:   cloneBytes(value),
+		version: tdt.getCurrentVersion(),
+	}
+
+	return nil
+}
+
+func (tdt *trackableDataTrie) getCurrentVersion() core.TrieNodeVersion {
+	if tdt.enableEpochsHandler.IsFlagEnabled(builtInFunctions.AutoBalanceDataTriesFlag) {
+		return core.AutoBalanceEnabled
+	}
+
+	return core.NotSpecified
+}
+
+// MigrateDataTrieLeaves migrates, in key order, the leaves saved with the old version to the new version.
+// The migration stops when the trie migrator signals that it cannot handle more leaves
+func (tdt *trackableDataTrie) MigrateDataTrieLeaves(args vmcommon.ArgsMigrateDataTrieLeaves) error {
+	if check.IfNil(args.TrieMigrator) {
+		return ErrNilTrieMigrator
+	}
+
+	for _, key := range tdt.sortedKeys() {
+		if !args.TrieMigrator.ConsumeStorageLoadGas() {
+			break
+		}
+
+		entry := tdt.entries[key]
+		if entry.version != args.OldVersion {
+			continue
+		}
+
+		leafData := core.TrieData{
+			Key:     []byte(key),
+			Value:   cloneBytes(entry.value),
+			Version: entry.version,
+		}
+		shouldContinue, err := args.TrieMigrator.AddLeafToMigrationQueue(leafData, args.NewVersion)
+		if err != nil {
+			return err
+		}
+		if !shouldContinue {
+			break
+		}
+	}
+
+	for _, leafData := range args.TrieMigrator.GetLeavesToBeMigrated() {
+		entry, found := tdt.entries[string(leafData.Key)]
+		if !found {
+			continue
+		}
+
+		entry.version = args.NewVersion
+	}
+
+	return nil
+}
+
+// DirtyData returns a copy of all the key-value pairs held by the data trie
+func (tdt *trackableDataTrie) DirtyData() map[string][]byte {
+	dirtyData := make(map[string][]byte, len(tdt.entries))
+	for key, entry := range tdt.entries {
+		dirtyData[key] = cloneBytes(entry.value)
+	}
+
+	return dirtyData
+}
+
+func (tdt *trackableDataTrie) rootHash() []byte {
+	if len(tdt.entries) == 0 {
+		return nil
+	}
+
+	hasher := sha256.New()
+	for _, key := range tdt.sortedKeys() {
+		entry := tdt.entries[key]
+		writeWithLength(hasher, []byte(key))
+		writeWithLength(hasher, entry.value)
+		writeWithLength(hasher, []byte{byte(entry.version)})
+	}
+
+	return hasher.Sum(nil)
+}
+
+func (tdt *trackableDataTrie) sortedKeys() []string {
+	keys := make([]string, 0, len(tdt.entries))
+	for key := range tdt.entries {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (tdt *trackableDataTrie) clone() *trackableDataTrie {
+	entries := make(map[string]*dataTrieEntry, len(tdt.entries))
+	for key, entry := range tdt.entries {
+		entries[key] = &dataTrieEntry{
+			value:   cloneBytes(entry.value),
+			version: entry.version,
+		}
+	}
+
+	return &trackableDataTrie{
+		entries:             entries,
+		enableEpochsHandler: tdt.enableEpochsHandler,
+	}
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (tdt *trackableDataTrie) IsInterfaceNil() bool {
+	return tdt == nil
+}
+
+func writeWithLength(writer io.Writer, data []byte) {
+	length := make([]byte, 4)
+	binary.BigEndian.PutUint32(length, uint32(len(data)))
+
+	_, _ = writer.Write(length)
+	_, _ = writer.Write(data)
+}
+
+func cloneBytes(b []byte) []byte {
+	if b == nil {
+		return nil
+	}
+
+	clone := make([]byte, len(b))
+	copy(clone, b)
+	return clone
+}
@@ -0,0 +1,123 @@
+package state
+
+import (
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core"
+	"github.com/multiversx/mx-chain-vm-common-go"
+	"github.com/multiversx/mx-chain-vm-common-go/builtInFunctions"
+	"github.com/multiversx/mx-chain-vm-common-go/dataTrieMigrator"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func createEnableEpochsHandler(autoBalanceEnabled *bool) vmcommon.EnableEpochsHandler {
+	return &mock.EnableEpochsHandlerStub{
+		IsFlagEnabledCalled: func(flag core.EnableEpochFlag) bool {
+			if flag == builtInFunctions.AutoBalanceDataTriesFlag {
+				return *autoBalanceEnabled
+			}
+			return true
+		},
+	}
+}
+
+func TestTrackableDataTrie_SaveAndRetrieveValue(t *testing.T) {
+	t.Parallel()
+
+	autoBalanceEnabled := false
+	tdt := newTrackableDataTrie(createEnableEpochsHandler(&autoBalanceEnabled))
+
+	val, version, err := tdt.RetrieveValue([]byte("missing"))
+	assert.Nil(t, err)
+	assert.Nil(t, val)
+	assert.Equal(t, uint32(0), version)
+
+	require.Nil(t, tdt.SaveKeyValue([]byte("key1"), []byte("value1")))
+	autoBalanceEnabled = true
+	require.Nil(t, tdt.SaveKeyValue([]byte("key2"), []byte("value2")))
+
+	val, version, err = tdt.RetrieveValue([]byte("key1"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value1"), val)
+	assert.Equal(t, uint32(core.NotSpecified), version)
+
+	val, version, err = tdt.RetrieveValue([]byte("key2"))
+	assert.Nil(t, err)
+	assert.Equal(t, []byte("value2"), val)
+	assert.Equal(t, uint32(core.AutoBalanceEnabled), version)
+
+	require.Nil(t, tdt.SaveKeyValue([]byte("key1"), nil))
+	val, _, _ = tdt.RetrieveValue([]byte("key1"))
+	assert.Nil(t, val)
+	assert.Equal(t, 1, len(tdt.DirtyData()))
+}
+
+func TestTrackableDataTrie_MigrateDataTrieLeaves(t *testing.T) {
+	t.Parallel()
+
+	t.Run("nil trie migrator should error", func(t *testing.T) {
+		t.Parallel()
+
+		autoBalanceEnabled := false
+		tdt := newTrackableDataTrie(createEnableEpochsHandler(&autoBalanceEnabled))
+
+		err := tdt.MigrateDataTrieLeaves(vmcommon.ArgsMigrateDataTrieLeaves{})
+		assert.Equal(t, ErrNilTrieMigrator, err)
+	})
+	t.Run("should migrate all leaves if enough gas", func(t *testing.T) {
+		t.Parallel()
+
+		autoBalanceEnabled := false
+		tdt := newTrackableDataTrie(createEnableEpochsHandler(&autoBalanceEnabled))
+		_ = tdt.SaveKeyValue([]byte("key1"), []byte("value1"))
+		_ = tdt.SaveKeyValue([]byte("key2"), []byte("value2"))
+
+		dtm := dataTrieMigrator.NewDataTrieMigrator(dataTrieMigrator.ArgsNewDataTrieMigrator{
+			GasProvided: 1000,
+			DataTrieGasCost: dataTrieMigrator.DataTrieGasCost{
+				TrieLoadPerNode:  1,
+				TrieStorePerNode: 1,
+			},
+		})
+		err := tdt.MigrateDataTrieLeaves(vmcommon.ArgsMigrateDataTrieLeaves{
+			OldVersion:   core.NotSpecified,
+			NewVersion:   core.AutoBalanceEnabled,
+			TrieMigrator: dtm,
+		})
+		assert.Nil(t, err)
+
+		_, version, _ := tdt.RetrieveValue([]byte("key1"))
+		assert.Equal(t, uint32(core.AutoBalanceEnabled), version)
+		_, version, _ = tdt.RetrieveValue([]byte("key2"))
+		assert.Equal(t, uint32(core.AutoBalanceEnabled), version)
+	})
+	t.Run("should stop when out of gas", func(t *testing.T) {
+		t.Parallel()
+
+		autoBalanceEnabled := false
+		tdt := newTrackableDataTrie(createEnableEpochsHandler(&autoBalanceEnabled))
+		_ = tdt.SaveKeyValue([]byte("key1"), []byte("value1"))
+		_ = tdt.SaveKeyValue([]byte("key2"), []byte("value2"))
+
+		dtm := dataTrieMigrator.NewDataTrieMigrator(dataTrieMigrator.ArgsNewDataTrieMigrator{
+			GasProvided: 25,
+			DataTrieGasCost: dataTrieMigrator.DataTrieGasCost{
+				TrieLoadPerNode:  10,
+				TrieStorePerNode: 10,
+			},
+		})
+		err := tdt.MigrateDataTrieLeaves(vmcommon.ArgsMigrateDataTrieLeaves{
+			OldVersion:   core.NotSpecified,
+			NewVersion:   core.AutoBalanceEnabled,
+			TrieMigrator: dtm,
+		})
+		assert.Nil(t, err)
+
+		_, version, _ := tdt.RetrieveValue([]byte("key1"))
+		assert.Equal(t, uint32(core.AutoBalanceEnabled), version)
+		_, version, _ = tdt.RetrieveValue([]byte("key2"))
+		assert.Equal(t, uint32(core.NotSpecified), version)
+	})
+}
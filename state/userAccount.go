@@ -0,0 +1,229 @@
+package state
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"math/big"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+
+	vmcommon "github.com/multiversx/mx-chain-vm-common-go"
+)
+
+var _ vmcommon.UserAccountHandler = (*userAccount)(nil)
+
+var zero = big.NewInt(0)
+
+// userAccount is the in-memory implementation of a user account
+type userAccount struct {
+	address         []byte
+	nonce           uint64
+	balance         *big.Int
+	developerReward *big.Int
+	code            []byte
+	codeHash        []byte
+	codeMetadata    []byte
+	ownerAddress    []byte
+	userName        []byte
+	dataTrie        *trackableDataTrie
+}
+
+// NewUserAccount creates a new, empty, in-memory user account
+func NewUserAccount(address []byte, enableEpochsHandler vmcommon.EnableEpochsHandler) (*userAccount, error) {
+	if len(address) == 0 {
+		return nil, ErrNilAddress
+	}
+	if check.IfNil(enableEpochsHandler) {
+		return nil, ErrNilEnableEpochsHandler
+	}
+
+	return &userAccount{
+		address:         cloneBytes(address),
+		balance:         big.NewInt(0),
+		developerReward: big.NewInt(0),
+		dataTrie:        newTrackableDataTrie(enableEpochsHandler),
+	}, nil
+}
+
+// AddressBytes returns the address of the account
+func (a *userAccount) AddressBytes() []byte {
+	return a.address
+}
+
+// IncreaseNonce adds the given value to the current nonce
+func (a *userAccount) IncreaseNonce(value uint64) {
+	a.nonce += value
+}
+
+// GetNonce returns the account's nonce
+func (a *userAccount) GetNonce() uint64 {
+	return a.nonce
+}
+
+// AddToBalance adds the given value to the balance. The resulting balance can not be negative
+func (a *userAccount) AddToBalance(value *big.Int) error {
+	if value == nil {
+		return ErrNilValue
+	}
+
+	newBalance := big.NewInt(0).Add(a.balance, value)
+	if newBalance.Cmp(zero) < 0 {
+		return ErrInsufficientFunds
+	}
+
+	a.balance = newBalance
+	return nil
+}
+
+// SubFromBalance subtracts the given value from the balance. The resulting balance can not be negative
+func (a *userAccount) SubFromBalance(value *big.Int) error {
+	if value == nil {
+		return ErrNilValue
+	}
+
+	return a.AddToBalance(big.NewInt(0).Neg(value))
+}
+
+// GetBalance returns the current balance
+func (a *userAccount) GetBalance() *big.Int {
+	return big.NewInt(0).Set(a.balance)
+}
+
+// AddToDeveloperReward adds the given value to the accumulated developer reward
+func (a *userAccount) AddToDeveloperReward(value *big.Int) {
+	if value == nil {
+		return
+	}
+
+	a.developerReward = big.NewInt(0).Add(a.developerReward, value)
+}
+
+// ClaimDeveloperRewards returns the accumulated developer reward and resets it. Only the owner can claim
+func (a *userAccount) ClaimDeveloperRewards(sender []byte) (*big.Int, error) {
+	if !bytes.Equal(sender, a.ownerAddress) {
+		return nil, ErrOperationNotPermitted
+	}
+
+	oldValue := big.NewInt(0).Set(a.developerReward)
+	a.developerReward = big.NewInt(0)
+
+	return oldValue, nil
+}
+
+// GetDeveloperReward returns the accumulated developer reward
+func (a *userAccount) GetDeveloperReward() *big.Int {
+	return big.NewInt(0).Set(a.developerReward)
+}
+
+// ChangeOwnerAddress changes the owner of the account if the sender is the current owner
+func (a *userAccount) ChangeOwnerAddress(sender []byte, newAddress []byte) error {
+	if !bytes.Equal(sender, a.ownerAddress) {
+		return ErrOperationNotPermitted
+	}
+	if len(newAddress) != len(a.address) {
+		return ErrInvalidAddressLength
+	}
+
+	a.ownerAddress = cloneBytes(newAddress)
+	return nil
+}
+
+// SetOwnerAddress sets the owner of the account
+func (a *userAccount) SetOwnerAddress(address []byte) {
+	a.ownerAddress = cloneBytes(address)
+}
+
+// GetOwnerAddress returns the owner of the account
+func (a *userAccount) GetOwnerAddress() []byte {
+	return a.ownerAddress
+}
+
+// SetUserName sets the username of the account
+func (a *userAccount) SetUserName(userName []byte) {
+	a.userName = cloneBytes(userName)
+}
+
+// GetUserName returns the username of the account
+func (a *userAccount) GetUserName() []byte {
+	return a.userName
+}
+
+// SetCode sets the code of the account, the code hash being computed from it
+func (a *userAccount) SetCode(code []byte) {
+	a.code = cloneBytes(code)
+	a.codeHash = nil
+	if len(code) > 0 {
+		codeHash := sha256.Sum256(code)
+		a.codeHash = codeHash[:]
+	}
+}
+
+// GetCode returns the code of the account
+func (a *userAccount) GetCode() []byte {
+	return a.code
+}
+
+// GetCodeHash returns the code hash of the account
+func (a *userAccount) GetCodeHash() []byte {
+	return a.codeHash
+}
+
+// SetCodeMetadata sets the code metadata of the account
+func (a *userAccount) SetCodeMetadata(codeMetadata []byte) {
+	a.codeMetadata = cloneBytes(codeMetadata)
+}
+
+// GetCodeMetadata returns the code metadata of the account
+func (a *userAccount) GetCodeMetadata() []byte {
+	return a.codeMetadata
+}
+
+// GetRootHash returns the hash of the account's data
+func (a *userAccount) GetRootHash() []byte {
+	return a.dataTrie.rootHash()
+}
+
+// AccountDataHandler returns the handler of the account's data
+func (a *userAccount) AccountDataHandler() vmcommon.AccountDataHandler {
+	return a.dataTrie
+}
+
+// DirtyData returns a copy of all the key-value pairs saved in the account's data
+func (a *userAccount) DirtyData() map[string][]byte {
+	return a.dataTrie.DirtyData()
+}
+
+func (a *userAccount) clone() *userAccount {
+	return &userAccount{
+		address:         cloneBytes(a.address),
+		nonce:           a.nonce,
+		balance:         big.NewInt(0).Set(a.balance),
+		developerReward: big.NewInt(0).Set(a.developerReward),
+		code:            cloneBytes(a.code),
+		codeHash:        cloneBytes(a.codeHash),
+		codeMetadata:    cloneBytes(a.codeMetadata),
+		ownerAddress:    cloneBytes(a.ownerAddress),
+		userName:        cloneBytes(a.userName),
+		dataTrie:        a.dataTrie.clone(),
+	}
+}
+
+func (a *userAccount) hash() []byte {
+	hasher := sha256.New()
+	writeWithLength(hasher, a.address)
+	writeWithLength(hasher, big.NewInt(0).SetUint64(a.nonce).Bytes())
+	writeWithLength(hasher, a.balance.Bytes())
+	writeWithLength(hasher, a.developerReward.Bytes())
+	writeWithLength(hasher, a.codeHash)
+	writeWithLength(hasher, a.codeMetadata)
+	writeWithLength(hasher, a.ownerAddress)
+	writeWithLength(hasher, a.userName)
+	writeWithLength(hasher, a.GetRootHash())
+
+	return hasher.Sum(nil)
+}
+
+// IsInterfaceNil returns true if there is no value under the interface
+func (a *userAccount) IsInterfaceNil() bool {
+	return a == nil
+}
@@ -0,0 +1,80 @@
+package state
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/multiversx/mx-chain-core-go/core/check"
+	"github.com/multiversx/mx-chain-vm-common-go/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewUserAccount(t *testing.T) {
+	t.Parallel()
+
+	acc, err := NewUserAccount(nil, &mock.EnableEpochsHandlerStub{})
+	assert.Nil(t, acc)
+	assert.Equal(t, ErrNilAddress, err)
+
+	acc, err = NewUserAccount([]byte("address"), nil)
+	assert.Nil(t, acc)
+	assert.Equal(t, ErrNilEnableEpochsHandler, err)
+
+	acc, err = NewUserAccount([]byte("address"), &mock.EnableEpochsHandlerStub{})
+	assert.Nil(t, err)
+	assert.False(t, check.IfNil(acc))
+	assert.Equal(t, []byte("address"), acc.AddressBytes())
+	assert.Equal(t, big.NewInt(0), acc.GetBalance())
+}
+
+func TestUserAccount_Balance(t *testing.T) {
+	t.Parallel()
+
+	acc, _ := NewUserAccount([]byte("address"), &mock.EnableEpochsHandlerStub{})
+
+	assert.Equal(t, ErrNilValue, acc.AddToBalance(nil))
+	assert.Nil(t, acc.AddToBalance(big.NewInt(10)))
+	assert.Nil(t, acc.SubFromBalance(big.NewInt(3)))
+	assert.Equal(t, ErrInsufficientFunds, acc.SubFromBalance(big.NewInt(8)))
+	assert.Equal(t, big.NewInt(7), acc.GetBalance())
+}
+
+func TestUserAccount_OwnerAndDeveloperRewards(t *testing.T) {
+	t.Parallel()
+
+	acc, _ := NewUserAccount([]byte("address"), &mock.EnableEpochsHandlerStub{})
+	acc.SetOwnerAddress([]byte("owner01"))
+	acc.AddToDeveloperReward(big.NewInt(100))
+
+	_, err := acc.ClaimDeveloperRewards([]byte("other"))
+	assert.Equal(t, ErrOperationNotPermitted, err)
+
+	reward, err := acc.ClaimDeveloperRewards([]byte("owner01"))
+	assert.Nil(t, err)
+	assert.Equal(t, big.NewInt(100), reward)
+	assert.Equal(t, big.NewInt(0), acc.GetDeveloperReward())
+
+	assert.Equal(t, ErrOperationNotPermitted, acc.ChangeOwnerAddress([]byte("other"), []byte("owner02")))
+	assert.Equal(t, ErrInvalidAddressLength, acc.ChangeOwnerAddress([]byte("owner01"), []byte("short")))
+	assert.Nil(t, acc.ChangeOwnerAddress([]byte("owner01"), []byte("owner02")))
+	assert.Equal(t, []byte("owner02"), acc.GetOwnerAddress())
+}
+
+func TestUserAccount_CloneShouldNotShareData(t *testing.T) {
+	t.Parallel()
+
+	acc, _ := NewUserAccount([]byte("address"), &mock.EnableEpochsHandlerStub{})
+	require.Nil(t, acc.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("value")))
+	acc.SetCode([]byte("code"))
+
+	clone := acc.clone()
+	_ = clone.AccountDataHandler().SaveKeyValue([]byte("key"), []byte("changed"))
+	_ = clone.AddToBalance(big.NewInt(1))
+
+	val, _, _ := acc.AccountDataHandler().RetrieveValue([]byte("key"))
+	assert.Equal(t, []byte("value"), val)
+	assert.Equal(t, big.NewInt(0), acc.GetBalance())
+	assert.Equal(t, acc.GetCodeHash(), clone.GetCodeHash())
+	assert.NotEqual(t, acc.GetRootHash(), clone.GetRootHash())
+}